@@ -35,8 +35,8 @@ func main() {
 		"reason": "invalid password",
 	})
 
-	// Test WithContext for contextual logging
-	log := logger.WithContext(map[string]any{
+	// Test With for contextual logging
+	log := logger.With(map[string]any{
 		"request_id": "req-abc-123",
 		"user_id":    456,
 	})
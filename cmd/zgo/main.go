@@ -59,6 +59,10 @@ func registerCommands(app *console.Application) {
 	app.Register(commands.NewMakeSeederCommand())
 	app.Register(commands.NewMakeMigrationCommand())
 	app.Register(commands.NewMakeModuleCommand())
+	app.Register(commands.NewMakeTestCommand())
+	app.Register(commands.NewMakeEventCommand())
+	app.Register(commands.NewMakeListenerCommand())
+	app.Register(commands.NewMakePolicyCommand())
 
 	// Register database migration commands (new Migrator-based)
 	dbMigrate := commands.NewMigrateCommand()
@@ -86,11 +90,33 @@ func registerCommands(app *console.Application) {
 	app.Register(dbSeed)
 	app.RegisterAs("seed", dbSeed)
 
+	// Register backup commands
+	app.Register(commands.NewDBBackupCommand())
+	app.Register(commands.NewDBRestoreCommand())
+
+	// Register user commands
+	app.Register(commands.NewMakeAdminCommand())
+
+	// Register webhook commands
+	app.Register(commands.NewWebhookListCommand())
+
+	// Register feature flag commands
+	app.Register(commands.NewFeatureListCommand())
+	app.Register(commands.NewFeatureToggleCommand())
+
 	// Register other commands
 	app.Register(commands.NewServeCommand())
 	app.Register(commands.NewEnvCommand())
+	app.Register(commands.NewConfigShowCommand())
+	app.Register(commands.NewTinkerCommand())
 	app.Register(commands.NewVersionCommand(Version))
 	app.Register(commands.NewRouteListCommand())
+	app.Register(commands.NewRouteCheckCommand())
+	app.Register(commands.NewScheduleListCommand())
+	app.Register(commands.NewTaskCommand())
+	app.Register(commands.NewTaskListCommand())
+	app.Register(commands.NewSwaggerGenCommand())
+	app.Register(commands.NewSwaggerValidateCommand())
 
 	// Register plugin commands
 	app.Register(commands.NewPluginListCommand())
@@ -107,6 +133,9 @@ func isPluginCommand(cmd string) bool {
 		"make:seeder":      true,
 		"make:migration":   true,
 		"make:module":      true,
+		"make:test":        true,
+		"make:event":       true,
+		"make:listener":    true,
 		"migrate":          true,
 		"migrate:fresh":    true,
 		"migrate:rollback": true,
@@ -118,12 +147,24 @@ func isPluginCommand(cmd string) bool {
 		"db:reset":         true,
 		"db:status":        true,
 		"db:seed":          true,
+		"db:backup":        true,
+		"db:restore":       true,
 		"seed":             true,
 		"serve":            true,
 		"env":              true,
+		"config:show":      true,
+		"tinker":           true,
 		"version":          true,
 		"route:list":       true,
+		"route:check":      true,
+		"schedule:list":    true,
+		"swagger:gen":      true,
+		"swagger:validate": true,
+		"user:create":      true,
 		"plugin:list":      true,
+		"webhook:list":     true,
+		"feature:list":     true,
+		"feature:toggle":   true,
 		"help":             true,
 	}
 
@@ -136,7 +177,13 @@ func isPluginCommand(cmd string) bool {
 		strings.HasPrefix(cmd, "migrate:") ||
 		strings.HasPrefix(cmd, "db:") ||
 		strings.HasPrefix(cmd, "route:") ||
-		strings.HasPrefix(cmd, "plugin:") {
+		strings.HasPrefix(cmd, "schedule:") ||
+		strings.HasPrefix(cmd, "swagger:") ||
+		strings.HasPrefix(cmd, "config:") ||
+		strings.HasPrefix(cmd, "user:") ||
+		strings.HasPrefix(cmd, "plugin:") ||
+		strings.HasPrefix(cmd, "webhook:") ||
+		strings.HasPrefix(cmd, "feature:") {
 		return false
 	}
 
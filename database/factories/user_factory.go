@@ -0,0 +1,45 @@
+// Package factories defines model factories for seeders and tests
+package factories
+
+import (
+	"sync"
+
+	"github.com/zgiai/zgo/internal/modules/user"
+	"github.com/zgiai/zgo/pkg/factory"
+	"github.com/zgiai/zgo/pkg/hash"
+)
+
+// UserPassword is the password of every user the factory builds
+const UserPassword = "password"
+
+// userPasswordHash hashes UserPassword once; hashing per user would make
+// bulk seeding crawl
+var userPasswordHash = sync.OnceValues(func() (string, error) {
+	return hash.Make(UserPassword)
+})
+
+// UserFactory builds active users with random names, unique usernames and
+// emails, and UserPassword as their password.
+//
+// Example:
+//
+//	users, err := factories.UserFactory().Count(50).Create(db)
+//	admin := factories.UserFactory().State(func(u *user.UserPO) { u.Username = "admin" }).Make()[0]
+func UserFactory() *factory.Factory[user.UserPO] {
+	return factory.New(func(f *factory.Faker) user.UserPO {
+		password, err := userPasswordHash()
+		if err != nil {
+			// bcrypt only fails for passwords over 72 bytes
+			panic(err)
+		}
+		return user.UserPO{
+			Username: f.Username(),
+			Email:    f.Email(),
+			Password: password,
+			Nickname: f.Name(),
+			Phone:    f.Phone(),
+			Bio:      f.Sentence(8),
+			Status:   1,
+		}
+	})
+}
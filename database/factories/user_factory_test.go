@@ -0,0 +1,52 @@
+package factories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"github.com/zgiai/zgo/pkg/hash"
+	"gorm.io/gorm"
+)
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	return dbtest.Open(t, &user.UserPO{})
+}
+
+func TestUserFactory_Create50UniqueUsers(t *testing.T) {
+	db := setupDB(t)
+
+	users, err := UserFactory().Count(50).Create(db)
+	require.NoError(t, err)
+	require.Len(t, users, 50)
+
+	emails := make(map[string]struct{})
+	usernames := make(map[string]struct{})
+	for _, u := range users {
+		assert.NotZero(t, u.ID)
+		emails[u.Email] = struct{}{}
+		usernames[u.Username] = struct{}{}
+	}
+	assert.Len(t, emails, 50)
+	assert.Len(t, usernames, 50)
+	assert.True(t, hash.Check(UserPassword, users[0].Password))
+
+	var stored int64
+	require.NoError(t, db.Model(&user.UserPO{}).Distinct("email").Count(&stored).Error)
+	assert.Equal(t, int64(50), stored)
+}
+
+func TestUserFactory_SeedIsReproducible(t *testing.T) {
+	first := UserFactory().Count(5).Seed(42).Make()
+	second := UserFactory().Count(5).Seed(42).Make()
+	other := UserFactory().Count(5).Seed(7).Make()
+
+	for i := range first {
+		assert.Equal(t, first[i].Email, second[i].Email)
+		assert.Equal(t, first[i].Username, second[i].Username)
+	}
+	assert.NotEqual(t, first[0].Email, other[0].Email)
+}
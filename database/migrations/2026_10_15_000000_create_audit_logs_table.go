@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/zgiai/zgo/internal/infra/audit"
+	"github.com/zgiai/zgo/internal/infra/migration"
+	"gorm.io/gorm"
+)
+
+func init() {
+	register("2026_10_15_000000_create_audit_logs_table", &createAuditLogsTable{})
+}
+
+// createAuditLogsTable creates the audit_logs table.
+type createAuditLogsTable struct {
+	migration.BaseMigration
+}
+
+// Up applies the migration.
+func (m *createAuditLogsTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&audit.Log{})
+}
+
+// Down reverts the migration.
+func (m *createAuditLogsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable("audit_logs")
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"github.com/zgiai/zgo/internal/infra/migration"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"gorm.io/gorm"
+)
+
+func init() {
+	register("2026_10_15_000001_add_two_factor_to_users_table", &addTwoFactorToUsersTable{})
+}
+
+// addTwoFactorToUsersTable adds the TOTP secret and enabled flag to users.
+type addTwoFactorToUsersTable struct {
+	migration.BaseMigration
+}
+
+// Up applies the migration.
+func (m *addTwoFactorToUsersTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&user.UserPO{})
+}
+
+// Down reverts the migration.
+func (m *addTwoFactorToUsersTable) Down(db *gorm.DB) error {
+	for _, column := range []string{"two_factor_secret", "two_factor_enabled"} {
+		if db.Migrator().HasColumn(&user.UserPO{}, column) {
+			if err := db.Migrator().DropColumn(&user.UserPO{}, column); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
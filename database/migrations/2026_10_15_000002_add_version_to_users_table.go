@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/zgiai/zgo/internal/infra/migration"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"gorm.io/gorm"
+)
+
+func init() {
+	register("2026_10_15_000002_add_version_to_users_table", &addVersionToUsersTable{})
+}
+
+// addVersionToUsersTable adds the optimistic lock version to users.
+// Existing rows start at version 1.
+type addVersionToUsersTable struct {
+	migration.BaseMigration
+}
+
+// Up applies the migration.
+func (m *addVersionToUsersTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&user.UserPO{})
+}
+
+// Down reverts the migration.
+func (m *addVersionToUsersTable) Down(db *gorm.DB) error {
+	if db.Migrator().HasColumn(&user.UserPO{}, "version") {
+		return db.Migrator().DropColumn(&user.UserPO{}, "version")
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/zgiai/zgo/internal/infra/migration"
+	"github.com/zgiai/zgo/internal/infra/webhook"
+	"gorm.io/gorm"
+)
+
+func init() {
+	register("2026_10_15_000003_create_webhook_endpoints_table", &createWebhookEndpointsTable{})
+}
+
+// createWebhookEndpointsTable creates the webhook_endpoints table.
+type createWebhookEndpointsTable struct {
+	migration.BaseMigration
+}
+
+// Up applies the migration.
+func (m *createWebhookEndpointsTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&webhook.Endpoint{})
+}
+
+// Down reverts the migration.
+func (m *createWebhookEndpointsTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable("webhook_endpoints")
+}
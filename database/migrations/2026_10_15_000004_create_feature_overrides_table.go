@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/zgiai/zgo/internal/infra/feature"
+	"github.com/zgiai/zgo/internal/infra/migration"
+	"gorm.io/gorm"
+)
+
+func init() {
+	register("2026_10_15_000004_create_feature_overrides_table", &createFeatureOverridesTable{})
+}
+
+// createFeatureOverridesTable creates the feature_overrides table.
+type createFeatureOverridesTable struct {
+	migration.BaseMigration
+}
+
+// Up applies the migration.
+func (m *createFeatureOverridesTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&feature.Override{})
+}
+
+// Down reverts the migration.
+func (m *createFeatureOverridesTable) Down(db *gorm.DB) error {
+	return db.Migrator().DropTable("feature_overrides")
+}
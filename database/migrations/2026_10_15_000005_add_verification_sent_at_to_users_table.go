@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/zgiai/zgo/internal/infra/migration"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"gorm.io/gorm"
+)
+
+func init() {
+	register("2026_10_15_000005_add_verification_sent_at_to_users_table", &addVerificationSentAtToUsersTable{})
+}
+
+// addVerificationSentAtToUsersTable records when the latest verification
+// link was sent. Existing pending accounts fall back to created_at.
+type addVerificationSentAtToUsersTable struct {
+	migration.BaseMigration
+}
+
+// Up applies the migration.
+func (m *addVerificationSentAtToUsersTable) Up(db *gorm.DB) error {
+	return db.AutoMigrate(&user.UserPO{})
+}
+
+// Down reverts the migration.
+func (m *addVerificationSentAtToUsersTable) Down(db *gorm.DB) error {
+	if db.Migrator().HasColumn(&user.UserPO{}, "verification_sent_at") {
+		return db.Migrator().DropColumn(&user.UserPO{}, "verification_sent_at")
+	}
+	return nil
+}
@@ -1,12 +1,39 @@
 package seeders
 
-import "gorm.io/gorm"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Seeder interface defines the contract for database seeders
 type Seeder interface {
+	// Name identifies the seeder for dependencies and --class
+	Name() string
+	// Dependencies lists the seeders that must run first
+	Dependencies() []string
 	Run(db *gorm.DB) error
 }
 
+// Bulk is implemented by seeders that can also create any number of fake
+// records, for db:seed --count
+type Bulk interface {
+	// RunBulk seeds like Run plus count fake records. A non-zero seed makes
+	// the fake data reproducible.
+	RunBulk(db *gorm.DB, count int, seed uint64) error
+}
+
+var (
+	// ErrSeederNotFound is returned when a seeder or dependency is not registered
+	ErrSeederNotFound = errors.New("seeder not found")
+
+	// ErrDependencyCycle is returned when seeders depend on each other
+	ErrDependencyCycle = errors.New("seeder dependency cycle")
+)
+
 var registry []Seeder
 
 // register adds a seeder to the registry
@@ -19,12 +46,115 @@ func All() []Seeder {
 	return registry
 }
 
-// RunAll executes all registered seeders with the given database connection
+// RunAll executes all registered seeders in dependency order
 func RunAll(db *gorm.DB) error {
-	for _, seeder := range registry {
-		if err := seeder.Run(db); err != nil {
-			return err
+	return NewRunner(registry).Run(db)
+}
+
+// Runner executes seeders after the seeders they depend on
+type Runner struct {
+	seeders []Seeder
+
+	// OnRun, when set, is called after each seeder finishes
+	OnRun func(name string, elapsed time.Duration, err error)
+
+	// Count, when above 0, makes seeders implementing Bulk create that many
+	// fake records, with Seed passed on for reproducible data
+	Count int
+	Seed  uint64
+}
+
+// NewRunner creates a runner over the given seeders
+func NewRunner(seeders []Seeder) *Runner {
+	return &Runner{seeders: seeders}
+}
+
+// Run executes every seeder in dependency order
+func (r *Runner) Run(db *gorm.DB) error {
+	names := make([]string, len(r.seeders))
+	for i, s := range r.seeders {
+		names[i] = s.Name()
+	}
+	return r.run(db, names)
+}
+
+// RunSeeder executes the named seeder, preceded by its dependencies
+func (r *Runner) RunSeeder(db *gorm.DB, name string) error {
+	return r.run(db, []string{name})
+}
+
+func (r *Runner) run(db *gorm.DB, names []string) error {
+	ordered, err := r.Sort(names...)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range ordered {
+		start := time.Now()
+		var err error
+		if bulk, ok := s.(Bulk); ok && r.Count > 0 {
+			err = bulk.RunBulk(db, r.Count, r.Seed)
+		} else {
+			err = s.Run(db)
+		}
+		if r.OnRun != nil {
+			r.OnRun(s.Name(), time.Since(start), err)
+		}
+		if err != nil {
+			return fmt.Errorf("seeder %s: %w", s.Name(), err)
 		}
 	}
 	return nil
 }
+
+// Sort returns the named seeders and everything they depend on, with each
+// seeder placed after its dependencies. Independent seeders keep their
+// registration order.
+func (r *Runner) Sort(names ...string) ([]Seeder, error) {
+	byName := make(map[string]Seeder, len(r.seeders))
+	for _, s := range r.seeders {
+		byName[s.Name()] = s
+	}
+
+	const (
+		visiting = iota + 1
+		done
+	)
+	state := make(map[string]int, len(r.seeders))
+	ordered := make([]Seeder, 0, len(r.seeders))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(append(path, name), " -> "))
+		}
+
+		s, ok := byName[name]
+		if !ok {
+			if len(path) > 0 {
+				return fmt.Errorf("%w: %s (required by %s)", ErrSeederNotFound, name, path[len(path)-1])
+			}
+			return fmt.Errorf("%w: %s", ErrSeederNotFound, name)
+		}
+
+		state[name] = visiting
+		for _, dep := range s.Dependencies() {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		ordered = append(ordered, s)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
@@ -0,0 +1,151 @@
+package seeders
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"gorm.io/gorm"
+)
+
+// fakeSeeder records its name in ran when run
+type fakeSeeder struct {
+	name string
+	deps []string
+	ran  *[]string
+	err  error
+}
+
+func (s *fakeSeeder) Name() string           { return s.name }
+func (s *fakeSeeder) Dependencies() []string { return s.deps }
+
+func (s *fakeSeeder) Run(db *gorm.DB) error {
+	*s.ran = append(*s.ran, s.name)
+	return s.err
+}
+
+func TestRunner_RunsDependenciesFirst(t *testing.T) {
+	var ran []string
+	runner := NewRunner([]Seeder{
+		&fakeSeeder{name: "PostSeeder", deps: []string{"UserSeeder"}, ran: &ran},
+		&fakeSeeder{name: "UserSeeder", deps: []string{"RoleSeeder"}, ran: &ran},
+		&fakeSeeder{name: "TagSeeder", ran: &ran},
+		&fakeSeeder{name: "RoleSeeder", ran: &ran},
+	})
+
+	require.NoError(t, runner.Run(nil))
+	assert.Equal(t, []string{"RoleSeeder", "UserSeeder", "PostSeeder", "TagSeeder"}, ran)
+}
+
+func TestRunner_RunSeederIncludesDependencies(t *testing.T) {
+	var ran []string
+	var reported []string
+	runner := NewRunner([]Seeder{
+		&fakeSeeder{name: "RoleSeeder", ran: &ran},
+		&fakeSeeder{name: "UserSeeder", deps: []string{"RoleSeeder"}, ran: &ran},
+		&fakeSeeder{name: "TagSeeder", ran: &ran},
+	})
+	runner.OnRun = func(name string, elapsed time.Duration, err error) {
+		reported = append(reported, name)
+	}
+
+	require.NoError(t, runner.RunSeeder(nil, "UserSeeder"))
+	assert.Equal(t, []string{"RoleSeeder", "UserSeeder"}, ran)
+	assert.Equal(t, ran, reported)
+
+	err := runner.RunSeeder(nil, "MissingSeeder")
+	assert.ErrorIs(t, err, ErrSeederNotFound)
+}
+
+func TestRunner_DetectsCycle(t *testing.T) {
+	var ran []string
+	runner := NewRunner([]Seeder{
+		&fakeSeeder{name: "A", deps: []string{"B"}, ran: &ran},
+		&fakeSeeder{name: "B", deps: []string{"C"}, ran: &ran},
+		&fakeSeeder{name: "C", deps: []string{"A"}, ran: &ran},
+	})
+
+	err := runner.Run(nil)
+	require.ErrorIs(t, err, ErrDependencyCycle)
+	assert.Contains(t, err.Error(), "A -> B -> C -> A")
+	assert.Empty(t, ran, "nothing should run when the order cannot be resolved")
+}
+
+func TestRunner_UnknownDependency(t *testing.T) {
+	var ran []string
+	runner := NewRunner([]Seeder{
+		&fakeSeeder{name: "UserSeeder", deps: []string{"RoleSeeder"}, ran: &ran},
+	})
+
+	err := runner.Run(nil)
+	require.ErrorIs(t, err, ErrSeederNotFound)
+	assert.Contains(t, err.Error(), "required by UserSeeder")
+}
+
+func TestRunner_StopsOnFailure(t *testing.T) {
+	var ran []string
+	boom := errors.New("boom")
+	runner := NewRunner([]Seeder{
+		&fakeSeeder{name: "RoleSeeder", ran: &ran, err: boom},
+		&fakeSeeder{name: "UserSeeder", deps: []string{"RoleSeeder"}, ran: &ran},
+	})
+
+	err := runner.Run(nil)
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, []string{"RoleSeeder"}, ran)
+}
+
+func TestRegisteredSeeders_RolesBeforeUsers(t *testing.T) {
+	ordered, err := NewRunner(All()).Sort("UserSeeder")
+	require.NoError(t, err)
+
+	names := make([]string, len(ordered))
+	for i, s := range ordered {
+		names[i] = s.Name()
+	}
+	assert.Equal(t, []string{"RoleSeeder", "UserSeeder"}, names)
+}
+
+// bulkSeeder is a fakeSeeder that also supports Bulk
+type bulkSeeder struct {
+	fakeSeeder
+	count int
+	seed  uint64
+}
+
+func (s *bulkSeeder) RunBulk(db *gorm.DB, count int, seed uint64) error {
+	s.count, s.seed = count, seed
+	return s.Run(db)
+}
+
+func TestRunner_CountRunsBulkSeeders(t *testing.T) {
+	var ran []string
+	bulk := &bulkSeeder{fakeSeeder: fakeSeeder{name: "UserSeeder", ran: &ran}}
+	runner := NewRunner([]Seeder{bulk, &fakeSeeder{name: "RoleSeeder", ran: &ran}})
+
+	require.NoError(t, runner.Run(nil))
+	assert.Zero(t, bulk.count, "RunBulk needs a count")
+
+	runner.Count, runner.Seed = 50, 42
+	require.NoError(t, runner.Run(nil))
+	assert.Equal(t, 50, bulk.count)
+	assert.Equal(t, uint64(42), bulk.seed)
+	assert.Len(t, ran, 4)
+}
+
+func TestUserSeeder_RunBulkIsRepeatable(t *testing.T) {
+	db := dbtest.Open(t, &user.UserPO{})
+
+	seeder := &UserSeeder{}
+	require.NoError(t, seeder.RunBulk(db, 50, 42))
+	// The same seed produces the same emails; existing users are skipped
+	require.NoError(t, seeder.RunBulk(db, 50, 42))
+
+	var count int64
+	require.NoError(t, db.Model(&user.UserPO{}).Count(&count).Error)
+	assert.Equal(t, int64(52), count)
+}
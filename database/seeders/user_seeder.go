@@ -1,12 +1,19 @@
 package seeders
 
 import (
+	"github.com/zgiai/zgo/database/factories"
 	"github.com/zgiai/zgo/internal/modules/user"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserSeeder struct{}
 
+func (s *UserSeeder) Name() string { return "UserSeeder" }
+
+// Dependencies makes sure the default roles exist before any user
+func (s *UserSeeder) Dependencies() []string { return []string{"RoleSeeder"} }
+
 func (s *UserSeeder) Run(db *gorm.DB) error {
 	users := []user.UserPO{
 		{
@@ -34,6 +41,18 @@ func (s *UserSeeder) Run(db *gorm.DB) error {
 	return nil
 }
 
+// RunBulk seeds the default users plus count users from UserFactory.
+// A seed yields the same users on every run, so users whose email is
+// already taken are skipped and rerunning the seeder is harmless.
+func (s *UserSeeder) RunBulk(db *gorm.DB, count int, seed uint64) error {
+	if err := s.Run(db); err != nil {
+		return err
+	}
+	_, err := factories.UserFactory().Count(count).Seed(seed).
+		Create(db.Clauses(clause.OnConflict{DoNothing: true}))
+	return err
+}
+
 func init() {
 	register(&UserSeeder{})
 }
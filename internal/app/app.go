@@ -2,7 +2,9 @@ package app
 
 import (
 	"github.com/zgiai/zgo/internal/contracts"
+	"github.com/zgiai/zgo/internal/domain"
 	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/container"
 	"github.com/zgiai/zgo/internal/infra/email"
 	"github.com/zgiai/zgo/internal/infra/events"
 	"github.com/zgiai/zgo/internal/infra/jwt"
@@ -21,9 +23,21 @@ type Application struct {
 	EmailService *email.Service
 	EventBus     *events.EventBus
 	Migrator     *migration.Migrator
+	Authorizer   domain.Authorizer
 	Handlers     *Handlers
 }
 
+// Register puts the infrastructure services in c so they can be resolved
+// by key. Modules register their own services in Init.
+func (a *Application) Register(c *container.Container) {
+	c.Set(container.ServiceConfig, a.Config)
+	if a.DB != nil {
+		c.Set(container.ServiceDB, a.DB)
+	}
+	c.Set(container.ServiceJWT, a.JWTService)
+	c.Set(container.ServiceEmail, a.EmailService)
+}
+
 // Handlers holds all HTTP handlers for modules.
 type Handlers struct {
 	User       *user.Handler
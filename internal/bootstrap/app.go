@@ -1,7 +1,13 @@
 package bootstrap
 
 import (
+	"context"
+	"log"
+
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/lang"
 	"github.com/zgiai/zgo/pkg/logger"
+	"github.com/zgiai/zgo/pkg/response"
 )
 
 // InitLogger initializes the logger.
@@ -9,3 +15,30 @@ import (
 func InitLogger() {
 	logger.Boot()
 }
+
+// ConfigureLogger applies the configured minimum level and output format
+// to the default logger. Called once config is loaded and on every reload.
+func ConfigureLogger(cfg config.LogConfig) {
+	logger.SetLevel(logger.ParseLevel(cfg.Level))
+	logger.SetFormat(logger.ParseFormat(cfg.Format))
+}
+
+// InitLang points the global translator at the message bundles and loads
+// every supported locale, so a broken bundle is reported at startup.
+func InitLang(cfg config.AppConfig) {
+	t := lang.Global()
+	t.SetLocale(cfg.Locale)
+	t.SetFallback(cfg.FallbackLocale)
+	t.AddPath(cfg.LangPath)
+
+	for _, locale := range cfg.Locales {
+		if err := t.Load(locale); err != nil {
+			log.Printf("Warning: Failed to load %s translations: %v", locale, err)
+		}
+	}
+
+	// Send response messages in the request locale
+	response.SetTranslator(func(ctx context.Context, message string) string {
+		return lang.T(lang.FromContext(ctx), message)
+	})
+}
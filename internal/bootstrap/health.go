@@ -0,0 +1,42 @@
+package bootstrap
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/zgiai/zgo/internal/app"
+	"github.com/zgiai/zgo/internal/infra/health"
+)
+
+// emailCheckTimeout bounds the Resend API round trip of the email check
+const emailCheckTimeout = 2 * time.Second
+
+// newHealth registers the checks enabled by HEALTH_CHECKS
+func newHealth(application *app.Application) *health.Health {
+	h := health.New()
+
+	for _, name := range application.Config.Health.Checks {
+		switch strings.TrimSpace(name) {
+		case "database":
+			if application.DB == nil {
+				log.Println("Warning: database health check enabled but the database is disabled")
+				continue
+			}
+			h.Register("database", health.DatabaseChecker(application.DB))
+		case "email":
+			if application.EmailService == nil {
+				continue
+			}
+			// Email outages degrade the service but must not take it out of rotation
+			h.Register("email", health.NonCritical(health.Timeout(
+				health.Email(application.EmailService), emailCheckTimeout,
+			)))
+		case "":
+		default:
+			log.Printf("Warning: unknown health check %q", name)
+		}
+	}
+
+	return h
+}
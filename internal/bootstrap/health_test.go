@@ -0,0 +1,71 @@
+package bootstrap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/zgiai/zgo/internal/app"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/email"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestHealth_ReadinessFailsWhenDatabaseStops(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.Health.Checks = []string{"database", "email"}
+	h := newHealth(&app.Application{Config: cfg, DB: db, EmailService: email.NewService(cfg)})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h.RegisterRoutes(r)
+
+	ready := func() int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+		return w.Code
+	}
+
+	if code := ready(); code != http.StatusOK {
+		t.Fatalf("readiness with a live database = %d, want 200", code)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("get sql.DB: %v", err)
+	}
+	if err := sqlDB.Close(); err != nil {
+		t.Fatalf("close database: %v", err)
+	}
+
+	if code := ready(); code != http.StatusServiceUnavailable {
+		t.Errorf("readiness with a stopped database = %d, want 503", code)
+	}
+}
+
+func TestHealth_OnlyEnabledChecks(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Health.Checks = []string{"email", "bogus"}
+	h := newHealth(&app.Application{Config: cfg, EmailService: email.NewService(cfg)})
+
+	results := h.Check(t.Context())
+	if _, ok := results["database"]; ok {
+		t.Error("database check registered although not enabled")
+	}
+	if _, ok := results["email"]; !ok {
+		t.Error("email check not registered")
+	}
+	if len(results) != 1 {
+		t.Errorf("got %d checks, want 1", len(results))
+	}
+}
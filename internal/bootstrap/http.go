@@ -8,20 +8,30 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/zgiai/zgo/internal/app"
+	"github.com/zgiai/zgo/internal/infra/audit"
+	"github.com/zgiai/zgo/internal/infra/cache"
 	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/container"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/internal/infra/feature"
 	"github.com/zgiai/zgo/internal/infra/health"
+	"github.com/zgiai/zgo/internal/infra/kv"
 	"github.com/zgiai/zgo/internal/infra/metrics"
 	"github.com/zgiai/zgo/internal/infra/middleware"
+	"github.com/zgiai/zgo/internal/infra/queue"
+	"github.com/zgiai/zgo/internal/infra/schedule"
+	"github.com/zgiai/zgo/internal/infra/session"
 	"github.com/zgiai/zgo/internal/infra/tracing"
-	"github.com/zgiai/zgo/pkg/logger"
+	"github.com/zgiai/zgo/pkg/pagination"
 	"github.com/zgiai/zgo/pkg/support"
 	"github.com/zgiai/zgo/routes"
+	"gorm.io/gorm"
 )
 
 // HttpKernel handles HTTP server lifecycle
@@ -30,12 +40,31 @@ type HttpKernel struct {
 	Engine         *gin.Engine
 	TracerProvider *tracing.TracerProvider
 	Health         *health.Health
+	Scheduler      *schedule.Scheduler
+	Webhooks       *queue.Worker
 }
 
 // NewHttpKernel creates a new HTTP kernel from Wire-injected Application
 func NewHttpKernel(application *app.Application) *HttpKernel {
-	// Set JWT service for middleware
+	// Set JWT service, authorizer and database for middleware
 	middleware.SetJWTService(application.JWTService)
+	middleware.SetAuthorizer(application.Authorizer)
+	middleware.SetDB(application.DB)
+
+	// Apply LOG_LEVEL and LOG_FORMAT from config
+	ConfigureLogger(application.Config.Log)
+
+	// Report panics and Error-level logs to Sentry if SENTRY_DSN is set
+	InitErrorReporter(application.Config)
+
+	// Load translations for response messages and emails
+	InitLang(application.Config.App)
+
+	// Sign pagination cursors with APP_KEY so they stay valid across
+	// restarts and instances; without it each process uses a random key
+	if key := application.Config.App.Key; key != "" {
+		pagination.SetCursorSecret([]byte(key))
+	}
 
 	// Set Mode
 	setGinMode(application.Config.Server.Mode)
@@ -72,22 +101,36 @@ func NewHttpKernel(application *app.Application) *HttpKernel {
 	}
 
 	// Add custom logger and recovery middleware
-	r.Use(logger.GinLogger())
-	r.Use(gin.Recovery())
-
-	// Add Prometheus metrics middleware
-	r.Use(metrics.Middleware())
-
-	// Apply Global Middleware (CORS mainly)
-	applyGlobalMiddleware(r, application.Config)
+	accessLog := middleware.DefaultAccessLogOptions()
+	accessLog.SampleRate = application.Config.Middleware.AccessLogSampleRate
+	r.Use(middleware.AccessLog(accessLog))
+	r.Use(middleware.RecoveryWithConfig(middleware.RecoveryConfig{
+		Debug:    application.Config.Server.Mode == "debug",
+		EventBus: application.EventBus,
+	}))
+
+	// Apply Global Middleware (metrics, real IP, request ID, locale, body limit, timeout, CORS)
+	corsPolicy := applyGlobalMiddleware(r, application.Config)
+	if application.Config.App.WatchConfig {
+		watchConfig(corsPolicy, application.DB)
+	}
 
 	// Initialize Health Checks
-	h := health.New()
-	h.Register("database", health.DatabaseChecker(application.DB))
+	h := newHealth(application)
 
 	// Register health and metrics routes
-	h.RegisterRoutes(r)
-	r.GET("/metrics", metrics.Handler())
+	h.RegisterRoutes(r, health.WithPrometheus(application.Config.Health.PrometheusEnabled))
+	if application.Config.Metrics.Enabled {
+		r.GET("/metrics", metrics.Handler())
+	}
+	r.GET("/.well-known/jwks.json", application.JWTService.JWKSHandler())
+
+	// Evaluate feature flags from FEATURE_FLAGS and the feature_overrides table
+	feature.SetGlobal(feature.NewManager(application.DB, application.Config.Feature.Flags).
+		WithCache(cache.Default(), application.Config.Cache.FeatureTTL))
+
+	// Expose services to the container before modules register theirs
+	application.Register(container.App())
 
 	// Initialize Modules (Events and Init)
 	for _, m := range application.Handlers.Modules() {
@@ -97,21 +140,52 @@ func NewHttpKernel(application *app.Application) *HttpKernel {
 		m.RegisterEvents(application.EventBus)
 	}
 
+	// Record user lifecycle events in audit_logs
+	if application.DB != nil {
+		audit.NewListener(application.DB).Subscribe(application.EventBus)
+	}
+
+	// Queue signed deliveries to the endpoints in webhook_endpoints
+	var webhooks *queue.Worker
+	if application.DB != nil {
+		webhooks = startWebhooks(application)
+	}
+
+	// Publish statements slower than DB_SLOW_THRESHOLD as SlowQueryEvents
+	if application.DB != nil && application.Config.Database.SlowThreshold > 0 {
+		if err := database.WithSlowQueryReporting(application.DB, application.EventBus, application.Config.Database.SlowThreshold); err != nil {
+			log.Printf("Warning: slow query reporting disabled: %v", err)
+		}
+	}
+
+	// Back rate limits and idempotency keys with the KV_DRIVER store
+	kv.SetDefault(newKVStore(application.Config))
+	session.SetDefault(newSessionStore(application.Config))
+
 	// Register Routes
 	// We temporarily silence Gin's default route logging to keep console clean
 	gin.SetMode(gin.ReleaseMode) // Temporarily set to release to silence route logs
 	routes.Setup(r, application.Handlers)
 	setGinMode(application.Config.Server.Mode) // Restore correct mode
 
+	// Register scheduled jobs
+	scheduler := schedule.Global()
+	routes.Schedule(scheduler, application)
+
 	// Print Professional Banner
 	support.PrintBanner("1.0.0")
 
-	return &HttpKernel{
+	k := &HttpKernel{
 		App:            application,
 		Engine:         r,
 		TracerProvider: tracerProvider,
 		Health:         h,
+		Scheduler:      scheduler,
+		Webhooks:       webhooks,
 	}
+	k.registerShutdownHooks()
+
+	return k
 }
 
 // Handle starts the HTTP server with graceful shutdown
@@ -126,6 +200,8 @@ func (k *HttpKernel) Handle() {
 		WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
 	}
 
+	k.startScheduler()
+
 	// Start Server in goroutine
 	go func() {
 		host := cfg.Server.Host
@@ -149,44 +225,102 @@ func (k *HttpKernel) Handle() {
 	k.gracefulShutdown(srv)
 }
 
-// gracefulShutdown handles graceful shutdown of the server and resources
+// gracefulShutdown waits for SIGINT/SIGTERM, then shuts down the server and resources
 func (k *HttpKernel) gracefulShutdown(srv *http.Server) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	k.shutdownOnSignal(quit, srv)
+}
+
+// shutdownOnSignal blocks until quit fires, then drains the server and runs
+// the shutdown hooks, all within the configured shutdown timeout
+func (k *HttpKernel) shutdownOnSignal(quit <-chan os.Signal, srv *http.Server) {
 	<-quit
 
 	log.Println("Shutting down server...")
 
-	// Create context with timeout for shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	timeout := time.Duration(k.App.Config.Server.ShutdownTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	// 1. Shutdown HTTP server (stop accepting new requests, wait for existing)
+	// Fail readiness probes from here on, so load balancers stop routing
+	// to this instance while in-flight requests drain
+	if k.Health != nil {
+		k.Health.Stop()
+	}
+
+	// Stop accepting new requests and wait for in-flight ones
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Printf("HTTP server shutdown error: %v", err)
 	}
 
-	// 2. Shutdown tracer provider (flush remaining spans)
-	if k.TracerProvider != nil {
-		if err := k.TracerProvider.Shutdown(ctx); err != nil {
-			log.Printf("Tracer shutdown error: %v", err)
-		} else {
-			log.Println("Tracer provider shutdown complete")
+	// Release resources with whatever budget is left
+	runShutdownHooks(ctx)
+
+	log.Println("Server exited gracefully")
+}
+
+// startScheduler runs the scheduled jobs until shutdown. Its hook is
+// registered last so it is released first: running jobs get the shutdown
+// budget to finish while the database, cache and event bus are still up.
+func (k *HttpKernel) startScheduler() {
+	if !k.App.Config.Schedule.Enabled || k.Scheduler == nil || len(k.Scheduler.Events()) == 0 {
+		return
+	}
+	for _, event := range k.Scheduler.Events() {
+		if err := event.Err(); err != nil {
+			log.Printf("Warning: Scheduled task '%s' will never run: %v", event.Name(), err)
 		}
 	}
 
-	// 3. Close database connection
-	if k.App.DB != nil {
-		if sqlDB, err := k.App.DB.DB(); err == nil {
-			if err := sqlDB.Close(); err != nil {
-				log.Printf("Database close error: %v", err)
-			} else {
-				log.Println("Database connection closed")
-			}
-		}
+	ctx, cancel := context.WithCancel(context.Background())
+	go k.Scheduler.Start(ctx)
+	OnShutdown("scheduler", func(shutdownCtx context.Context) error {
+		// Jobs still running when the budget runs out see ctx cancelled
+		defer cancel()
+		return k.Scheduler.Shutdown(shutdownCtx)
+	})
+	log.Printf("Scheduler started with %d jobs", len(k.Scheduler.Events()))
+}
+
+// registerShutdownHooks registers the kernel's own resources.
+// Registration order is the reverse of release order.
+func (k *HttpKernel) registerShutdownHooks() {
+	if db := k.App.DB; db != nil {
+		OnShutdown("database", func(ctx context.Context) error {
+			return database.Close(db)
+		})
 	}
 
-	log.Println("Server exited gracefully")
+	OnShutdown("cache", func(ctx context.Context) error {
+		return cache.Global().Close()
+	})
+
+	// Deliveries look up their endpoint, so finish them while the database
+	// is up, after the event handlers that queue them have drained
+	if w := k.Webhooks; w != nil {
+		OnShutdown("webhooks", w.Shutdown)
+	}
+
+	// Let emails already handed to the transport finish; the event
+	// handlers that send them are drained first
+	if svc := k.App.EmailService; svc != nil {
+		OnShutdown("email", svc.Flush)
+	}
+
+	// Async event handlers carry outgoing email, so drain them before the
+	// cache and database they rely on go away
+	if bus := k.App.EventBus; bus != nil {
+		OnShutdown("events", bus.Drain)
+	}
+
+	// Flush remaining spans first
+	if tp := k.TracerProvider; tp != nil {
+		OnShutdown("tracer", tp.Shutdown)
+	}
 }
 
 func setGinMode(mode string) {
@@ -200,13 +334,74 @@ func setGinMode(mode string) {
 	}
 }
 
-func applyGlobalMiddleware(r *gin.Engine, cfg *config.Config) {
-	corsConfig := cors.Config{
-		AllowOrigins:     cfg.CORS.AllowOrigins,
-		AllowMethods:     cfg.CORS.AllowMethods,
-		AllowHeaders:     cfg.CORS.AllowHeaders,
-		ExposeHeaders:    cfg.CORS.ExposeHeaders,
-		AllowCredentials: cfg.CORS.AllowCredentials,
+func applyGlobalMiddleware(r *gin.Engine, cfg *config.Config) *reloadableCORS {
+	if cfg.Metrics.Enabled {
+		r.Use(metrics.MiddlewareWithConfig(metrics.MiddlewareOptions{SkipPaths: cfg.Metrics.SkipPaths}))
+	}
+
+	// Keep gin's own c.ClientIP() in line with RealIP for code that still uses it
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Printf("Warning: Invalid SERVER_TRUSTED_PROXIES: %v", err)
+	}
+	r.Use(middleware.RealIP(cfg.Server.TrustedProxies))
+	r.Use(audit.Middleware())
+	r.Use(middleware.RequestID())
+	r.Use(middleware.Locale(middleware.LocaleConfig{
+		Default:   cfg.App.Locale,
+		Supported: cfg.App.Locales,
+	}))
+	r.Use(middleware.MaxBodySize(cfg.Server.MaxBodyBytes))
+	r.Use(middleware.Timeout(time.Duration(cfg.Server.RequestTimeout) * time.Second))
+
+	c := newReloadableCORS(cfg)
+	r.Use(c.Handle)
+	return c
+}
+
+// reloadableCORS serves the CORS policy from the current config and can be
+// swapped while the server runs
+type reloadableCORS struct {
+	handler atomic.Pointer[gin.HandlerFunc]
+}
+
+func newReloadableCORS(cfg *config.Config) *reloadableCORS {
+	c := &reloadableCORS{}
+	c.Update(cfg)
+	return c
+}
+
+// Update replaces the policy for subsequent requests
+func (c *reloadableCORS) Update(cfg *config.Config) {
+	h := middleware.CORSWithConfig(middleware.CORSConfigFrom(cfg.CORS))
+	c.handler.Store(&h)
+}
+
+// Handle runs the current policy
+func (c *reloadableCORS) Handle(ctx *gin.Context) {
+	(*c.handler.Load())(ctx)
+}
+
+// watchConfig applies log level, CORS and read replica changes from the env
+// files until shutdown
+func watchConfig(corsPolicy *reloadableCORS, db *gorm.DB) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := config.Watch(ctx, func(cfg *config.Config) {
+		ConfigureLogger(cfg.Log)
+		corsPolicy.Update(cfg)
+		if db != nil {
+			if err := database.ReloadReplicas(db, cfg.Database); err != nil {
+				log.Printf("Warning: Failed to reload read replicas: %v", err)
+			}
+		}
+		log.Println("Configuration reloaded")
+	})
+	if err != nil {
+		cancel()
+		log.Printf("Warning: Failed to watch config: %v", err)
+		return
 	}
-	r.Use(cors.New(corsConfig))
+	OnShutdown("config watcher", func(context.Context) error {
+		cancel()
+		return nil
+	})
 }
@@ -0,0 +1,35 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/kv"
+)
+
+// newKVStore builds the store selected by KV_DRIVER. An unreachable Redis
+// falls back to memory, so the API stays up with per-instance limits.
+func newKVStore(cfg *config.Config) kv.Store {
+	if cfg.KV.Driver != config.KVDriverRedis {
+		return kv.NewMemoryStore()
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		log.Printf("Warning: KV_DRIVER=redis but Redis is unreachable, using memory: %v", err)
+		_ = client.Close()
+		return kv.NewMemoryStore()
+	}
+
+	OnShutdown("kv", func(ctx context.Context) error {
+		return client.Close()
+	})
+	return kv.NewRedisStore(client)
+}
@@ -0,0 +1,35 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/pkg/errors"
+)
+
+// InitErrorReporter sends recovered panics and Error-level logs to Sentry
+// when SENTRY_DSN is set. Without it errors are only logged locally.
+func InitErrorReporter(cfg *config.Config) {
+	if cfg.Sentry.DSN == "" {
+		return
+	}
+
+	reporter, err := errors.NewSentryReporter(cfg.Sentry.DSN, cfg.App.Env)
+	if err != nil {
+		log.Printf("Warning: Failed to initialize Sentry: %v", err)
+		return
+	}
+	errors.SetReporter(reporter)
+
+	// Registered early so it runs late, after hooks that may still log errors
+	OnShutdown("sentry", func(ctx context.Context) error {
+		timeout := 2 * time.Second
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout = time.Until(deadline)
+		}
+		reporter.Flush(timeout)
+		return nil
+	})
+}
@@ -0,0 +1,21 @@
+package bootstrap
+
+import (
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/kv"
+	"github.com/zgiai/zgo/internal/infra/session"
+)
+
+// newSessionStore builds the store selected by SESSION_DRIVER. Server-side
+// sessions share the KV_DRIVER store, so kv.SetDefault must run first.
+func newSessionStore(cfg *config.Config) session.Store {
+	if cfg.Session.Driver != config.SessionDriverCookie {
+		return session.NewKVStore(kv.Default())
+	}
+
+	store := session.NewCookieStore(cfg.App.Key)
+	if cfg.Session.Encrypt {
+		store.WithEncryption()
+	}
+	return store
+}
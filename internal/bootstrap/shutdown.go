@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// ShutdownHook releases a resource during graceful shutdown
+type ShutdownHook struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
+var shutdownHooks struct {
+	mu    sync.Mutex
+	hooks []ShutdownHook
+}
+
+// OnShutdown registers a hook to run after the HTTP server stops accepting
+// requests. Hooks run in reverse registration order, so resources registered
+// first (like the database) are released last.
+//
+// Example:
+//
+//	bootstrap.OnShutdown("queue", func(ctx context.Context) error {
+//		return queue.Flush(ctx)
+//	})
+func OnShutdown(name string, fn func(ctx context.Context) error) {
+	shutdownHooks.mu.Lock()
+	defer shutdownHooks.mu.Unlock()
+	shutdownHooks.hooks = append(shutdownHooks.hooks, ShutdownHook{Name: name, Fn: fn})
+}
+
+// runShutdownHooks runs every registered hook within ctx's remaining budget.
+// Hooks left over once the budget is spent are skipped and logged.
+func runShutdownHooks(ctx context.Context) {
+	shutdownHooks.mu.Lock()
+	hooks := make([]ShutdownHook, len(shutdownHooks.hooks))
+	copy(hooks, shutdownHooks.hooks)
+	shutdownHooks.mu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		if err := ctx.Err(); err != nil {
+			log.Printf("Shutdown hook %s skipped: %v", hook.Name, err)
+			continue
+		}
+		if err := hook.Fn(ctx); err != nil {
+			log.Printf("Shutdown hook %s failed: %v", hook.Name, err)
+		} else {
+			log.Printf("Shutdown hook %s complete", hook.Name)
+		}
+	}
+}
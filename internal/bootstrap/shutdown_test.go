@@ -0,0 +1,134 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/zgiai/zgo/internal/app"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/health"
+	"github.com/zgiai/zgo/internal/infra/schedule"
+)
+
+func resetShutdownHooks(t *testing.T) {
+	t.Helper()
+	shutdownHooks.mu.Lock()
+	saved := shutdownHooks.hooks
+	shutdownHooks.hooks = nil
+	shutdownHooks.mu.Unlock()
+	t.Cleanup(func() {
+		shutdownHooks.mu.Lock()
+		shutdownHooks.hooks = saved
+		shutdownHooks.mu.Unlock()
+	})
+}
+
+func newTestKernel(timeout int) *HttpKernel {
+	cfg := &config.Config{}
+	cfg.Server.ShutdownTimeout = timeout
+	return &HttpKernel{App: &app.Application{Config: cfg}}
+}
+
+func TestShutdownOnSignal_RunsHooks(t *testing.T) {
+	resetShutdownHooks(t)
+
+	var order []string
+	OnShutdown("first", func(ctx context.Context) error {
+		order = append(order, "first")
+		return nil
+	})
+	OnShutdown("second", func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("hook context has no deadline")
+		}
+		order = append(order, "second")
+		return nil
+	})
+
+	quit := make(chan os.Signal, 1)
+	quit <- syscall.SIGTERM
+
+	done := make(chan struct{})
+	go func() {
+		newTestKernel(5).shutdownOnSignal(quit, &http.Server{})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not complete")
+	}
+
+	if len(order) != 2 || order[0] != "second" || order[1] != "first" {
+		t.Errorf("hooks ran as %v, want [second first]", order)
+	}
+}
+
+func TestRunShutdownHooks_SkipsWhenBudgetSpent(t *testing.T) {
+	resetShutdownHooks(t)
+
+	ran := false
+	OnShutdown("late", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	OnShutdown("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	runShutdownHooks(ctx)
+
+	if ran {
+		t.Error("hook ran after the shutdown budget was spent")
+	}
+}
+
+func TestStartScheduler_StopsOnShutdown(t *testing.T) {
+	resetShutdownHooks(t)
+
+	k := newTestKernel(5)
+	k.Scheduler = schedule.New()
+	k.Scheduler.Call("noop", func(ctx context.Context) error { return nil })
+
+	// Disabled: nothing starts
+	k.startScheduler()
+	if len(shutdownHooks.hooks) != 0 {
+		t.Fatalf("hooks = %v, want none while disabled", shutdownHooks.hooks)
+	}
+
+	k.App.Config.Schedule.Enabled = true
+	k.startScheduler()
+	if len(shutdownHooks.hooks) != 1 || shutdownHooks.hooks[0].Name != "scheduler" {
+		t.Fatalf("hooks = %v, want a scheduler hook", shutdownHooks.hooks)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := shutdownHooks.hooks[0].Fn(ctx); err != nil {
+		t.Errorf("scheduler hook error = %v", err)
+	}
+}
+
+func TestShutdownOnSignal_StopsHealthChecks(t *testing.T) {
+	resetShutdownHooks(t)
+
+	k := newTestKernel(5)
+	k.Health = health.New()
+	k.Health.Register("database", health.Up("ok"))
+
+	quit := make(chan os.Signal, 1)
+	quit <- syscall.SIGTERM
+	k.shutdownOnSignal(quit, &http.Server{})
+
+	if status := k.Health.OverallStatus(context.Background()); status != health.StatusDown {
+		t.Errorf("status after shutdown = %s, want down", status)
+	}
+}
@@ -0,0 +1,29 @@
+package bootstrap
+
+import (
+	"context"
+
+	"github.com/zgiai/zgo/internal/app"
+	"github.com/zgiai/zgo/internal/infra/queue"
+	"github.com/zgiai/zgo/internal/infra/webhook"
+	"github.com/zgiai/zgo/pkg/encryption"
+)
+
+// startWebhooks subscribes the webhook listener and starts the worker that
+// delivers and retries its jobs. Deliveries are queued in memory, so those
+// still pending when the process exits are lost.
+func startWebhooks(application *app.Application) *queue.Worker {
+	driver := queue.NewMemoryDriver(1000)
+	manager := queue.NewManager()
+	manager.RegisterDriver("memory", driver)
+	_ = manager.SetDefaultDriver("memory")
+
+	registry := webhook.NewRegistry(application.DB, encryption.New(application.Config.App.Key))
+	webhook.NewListener(registry, manager).Subscribe(application.EventBus)
+
+	worker := queue.NewWorker(queue.DefaultWorkerConfig())
+	worker.SetManager(manager)
+	worker.SetDriver(driver)
+	_ = worker.Start(context.Background())
+	return worker
+}
@@ -2,9 +2,23 @@ package domain
 
 import (
 	"errors"
+	"net/http"
 	"time"
+
+	"github.com/zgiai/zgo/pkg/response"
 )
 
+// Report domain sentinels through response.HandleError with their own
+// status and code
+func init() {
+	response.RegisterErrorResolver(func(err error) response.CodedError {
+		if appErr := AsAppError(err); appErr != nil {
+			return appErr
+		}
+		return nil
+	})
+}
+
 // Domain-specific errors
 // These are business errors that can be returned by any layer
 var (
@@ -13,6 +27,25 @@ var (
 	ErrEmailAlreadyExists = errors.New("email already registered")
 	ErrInvalidCredentials = errors.New("invalid username or password")
 	ErrAccountDisabled    = errors.New("account is disabled")
+	ErrStaleUpdate        = errors.New("user was modified by another request")
+
+	// ErrEmailBelongsToDeletedAccount is returned when registering with the
+	// email of a soft-deleted user, which stays reserved until force-deleted
+	ErrEmailBelongsToDeletedAccount = errors.New("email belongs to a deleted account")
+
+	// Email verification errors
+	ErrEmailNotVerified          = errors.New("email address is not verified")
+	ErrEmailAlreadyVerified      = errors.New("email address is already verified")
+	ErrInvalidVerificationToken  = errors.New("invalid verification token")
+	ErrVerificationTokenExpired  = errors.New("verification token has expired")
+	ErrTooManyVerificationEmails = errors.New("too many verification emails requested")
+
+	// Two-factor authentication errors
+	ErrTwoFactorAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotSetUp         = errors.New("two-factor authentication has not been set up")
+	ErrInvalidTwoFactorCode      = errors.New("invalid two-factor code")
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+	ErrTooManyTwoFactorAttempts  = errors.New("too many invalid two-factor codes")
 
 	// Permission errors
 	ErrPermissionDenied = errors.New("permission denied")
@@ -24,6 +57,130 @@ var (
 	ErrInvalidInput = errors.New("invalid input")
 )
 
+// AppError is a domain error carrying the HTTP status and machine-readable
+// code it is reported with. It wraps the sentinel it was built from, so
+// errors.Is(err, domain.ErrUserNotFound) keeps working.
+type AppError struct {
+	Code       string
+	Message    string
+	HTTPStatus int
+	err        error
+	cause      error
+}
+
+// Error returns the message, followed by the cause when there is one
+func (e *AppError) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return e.Message
+}
+
+// StatusCode returns the HTTP status the error is reported with
+func (e *AppError) StatusCode() int {
+	return e.HTTPStatus
+}
+
+// ErrorCode returns the machine-readable code, e.g. "USER_NOT_FOUND"
+func (e *AppError) ErrorCode() string {
+	return e.Code
+}
+
+// Unwrap returns the wrapped sentinel and cause
+func (e *AppError) Unwrap() []error {
+	errs := make([]error, 0, 2)
+	if e.err != nil {
+		errs = append(errs, e.err)
+	}
+	if e.cause != nil {
+		errs = append(errs, e.cause)
+	}
+	return errs
+}
+
+// errorDetail is the code and status a sentinel error is reported with
+type errorDetail struct {
+	err    error
+	code   string
+	status int
+}
+
+// errorDetails lists the sentinels above with their codes and statuses
+var errorDetails = []errorDetail{
+	{ErrUserNotFound, "USER_NOT_FOUND", http.StatusNotFound},
+	{ErrEmailAlreadyExists, "EMAIL_ALREADY_EXISTS", http.StatusConflict},
+	{ErrInvalidCredentials, "INVALID_CREDENTIALS", http.StatusUnauthorized},
+	{ErrAccountDisabled, "ACCOUNT_DISABLED", http.StatusForbidden},
+	{ErrStaleUpdate, "STALE_UPDATE", http.StatusConflict},
+	{ErrEmailBelongsToDeletedAccount, "EMAIL_BELONGS_TO_DELETED_ACCOUNT", http.StatusConflict},
+
+	{ErrEmailNotVerified, "EMAIL_NOT_VERIFIED", http.StatusForbidden},
+	{ErrEmailAlreadyVerified, "EMAIL_ALREADY_VERIFIED", http.StatusConflict},
+	{ErrInvalidVerificationToken, "INVALID_VERIFICATION_TOKEN", http.StatusBadRequest},
+	{ErrVerificationTokenExpired, "VERIFICATION_TOKEN_EXPIRED", http.StatusGone},
+	{ErrTooManyVerificationEmails, "TOO_MANY_VERIFICATION_EMAILS", http.StatusTooManyRequests},
+
+	{ErrTwoFactorAlreadyEnabled, "TWO_FACTOR_ALREADY_ENABLED", http.StatusConflict},
+	{ErrTwoFactorNotSetUp, "TWO_FACTOR_NOT_SET_UP", http.StatusBadRequest},
+	{ErrInvalidTwoFactorCode, "INVALID_TWO_FACTOR_CODE", http.StatusUnauthorized},
+	{ErrInvalidTwoFactorChallenge, "INVALID_TWO_FACTOR_CHALLENGE", http.StatusUnauthorized},
+	{ErrTooManyTwoFactorAttempts, "TOO_MANY_TWO_FACTOR_ATTEMPTS", http.StatusTooManyRequests},
+
+	{ErrPermissionDenied, "PERMISSION_DENIED", http.StatusForbidden},
+	{ErrRoleNotFound, "ROLE_NOT_FOUND", http.StatusNotFound},
+
+	{ErrNotFound, "NOT_FOUND", http.StatusNotFound},
+	{ErrConflict, "CONFLICT", http.StatusConflict},
+	{ErrInvalidInput, "INVALID_INPUT", http.StatusUnprocessableEntity},
+}
+
+// NewAppError builds the AppError for one of the sentinel errors above.
+// An optional cause is wrapped as well, so errors.Is matches both.
+// Unknown sentinels are reported as 500 INTERNAL_ERROR.
+//
+// Example:
+//
+//	return nil, domain.NewAppError(domain.ErrUserNotFound)
+//	return domain.NewAppError(domain.ErrConflict, err)
+func NewAppError(sentinel error, cause ...error) *AppError {
+	appErr := &AppError{
+		Code:       "INTERNAL_ERROR",
+		Message:    sentinel.Error(),
+		HTTPStatus: http.StatusInternalServerError,
+		err:        sentinel,
+	}
+	for _, d := range errorDetails {
+		if d.err == sentinel {
+			appErr.Code = d.code
+			appErr.HTTPStatus = d.status
+			break
+		}
+	}
+	if len(cause) > 0 {
+		appErr.cause = cause[0]
+	}
+	return appErr
+}
+
+// AsAppError returns the AppError in err's chain. Plain sentinel errors,
+// bare or wrapped with fmt.Errorf, are converted. It returns nil for errors
+// the domain does not know.
+func AsAppError(err error) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	for _, d := range errorDetails {
+		if errors.Is(err, d.err) {
+			return &AppError{Code: d.code, Message: d.err.Error(), HTTPStatus: d.status, err: err}
+		}
+	}
+	return nil
+}
+
 // Events
 const (
 	EventUserCreated = "user.created"
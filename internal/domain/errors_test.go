@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/zgiai/zgo/pkg/response"
+	"gorm.io/gorm"
+)
+
+func TestNewAppError_WrapsSentinel(t *testing.T) {
+	appErr := NewAppError(ErrUserNotFound)
+
+	if !errors.Is(appErr, ErrUserNotFound) {
+		t.Error("Expected errors.Is(appErr, ErrUserNotFound)")
+	}
+	if appErr.Code != "USER_NOT_FOUND" || appErr.HTTPStatus != http.StatusNotFound {
+		t.Errorf("Expected USER_NOT_FOUND/404, got %s/%d", appErr.Code, appErr.HTTPStatus)
+	}
+	if appErr.Error() != ErrUserNotFound.Error() {
+		t.Errorf("Expected message %q, got %q", ErrUserNotFound.Error(), appErr.Error())
+	}
+}
+
+func TestNewAppError_WithCause(t *testing.T) {
+	cause := errors.New("duplicate key")
+	appErr := NewAppError(ErrConflict, cause)
+
+	if !errors.Is(appErr, ErrConflict) || !errors.Is(appErr, cause) {
+		t.Error("Expected both the sentinel and the cause to match")
+	}
+	if appErr.Error() != "resource already exists: duplicate key" {
+		t.Errorf("Unexpected message %q", appErr.Error())
+	}
+}
+
+func TestNewAppError_UnknownSentinel(t *testing.T) {
+	appErr := NewAppError(errors.New("boom"))
+
+	if appErr.Code != "INTERNAL_ERROR" || appErr.HTTPStatus != http.StatusInternalServerError {
+		t.Errorf("Expected INTERNAL_ERROR/500, got %s/%d", appErr.Code, appErr.HTTPStatus)
+	}
+}
+
+func TestAsAppError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		code   string
+		status int
+	}{
+		{"bare sentinel", ErrInvalidCredentials, "INVALID_CREDENTIALS", http.StatusUnauthorized},
+		{"wrapped sentinel", fmt.Errorf("login: %w", ErrAccountDisabled), "ACCOUNT_DISABLED", http.StatusForbidden},
+		{"wrapped app error", fmt.Errorf("verify: %w", NewAppError(ErrVerificationTokenExpired)), "VERIFICATION_TOKEN_EXPIRED", http.StatusGone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := AsAppError(tt.err)
+			if appErr == nil {
+				t.Fatal("Expected an AppError")
+			}
+			if appErr.Code != tt.code || appErr.HTTPStatus != tt.status {
+				t.Errorf("Expected %s/%d, got %s/%d", tt.code, tt.status, appErr.Code, appErr.HTTPStatus)
+			}
+		})
+	}
+
+	if AsAppError(errors.New("boom")) != nil {
+		t.Error("Expected nil for unknown errors")
+	}
+}
+
+func TestStatusCode_DomainErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"user not found", ErrUserNotFound, http.StatusNotFound},
+		{"role not found", ErrRoleNotFound, http.StatusNotFound},
+		{"invalid credentials", ErrInvalidCredentials, http.StatusUnauthorized},
+		{"account disabled", ErrAccountDisabled, http.StatusForbidden},
+		{"permission denied", ErrPermissionDenied, http.StatusForbidden},
+		{"email exists", ErrEmailAlreadyExists, http.StatusConflict},
+		{"invalid input", ErrInvalidInput, http.StatusUnprocessableEntity},
+		{"wrapped domain error", fmt.Errorf("login: %w", ErrInvalidCredentials), http.StatusUnauthorized},
+		{"app error", NewAppError(ErrTooManyVerificationEmails), http.StatusTooManyRequests},
+		{"app error overrides mapping", NewAppError(ErrConflict, gorm.ErrRecordNotFound), http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := response.StatusCode(tt.err); got != tt.want {
+				t.Errorf("Expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -115,6 +115,48 @@ func NewUserDeletedEvent(userID uint, email string) UserDeletedEvent {
 	}
 }
 
+// UserDisabledEvent is fired when an admin disables a user account
+type UserDisabledEvent struct {
+	BaseEvent
+	UserID uint
+	Email  string
+}
+
+// EventName returns the event name
+func (e UserDisabledEvent) EventName() string {
+	return "user.disabled"
+}
+
+// NewUserDisabledEvent creates a new UserDisabledEvent
+func NewUserDisabledEvent(userID uint, email string) UserDisabledEvent {
+	return UserDisabledEvent{
+		BaseEvent: NewBaseEvent(),
+		UserID:    userID,
+		Email:     email,
+	}
+}
+
+// UserEnabledEvent is fired when an admin enables a disabled user account
+type UserEnabledEvent struct {
+	BaseEvent
+	UserID uint
+	Email  string
+}
+
+// EventName returns the event name
+func (e UserEnabledEvent) EventName() string {
+	return "user.enabled"
+}
+
+// NewUserEnabledEvent creates a new UserEnabledEvent
+func NewUserEnabledEvent(userID uint, email string) UserEnabledEvent {
+	return UserEnabledEvent{
+		BaseEvent: NewBaseEvent(),
+		UserID:    userID,
+		Email:     email,
+	}
+}
+
 // Permission Domain Events
 
 // RoleAssignedEvent is fired when a role is assigned to a user
@@ -70,6 +70,17 @@ type UserRoleRepository interface {
 	GetUserRoles(ctx context.Context, userID uint) ([]*Role, error)
 }
 
+// RoleAssigner defines the contract for granting roles to new users
+type RoleAssigner interface {
+	AssignDefaultRole(ctx context.Context, userID uint) error
+}
+
+// Authorizer defines the contract for resolving a user's roles and permissions by name
+type Authorizer interface {
+	UserRoleNames(ctx context.Context, userID uint) ([]string, error)
+	UserPermissionNames(ctx context.Context, userID uint) ([]string, error)
+}
+
 // RolePermissionRepository defines the contract for role-permission association operations
 type RolePermissionRepository interface {
 	Grant(ctx context.Context, roleID, permissionID uint) error
@@ -20,6 +20,12 @@ type TokenGenerator interface {
 	Validate(token string) (userID uint, username string, err error)
 }
 
+// Transactor defines the contract for running a unit of work atomically.
+// Repositories called with the context passed to fn join the transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // AuthenticationService handles user authentication logic
 type AuthenticationService struct {
 	userRepo UserRepository
@@ -134,8 +140,11 @@ func (s *RegistrationService) Register(ctx context.Context, req *RegistrationReq
 		return nil, err
 	}
 
-	// Check if email already exists
-	existing, _ := s.userRepo.FindByEmail(ctx, email.String())
+	// Check if email already exists, soft-deleted users included
+	existing, _ := s.userRepo.FindByEmailWithTrashed(ctx, email.String())
+	if existing != nil && existing.DeletedAt != nil {
+		return nil, ErrEmailBelongsToDeletedAccount
+	}
 	if existing != nil {
 		return nil, ErrEmailAlreadyExists
 	}
@@ -3,6 +3,8 @@ package domain
 import (
 	"context"
 	"time"
+
+	"github.com/zgiai/zgo/pkg/query"
 )
 
 // User represents the core domain entity.
@@ -21,8 +23,26 @@ type User struct {
 	Bio       string     `json:"bio,omitempty"`
 	Status    int        `json:"status"`
 	LastLogin *time.Time `json:"last_login,omitempty"`
+
+	// VerificationSentAt is when the latest verification link was issued
+	VerificationSentAt *time.Time `json:"-"`
+
+	// TwoFactorSecret is the encrypted TOTP secret. It is stored when 2FA is
+	// set up and only checked at login once TwoFactorEnabled is confirmed.
+	TwoFactorSecret  string `json:"-"`
+	TwoFactorEnabled bool   `json:"two_factor_enabled"`
+
+	// Version increases with every update. Updates made from an older
+	// version fail with ErrStaleUpdate instead of overwriting newer data.
+	Version int `json:"version"`
+
+	// Roles holds the user's role names. It is only filled by the *With
+	// finders given UserRelationRoles; other finders leave it nil.
+	Roles []string `json:"roles,omitempty"`
+
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"` // Set only for soft-deleted users
 }
 
 // IsActive returns whether the user account is active
@@ -30,14 +50,37 @@ func (u *User) IsActive() bool {
 	return u.Status == 1
 }
 
+// IsPending returns whether the account is waiting for email verification
+func (u *User) IsPending() bool {
+	return u.Status == int(UserStatusPending)
+}
+
+// UserRelationRoles names the user's roles for the *With finders
+const UserRelationRoles = "Roles"
+
 // UserRepository defines the contract for user data operations
 // Implementations live in modules/user/repository.go
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
+	// Update saves user if its Version is still current and bumps the
+	// Version; otherwise it returns ErrStaleUpdate
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id uint) error
 	FindByID(ctx context.Context, id uint) (*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindByUsername(ctx context.Context, username string) (*User, error)
 	FindAll(ctx context.Context, page, pageSize int) ([]*User, int64, error)
+	// FindByIDWith and FindAllWith also load the named relations, such as
+	// UserRelationRoles, in one query each. Unknown relations are ignored.
+	FindByIDWith(ctx context.Context, id uint, relations ...string) (*User, error)
+	FindAllWith(ctx context.Context, page, pageSize int, relations ...string) ([]*User, int64, error)
+	Search(ctx context.Context, spec *query.Spec, page, pageSize int) ([]*User, int64, error)
+
+	// Soft delete
+	FindWithTrashed(ctx context.Context, page, pageSize int) ([]*User, int64, error)
+	// FindByEmailWithTrashed finds the user holding email, even soft-deleted.
+	// Soft-deleted users keep their email reserved so they can be restored.
+	FindByEmailWithTrashed(ctx context.Context, email string) (*User, error)
+	Restore(ctx context.Context, id uint) error
+	ForceDelete(ctx context.Context, id uint) error
 }
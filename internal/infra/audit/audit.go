@@ -0,0 +1,124 @@
+// Package audit records security-relevant user activity in the audit_logs
+// table. Entries are written by a listener on the event bus, so services
+// only publish domain events and never write audit rows themselves.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/events"
+	"github.com/zgiai/zgo/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// Log is one audit_logs row
+type Log struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ActorID    *uint     `gorm:"index" json:"actor_id"` // nil for system actions
+	Action     string    `gorm:"size:100;not null;index" json:"action"`
+	TargetType string    `gorm:"size:50" json:"target_type"`
+	TargetID   uint      `gorm:"index" json:"target_id"`
+	IP         string    `gorm:"size:45" json:"ip"`
+	Metadata   string    `gorm:"type:text" json:"metadata"` // JSON object
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName specifies the database table name
+func (Log) TableName() string {
+	return "audit_logs"
+}
+
+// Actions recorded by the listener, named after the events they come from
+var Actions = []string{
+	domain.UserRegisteredEvent{}.EventName(),
+	domain.UserLoggedInEvent{}.EventName(),
+	domain.UserPasswordChangedEvent{}.EventName(),
+	domain.UserDeletedEvent{}.EventName(),
+}
+
+// Listener writes an audit entry for each user lifecycle event
+type Listener struct {
+	db *gorm.DB
+}
+
+// NewListener creates a listener that writes to db
+func NewListener(db *gorm.DB) *Listener {
+	return &Listener{db: db}
+}
+
+// Subscribe registers the listener for every audited action. Entries are
+// written asynchronously so a slow insert never delays the request.
+func (l *Listener) Subscribe(bus *events.EventBus) {
+	for _, action := range Actions {
+		bus.Subscribe(action, l.Handle, events.WithAsync())
+	}
+}
+
+// Handle persists the entry for e. Events that are not audited are ignored.
+func (l *Listener) Handle(ctx context.Context, e events.Event) error {
+	entry, ok := entryFor(e)
+	if !ok {
+		return nil
+	}
+	if entry.IP == "" {
+		entry.IP = IPFromContext(ctx)
+	}
+	entry.CreatedAt = e.OccurredAt()
+
+	if err := l.db.WithContext(context.WithoutCancel(ctx)).Create(entry).Error; err != nil {
+		logger.Error("failed to write audit log", map[string]any{
+			"error":  err,
+			"action": entry.Action,
+		})
+		return fmt.Errorf("audit: %w", err)
+	}
+	return nil
+}
+
+// entryFor maps a user event to its audit entry. Users act on their own
+// account in every audited flow, so the actor is also the target.
+func entryFor(e events.Event) (*Log, bool) {
+	var underlying any = e
+	if wrapped, ok := e.(events.WrappedEvent); ok {
+		underlying = wrapped.Event
+	}
+
+	var (
+		userID   uint
+		ip       string
+		metadata map[string]any
+	)
+	switch ev := underlying.(type) {
+	case domain.UserRegisteredEvent:
+		userID = ev.UserID
+		metadata = map[string]any{"username": ev.Username, "email": ev.Email}
+	case domain.UserLoggedInEvent:
+		userID, ip = ev.UserID, ev.IPAddress
+		metadata = map[string]any{"username": ev.Username}
+	case domain.UserPasswordChangedEvent:
+		userID = ev.UserID
+	case domain.UserDeletedEvent:
+		userID = ev.UserID
+		metadata = map[string]any{"email": ev.Email}
+	default:
+		return nil, false
+	}
+
+	entry := &Log{
+		ActorID:    &userID,
+		Action:     e.EventName(),
+		TargetType: "user",
+		TargetID:   userID,
+		IP:         ip,
+	}
+	if len(metadata) > 0 {
+		if data, err := json.Marshal(metadata); err == nil {
+			entry.Metadata = string(data)
+		}
+	}
+	return entry, true
+}
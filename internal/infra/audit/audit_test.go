@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/events"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"gorm.io/gorm"
+)
+
+func setupAuditDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	return dbtest.Open(t, &Log{})
+}
+
+// publishAndDrain publishes e and waits for the async listener to finish
+func publishAndDrain(t *testing.T, db *gorm.DB, ctx context.Context, e domain.Event) {
+	t.Helper()
+	bus := events.NewEventBus()
+	NewListener(db).Subscribe(bus)
+
+	if err := bus.Publish(ctx, e); err != nil {
+		t.Fatal(err)
+	}
+	drainCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := bus.Drain(drainCtx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListener_PersistsRegistration(t *testing.T) {
+	db := setupAuditDB(t)
+	ctx := ContextWithIP(context.Background(), "203.0.113.7")
+
+	publishAndDrain(t, db, ctx, domain.NewUserRegisteredEvent(42, "alice", "alice@example.com"))
+
+	var logs []Log
+	if err := db.Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("got %d audit rows, want 1", len(logs))
+	}
+
+	got := logs[0]
+	if got.Action != "user.registered" || got.TargetType != "user" || got.TargetID != 42 {
+		t.Errorf("entry = %+v", got)
+	}
+	if got.ActorID == nil || *got.ActorID != 42 {
+		t.Errorf("ActorID = %v, want 42", got.ActorID)
+	}
+	if got.IP != "203.0.113.7" {
+		t.Errorf("IP = %q, want the context IP", got.IP)
+	}
+	if got.Metadata != `{"email":"alice@example.com","username":"alice"}` {
+		t.Errorf("Metadata = %s", got.Metadata)
+	}
+	if got.CreatedAt.IsZero() {
+		t.Error("CreatedAt is zero")
+	}
+}
+
+func TestListener_PrefersEventIP(t *testing.T) {
+	db := setupAuditDB(t)
+	ctx := ContextWithIP(context.Background(), "203.0.113.7")
+
+	publishAndDrain(t, db, ctx, domain.NewUserLoggedInEvent(7, "bob", "198.51.100.1"))
+
+	var entry Log
+	if err := db.First(&entry).Error; err != nil {
+		t.Fatal(err)
+	}
+	if entry.Action != "user.logged_in" || entry.IP != "198.51.100.1" {
+		t.Errorf("entry = %+v", entry)
+	}
+}
+
+func TestListener_IgnoresOtherEvents(t *testing.T) {
+	db := setupAuditDB(t)
+	if err := NewListener(db).Handle(context.Background(), events.Wrap(domain.NewRoleAssignedEvent(1, 2, "admin"))); err != nil {
+		t.Fatal(err)
+	}
+
+	var count int64
+	db.Model(&Log{}).Count(&count)
+	if count != 0 {
+		t.Errorf("got %d audit rows, want 0", count)
+	}
+}
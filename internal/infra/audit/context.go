@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/middleware"
+)
+
+// ipKey is the context key for the client IP
+type ipKey struct{}
+
+// ContextWithIP returns a copy of ctx carrying the client IP
+func ContextWithIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, ipKey{}, ip)
+}
+
+// IPFromContext returns the client IP carried by ctx, or ""
+func IPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(ipKey{}).(string)
+	return ip
+}
+
+// Middleware copies the client IP resolved by middleware.RealIP onto the
+// request context, where the listener can read it after the request ends.
+// Install it after RealIP.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(ContextWithIP(c.Request.Context(), middleware.ClientIP(c)))
+		c.Next()
+	}
+}
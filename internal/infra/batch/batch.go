@@ -0,0 +1,196 @@
+// Package batch serves several API requests in one round trip. A batch is
+// a JSON array of sub-requests that are replayed through the gin engine in
+// order, carrying the caller's headers so each route authenticates them as
+// it would on its own.
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// batchKey marks requests made from inside a batch
+type batchKey struct{}
+
+// Request is one sub-request of a batch
+type Request struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// Response is the outcome of one sub-request, in the order requested
+type Response struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// Options holds batch handler configuration
+type Options struct {
+	// MaxRequests caps the number of sub-requests in one batch
+	// Default: 20
+	MaxRequests int
+
+	// DropHeaders are caller headers not passed on to sub-requests
+	// Default: Content-Length, Accept-Encoding, Idempotency-Key
+	DropHeaders []string
+}
+
+// DefaultOptions returns default configuration
+func DefaultOptions() Options {
+	return Options{
+		MaxRequests: 20,
+		// Compressed bodies cannot be embedded, and one idempotency key
+		// cannot cover several requests
+		DropHeaders: []string{"Content-Length", "Accept-Encoding", "Idempotency-Key"},
+	}
+}
+
+var (
+	errEmptyBatch = errors.New("batch: no requests")
+	errRecursive  = errors.New("batch: a batch cannot contain another batch")
+)
+
+// Handler returns a handler running each sub-request through engine.
+// Items run sequentially, so a later item sees the effects of earlier ones,
+// and a failing item does not stop the rest.
+//
+// Example:
+//
+//	api.POST("/batch", batch.Handler(engine)).Name("batch")
+//
+//	POST /v1/batch
+//	[{"method": "GET", "path": "/v1/users/profile"},
+//	 {"method": "PUT", "path": "/v1/users/profile", "body": {"username": "alice"}}]
+func Handler(engine http.Handler) gin.HandlerFunc {
+	return HandlerWithOptions(engine, DefaultOptions())
+}
+
+// HandlerWithOptions returns a batch handler with custom options
+func HandlerWithOptions(engine http.Handler, opts Options) gin.HandlerFunc {
+	if opts.MaxRequests <= 0 {
+		opts.MaxRequests = DefaultOptions().MaxRequests
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Context().Value(batchKey{}) != nil {
+			response.BadRequest(c, "Invalid batch request", errRecursive)
+			return
+		}
+
+		var requests []Request
+		if err := c.ShouldBindJSON(&requests); err != nil {
+			response.BadRequest(c, "Invalid batch request", err)
+			return
+		}
+		if len(requests) == 0 {
+			response.BadRequest(c, "Invalid batch request", errEmptyBatch)
+			return
+		}
+		if len(requests) > opts.MaxRequests {
+			response.BadRequest(c, "Too many batch requests",
+				fmt.Errorf("batch: %d requests, at most %d allowed", len(requests), opts.MaxRequests))
+			return
+		}
+
+		// Validate every item first so a bad batch has no side effects
+		self := c.Request.URL.Path
+		for i, req := range requests {
+			if err := validate(req, self); err != nil {
+				response.BadRequest(c, "Invalid batch request", fmt.Errorf("request %d: %w", i, err))
+				return
+			}
+		}
+
+		ctx := context.WithValue(c.Request.Context(), batchKey{}, true)
+		responses := make([]Response, len(requests))
+		for i, req := range requests {
+			responses[i] = serve(ctx, engine, c.Request, req, opts.DropHeaders)
+		}
+		response.Success(c, responses)
+	}
+}
+
+// validate checks a sub-request before any item runs
+func validate(req Request, self string) error {
+	switch strings.ToUpper(req.Method) {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return fmt.Errorf("unsupported method %q", req.Method)
+	}
+
+	u, err := url.Parse(req.Path)
+	if err != nil || u.IsAbs() || !strings.HasPrefix(u.Path, "/") {
+		return fmt.Errorf("path %q must be an absolute path such as /v1/users/profile", req.Path)
+	}
+	if path.Clean(u.Path) == path.Clean(self) {
+		return errRecursive
+	}
+	return nil
+}
+
+// serve runs one sub-request through engine with the caller's headers
+func serve(ctx context.Context, engine http.Handler, parent *http.Request, req Request, dropHeaders []string) Response {
+	var body io.Reader = http.NoBody
+	if len(req.Body) > 0 {
+		body = bytes.NewReader(req.Body)
+	}
+
+	sub, err := http.NewRequestWithContext(ctx, strings.ToUpper(req.Method), req.Path, body)
+	if err != nil {
+		return Response{Status: http.StatusBadRequest, Body: encodeBody([]byte(err.Error()))}
+	}
+	sub.Header = parent.Header.Clone()
+	for _, name := range dropHeaders {
+		sub.Header.Del(name)
+	}
+	if len(req.Body) > 0 {
+		sub.Header.Set("Content-Type", "application/json")
+	}
+	sub.RemoteAddr = parent.RemoteAddr
+	sub.Host = parent.Host
+
+	rec := newRecorder()
+	engine.ServeHTTP(rec, sub)
+	return Response{Status: rec.status, Body: encodeBody(rec.body.Bytes())}
+}
+
+// encodeBody embeds JSON bodies as they are and anything else as a string
+func encodeBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return json.RawMessage("null")
+	}
+	if json.Valid(body) {
+		return body
+	}
+	data, _ := json.Marshal(string(body))
+	return data
+}
+
+// recorder captures a sub-response in memory
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newRecorder() *recorder {
+	return &recorder{header: make(http.Header), status: http.StatusOK}
+}
+
+func (r *recorder) Header() http.Header { return r.header }
+
+func (r *recorder) Write(b []byte) (int, error) { return r.body.Write(b) }
+
+func (r *recorder) WriteHeader(status int) { r.status = status }
@@ -0,0 +1,154 @@
+package batch
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newBatchEngine serves a token-protected profile the way the user module
+// does, plus the batch endpoint
+func newBatchEngine(opts Options) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	profiles := map[string]string{"alice-token": "alice"}
+
+	r := gin.New()
+	auth := func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if _, ok := profiles[token]; !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized"})
+			return
+		}
+		c.Set("token", token)
+	}
+	v1 := r.Group("/v1")
+	v1.GET("/users/profile", auth, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"username": profiles[c.GetString("token")]})
+	})
+	v1.PUT("/users/profile", auth, func(c *gin.Context) {
+		var req struct {
+			Username string `json:"username"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil || req.Username == "" {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "username is required"})
+			return
+		}
+		profiles[c.GetString("token")] = req.Username
+		c.JSON(http.StatusOK, gin.H{"username": req.Username})
+	})
+	v1.POST("/batch", HandlerWithOptions(r, opts))
+	return r
+}
+
+type batchResult struct {
+	Data []struct {
+		Status int            `json:"status"`
+		Body   map[string]any `json:"body"`
+	} `json:"data"`
+}
+
+func postBatch(r http.Handler, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestBatch_ProfileReadAndUpdate(t *testing.T) {
+	r := newBatchEngine(DefaultOptions())
+
+	w := postBatch(r, `[
+		{"method": "GET", "path": "/v1/users/profile"},
+		{"method": "PUT", "path": "/v1/users/profile", "body": {"username": "alice2"}},
+		{"method": "GET", "path": "/v1/users/profile"},
+		{"method": "PUT", "path": "/v1/users/profile", "body": {}}
+	]`, "alice-token")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	var result batchResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Data) != 4 {
+		t.Fatalf("got %d sub-responses, want 4", len(result.Data))
+	}
+
+	want := []struct {
+		status   int
+		username any
+	}{
+		{http.StatusOK, "alice"},
+		{http.StatusOK, "alice2"},
+		{http.StatusOK, "alice2"}, // items run in order
+		{http.StatusUnprocessableEntity, nil},
+	}
+	for i, exp := range want {
+		got := result.Data[i]
+		if got.Status != exp.status || got.Body["username"] != exp.username {
+			t.Errorf("item %d = %d %v, want %d username %v", i, got.Status, got.Body, exp.status, exp.username)
+		}
+	}
+}
+
+func TestBatch_SharesCallerAuth(t *testing.T) {
+	r := newBatchEngine(DefaultOptions())
+
+	w := postBatch(r, `[{"method": "GET", "path": "/v1/users/profile"}]`, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+
+	var result batchResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Data[0].Status != http.StatusUnauthorized {
+		t.Errorf("anonymous sub-request status = %d, want 401", result.Data[0].Status)
+	}
+}
+
+func TestBatch_RejectsInvalidBatches(t *testing.T) {
+	r := newBatchEngine(Options{MaxRequests: 2})
+
+	cases := map[string]string{
+		"not an array": `{"method": "GET", "path": "/v1/users/profile"}`,
+		"empty":        `[]`,
+		"too many":     `[{"method": "GET", "path": "/v1/users/profile"}, {"method": "GET", "path": "/v1/users/profile"}, {"method": "GET", "path": "/v1/users/profile"}]`,
+		"recursive":    `[{"method": "POST", "path": "/v1/batch/", "body": []}]`,
+		"bad method":   `[{"method": "CONNECT", "path": "/v1/users/profile"}]`,
+		"absolute url": `[{"method": "GET", "path": "http://example.com/v1/users/profile"}]`,
+	}
+	for name, body := range cases {
+		if w := postBatch(r, body, "alice-token"); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", name, w.Code)
+		}
+	}
+}
+
+func TestBatch_ValidatesBeforeRunning(t *testing.T) {
+	r := newBatchEngine(DefaultOptions())
+
+	postBatch(r, `[
+		{"method": "PUT", "path": "/v1/users/profile", "body": {"username": "mallory"}},
+		{"method": "POST", "path": "/v1/batch", "body": []}
+	]`, "alice-token")
+
+	w := postBatch(r, `[{"method": "GET", "path": "/v1/users/profile"}]`, "alice-token")
+	var result batchResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if got := result.Data[0].Body["username"]; got != "alice" {
+		t.Errorf("username = %v, a rejected batch must not run any item", got)
+	}
+}
@@ -3,8 +3,11 @@ package cache
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
+
+	"github.com/zgiai/zgo/internal/infra/singleflight"
 )
 
 // ErrCacheMiss is returned when a key is not found in the cache
@@ -47,6 +50,9 @@ type Manager struct {
 var (
 	manager *Manager
 	once    sync.Once
+
+	// remembering collapses concurrent Remember misses for the same key
+	remembering = singleflight.New()
 )
 
 // Global returns the global cache manager
@@ -91,6 +97,50 @@ func (m *Manager) Default() Store {
 	return m.Store(m.default_)
 }
 
+// Close releases every registered store that holds resources,
+// such as Redis connections or memory cleanup goroutines
+func (m *Manager) Close() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var errs []error
+	for name, store := range m.stores {
+		switch s := store.(type) {
+		case interface{ Close() error }:
+			if err := s.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("cache store %s: %w", name, err))
+			}
+		case interface{ Close() }:
+			s.Close()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterStore registers a cache store on the global manager
+func RegisterStore(name string, store Store) {
+	Global().Register(name, store)
+}
+
+// SetDefaultStore sets the default store of the global manager
+func SetDefaultStore(name string) {
+	Global().SetDefault(name)
+}
+
+// Use returns a store by name, falling back to the default store.
+//
+// Example:
+//
+//	cache.Use("redis").Put(ctx, "key", "value", time.Hour)
+func Use(name string) Store {
+	return Global().Store(name)
+}
+
+// Default returns the default store of the global manager
+func Default() Store {
+	return Global().Default()
+}
+
 // --- Convenience functions using default store ---
 
 // Get retrieves a value from the default cache
@@ -157,27 +207,35 @@ func Remember(ctx context.Context, key string, ttl time.Duration, callback func(
 	return RememberStore(ctx, Global().Default(), key, ttl, callback)
 }
 
-// RememberStore gets a value from a specific store or stores the result of callback
+// RememberStore gets a value from a specific store or stores the result of callback.
+// Concurrent misses for the same key run callback only once.
 func RememberStore(ctx context.Context, store Store, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
 	// Try to get from cache first
 	if val, err := store.Get(ctx, key); err == nil {
 		return val, nil
 	}
 
-	// Execute callback
-	val, err := callback()
-	if err != nil {
-		return nil, err
-	}
+	return remembering.Do(fmt.Sprintf("%p:%s", store, key), func() (any, error) {
+		// Another caller may have filled the key while we waited
+		if val, err := store.Get(ctx, key); err == nil {
+			return val, nil
+		}
 
-	// Store in cache
-	if ttl > 0 {
-		_ = store.Put(ctx, key, val, ttl)
-	} else {
-		_ = store.Forever(ctx, key, val)
-	}
+		// Execute callback
+		val, err := callback()
+		if err != nil {
+			return nil, err
+		}
 
-	return val, nil
+		// Store in cache
+		if ttl > 0 {
+			_ = store.Put(ctx, key, val, ttl)
+		} else {
+			_ = store.Forever(ctx, key, val)
+		}
+
+		return val, nil
+	})
 }
 
 // RememberForever gets a value from cache or stores the result indefinitely
@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore_TTLExpiry(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+	ctx := context.Background()
+
+	_ = store.Put(ctx, "short", "value", 20*time.Millisecond)
+	_ = store.Forever(ctx, "forever", "value")
+
+	if _, err := store.Get(ctx, "short"); err != nil {
+		t.Fatalf("Expected hit before expiry, got %v", err)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+
+	if _, err := store.Get(ctx, "short"); err != ErrCacheMiss {
+		t.Errorf("Expected ErrCacheMiss after expiry, got %v", err)
+	}
+	if !store.Has(ctx, "forever") {
+		t.Error("Expected item without TTL to remain")
+	}
+}
+
+func TestMemoryStore_MaxItemsEvictsLRU(t *testing.T) {
+	store := NewMemoryStore(WithMaxItems(2))
+	defer store.Close()
+	ctx := context.Background()
+
+	_ = store.Put(ctx, "a", 1, 0)
+	_ = store.Put(ctx, "b", 2, 0)
+	_, _ = store.Get(ctx, "a") // a is now the most recently used
+	_ = store.Put(ctx, "c", 3, 0)
+
+	if store.Len() != 2 {
+		t.Errorf("Expected 2 items, got %d", store.Len())
+	}
+	if store.Has(ctx, "b") {
+		t.Error("Expected least recently used 'b' to be evicted")
+	}
+	if !store.Has(ctx, "a") || !store.Has(ctx, "c") {
+		t.Error("Expected 'a' and 'c' to remain")
+	}
+}
+
+func TestRememberStore_PopulatesOnMiss(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+	ctx := context.Background()
+
+	calls := 0
+	load := func() (interface{}, error) {
+		calls++
+		return "loaded", nil
+	}
+
+	for i := 0; i < 3; i++ {
+		val, err := RememberStore(ctx, store, "key", time.Minute, load)
+		if err != nil || val != "loaded" {
+			t.Fatalf("Expected 'loaded', got %v (%v)", val, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected callback once, got %d calls", calls)
+	}
+}
+
+func TestRememberStore_CallbackError(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+	ctx := context.Background()
+
+	_, err := RememberStore(ctx, store, "key", time.Minute, func() (interface{}, error) {
+		return nil, fmt.Errorf("db down")
+	})
+	if err == nil {
+		t.Fatal("Expected callback error")
+	}
+	if store.Has(ctx, "key") {
+		t.Error("Expected nothing cached after an error")
+	}
+}
+
+func TestRememberStore_ConcurrentMisses(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+	ctx := context.Background()
+
+	var calls int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := RememberStore(ctx, store, "shared", time.Minute, func() (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(20 * time.Millisecond)
+				return "value", nil
+			})
+			if err != nil || val != "value" {
+				t.Errorf("Expected 'value', got %v (%v)", val, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected callback once for concurrent misses, got %d", calls)
+	}
+}
+
+func TestMemoryStore_Concurrency(t *testing.T) {
+	store := NewMemoryStore(WithMaxItems(64))
+	defer store.Close()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				key := fmt.Sprintf("key:%d", (n*j)%100)
+				_ = store.Put(ctx, key, j, time.Minute)
+				_, _ = store.Get(ctx, key)
+				_, _ = store.Increment(ctx, "counter", 1)
+				if j%50 == 0 {
+					_ = store.Forget(ctx, key)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if store.Len() > 64 {
+		t.Errorf("Expected at most 64 items, got %d", store.Len())
+	}
+}
+
+func TestGlobalStores(t *testing.T) {
+	custom := NewMemoryStore()
+	defer custom.Close()
+	RegisterStore("custom", custom)
+
+	if Use("custom") != custom {
+		t.Error("Expected Use to return the registered store")
+	}
+	if Use("missing") != Default() {
+		t.Error("Expected unknown store to fall back to the default")
+	}
+}
+
+func TestManager_Close(t *testing.T) {
+	m := &Manager{stores: map[string]Store{"memory": NewMemoryStore()}, default_: "memory"}
+
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close error = %v", err)
+	}
+	// Closing twice must not panic on the memory store's cleanup channel
+	if err := m.Close(); err != nil {
+		t.Fatalf("second Close error = %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrLockNotSupported is returned when the store cannot hold locks
+	ErrLockNotSupported = errors.New("cache: store does not support locks")
+
+	// ErrLockNotAcquired is returned by WithLock when another holder has the lock
+	ErrLockNotAcquired = errors.New("cache: lock is held by another owner")
+)
+
+// lockPrefix keeps lock keys apart from cached values
+const lockPrefix = "lock:"
+
+// Locker is implemented by stores that provide atomic, expiring locks.
+// MemoryStore locks are local to the process; RedisStore locks are shared
+// by every instance using the same Redis.
+type Locker interface {
+	// AcquireLock takes key for owner unless it is already held, and
+	// releases it automatically after ttl
+	AcquireLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+
+	// ReleaseLock frees key if owner still holds it
+	ReleaseLock(ctx context.Context, key, owner string) error
+}
+
+// Lock tries to take key on the default store. The lock expires after ttl
+// even if unlock is never called, so a crashed holder cannot block others
+// forever; pick a ttl longer than the work it guards.
+//
+// Example:
+//
+//	unlock, acquired, err := cache.Lock(ctx, "jobs:cleanup", time.Minute)
+//	if err != nil || !acquired {
+//	    return err // another instance is running it
+//	}
+//	defer unlock()
+func Lock(ctx context.Context, key string, ttl time.Duration) (unlock func(), acquired bool, err error) {
+	return LockStore(ctx, Global().Default(), key, ttl)
+}
+
+// LockStore is Lock on a specific store
+func LockStore(ctx context.Context, store Store, key string, ttl time.Duration) (unlock func(), acquired bool, err error) {
+	locker, ok := store.(Locker)
+	if !ok {
+		return nil, false, ErrLockNotSupported
+	}
+
+	owner, err := lockToken()
+	if err != nil {
+		return nil, false, err
+	}
+
+	key = lockPrefix + key
+	acquired, err = locker.AcquireLock(ctx, key, owner, ttl)
+	if err != nil {
+		return nil, false, fmt.Errorf("cache: acquire lock %s: %w", key, err)
+	}
+	if !acquired {
+		return func() {}, false, nil
+	}
+
+	return func() {
+		// Release even if ctx was cancelled while the work ran
+		_ = locker.ReleaseLock(context.WithoutCancel(ctx), key, owner)
+	}, true, nil
+}
+
+// WithLock runs fn while holding key on the default store. It returns
+// ErrLockNotAcquired without running fn when the lock is held elsewhere.
+func WithLock(ctx context.Context, key string, ttl time.Duration, fn func() error) error {
+	return WithLockStore(ctx, Global().Default(), key, ttl, fn)
+}
+
+// WithLockStore is WithLock on a specific store
+func WithLockStore(ctx context.Context, store Store, key string, ttl time.Duration, fn func() error) error {
+	unlock, acquired, err := LockStore(ctx, store, key, ttl)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return ErrLockNotAcquired
+	}
+	defer unlock()
+	return fn()
+}
+
+// lockToken returns a random token identifying one lock holder
+func lockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLockStore_HeldUntilRelease(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+	ctx := context.Background()
+
+	unlock, acquired, err := LockStore(ctx, store, "job", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("first LockStore() = %v, %v; want acquired", acquired, err)
+	}
+
+	if _, acquired, err := LockStore(ctx, store, "job", time.Minute); err != nil || acquired {
+		t.Fatalf("second LockStore() while held = %v, %v; want not acquired", acquired, err)
+	}
+
+	// Other keys are independent, and clearing the cache does not drop locks
+	if _, acquired, _ := LockStore(ctx, store, "other", time.Minute); !acquired {
+		t.Error("LockStore() on another key not acquired")
+	}
+	_ = store.Flush(ctx)
+	if _, acquired, _ := LockStore(ctx, store, "job", time.Minute); acquired {
+		t.Error("Flush() released the lock")
+	}
+
+	unlock()
+	unlock() // releasing twice is harmless
+
+	unlock, acquired, err = LockStore(ctx, store, "job", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("LockStore() after release = %v, %v; want acquired", acquired, err)
+	}
+	unlock()
+}
+
+func TestLockStore_ExpiresAfterTTL(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+	ctx := context.Background()
+
+	staleUnlock, acquired, _ := LockStore(ctx, store, "job", 20*time.Millisecond)
+	if !acquired {
+		t.Fatal("first LockStore() not acquired")
+	}
+
+	time.Sleep(40 * time.Millisecond)
+
+	unlock, acquired, err := LockStore(ctx, store, "job", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("LockStore() after expiry = %v, %v; want acquired", acquired, err)
+	}
+
+	// The expired holder's unlock must not free the new holder's lock
+	staleUnlock()
+	if _, acquired, _ := LockStore(ctx, store, "job", time.Minute); acquired {
+		t.Error("stale unlock released the new holder's lock")
+	}
+	unlock()
+}
+
+func TestMemoryStore_CleanupEvictsExpiredLocks(t *testing.T) {
+	store := NewMemoryStore(WithCleanupInterval(10 * time.Millisecond))
+	defer store.Close()
+	ctx := context.Background()
+
+	if _, acquired, _ := LockStore(ctx, store, "expired", 5*time.Millisecond); !acquired {
+		t.Fatal("LockStore(expired) not acquired")
+	}
+	if _, acquired, _ := LockStore(ctx, store, "held", time.Minute); !acquired {
+		t.Fatal("LockStore(held) not acquired")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	store.lockMu.Lock()
+	_, expired := store.locks[lockPrefix+"expired"]
+	_, held := store.locks[lockPrefix+"held"]
+	store.lockMu.Unlock()
+	if expired {
+		t.Error("expired lock was not evicted")
+	}
+	if !held {
+		t.Error("unexpired lock was evicted")
+	}
+}
+
+func TestWithLockStore_MutualExclusion(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+	ctx := context.Background()
+
+	var ran, skipped atomic.Int32
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			err := WithLockStore(ctx, store, "job", time.Minute, func() error {
+				ran.Add(1)
+				time.Sleep(20 * time.Millisecond)
+				return nil
+			})
+			if errors.Is(err, ErrLockNotAcquired) {
+				skipped.Add(1)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if ran.Load() != 1 || skipped.Load() != 9 {
+		t.Errorf("ran = %d, skipped = %d; want 1 and 9", ran.Load(), skipped.Load())
+	}
+
+	// Released after fn returns, and fn's error is passed through
+	want := errors.New("boom")
+	if err := WithLockStore(ctx, store, "job", time.Minute, func() error { return want }); err != want {
+		t.Errorf("WithLockStore() = %v, want %v", err, want)
+	}
+}
+
+// plainStore is a Store without lock support
+type plainStore struct{ Store }
+
+func TestLockStore_Unsupported(t *testing.T) {
+	_, _, err := LockStore(context.Background(), plainStore{}, "job", time.Minute)
+	if !errors.Is(err, ErrLockNotSupported) {
+		t.Errorf("LockStore() = %v, want ErrLockNotSupported", err)
+	}
+}
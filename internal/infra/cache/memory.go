@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"container/list"
 	"context"
 	"sync"
 	"time"
@@ -9,7 +10,8 @@ import (
 // item represents a cached item with expiration
 type item struct {
 	value      interface{}
-	expiration int64 // Unix nano timestamp, 0 means no expiration
+	expiration int64         // Unix nano timestamp, 0 means no expiration
+	element    *list.Element // Position in the LRU order
 }
 
 // isExpired checks if the item has expired
@@ -20,30 +22,48 @@ func (i *item) isExpired() bool {
 	return time.Now().UnixNano() > i.expiration
 }
 
-// MemoryStore implements an in-memory cache store
+// MemoryStore implements an in-memory cache store.
+// With WithMaxItems set, the least recently used item is evicted once the cap is reached.
 type MemoryStore struct {
 	mu    sync.RWMutex
 	items map[string]*item
+	order *list.List // Keys, most recently used first
+	max   int
+
+	// Locks live apart from items so Flush and LRU eviction never drop them
+	lockMu sync.Mutex
+	locks  map[string]memoryLock
 
 	// Cleanup settings
 	cleanupInterval time.Duration
 	stopCleanup     chan struct{}
+	closeOnce       sync.Once
 }
 
 // MemoryOption configures the memory store
 type MemoryOption func(*MemoryStore)
 
-// WithCleanupInterval sets the cleanup interval for expired items
+// WithCleanupInterval sets the cleanup interval for expired items and locks
 func WithCleanupInterval(d time.Duration) MemoryOption {
 	return func(s *MemoryStore) {
 		s.cleanupInterval = d
 	}
 }
 
+// WithMaxItems caps the number of items, evicting the least recently used.
+// Zero means unlimited.
+func WithMaxItems(n int) MemoryOption {
+	return func(s *MemoryStore) {
+		s.max = n
+	}
+}
+
 // NewMemoryStore creates a new in-memory cache store
 func NewMemoryStore(opts ...MemoryOption) *MemoryStore {
 	s := &MemoryStore{
 		items:           make(map[string]*item),
+		order:           list.New(),
+		locks:           make(map[string]memoryLock),
 		cleanupInterval: 5 * time.Minute,
 		stopCleanup:     make(chan struct{}),
 	}
@@ -67,6 +87,7 @@ func (s *MemoryStore) cleanup() {
 		select {
 		case <-ticker.C:
 			s.deleteExpired()
+			s.deleteExpiredLocks()
 		case <-s.stopCleanup:
 			return
 		}
@@ -81,31 +102,67 @@ func (s *MemoryStore) deleteExpired() {
 	now := time.Now().UnixNano()
 	for key, item := range s.items {
 		if item.expiration > 0 && now > item.expiration {
-			delete(s.items, key)
+			s.remove(key, item)
+		}
+	}
+}
+
+// deleteExpiredLocks removes locks whose holder never released them, such
+// as one-off keys held by a job that crashed
+func (s *MemoryStore) deleteExpiredLocks() {
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+
+	now := time.Now()
+	for key, held := range s.locks {
+		if !now.Before(held.expires) {
+			delete(s.locks, key)
 		}
 	}
 }
 
+// remove deletes an item; callers must hold the write lock
+func (s *MemoryStore) remove(key string, itm *item) {
+	s.order.Remove(itm.element)
+	delete(s.items, key)
+}
+
+// set stores an item and evicts over the cap; callers must hold the write lock
+func (s *MemoryStore) set(key string, itm *item) {
+	if old, ok := s.items[key]; ok {
+		s.order.Remove(old.element)
+	}
+	itm.element = s.order.PushFront(key)
+	s.items[key] = itm
+
+	for s.max > 0 && len(s.items) > s.max {
+		oldest := s.order.Back()
+		evict := oldest.Value.(string)
+		s.remove(evict, s.items[evict])
+	}
+}
+
 // Close stops the cleanup goroutine
 func (s *MemoryStore) Close() {
-	close(s.stopCleanup)
+	s.closeOnce.Do(func() { close(s.stopCleanup) })
 }
 
 // Get retrieves a value from the cache
 func (s *MemoryStore) Get(ctx context.Context, key string) (interface{}, error) {
-	s.mu.RLock()
-	item, ok := s.items[key]
-	s.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
+	item, ok := s.items[key]
 	if !ok {
 		return nil, ErrCacheMiss
 	}
 
 	if item.isExpired() {
-		s.Forget(ctx, key)
+		s.remove(key, item)
 		return nil, ErrCacheMiss
 	}
 
+	s.order.MoveToFront(item.element)
 	return item.value, nil
 }
 
@@ -117,10 +174,10 @@ func (s *MemoryStore) Put(ctx context.Context, key string, value interface{}, tt
 	}
 
 	s.mu.Lock()
-	s.items[key] = &item{
+	s.set(key, &item{
 		value:      value,
 		expiration: expiration,
-	}
+	})
 	s.mu.Unlock()
 
 	return nil
@@ -134,7 +191,9 @@ func (s *MemoryStore) Forever(ctx context.Context, key string, value interface{}
 // Forget removes a value from the cache
 func (s *MemoryStore) Forget(ctx context.Context, key string) error {
 	s.mu.Lock()
-	delete(s.items, key)
+	if item, ok := s.items[key]; ok {
+		s.remove(key, item)
+	}
 	s.mu.Unlock()
 	return nil
 }
@@ -143,6 +202,7 @@ func (s *MemoryStore) Forget(ctx context.Context, key string) error {
 func (s *MemoryStore) Flush(ctx context.Context) error {
 	s.mu.Lock()
 	s.items = make(map[string]*item)
+	s.order.Init()
 	s.mu.Unlock()
 	return nil
 }
@@ -172,7 +232,7 @@ func (s *MemoryStore) Increment(ctx context.Context, key string, value int64) (i
 
 	itm, ok := s.items[key]
 	if !ok || itm.isExpired() {
-		s.items[key] = &item{value: value, expiration: 0}
+		s.set(key, &item{value: value, expiration: 0})
 		return value, nil
 	}
 
@@ -190,6 +250,7 @@ func (s *MemoryStore) Increment(ctx context.Context, key string, value int64) (i
 
 	newValue := current + value
 	itm.value = newValue
+	s.order.MoveToFront(itm.element)
 	return newValue, nil
 }
 
@@ -218,3 +279,33 @@ func (s *MemoryStore) Len() int {
 	defer s.mu.RUnlock()
 	return len(s.items)
 }
+
+// memoryLock is a lock held in a MemoryStore
+type memoryLock struct {
+	owner   string
+	expires time.Time
+}
+
+// AcquireLock takes key for owner unless another owner holds it unexpired
+func (s *MemoryStore) AcquireLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+
+	now := time.Now()
+	if held, ok := s.locks[key]; ok && now.Before(held.expires) {
+		return false, nil
+	}
+	s.locks[key] = memoryLock{owner: owner, expires: now.Add(ttl)}
+	return true, nil
+}
+
+// ReleaseLock frees key if owner still holds it
+func (s *MemoryStore) ReleaseLock(ctx context.Context, key, owner string) error {
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+
+	if held, ok := s.locks[key]; ok && held.owner == owner {
+		delete(s.locks, key)
+	}
+	return nil
+}
@@ -151,3 +151,22 @@ func (s *RedisStore) TTL(ctx context.Context, key string) (time.Duration, error)
 func (s *RedisStore) Expire(ctx context.Context, key string, ttl time.Duration) error {
 	return s.client.Expire(ctx, s.prefixKey(key), ttl).Err()
 }
+
+// releaseLockScript deletes the lock only if it still holds the caller's
+// token, so a lock that expired and was taken by someone else is left alone
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// AcquireLock takes key for owner with SET NX, expiring after ttl
+func (s *RedisStore) AcquireLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.prefixKey(key), owner, ttl).Result()
+}
+
+// ReleaseLock frees key if owner still holds it
+func (s *RedisStore) ReleaseLock(ctx context.Context, key, owner string) error {
+	return releaseLockScript.Run(ctx, s.client, []string{s.prefixKey(key)}, owner).Err()
+}
@@ -2,9 +2,14 @@ package config
 
 import (
 	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/zgiai/zgo/pkg/env"
+	"github.com/zgiai/zgo/pkg/hash"
 )
 
 // GlobalConfig stores the global configuration
@@ -15,8 +20,13 @@ type Config struct {
 	App        AppConfig
 	Server     ServerConfig
 	Database   DatabaseConfig
+	Tenant     TenantConfig
 	Redis      RedisConfig
+	Cache      CacheSettings
+	KV         KVConfig
+	Session    SessionConfig
 	JWT        JWTConfig
+	Auth       AuthConfig
 	Log        LogConfig
 	CORS       CORSConfig
 	Email      EmailConfig
@@ -24,6 +34,11 @@ type Config struct {
 	R2         R2Config
 	Middleware MiddlewareConfig
 	Tracing    TracingConfig
+	Sentry     SentryConfig
+	Health     HealthConfig
+	Metrics    MetricsConfig
+	Schedule   ScheduleConfig
+	Feature    FeatureConfig
 }
 
 type AppConfig struct {
@@ -34,6 +49,17 @@ type AppConfig struct {
 	Key       string
 	JWTSecret string
 	JWTExpire time.Duration
+	// WatchConfig reloads log level, CORS and read replicas when the env files change
+	WatchConfig bool
+
+	// Locale is used when a request asks for no supported locale
+	Locale string
+	// FallbackLocale supplies messages missing from the requested locale
+	FallbackLocale string
+	// Locales lists the locales requests may select
+	Locales []string
+	// LangPath is the directory holding the message bundles
+	LangPath string
 }
 
 type ServerConfig struct {
@@ -42,13 +68,20 @@ type ServerConfig struct {
 	Mode           string
 	ReadTimeout    int
 	WriteTimeout   int
-	RequestTimeout int // Request timeout in seconds (for middleware)
+	RequestTimeout int // Request timeout in seconds; route groups may override it
+	// ShutdownTimeout bounds graceful shutdown (server drain plus hooks) in seconds
+	ShutdownTimeout int
+	// MaxBodyBytes caps request bodies globally; route groups may override it
+	MaxBodyBytes int64
+	// TrustedProxies are the proxy IPs/CIDRs whose forwarding headers are believed
+	TrustedProxies []string
 }
 
 // MiddlewareConfig holds middleware configuration
 type MiddlewareConfig struct {
-	RequestTimeout int   // Request timeout in seconds, default 180 (3 min)
-	BodyLimit      int64 // Max body size in bytes, default 10MB
+	RequestTimeout      int     // Request timeout in seconds, default 180 (3 min)
+	BodyLimit           int64   // Max body size in bytes, default 10MB
+	AccessLogSampleRate float64 // Fraction of successful requests logged, default 1
 }
 
 type DatabaseConfig struct {
@@ -61,9 +94,28 @@ type DatabaseConfig struct {
 	Password     string
 	SSLMode      string
 	Timezone     string
+	Charset      string // MySQL only
+	Collation    string // MySQL only
 	MaxIdleConns int
 	MaxOpenConns int
 	Memory       bool
+	Replicas     []ReplicaConfig
+	// QueryTimeout bounds every statement; 0 disables it
+	QueryTimeout time.Duration
+	// SlowThreshold is the duration past which a statement is logged as slow
+	// and reported as a SlowQueryEvent; 0 disables both
+	SlowThreshold time.Duration
+}
+
+// ReplicaConfig describes a read replica. Empty fields inherit from the primary.
+type ReplicaConfig struct {
+	Host         string
+	Port         int
+	Name         string
+	Username     string
+	Password     string
+	MaxIdleConns int
+	MaxOpenConns int
 }
 
 // DBName returns the database name (alias for Name)
@@ -71,6 +123,14 @@ func (d DatabaseConfig) DBName() string {
 	return d.Name
 }
 
+// TenantConfig configures tenant resolution on /v1 requests
+type TenantConfig struct {
+	Enabled    bool   // Mount the Tenant middleware on /v1
+	Header     string // Header naming the tenant, default X-Tenant-ID
+	BaseDomain string // Resolve acme.<BaseDomain> to tenant acme; empty disables
+	Required   bool   // Reject requests without a tenant, default true
+}
+
 type RedisConfig struct {
 	Host     string
 	Port     int
@@ -78,10 +138,83 @@ type RedisConfig struct {
 	DB       int
 }
 
+// KV store drivers
+const (
+	KVDriverMemory = "memory" // Per-instance, lost on restart
+	KVDriverRedis  = "redis"  // Shared through the REDIS_* server
+)
+
+// KVConfig selects the key-value store behind rate limiting and idempotency
+type KVConfig struct {
+	Driver string
+}
+
+// Session drivers
+const (
+	SessionDriverKV     = "kv"     // Server-side, in the KV_DRIVER store
+	SessionDriverCookie = "cookie" // Client-side, signed with APP_KEY
+)
+
+// SessionConfig configures cookie sessions, the alternative to bearer tokens
+type SessionConfig struct {
+	Driver   string
+	Cookie   string        // Cookie name
+	Lifetime time.Duration // Idle time after which a session expires
+	Encrypt  bool          // Encrypt cookie sessions as well as signing them
+	Secure   bool          // Only send the cookie over HTTPS
+}
+
+// CacheSettings configures application-level caching
+type CacheSettings struct {
+	UserTTL       time.Duration // TTL of cached user lookups, 0 disables the cache
+	PermissionTTL time.Duration // TTL of cached effective permissions, 0 disables the cache
+	FeatureTTL    time.Duration // TTL of cached feature flag overrides, 0 disables the cache
+}
+
+// Supported JWT signing algorithms
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+)
+
+// MinJWTSecretLength is the shortest HS256 secret Validate accepts
+const MinJWTSecretLength = 32
+
+// MinSecureBcryptCost is the lowest bcrypt cost accepted in production
+// without a warning. Lower costs are meant for tests.
+const MinSecureBcryptCost = 10
+
 type JWTConfig struct {
 	Secret     string
 	ExpireDays int
 	Expire     time.Duration
+
+	// VerifyExpire is how long email verification links stay valid
+	VerifyExpire time.Duration
+
+	// Algorithm is HS256 (shared secret) or RS256 (key pair, published as JWKS)
+	Algorithm string
+	// PrivateKeyFile is the PEM key RS256 tokens are signed with
+	PrivateKeyFile string
+	// KeyID is the signing key's kid, defaulting to its RFC 7638 thumbprint
+	KeyID string
+	// PublicKeyFiles are extra verification keys, e.g. keys being rotated out
+	PublicKeyFiles []JWTKeyFile
+}
+
+// AuthConfig holds password hashing settings
+type AuthConfig struct {
+	// HashDriver is "bcrypt" or "argon2id". Existing hashes of the other
+	// kind still verify and are re-hashed on the next login.
+	HashDriver string
+	// BcryptCost is the bcrypt work factor (4-31); raising it re-hashes on login
+	BcryptCost int
+}
+
+// JWTKeyFile is a PEM public key file and the kid it is published under
+type JWTKeyFile struct {
+	ID   string
+	Path string
 }
 
 // ExpireDuration returns the expiration duration (alias for Expire)
@@ -90,8 +223,9 @@ func (j JWTConfig) ExpireDuration() time.Duration {
 }
 
 type LogConfig struct {
-	Level string
-	File  string
+	Level  string
+	File   string
+	Format string // console or json
 }
 
 type CORSConfig struct {
@@ -100,11 +234,23 @@ type CORSConfig struct {
 	AllowHeaders     []string
 	ExposeHeaders    []string
 	AllowCredentials bool
+	MaxAge           time.Duration // How long browsers may cache preflight results, 0 leaves it to them
 }
 
+// Email drivers
+const (
+	EmailDriverResend = "resend" // Send through the Resend API
+	EmailDriverLog    = "log"    // Log and drop emails
+)
+
 type EmailConfig struct {
+	Driver       string
 	From         string
 	ResendAPIKey string
+
+	// DryRun captures emails in memory and logs them instead of sending,
+	// for staging and tests. Read them back with email.Sent.
+	DryRun bool
 }
 
 type OpenAIConfig struct {
@@ -121,6 +267,30 @@ type R2Config struct {
 	PublicDomain    string
 }
 
+// MetricsConfig holds Prometheus HTTP metrics configuration
+type MetricsConfig struct {
+	Enabled   bool     // Record HTTP metrics and serve /metrics
+	SkipPaths []string // Request paths left out of the HTTP metrics
+}
+
+// ScheduleConfig holds scheduled job configuration
+type ScheduleConfig struct {
+	Enabled bool // Run the jobs registered in routes.Schedule alongside the HTTP server
+}
+
+// FeatureConfig holds feature flag defaults
+type FeatureConfig struct {
+	// Flags maps each flag to the percentage of users it is on for:
+	// 100 for everyone, 0 for no one
+	Flags map[string]int
+}
+
+// HealthConfig holds health check endpoint configuration
+type HealthConfig struct {
+	PrometheusEnabled bool     // Expose check results at /metrics/health
+	Checks            []string // Enabled checks: database, email
+}
+
 // TracingConfig holds OpenTelemetry tracing configuration
 type TracingConfig struct {
 	Enabled    bool
@@ -129,6 +299,11 @@ type TracingConfig struct {
 	SampleRate float64 // Sampling rate (0.0 to 1.0)
 }
 
+// SentryConfig configures error reporting to Sentry
+type SentryConfig struct {
+	DSN string // Project DSN; empty disables reporting
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	env.Load()
@@ -144,6 +319,13 @@ func Load() (*Config, error) {
 			Key:       env.Get("APP_KEY", ""),
 			JWTSecret: env.Get("JWT_SECRET", ""),
 			JWTExpire: time.Duration(expireDays) * 24 * time.Hour,
+
+			WatchConfig: env.GetBool("CONFIG_WATCH", false),
+
+			Locale:         env.Get("APP_LOCALE", "en"),
+			FallbackLocale: env.Get("APP_FALLBACK_LOCALE", "en"),
+			Locales:        env.GetSlice("APP_LOCALES", []string{"en", "zh"}),
+			LangPath:       env.Get("LANG_PATH", "lang"),
 		},
 		Server: ServerConfig{
 			Host:         env.Get("SERVER_HOST", ""),
@@ -151,19 +333,35 @@ func Load() (*Config, error) {
 			Mode:         env.Get("GIN_MODE", "debug"),
 			ReadTimeout:  env.GetInt("SERVER_READ_TIMEOUT", 60),
 			WriteTimeout: env.GetInt("SERVER_WRITE_TIMEOUT", 60),
+
+			RequestTimeout:  env.GetInt("SERVER_REQUEST_TIMEOUT", env.GetInt("MIDDLEWARE_REQUEST_TIMEOUT", 180)),
+			ShutdownTimeout: env.GetInt("SERVER_SHUTDOWN_TIMEOUT", 10),
+			MaxBodyBytes:    int64(env.GetInt("SERVER_MAX_BODY_BYTES", env.GetInt("MIDDLEWARE_BODY_LIMIT_MB", 10)*1024*1024)),
+			TrustedProxies:  env.GetSlice("SERVER_TRUSTED_PROXIES", nil),
 		},
 		Database: DatabaseConfig{
-			Enabled:      env.GetBool("DB_ENABLED", true),
-			Driver:       env.Get("DB_DRIVER", "postgres"),
-			Host:         env.Get("DB_HOST", "localhost"),
-			Port:         env.GetInt("DB_PORT", 5432),
-			Name:         env.Get("DB_NAME", ""),
-			Username:     env.Get("DB_USERNAME", ""),
-			Password:     env.Get("DB_PASSWORD", ""),
-			SSLMode:      env.Get("DB_SSLMODE", "disable"),
-			Timezone:     env.Get("DB_TIMEZONE", "Asia/Shanghai"),
-			MaxIdleConns: env.GetInt("DB_MAX_IDLE_CONNS", 10),
-			MaxOpenConns: env.GetInt("DB_MAX_OPEN_CONNS", 100),
+			Enabled:       env.GetBool("DB_ENABLED", true),
+			Driver:        env.Get("DB_DRIVER", "postgres"),
+			Host:          env.Get("DB_HOST", "localhost"),
+			Port:          env.GetInt("DB_PORT", 5432),
+			Name:          env.Get("DB_NAME", ""),
+			Username:      env.Get("DB_USERNAME", ""),
+			Password:      env.Get("DB_PASSWORD", ""),
+			SSLMode:       env.Get("DB_SSLMODE", "disable"),
+			Timezone:      env.Get("DB_TIMEZONE", "Asia/Shanghai"),
+			Charset:       env.Get("DB_CHARSET", "utf8mb4"),
+			Collation:     env.Get("DB_COLLATION", "utf8mb4_unicode_ci"),
+			MaxIdleConns:  env.GetInt("DB_MAX_IDLE_CONNS", 10),
+			MaxOpenConns:  env.GetInt("DB_MAX_OPEN_CONNS", 100),
+			QueryTimeout:  time.Duration(env.GetInt("DB_QUERY_TIMEOUT", 30)) * time.Second,
+			SlowThreshold: time.Duration(env.GetInt("DB_SLOW_THRESHOLD", 1000)) * time.Millisecond,
+			Replicas:      loadReplicas(),
+		},
+		Tenant: TenantConfig{
+			Enabled:    env.GetBool("TENANT_ENABLED", false),
+			Header:     env.Get("TENANT_HEADER", "X-Tenant-ID"),
+			BaseDomain: env.Get("TENANT_BASE_DOMAIN", ""),
+			Required:   env.GetBool("TENANT_REQUIRED", true),
 		},
 		Redis: RedisConfig{
 			Host:     env.Get("REDIS_HOST", "localhost"),
@@ -171,25 +369,55 @@ func Load() (*Config, error) {
 			Password: env.Get("REDIS_PASSWORD", ""),
 			DB:       env.GetInt("REDIS_DB", 0),
 		},
+		KV: KVConfig{
+			Driver: env.Get("KV_DRIVER", KVDriverMemory),
+		},
+		Session: SessionConfig{
+			Driver:   env.Get("SESSION_DRIVER", SessionDriverKV),
+			Cookie:   env.Get("SESSION_COOKIE", "zgo_session"),
+			Lifetime: time.Duration(env.GetInt("SESSION_LIFETIME", 7200)) * time.Second,
+			Encrypt:  env.GetBool("SESSION_ENCRYPT", false),
+			Secure:   env.GetBool("SESSION_SECURE", false),
+		},
+		Cache: CacheSettings{
+			UserTTL:       time.Duration(env.GetInt("CACHE_USER_TTL", 300)) * time.Second,
+			PermissionTTL: time.Duration(env.GetInt("CACHE_PERMISSION_TTL", 60)) * time.Second,
+			FeatureTTL:    time.Duration(env.GetInt("CACHE_FEATURE_TTL", 60)) * time.Second,
+		},
 		JWT: JWTConfig{
 			Secret:     env.Get("JWT_SECRET", ""),
 			ExpireDays: expireDays,
 			Expire:     time.Duration(expireDays) * 24 * time.Hour,
+
+			VerifyExpire: time.Duration(env.GetInt("JWT_VERIFY_EXPIRE_HOURS", 24)) * time.Hour,
+
+			Algorithm:      strings.ToUpper(env.Get("JWT_ALGORITHM", JWTAlgorithmHS256)),
+			PrivateKeyFile: env.Get("JWT_PRIVATE_KEY_FILE", ""),
+			KeyID:          env.Get("JWT_KEY_ID", ""),
+			PublicKeyFiles: loadJWTKeyFiles(),
+		},
+		Auth: AuthConfig{
+			HashDriver: env.Get("AUTH_HASH_DRIVER", string(hash.AlgorithmBcrypt)),
+			BcryptCost: env.GetInt("AUTH_BCRYPT_COST", 10),
 		},
 		Log: LogConfig{
-			Level: env.Get("LOG_LEVEL", "debug"),
-			File:  env.Get("LOG_FILE", "storage/logs/app.log"),
+			Level:  env.Get("LOG_LEVEL", "debug"),
+			File:   env.Get("LOG_FILE", "storage/logs/app.log"),
+			Format: logFormat(),
 		},
 		CORS: CORSConfig{
 			AllowOrigins:     env.GetSlice("CORS_ALLOW_ORIGINS", []string{"*"}),
 			AllowMethods:     env.GetSlice("CORS_ALLOW_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
 			AllowHeaders:     env.GetSlice("CORS_ALLOW_HEADERS", []string{"Origin", "Content-Type", "Accept", "Authorization"}),
 			ExposeHeaders:    env.GetSlice("CORS_EXPOSE_HEADERS", []string{"Content-Length"}),
-			AllowCredentials: env.GetBool("CORS_ALLOW_CREDENTIALS", true),
+			AllowCredentials: env.GetBool("CORS_ALLOW_CREDENTIALS", false),
+			MaxAge:           time.Duration(env.GetInt("CORS_MAX_AGE", 86400)) * time.Second,
 		},
 		Email: EmailConfig{
+			Driver:       emailDriver(),
 			From:         env.Get("MAIL_FROM", ""),
 			ResendAPIKey: env.Get("RESEND_API_KEY", ""),
+			DryRun:       env.GetBool("MAIL_DRY_RUN", false),
 		},
 		OpenAI: OpenAIConfig{
 			APIKey: env.Get("OPENAI_API_KEY", ""),
@@ -204,8 +432,9 @@ func Load() (*Config, error) {
 			PublicDomain:    env.Get("R2_PUBLIC_DOMAIN", ""),
 		},
 		Middleware: MiddlewareConfig{
-			RequestTimeout: env.GetInt("MIDDLEWARE_REQUEST_TIMEOUT", 180),                   // 3 minutes default
-			BodyLimit:      int64(env.GetInt("MIDDLEWARE_BODY_LIMIT_MB", 10)) * 1024 * 1024, // 10MB default
+			RequestTimeout:      env.GetInt("MIDDLEWARE_REQUEST_TIMEOUT", 180),                   // 3 minutes default
+			BodyLimit:           int64(env.GetInt("MIDDLEWARE_BODY_LIMIT_MB", 10)) * 1024 * 1024, // 10MB default
+			AccessLogSampleRate: env.GetFloat("MIDDLEWARE_ACCESS_LOG_SAMPLE_RATE", 1.0),
 		},
 		Tracing: TracingConfig{
 			Enabled:    env.GetBool("TRACING_ENABLED", false),
@@ -213,12 +442,32 @@ func Load() (*Config, error) {
 			Insecure:   env.GetBool("TRACING_INSECURE", true),
 			SampleRate: env.GetFloat("TRACING_SAMPLE_RATE", 1.0),
 		},
+		Sentry: SentryConfig{
+			DSN: env.Get("SENTRY_DSN", ""),
+		},
+		Health: HealthConfig{
+			PrometheusEnabled: env.GetBool("HEALTH_PROMETHEUS_ENABLED", false),
+			Checks:            env.GetSlice("HEALTH_CHECKS", []string{"database"}),
+		},
+		Metrics: MetricsConfig{
+			Enabled:   env.GetBool("METRICS_ENABLED", true),
+			SkipPaths: env.GetSlice("METRICS_SKIP_PATHS", []string{"/health", "/health/live", "/health/ready", "/metrics", "/metrics/health"}),
+		},
+		Schedule: ScheduleConfig{
+			Enabled: env.GetBool("SCHEDULE_ENABLED", true),
+		},
+		Feature: FeatureConfig{
+			Flags: loadFeatureFlags(),
+		},
 	}
 
 	// Validate required fields
-	if err := validate(cfg); err != nil {
+	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
+	for _, warning := range cfg.Warnings() {
+		log.Printf("Warning: %s", warning)
+	}
 
 	GlobalConfig = cfg
 	return cfg, nil
@@ -233,16 +482,203 @@ func MustLoad() *Config {
 	return cfg
 }
 
-func validate(cfg *Config) error {
-	if cfg.Database.Enabled && cfg.Database.Password == "" {
-		return fmt.Errorf("DB_PASSWORD is required when database is enabled")
+// emailDriver reads MAIL_DRIVER, defaulting to Resend only when a key is configured
+func emailDriver() string {
+	if driver := env.Get("MAIL_DRIVER", ""); driver != "" {
+		return strings.ToLower(driver)
+	}
+	if env.Get("RESEND_API_KEY", "") != "" {
+		return EmailDriverResend
+	}
+	return EmailDriverLog
+}
+
+// loadReplicas reads DB_REPLICA_HOSTS as a comma-separated list of host[:port]
+func loadReplicas() []ReplicaConfig {
+	hosts := env.GetSlice("DB_REPLICA_HOSTS", nil)
+	replicas := make([]ReplicaConfig, 0, len(hosts))
+	for _, h := range hosts {
+		r := ReplicaConfig{
+			Host:         h,
+			Username:     env.Get("DB_REPLICA_USERNAME", ""),
+			Password:     env.Get("DB_REPLICA_PASSWORD", ""),
+			MaxIdleConns: env.GetInt("DB_REPLICA_MAX_IDLE_CONNS", 0),
+			MaxOpenConns: env.GetInt("DB_REPLICA_MAX_OPEN_CONNS", 0),
+		}
+		if host, port, found := strings.Cut(h, ":"); found {
+			r.Host = host
+			r.Port, _ = strconv.Atoi(port)
+		}
+		replicas = append(replicas, r)
 	}
-	if cfg.JWT.Secret == "" {
-		return fmt.Errorf("JWT_SECRET is required")
+	return replicas
+}
+
+// loadFeatureFlags reads FEATURE_FLAGS as a comma-separated list of
+// name[:state] entries, where state is on, off or a rollout percentage;
+// a bare name is on
+func loadFeatureFlags() map[string]int {
+	entries := env.GetSlice("FEATURE_FLAGS", nil)
+	flags := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		name, state, _ := strings.Cut(entry, ":")
+		switch state {
+		case "", "on":
+			flags[name] = 100
+		case "off":
+			flags[name] = 0
+		default:
+			percent, err := strconv.Atoi(strings.TrimSuffix(state, "%"))
+			if err != nil {
+				log.Printf("Warning: ignoring feature flag %q: state must be on, off or a percentage", entry)
+				continue
+			}
+			flags[name] = min(max(percent, 0), 100)
+		}
+	}
+	return flags
+}
+
+// logFormat reads LOG_FORMAT, falling back to LOG_JSON and then to JSON in
+// production, as the logger does when it boots
+func logFormat() string {
+	if format := env.Get("LOG_FORMAT", ""); format != "" {
+		return format
+	}
+	if env.GetBool("LOG_JSON", env.Get("APP_ENV", "development") == "production") {
+		return "json"
+	}
+	return "console"
+}
+
+// loadJWTKeyFiles reads JWT_PUBLIC_KEY_FILES as a comma-separated list of
+// kid=path entries; a bare path gets its key's thumbprint as kid
+func loadJWTKeyFiles() []JWTKeyFile {
+	entries := env.GetSlice("JWT_PUBLIC_KEY_FILES", nil)
+	files := make([]JWTKeyFile, 0, len(entries))
+	for _, entry := range entries {
+		if kid, path, found := strings.Cut(entry, "="); found {
+			files = append(files, JWTKeyFile{ID: kid, Path: path})
+		} else {
+			files = append(files, JWTKeyFile{Path: entry})
+		}
+	}
+	return files
+}
+
+// ValidationError lists every problem found by Config.Validate
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks the settings each enabled feature depends on and reports
+// all problems at once rather than stopping at the first
+func (c *Config) Validate() error {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.Database.Enabled {
+		switch c.Database.Driver {
+		case "sqlite":
+			if c.Database.Name == "" && !c.Database.Memory {
+				add("DB_NAME is required for the sqlite driver")
+			}
+		default:
+			if c.Database.Host == "" {
+				add("DB_HOST is required when the database is enabled")
+			}
+			if c.Database.Name == "" {
+				add("DB_NAME is required when the database is enabled")
+			}
+			if c.Database.Password == "" {
+				add("DB_PASSWORD is required when the database is enabled")
+			}
+		}
+	}
+
+	switch c.JWT.Algorithm {
+	case JWTAlgorithmHS256:
+		if c.JWT.Secret == "" {
+			add("JWT_SECRET is required")
+		} else if len(c.JWT.Secret) < MinJWTSecretLength {
+			add("JWT_SECRET must be at least %d bytes, got %d", MinJWTSecretLength, len(c.JWT.Secret))
+		}
+	case JWTAlgorithmRS256:
+		if c.JWT.PrivateKeyFile == "" {
+			add("JWT_PRIVATE_KEY_FILE is required when JWT_ALGORITHM is RS256")
+		}
+	default:
+		add("unsupported JWT_ALGORITHM %q", c.JWT.Algorithm)
+	}
+
+	switch hash.Algorithm(c.Auth.HashDriver) {
+	case hash.AlgorithmBcrypt:
+		if c.Auth.BcryptCost < hash.MinBcryptCost || c.Auth.BcryptCost > hash.MaxBcryptCost {
+			add("AUTH_BCRYPT_COST must be between %d and %d, got %d", hash.MinBcryptCost, hash.MaxBcryptCost, c.Auth.BcryptCost)
+		}
+	case hash.AlgorithmArgon2:
+	default:
+		add("unsupported AUTH_HASH_DRIVER %q", c.Auth.HashDriver)
+	}
+
+	switch c.KV.Driver {
+	case KVDriverMemory, KVDriverRedis:
+	default:
+		add("unsupported KV_DRIVER %q", c.KV.Driver)
+	}
+
+	switch c.Session.Driver {
+	case SessionDriverKV:
+	case SessionDriverCookie:
+		if c.App.Key == "" {
+			add("APP_KEY is required when SESSION_DRIVER is cookie")
+		}
+	default:
+		add("unsupported SESSION_DRIVER %q", c.Session.Driver)
+	}
+
+	if c.CORS.AllowCredentials && slices.Contains(c.CORS.AllowOrigins, "*") {
+		add("CORS_ALLOW_CREDENTIALS cannot be used with CORS_ALLOW_ORIGINS=*; list the trusted origins")
+	}
+
+	switch c.Email.Driver {
+	case EmailDriverResend:
+		if c.Email.ResendAPIKey == "" && !c.Email.DryRun {
+			add("RESEND_API_KEY is required when MAIL_DRIVER is resend")
+		}
+	case EmailDriverLog:
+	default:
+		add("unsupported MAIL_DRIVER %q", c.Email.Driver)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
 	}
 	return nil
 }
 
+// Warnings lists settings that are valid but unsafe for the environment
+func (c *Config) Warnings() []string {
+	var warnings []string
+	if c.App.Env == "production" &&
+		hash.Algorithm(c.Auth.HashDriver) == hash.AlgorithmBcrypt &&
+		c.Auth.BcryptCost < MinSecureBcryptCost {
+		warnings = append(warnings, fmt.Sprintf(
+			"AUTH_BCRYPT_COST=%d is below the recommended minimum of %d for production",
+			c.Auth.BcryptCost, MinSecureBcryptCost))
+	}
+	if c.App.Env == "production" && c.App.Key == "" {
+		warnings = append(warnings, "APP_KEY is empty; stored secrets such as 2FA keys are encrypted with a predictable key")
+	}
+	return warnings
+}
+
 // IsProduction returns true if running in production
 func IsProduction() bool {
 	return GlobalConfig != nil && GlobalConfig.App.Env == "production"
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// validConfig returns a config that passes Validate
+func validConfig() *Config {
+	return &Config{
+		App: AppConfig{Key: "base64:app-key"},
+		Database: DatabaseConfig{
+			Enabled:  true,
+			Driver:   "postgres",
+			Host:     "localhost",
+			Name:     "app",
+			Password: "secret",
+		},
+		JWT: JWTConfig{
+			Algorithm: JWTAlgorithmHS256,
+			Secret:    strings.Repeat("s", MinJWTSecretLength),
+		},
+		Auth:    AuthConfig{HashDriver: "bcrypt", BcryptCost: 10},
+		Email:   EmailConfig{Driver: EmailDriverResend, ResendAPIKey: "re_123"},
+		KV:      KVConfig{Driver: KVDriverMemory},
+		Session: SessionConfig{Driver: SessionDriverKV},
+	}
+}
+
+func TestValidate_ValidConfig(t *testing.T) {
+	if err := validConfig().Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestValidate_MissingFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string
+	}{
+		{"db host", func(c *Config) { c.Database.Host = "" }, "DB_HOST is required"},
+		{"db name", func(c *Config) { c.Database.Name = "" }, "DB_NAME is required"},
+		{"db password", func(c *Config) { c.Database.Password = "" }, "DB_PASSWORD is required"},
+		{"sqlite file", func(c *Config) { c.Database.Driver = "sqlite"; c.Database.Name = "" }, "DB_NAME is required for the sqlite driver"},
+		{"jwt secret", func(c *Config) { c.JWT.Secret = "" }, "JWT_SECRET is required"},
+		{"short jwt secret", func(c *Config) { c.JWT.Secret = "too-short" }, "JWT_SECRET must be at least 32 bytes"},
+		{"rs256 key", func(c *Config) { c.JWT.Algorithm = JWTAlgorithmRS256 }, "JWT_PRIVATE_KEY_FILE is required"},
+		{"jwt algorithm", func(c *Config) { c.JWT.Algorithm = "none" }, `unsupported JWT_ALGORITHM "none"`},
+		{"hash driver", func(c *Config) { c.Auth.HashDriver = "md5" }, `unsupported AUTH_HASH_DRIVER "md5"`},
+		{"bcrypt cost too low", func(c *Config) { c.Auth.BcryptCost = 3 }, "AUTH_BCRYPT_COST must be between 4 and 31, got 3"},
+		{"bcrypt cost too high", func(c *Config) { c.Auth.BcryptCost = 32 }, "AUTH_BCRYPT_COST must be between 4 and 31, got 32"},
+		{"resend key", func(c *Config) { c.Email.ResendAPIKey = "" }, "RESEND_API_KEY is required"},
+		{"mail driver", func(c *Config) { c.Email.Driver = "smtp" }, `unsupported MAIL_DRIVER "smtp"`},
+		{"kv driver", func(c *Config) { c.KV.Driver = "memcached" }, `unsupported KV_DRIVER "memcached"`},
+		{"session driver", func(c *Config) { c.Session.Driver = "file" }, `unsupported SESSION_DRIVER "file"`},
+		{"cors credentials with any origin", func(c *Config) { c.CORS = CORSConfig{AllowOrigins: []string{"*"}, AllowCredentials: true} }, "CORS_ALLOW_CREDENTIALS cannot be used with CORS_ALLOW_ORIGINS=*"},
+		{"cookie session key", func(c *Config) { c.Session.Driver = SessionDriverCookie; c.App.Key = "" }, "APP_KEY is required when SESSION_DRIVER is cookie"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() = %v, want a *ValidationError", err)
+			}
+			if len(verr.Problems) != 1 || !strings.Contains(verr.Problems[0], tt.want) {
+				t.Errorf("Problems = %q, want one containing %q", verr.Problems, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_SkipsDisabledFeatures(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database = DatabaseConfig{Enabled: false}
+	cfg.Email = EmailConfig{Driver: EmailDriverLog}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+
+	cfg.Database = DatabaseConfig{Enabled: true, Driver: "sqlite", Memory: true}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() with in-memory sqlite = %v, want nil", err)
+	}
+
+	cfg.Email = EmailConfig{Driver: EmailDriverResend, DryRun: true}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() with dry-run resend = %v, want nil", err)
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	cfg := &Config{
+		Database: DatabaseConfig{Enabled: true, Driver: "postgres"},
+		JWT:      JWTConfig{Algorithm: JWTAlgorithmHS256},
+		Auth:     AuthConfig{HashDriver: "bcrypt", BcryptCost: 10},
+		Email:    EmailConfig{Driver: EmailDriverResend},
+		KV:       KVConfig{Driver: KVDriverMemory},
+		Session:  SessionConfig{Driver: SessionDriverKV},
+	}
+
+	err := cfg.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Validate() = %v, want a *ValidationError", err)
+	}
+	// Host, name, password, JWT secret and Resend key
+	if len(verr.Problems) != 5 {
+		t.Errorf("got %d problems, want 5: %q", len(verr.Problems), verr.Problems)
+	}
+	if !strings.HasPrefix(err.Error(), "invalid configuration: ") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestWarnings_LowBcryptCostInProduction(t *testing.T) {
+	cfg := validConfig()
+	cfg.Auth.BcryptCost = 4
+	if warnings := cfg.Warnings(); len(warnings) != 0 {
+		t.Errorf("Warnings() outside production = %q, want none", warnings)
+	}
+
+	cfg.App.Env = "production"
+	warnings := cfg.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "AUTH_BCRYPT_COST=4") {
+		t.Errorf("Warnings() = %q, want one about AUTH_BCRYPT_COST", warnings)
+	}
+
+	cfg.Auth.BcryptCost = MinSecureBcryptCost
+	if warnings := cfg.Warnings(); len(warnings) != 0 {
+		t.Errorf("Warnings() at the minimum = %q, want none", warnings)
+	}
+}
+
+func TestWarnings_EmptyAppKeyInProduction(t *testing.T) {
+	cfg := validConfig()
+	cfg.App.Key = ""
+	if warnings := cfg.Warnings(); len(warnings) != 0 {
+		t.Errorf("Warnings() outside production = %q, want none", warnings)
+	}
+
+	cfg.App.Env = "production"
+	warnings := cfg.Warnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "APP_KEY") {
+		t.Errorf("Warnings() = %q, want one about APP_KEY", warnings)
+	}
+}
+
+func TestLoad_RejectsInvalidConfig(t *testing.T) {
+	t.Setenv("DB_ENABLED", "false")
+	t.Setenv("JWT_SECRET", "short")
+	t.Setenv("MAIL_DRIVER", "")
+	t.Setenv("RESEND_API_KEY", "")
+
+	_, err := Load()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Load() error = %v, want a *ValidationError", err)
+	}
+}
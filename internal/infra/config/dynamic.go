@@ -68,6 +68,7 @@ func (r *Repository) loadFromEnv() {
 	r.Set("server.mode", env.Get("GIN_MODE", "debug"))
 	r.Set("server.read_timeout", env.GetInt("SERVER_READ_TIMEOUT", 60))
 	r.Set("server.write_timeout", env.GetInt("SERVER_WRITE_TIMEOUT", 60))
+	r.Set("server.shutdown_timeout", env.GetInt("SERVER_SHUTDOWN_TIMEOUT", 10))
 
 	// Database
 	r.Set("database.enabled", env.GetBool("DB_ENABLED", true))
@@ -91,10 +92,12 @@ func (r *Repository) loadFromEnv() {
 	// JWT
 	r.Set("jwt.secret", env.Get("JWT_SECRET", ""))
 	r.Set("jwt.expire_days", env.GetInt("JWT_EXPIRE_DAYS", 7))
+	r.Set("jwt.verify_expire_hours", env.GetInt("JWT_VERIFY_EXPIRE_HOURS", 24))
 
 	// Log
 	r.Set("log.level", env.Get("LOG_LEVEL", "debug"))
 	r.Set("log.file", env.Get("LOG_FILE", "storage/logs/app.log"))
+	r.Set("log.format", logFormat())
 
 	// CORS
 	r.Set("cors.allowed_origins", env.GetSlice("CORS_ALLOWED_ORIGINS", []string{"*"}))
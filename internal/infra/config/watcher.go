@@ -1,11 +1,13 @@
 package config
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/zgiai/zgo/pkg/env"
 	"github.com/zgiai/zgo/pkg/logger"
 	"github.com/fsnotify/fsnotify"
 )
@@ -287,3 +289,95 @@ func OnConfigChange(key string, callback Observer) {
 func OnAnyChange(callback Observer) {
 	GlobalWatcher().WatchAll(callback)
 }
+
+// --- Typed Config Reload ---
+
+// watchDebounce is how long the env files must stay quiet before a reload,
+// so an editor saving in several writes triggers a single reload
+var watchDebounce = 300 * time.Millisecond
+
+// Watch reloads the Config whenever one of the active env files changes and
+// passes the result to onChange. Configs that fail validation are logged and
+// dropped, so onChange only ever sees a complete, valid config. Watching
+// stops when ctx is cancelled.
+//
+// Example:
+//
+//	config.Watch(ctx, func(cfg *config.Config) {
+//		logger.SetLevel(logger.ParseLevel(cfg.Log.Level))
+//	})
+func Watch(ctx context.Context, onChange func(*Config)) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	files := make(map[string]bool)
+	for _, file := range env.Files() {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			continue
+		}
+		if !files[abs] {
+			files[abs] = true
+			// Watch the directory so files created later (or replaced by editors) are seen
+			if err := fw.Add(filepath.Dir(abs)); err != nil {
+				logger.Warningf("failed to watch directory %s: %v", filepath.Dir(abs), err)
+			}
+		}
+	}
+
+	go func() {
+		defer fw.Close()
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		for {
+			select {
+			case event, ok := <-fw.Events:
+				if !ok {
+					return
+				}
+				abs, err := filepath.Abs(event.Name)
+				if err != nil || !files[abs] {
+					continue
+				}
+				if timer == nil {
+					timer = time.NewTimer(watchDebounce)
+				} else {
+					timer.Reset(watchDebounce)
+				}
+				fire = timer.C
+
+			case <-fire:
+				fire = nil
+				cfg, err := reload()
+				if err != nil {
+					logger.Warningf("config reload failed, keeping current config: %v", err)
+					continue
+				}
+				onChange(cfg)
+
+			case err, ok := <-fw.Errors:
+				if !ok {
+					return
+				}
+				logger.Warningf("config watcher error: %v", err)
+
+			case <-ctx.Done():
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// reload re-reads the env files and builds a validated Config from them
+func reload() (*Config, error) {
+	env.Reload()
+	return Load()
+}
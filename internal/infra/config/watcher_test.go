@@ -0,0 +1,60 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zgiai/zgo/pkg/env"
+)
+
+func writeEnvFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+func TestWatch_ReloadsOnChange(t *testing.T) {
+	watchDebounce = 20 * time.Millisecond
+	t.Cleanup(func() { watchDebounce = 300 * time.Millisecond })
+
+	path := filepath.Join(t.TempDir(), "app.env")
+	writeEnvFile(t, path, "DB_ENABLED=false\nJWT_SECRET=watcher-test-secret-0123456789abcdef\nLOG_LEVEL=debug\nCORS_ALLOW_ORIGINS=https://a.example\n")
+
+	// Runs after ZGO_ENV_FILE is restored, dropping the temp file's values
+	t.Cleanup(env.Reload)
+	t.Setenv("ZGO_ENV_FILE", path)
+	env.Reload()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan *Config, 4)
+	if err := Watch(ctx, func(cfg *Config) { changes <- cfg }); err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	// An invalid config is dropped without calling back
+	writeEnvFile(t, path, "DB_ENABLED=false\nLOG_LEVEL=error\n")
+	select {
+	case cfg := <-changes:
+		t.Fatalf("Expected no callback for invalid config, got log level %q", cfg.Log.Level)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	writeEnvFile(t, path, "DB_ENABLED=false\nJWT_SECRET=watcher-test-secret-0123456789abcdef\nLOG_LEVEL=warning\nCORS_ALLOW_ORIGINS=https://b.example\n")
+	select {
+	case cfg := <-changes:
+		if cfg.Log.Level != "warning" {
+			t.Errorf("Expected log level warning, got %q", cfg.Log.Level)
+		}
+		if len(cfg.CORS.AllowOrigins) != 1 || cfg.CORS.AllowOrigins[0] != "https://b.example" {
+			t.Errorf("Expected new CORS origins, got %v", cfg.CORS.AllowOrigins)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for config reload")
+	}
+}
@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/pkg/env"
+)
+
+// ConfigShowCommand prints the resolved configuration with secrets masked
+type ConfigShowCommand struct {
+	output *console.Output
+}
+
+// NewConfigShowCommand creates a new ConfigShowCommand instance.
+func NewConfigShowCommand() *ConfigShowCommand {
+	return &ConfigShowCommand{output: console.NewOutput()}
+}
+
+func (c *ConfigShowCommand) Name() string        { return "config:show" }
+func (c *ConfigShowCommand) Description() string { return "Show the resolved configuration" }
+func (c *ConfigShowCommand) Usage() string       { return "config:show [--format=table|json]" }
+
+func (c *ConfigShowCommand) Run(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		c.output.Error("Failed to load config: %v", err)
+		return err
+	}
+
+	if flagValue(args, "format") == "json" {
+		return c.printJSON(cfg)
+	}
+	c.printTable(cfg)
+	return nil
+}
+
+// configSection is one top-level block of the Config struct
+type configSection struct {
+	name   string
+	fields [][2]string
+}
+
+func (c *ConfigShowCommand) printTable(cfg *config.Config) {
+	c.output.Title("Configuration")
+	files := strings.Join(env.LoadedFiles(), ", ")
+	if files == "" {
+		files = "none"
+	}
+	c.output.TwoColumn("Env files", files)
+
+	for _, section := range configSections(cfg) {
+		c.output.Section(section.name)
+		rows := make([][]string, 0, len(section.fields))
+		for _, f := range section.fields {
+			rows = append(rows, []string{f[0], f[1]})
+		}
+		c.output.Table([]string{"Key", "Value"}, rows)
+	}
+}
+
+func (c *ConfigShowCommand) printJSON(cfg *config.Config) error {
+	sections := make(map[string]map[string]string)
+	for _, section := range configSections(cfg) {
+		fields := make(map[string]string, len(section.fields))
+		for _, f := range section.fields {
+			fields[f[0]] = f[1]
+		}
+		sections[section.name] = fields
+	}
+
+	data, err := json.MarshalIndent(map[string]any{
+		"env_files": env.LoadedFiles(),
+		"config":    sections,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// configSections flattens cfg into sections in declaration order, masking secrets
+func configSections(cfg *config.Config) []configSection {
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+
+	sections := make([]configSection, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		section := configSection{name: t.Field(i).Name}
+		flattenConfig(v.Field(i), "", &section.fields)
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+func flattenConfig(v reflect.Value, prefix string, out *[][2]string) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			flattenConfig(v.Field(i), prefix+field.Name+".", out)
+		}
+		return
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Struct {
+			for i := 0; i < v.Len(); i++ {
+				flattenConfig(v.Index(i), fmt.Sprintf("%s[%d].", strings.TrimSuffix(prefix, "."), i), out)
+			}
+			return
+		}
+	}
+
+	key := strings.TrimSuffix(prefix, ".")
+	value := fmt.Sprint(v.Interface())
+	if isSecretKey(key) {
+		value = maskSecret(value)
+	}
+	*out = append(*out, [2]string{key, value})
+}
+
+// isSecretKey reports whether a config key holds a credential
+func isSecretKey(key string) bool {
+	name := key[strings.LastIndex(key, ".")+1:]
+	return strings.Contains(name, "Password") ||
+		strings.Contains(name, "Secret") ||
+		strings.Contains(name, "AccessKey") ||
+		strings.HasSuffix(name, "Key")
+}
+
+// maskSecret keeps only the last 4 characters of a secret
+func maskSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	if len(s) <= 4 {
+		return "****"
+	}
+	return "****" + s[len(s)-4:]
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/console"
+)
+
+// captureStdout returns everything fn writes to os.Stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	fn()
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func secretConfig() *config.Config {
+	return &config.Config{
+		Server:   config.ServerConfig{Port: 7030, Mode: "release"},
+		Database: config.DatabaseConfig{Host: "db.internal", Password: "db-password-9999"},
+		JWT:      config.JWTConfig{Secret: "jwt-signing-secret-1234"},
+		Email:    config.EmailConfig{From: "noreply@example.com", ResendAPIKey: "re_live_key_5678"},
+	}
+}
+
+func TestConfigShowCommand_MasksSecretsInTable(t *testing.T) {
+	cmd := &ConfigShowCommand{output: console.NewOutput()}
+
+	out := captureStdout(t, func() { cmd.printTable(secretConfig()) })
+
+	for _, secret := range []string{"db-password-9999", "jwt-signing-secret-1234", "re_live_key_5678"} {
+		assert.NotContains(t, out, secret)
+	}
+	assert.Contains(t, out, "****9999")
+	assert.Contains(t, out, "****1234")
+	assert.Contains(t, out, "****5678")
+	assert.Contains(t, out, "db.internal", "non-secret values are shown")
+}
+
+func TestConfigShowCommand_JSON(t *testing.T) {
+	cmd := &ConfigShowCommand{output: console.NewOutput()}
+
+	out := captureStdout(t, func() { require.NoError(t, cmd.printJSON(secretConfig())) })
+
+	var got struct {
+		Config map[string]map[string]string `json:"config"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+
+	assert.Equal(t, "****1234", got.Config["JWT"]["Secret"])
+	assert.Equal(t, "****5678", got.Config["Email"]["ResendAPIKey"])
+	assert.Equal(t, "****9999", got.Config["Database"]["Password"])
+	assert.Equal(t, "7030", got.Config["Server"]["Port"])
+	assert.Equal(t, "noreply@example.com", got.Config["Email"]["From"])
+}
+
+func TestMaskSecret(t *testing.T) {
+	assert.Equal(t, "", maskSecret(""))
+	assert.Equal(t, "****", maskSecret("abc"))
+	assert.Equal(t, "****6789", maskSecret("123456789"))
+}
@@ -1,10 +1,15 @@
 package commands
 
 import (
-	"github.com/zgiai/zgo/internal/bootstrap"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zgiai/zgo/database/seeders"
 	"github.com/zgiai/zgo/internal/infra/config"
 	"github.com/zgiai/zgo/internal/infra/console"
 	"github.com/zgiai/zgo/internal/infra/database"
+	"gorm.io/gorm"
 )
 
 // DBSeedCommand runs database seeders
@@ -18,9 +23,29 @@ func NewDBSeedCommand() *DBSeedCommand {
 
 func (c *DBSeedCommand) Name() string        { return "db:seed" }
 func (c *DBSeedCommand) Description() string { return "Run database seeders" }
-func (c *DBSeedCommand) Usage() string       { return "db:seed" }
+func (c *DBSeedCommand) Usage() string {
+	return "db:seed [--class=UserSeeder] [--count=50] [--seed=42]"
+}
 
 func (c *DBSeedCommand) Run(args []string) error {
+	class := flagValue(args, "class")
+	var opts seedOptions
+	if v := flagValue(args, "count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.output.Error("Invalid --count: %s", v)
+			return fmt.Errorf("invalid count %q", v)
+		}
+		opts.count = n
+	}
+	if v := flagValue(args, "seed"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.output.Error("Invalid --seed: %s", v)
+			return fmt.Errorf("invalid seed %q", v)
+		}
+		opts.seed = n
+	}
 	c.output.Info("Running database seeders...")
 
 	// Load config
@@ -41,8 +66,7 @@ func (c *DBSeedCommand) Run(args []string) error {
 		return nil
 	}
 
-	// Execute seeders
-	if err := bootstrap.RunSeeders(db); err != nil {
+	if err := c.seed(db, seeders.All(), class, opts); err != nil {
 		c.output.Error("Seeding failed: %v", err)
 		return err
 	}
@@ -50,3 +74,28 @@ func (c *DBSeedCommand) Run(args []string) error {
 	c.output.Success("Seeders completed")
 	return nil
 }
+
+// seedOptions holds the --count and --seed flags of db:seed
+type seedOptions struct {
+	count int
+	seed  uint64
+}
+
+// seed runs all seeders, or only class and its dependencies, reporting each
+func (c *DBSeedCommand) seed(db *gorm.DB, list []seeders.Seeder, class string, opts seedOptions) error {
+	runner := seeders.NewRunner(list)
+	runner.Count = opts.count
+	runner.Seed = opts.seed
+	runner.OnRun = func(name string, elapsed time.Duration, err error) {
+		if err != nil {
+			c.output.Error("%s failed: %v", name, err)
+			return
+		}
+		c.output.Success("%s (%v)", name, elapsed.Round(time.Millisecond))
+	}
+
+	if class != "" {
+		return runner.RunSeeder(db, class)
+	}
+	return runner.Run(db)
+}
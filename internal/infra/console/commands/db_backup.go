@@ -0,0 +1,321 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/storage"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	// defaultBackupDir is where backups go without --path or --disk
+	defaultBackupDir = "storage/backups"
+
+	// diskBackupDir is where backups go on a storage disk
+	diskBackupDir = "backups"
+)
+
+// DBBackupCommand snapshots the database to a file or a storage disk
+type DBBackupCommand struct {
+	output *console.Output
+}
+
+func NewDBBackupCommand() *DBBackupCommand {
+	return &DBBackupCommand{output: console.NewOutput()}
+}
+
+func (c *DBBackupCommand) Name() string        { return "db:backup" }
+func (c *DBBackupCommand) Description() string { return "Back up the database" }
+func (c *DBBackupCommand) Usage() string       { return "db:backup [--path=dir] [--disk=name]" }
+
+func (c *DBBackupCommand) Run(args []string) error {
+	dir := flagValue(args, "path")
+	if dir == "" {
+		dir = defaultBackupDir
+	}
+	disk := flagValue(args, "disk")
+
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		c.output.Error("Failed to load config: %v", err)
+		return err
+	}
+	if !cfg.Database.Enabled {
+		c.output.Warning("Database is disabled in config")
+		return nil
+	}
+
+	c.output.Info("Backing up database '%s'...", cfg.Database.Name)
+	location, err := c.backup(context.Background(), cfg.Database, dir, disk)
+	if err != nil {
+		c.output.Error("Backup failed: %v", err)
+		return err
+	}
+
+	c.output.Success("Backup written to %s", location)
+	return nil
+}
+
+// backup dumps the database into dir, or to backups/ on disk when one is
+// given, and returns where the backup was written
+func (c *DBBackupCommand) backup(ctx context.Context, cfg config.DatabaseConfig, dir, disk string) (string, error) {
+	name := backupFileName(cfg, time.Now())
+	if disk == "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", err
+		}
+		dest := filepath.Join(dir, name)
+		return dest, dumpDatabase(ctx, cfg, dest)
+	}
+
+	// Dump locally first: neither VACUUM INTO nor pg_dump can write to a disk
+	tmp, err := os.MkdirTemp("", "zgo-backup-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	local := filepath.Join(tmp, name)
+	if err := dumpDatabase(ctx, cfg, local); err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(local)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	dest := path.Join(diskBackupDir, name)
+	if err := storage.Disk(disk).WriteStream(dest, f); err != nil {
+		return "", fmt.Errorf("failed to write to disk %s: %w", disk, err)
+	}
+	return disk + ":" + dest, nil
+}
+
+// DBRestoreCommand replaces the database with a backup taken by db:backup
+type DBRestoreCommand struct {
+	output *console.Output
+}
+
+func NewDBRestoreCommand() *DBRestoreCommand {
+	return &DBRestoreCommand{output: console.NewOutput()}
+}
+
+func (c *DBRestoreCommand) Name() string        { return "db:restore" }
+func (c *DBRestoreCommand) Description() string { return "Restore the database from a backup" }
+func (c *DBRestoreCommand) Usage() string       { return "db:restore <file> [--disk=name] [--force]" }
+
+func (c *DBRestoreCommand) Run(args []string) error {
+	if len(args) < 1 || strings.HasPrefix(args[0], "--") {
+		c.output.Error("Usage: %s", c.Usage())
+		return fmt.Errorf("backup file is required")
+	}
+	file := args[0]
+	disk := flagValue(args, "disk")
+	force := slices.Contains(args, "--force")
+
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		c.output.Error("Failed to load config: %v", err)
+		return err
+	}
+	if !cfg.Database.Enabled {
+		c.output.Warning("Database is disabled in config")
+		return nil
+	}
+
+	// Overwriting the database requires explicit confirmation
+	if !force {
+		c.output.Warning("Restoring will REPLACE ALL DATA in database '%s' with %s", cfg.Database.Name, file)
+		if !c.output.Confirm("Continue?", false) {
+			c.output.Info("Operation cancelled")
+			return nil
+		}
+	}
+
+	c.output.Info("Restoring database '%s'...", cfg.Database.Name)
+	if err := c.restore(context.Background(), cfg.Database, file, disk); err != nil {
+		c.output.Error("Restore failed: %v", err)
+		return err
+	}
+
+	c.output.Success("Database restored from %s", file)
+	return nil
+}
+
+// restore loads file into the database, reading it from disk when one is given
+func (c *DBRestoreCommand) restore(ctx context.Context, cfg config.DatabaseConfig, file, disk string) error {
+	if disk == "" {
+		if _, err := os.Stat(file); err != nil {
+			return err
+		}
+		return restoreDatabase(ctx, cfg, file)
+	}
+
+	src, err := storage.Disk(disk).ReadStream(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s from disk %s: %w", file, disk, err)
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp("", "zgo-restore-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return restoreDatabase(ctx, cfg, tmp.Name())
+}
+
+// backupFileName names a backup after the database and the time it was taken
+func backupFileName(cfg config.DatabaseConfig, at time.Time) string {
+	name, ext := cfg.Name, ".dump"
+	if cfg.Driver == "sqlite" {
+		ext = filepath.Ext(name)
+		name = strings.TrimSuffix(filepath.Base(name), ext)
+		if ext == "" {
+			ext = ".sqlite"
+		}
+	}
+	return fmt.Sprintf("%s-%s%s", name, at.Format("20060102-150405"), ext)
+}
+
+// dumpDatabase writes a backup of the database to dest
+func dumpDatabase(ctx context.Context, cfg config.DatabaseConfig, dest string) error {
+	switch cfg.Driver {
+	case "sqlite":
+		return sqliteBackup(ctx, cfg, dest)
+	case "postgres":
+		return runPostgresTool(ctx, cfg, "pg_dump", "--format=custom", "--no-owner", "--file="+dest)
+	default:
+		return fmt.Errorf("backups are not supported for driver %q", cfg.Driver)
+	}
+}
+
+// restoreDatabase replaces the database with the backup at src
+func restoreDatabase(ctx context.Context, cfg config.DatabaseConfig, src string) error {
+	switch cfg.Driver {
+	case "sqlite":
+		return sqliteRestore(cfg, src)
+	case "postgres":
+		return runPostgresTool(ctx, cfg, "pg_restore", "--clean", "--if-exists", "--no-owner", src)
+	default:
+		return fmt.Errorf("restores are not supported for driver %q", cfg.Driver)
+	}
+}
+
+// sqliteBackup copies the database with VACUUM INTO, which takes a
+// consistent snapshot while other connections keep using the database
+func sqliteBackup(ctx context.Context, cfg config.DatabaseConfig, dest string) error {
+	if cfg.Memory {
+		return errors.New("an in-memory database cannot be backed up")
+	}
+	if _, err := os.Stat(cfg.Name); err != nil {
+		return err
+	}
+
+	db, err := gorm.Open(sqlite.Open(cfg.Name), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	return db.WithContext(ctx).Exec("VACUUM INTO ?", dest).Error
+}
+
+// sqliteRestore swaps the database file for a copy of src. The copy is
+// renamed into place so the database is never left half-written.
+func sqliteRestore(cfg config.DatabaseConfig, src string) error {
+	if cfg.Memory {
+		return errors.New("an in-memory database cannot be restored")
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	staged := cfg.Name + ".restore"
+	out, err := os.Create(staged)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(staged)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(staged)
+		return err
+	}
+
+	// Journal files of the old database would be replayed onto the new one
+	for _, suffix := range []string{"-wal", "-shm", "-journal"} {
+		if err := os.Remove(cfg.Name + suffix); err != nil && !os.IsNotExist(err) {
+			os.Remove(staged)
+			return err
+		}
+	}
+	return os.Rename(staged, cfg.Name)
+}
+
+// runPostgresTool runs pg_dump or pg_restore against the configured server
+func runPostgresTool(ctx context.Context, cfg config.DatabaseConfig, tool string, args ...string) error {
+	binary, err := exec.LookPath(tool)
+	if err != nil {
+		return fmt.Errorf("%s not found in PATH: %w", tool, err)
+	}
+
+	args = append([]string{
+		"--host=" + cfg.Host,
+		"--port=" + strconv.Itoa(cfg.Port),
+		"--username=" + cfg.Username,
+		"--dbname=" + cfg.Name,
+	}, args...)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Env = append(os.Environ(), "PGPASSWORD="+cfg.Password)
+	if cfg.SSLMode != "" {
+		cmd.Env = append(cmd.Env, "PGSSLMODE="+cfg.SSLMode)
+	}
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", tool, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/storage"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type backupItem struct {
+	ID   uint
+	Name string
+}
+
+// countItems opens the database file, counts its rows and closes it again,
+// so no connection is held while the file is replaced
+func countItems(t *testing.T, file string) int64 {
+	t.Helper()
+	db := openItems(t, file)
+	defer closeDB(t, db)
+
+	var count int64
+	require.NoError(t, db.Model(&backupItem{}).Count(&count).Error)
+	return count
+}
+
+func openItems(t *testing.T, file string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(file), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&backupItem{}))
+	return db
+}
+
+func closeDB(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+}
+
+func seedItems(t *testing.T, file string, names ...string) {
+	t.Helper()
+	db := openItems(t, file)
+	defer closeDB(t, db)
+	for _, name := range names {
+		require.NoError(t, db.Create(&backupItem{Name: name}).Error)
+	}
+}
+
+func TestDBBackup_SQLiteRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		disk string
+	}{
+		{name: "directory"},
+		{name: "disk", disk: "backup-test"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cfg := config.DatabaseConfig{Driver: "sqlite", Name: filepath.Join(dir, "app.db")}
+			seedItems(t, cfg.Name, "a", "b", "c")
+
+			if tt.disk != "" {
+				storage.RegisterDisk(tt.disk, storage.NewLocalFilesystem(filepath.Join(dir, "disk")))
+			}
+
+			backup := &DBBackupCommand{output: console.NewOutput()}
+			location, err := backup.backup(context.Background(), cfg, filepath.Join(dir, "backups"), tt.disk)
+			require.NoError(t, err)
+
+			file := location
+			if tt.disk != "" {
+				file = location[len(tt.disk)+1:]
+				assert.FileExists(t, filepath.Join(dir, "disk", file))
+			}
+			assert.Regexp(t, `app-\d{8}-\d{6}\.db$`, file)
+
+			// Change the database after the snapshot ...
+			seedItems(t, cfg.Name, "d", "e")
+			require.Equal(t, int64(5), countItems(t, cfg.Name))
+
+			// ... and restoring brings back exactly what was backed up
+			restore := &DBRestoreCommand{output: console.NewOutput()}
+			require.NoError(t, restore.restore(context.Background(), cfg, file, tt.disk))
+			assert.Equal(t, int64(3), countItems(t, cfg.Name))
+			assert.NoFileExists(t, cfg.Name+".restore")
+		})
+	}
+}
+
+func TestDBBackup_Unsupported(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "backup")
+
+	err := dumpDatabase(context.Background(), config.DatabaseConfig{Driver: "sqlite", Memory: true}, dest)
+	assert.Error(t, err, "in-memory databases have no file to back up")
+
+	err = dumpDatabase(context.Background(), config.DatabaseConfig{Driver: "mysql", Name: "app"}, dest)
+	assert.ErrorContains(t, err, "not supported")
+}
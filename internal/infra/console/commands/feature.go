@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/internal/infra/feature"
+	"gorm.io/gorm"
+)
+
+// FeatureListCommand lists feature flags with their defaults and overrides
+type FeatureListCommand struct {
+	output *console.Output
+}
+
+func NewFeatureListCommand() *FeatureListCommand {
+	return &FeatureListCommand{output: console.NewOutput()}
+}
+
+func (c *FeatureListCommand) Name() string        { return "feature:list" }
+func (c *FeatureListCommand) Description() string { return "List feature flags and their overrides" }
+func (c *FeatureListCommand) Usage() string       { return "feature:list" }
+
+func (c *FeatureListCommand) Run(args []string) error {
+	cfg, db, err := loadFeatureDB(c.output)
+	if err != nil {
+		return err
+	}
+
+	states, err := feature.NewManager(db, cfg.Feature.Flags).Flags(context.Background())
+	if err != nil {
+		c.output.Error("Failed to load feature flags: %v", err)
+		return err
+	}
+	if len(states) == 0 {
+		c.output.Info("No feature flags configured. Set FEATURE_FLAGS to add some.")
+		return nil
+	}
+
+	c.output.Title("Feature Flags")
+	c.output.Table([]string{"Flag", "Default", "Override", "User Overrides"}, featureRows(states))
+	return nil
+}
+
+// featureRows renders one table row per flag
+func featureRows(states []feature.State) [][]string {
+	rows := make([][]string, 0, len(states))
+	for _, state := range states {
+		def := strconv.Itoa(state.Percentage) + "%"
+		switch state.Percentage {
+		case 0:
+			def = "off"
+		case 100:
+			def = "on"
+		}
+		override := "-"
+		if state.Override != nil {
+			override = "off"
+			if *state.Override {
+				override = "on"
+			}
+		}
+		rows = append(rows, []string{state.Name, def, override, strconv.FormatInt(state.UserOverrides, 10)})
+	}
+	return rows
+}
+
+// FeatureToggleCommand switches a feature flag for everyone or one user
+type FeatureToggleCommand struct {
+	output *console.Output
+}
+
+func NewFeatureToggleCommand() *FeatureToggleCommand {
+	return &FeatureToggleCommand{output: console.NewOutput()}
+}
+
+func (c *FeatureToggleCommand) Name() string        { return "feature:toggle" }
+func (c *FeatureToggleCommand) Description() string { return "Switch a feature flag on or off" }
+func (c *FeatureToggleCommand) Usage() string {
+	return "feature:toggle <flag> [--user=ID] [--on|--off|--clear]"
+}
+
+func (c *FeatureToggleCommand) Run(args []string) error {
+	if len(args) < 1 || strings.HasPrefix(args[0], "--") {
+		c.output.Error("Usage: %s", c.Usage())
+		return fmt.Errorf("flag name is required")
+	}
+	flag := args[0]
+
+	userID := feature.Everyone
+	if user := flagValue(args, "user"); user != "" {
+		id, err := strconv.ParseUint(user, 10, 64)
+		if err != nil || id == 0 {
+			c.output.Error("Invalid user ID: %s", user)
+			return fmt.Errorf("invalid user ID %q", user)
+		}
+		userID = uint(id)
+	}
+
+	cfg, db, err := loadFeatureDB(c.output)
+	if err != nil {
+		return err
+	}
+	if db == nil {
+		return feature.ErrNoStore
+	}
+
+	ctx := context.Background()
+	m := feature.NewManager(db, cfg.Feature.Flags)
+	scope := "everyone"
+	if userID != feature.Everyone {
+		scope = fmt.Sprintf("user %d", userID)
+		ctx = feature.WithUser(ctx, userID)
+	}
+
+	if slices.Contains(args, "--clear") {
+		if err := m.ClearOverride(ctx, flag, userID); err != nil {
+			c.output.Error("Failed to clear override: %v", err)
+			return err
+		}
+		c.output.Success("Cleared the %s override for %s", flag, scope)
+		return nil
+	}
+
+	// Without --on or --off, flip what the scope sees now
+	enabled := !m.IsEnabled(ctx, flag)
+	switch {
+	case slices.Contains(args, "--on"):
+		enabled = true
+	case slices.Contains(args, "--off"):
+		enabled = false
+	}
+
+	if err := m.SetOverride(ctx, flag, userID, enabled); err != nil {
+		c.output.Error("Failed to toggle feature flag: %v", err)
+		return err
+	}
+	state := "off"
+	if enabled {
+		state = "on"
+	}
+	c.output.Success("Turned %s %s for %s", flag, state, scope)
+	return nil
+}
+
+// loadFeatureDB loads config and connects to the database holding overrides.
+// The returned DB is nil when the database is disabled.
+func loadFeatureDB(output *console.Output) (*config.Config, *gorm.DB, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		output.Error("Failed to load config: %v", err)
+		return nil, nil, err
+	}
+
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		output.Error("Failed to connect to database: %v", err)
+		return nil, nil, err
+	}
+	if db == nil {
+		output.Warning("Database is disabled in config; only FEATURE_FLAGS defaults apply")
+	}
+	return cfg, db, nil
+}
@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zgiai/zgo/internal/infra/feature"
+)
+
+func TestFeatureRows(t *testing.T) {
+	on := true
+	rows := featureRows([]feature.State{
+		{Name: "beta_search", Percentage: 25},
+		{Name: "new_checkout", Percentage: 0, Override: &on, UserOverrides: 2},
+		{Name: "old_export", Percentage: 100},
+	})
+
+	assert.Equal(t, []string{"beta_search", "25%", "-", "0"}, rows[0])
+	assert.Equal(t, []string{"new_checkout", "off", "on", "2"}, rows[1])
+	assert.Equal(t, "on", rows[2][1])
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 	"time"
@@ -413,6 +414,73 @@ func (c *MakeModuleCommand) Run(args []string) error {
 	return nil
 }
 
+// testTemplates maps a make:test --type to its stub template
+var testTemplates = map[string]string{
+	"service":    moduleServiceTestTemplate,
+	"handler":    handlerTestTemplate,
+	"repository": repositoryTestTemplate,
+}
+
+// MakeTestCommand creates a test stub for a module component
+type MakeTestCommand struct {
+	output *console.Output
+}
+
+func NewMakeTestCommand() *MakeTestCommand {
+	return &MakeTestCommand{output: console.NewOutput()}
+}
+
+func (c *MakeTestCommand) Name() string        { return "make:test" }
+func (c *MakeTestCommand) Description() string { return "Create a test stub for a module component" }
+func (c *MakeTestCommand) Usage() string {
+	return "make:test <module> [--type=service|handler|repository] [--force]"
+}
+
+func (c *MakeTestCommand) Run(args []string) error {
+	var name string
+	kind := "service"
+	force := slices.Contains(args, "--force")
+
+	for i, arg := range args {
+		if val, found := strings.CutPrefix(arg, "--type="); found {
+			kind = val
+			continue
+		}
+		if arg == "--type" && i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+			kind = args[i+1]
+			continue
+		}
+
+		// First non-flag argument that isn't a --type value is the module name
+		if !strings.HasPrefix(arg, "--") && name == "" && (i == 0 || args[i-1] != "--type") {
+			name = arg
+		}
+	}
+
+	if name == "" {
+		return fmt.Errorf("module name is required")
+	}
+
+	tmpl, ok := testTemplates[kind]
+	if !ok {
+		return fmt.Errorf("unknown test type %q (expected service, handler or repository)", kind)
+	}
+
+	snake := toSnakeCase(name)
+	pascal := toPascalCase(name)
+
+	path := filepath.Join("internal", "modules", snake, kind+"_test.go")
+	if err := generateModuleFile(c.output, path, tmpl, map[string]string{
+		"Package":   snake,
+		"ModelName": pascal,
+	}, force); err != nil {
+		return err
+	}
+
+	c.output.Success("Test created: %s", path)
+	return nil
+}
+
 func injectProvider(moduleName string) error {
 	path := "internal/modules/wire.go"
 	content, err := os.ReadFile(path)
@@ -822,6 +890,11 @@ import (
 
 type {{.SeederName}}Seeder struct{}
 
+func (s *{{.SeederName}}Seeder) Name() string { return "{{.SeederName}}Seeder" }
+
+// Dependencies lists seeders that must run first, e.g. "RoleSeeder"
+func (s *{{.SeederName}}Seeder) Dependencies() []string { return nil }
+
 func (s *{{.SeederName}}Seeder) Run(db *gorm.DB) error {
 	// TODO: Implement seeder logic
 	// Example:
@@ -960,3 +1033,192 @@ var ProviderSet = wire.NewSet(
 	NewHandler,
 )
 `
+
+const moduleServiceTestTemplate = `package {{.Package}}
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a mock implementation of Repository
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Create(ctx context.Context, model *{{.ModelName}}) error {
+	args := m.Called(ctx, model)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Update(ctx context.Context, model *{{.ModelName}}) error {
+	args := m.Called(ctx, model)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindByID(ctx context.Context, id uint) (*{{.ModelName}}, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*{{.ModelName}}), args.Error(1)
+}
+
+func (m *MockRepository) FindAll(ctx context.Context, page, pageSize int) ([]*{{.ModelName}}, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]*{{.ModelName}}), args.Get(1).(int64), args.Error(2)
+}
+
+func TestService_Get(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(repo *MockRepository)
+		wantErr bool
+	}{
+		{
+			name: "found",
+			setup: func(repo *MockRepository) {
+				repo.On("FindByID", mock.Anything, uint(1)).Return(&{{.ModelName}}{ID: 1}, nil)
+			},
+		},
+		{
+			name: "not found",
+			setup: func(repo *MockRepository) {
+				repo.On("FindByID", mock.Anything, uint(1)).Return(nil, errors.New("not found"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockRepository)
+			tt.setup(repo)
+			service := NewService(repo)
+
+			got, err := service.Get(context.Background(), 1)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, uint(1), got.ID)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+`
+
+const handlerTestTemplate = `package {{.Package}}
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockService is a mock implementation of Service
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) Create(ctx context.Context, model *{{.ModelName}}) error {
+	args := m.Called(ctx, model)
+	return args.Error(0)
+}
+
+func (m *MockService) Update(ctx context.Context, model *{{.ModelName}}) error {
+	args := m.Called(ctx, model)
+	return args.Error(0)
+}
+
+func (m *MockService) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockService) Get(ctx context.Context, id uint) (*{{.ModelName}}, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*{{.ModelName}}), args.Error(1)
+}
+
+func (m *MockService) List(ctx context.Context, page, pageSize int) ([]*{{.ModelName}}, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	return args.Get(0).([]*{{.ModelName}}), args.Get(1).(int64), args.Error(2)
+}
+
+func TestHandler_Get(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := new(MockService)
+	svc.On("Get", mock.Anything, uint(1)).Return(&{{.ModelName}}{ID: 1}, nil)
+
+	r := gin.New()
+	r.GET("/{{.Package}}s/:id", NewHandler(svc).Get)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/{{.Package}}s/1", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	svc.AssertExpectations(t)
+}
+`
+
+const repositoryTestTemplate = `package {{.Package}}
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&{{.ModelName}}{}))
+	return db
+}
+
+func TestRepository_CreateAndFind(t *testing.T) {
+	repo := NewRepository(setupTestDB(t))
+	ctx := context.Background()
+
+	model := &{{.ModelName}}{}
+	require.NoError(t, repo.Create(ctx, model))
+
+	found, err := repo.FindByID(ctx, model.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ID, found.ID)
+
+	items, total, err := repo.FindAll(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, int64(1), total)
+}
+`
@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/zgiai/zgo/internal/infra/console"
+)
+
+// MakeEventCommand creates a module event
+type MakeEventCommand struct {
+	output *console.Output
+}
+
+func NewMakeEventCommand() *MakeEventCommand {
+	return &MakeEventCommand{output: console.NewOutput()}
+}
+
+func (c *MakeEventCommand) Name() string        { return "make:event" }
+func (c *MakeEventCommand) Description() string { return "Create a new event in a module" }
+func (c *MakeEventCommand) Usage() string       { return "make:event <module> <name> [--force]" }
+
+func (c *MakeEventCommand) Run(args []string) error {
+	names := positionalArgs(args)
+	if len(names) < 2 {
+		return fmt.Errorf("module and event name are required")
+	}
+
+	module := toSnakeCase(names[0])
+	event := newEventNames(module, names[1])
+
+	path := filepath.Join("internal", "modules", module, toSnakeCase(event.Base)+"_event.go")
+	if err := generateModuleFile(c.output, path, eventTemplate, map[string]string{
+		"Package":   module,
+		"EventType": event.Type,
+		"EventName": event.Name,
+	}, slices.Contains(args, "--force")); err != nil {
+		return err
+	}
+
+	c.output.Success("Event created: %s (%s)", path, event.Name)
+	return nil
+}
+
+// MakeListenerCommand creates a module event listener
+type MakeListenerCommand struct {
+	output *console.Output
+}
+
+func NewMakeListenerCommand() *MakeListenerCommand {
+	return &MakeListenerCommand{output: console.NewOutput()}
+}
+
+func (c *MakeListenerCommand) Name() string        { return "make:listener" }
+func (c *MakeListenerCommand) Description() string { return "Create a new event listener in a module" }
+func (c *MakeListenerCommand) Usage() string {
+	return "make:listener <module> <name> [--event=Name] [--force]"
+}
+
+func (c *MakeListenerCommand) Run(args []string) error {
+	names := positionalArgs(args, "event")
+	if len(names) < 2 {
+		return fmt.Errorf("module and listener name are required")
+	}
+
+	module := toSnakeCase(names[0])
+	listener := toPascalCase(toSnakeCase(names[1]))
+	data := map[string]string{
+		"Package":      module,
+		"ListenerName": listener,
+	}
+	if name := flagValue(args, "event"); name != "" {
+		event := newEventNames(module, name)
+		data["EventType"] = event.Type
+		data["EventName"] = event.Name
+	}
+
+	path := filepath.Join("internal", "modules", module, toSnakeCase(listener)+"_listener.go")
+	if err := generateModuleFile(c.output, path, listenerTemplate, data, slices.Contains(args, "--force")); err != nil {
+		return err
+	}
+
+	c.output.Success("Listener created: %s", path)
+	return nil
+}
+
+// eventNames holds the identifiers derived from an event name
+type eventNames struct {
+	Base string // OrderShipped
+	Type string // OrderShippedEvent
+	Name string // order.order_shipped
+}
+
+// newEventNames derives the struct and bus names for an event, accepting
+// the name with or without an Event suffix
+func newEventNames(module, name string) eventNames {
+	base := toPascalCase(toSnakeCase(name))
+	if trimmed := strings.TrimSuffix(base, "Event"); trimmed != "" {
+		base = trimmed
+	}
+	return eventNames{
+		Base: base,
+		Type: base + "Event",
+		Name: module + "." + toSnakeCase(base),
+	}
+}
+
+// generateModuleFile creates path from tmpl, refusing to overwrite an
+// existing file unless force is set
+func generateModuleFile(output *console.Output, path, tmpl string, data map[string]string, force bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if force {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := generateFile(path, tmpl, data); err != nil {
+		if !force {
+			output.Info("Use --force to overwrite")
+		}
+		return err
+	}
+	return nil
+}
+
+// positionalArgs returns the arguments that are neither flags nor the
+// values of the given space-separated value flags
+func positionalArgs(args []string, valueFlags ...string) []string {
+	var out []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if name, found := strings.CutPrefix(arg, "--"); found {
+			if slices.Contains(valueFlags, name) && i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+				i++
+			}
+			continue
+		}
+		out = append(out, arg)
+	}
+	return out
+}
+
+const eventTemplate = `package {{.Package}}
+
+import "github.com/zgiai/zgo/internal/infra/events"
+
+// {{.EventType}} is published on the event bus as "{{.EventName}}"
+type {{.EventType}} struct {
+	events.BaseEvent
+	// Add event data here
+}
+
+// EventName returns the event name
+func (e {{.EventType}}) EventName() string {
+	return "{{.EventName}}"
+}
+
+// New{{.EventType}} creates a new {{.EventType}}
+func New{{.EventType}}() {{.EventType}} {
+	return {{.EventType}}{
+		BaseEvent: events.NewBaseEventWithSource("{{.Package}}"),
+	}
+}
+`
+
+const listenerTemplate = `package {{.Package}}
+
+import (
+	"context"
+
+	"github.com/zgiai/zgo/internal/infra/events"
+)
+
+// {{.ListenerName}} matches the events.EventHandler signature.
+// Subscribe it with bus.Subscribe("{{if .EventName}}{{.EventName}}{{else}}event.name{{end}}", {{.ListenerName}}).
+func {{.ListenerName}}(ctx context.Context, e events.Event) error {
+{{- if .EventType}}
+	// Simple domain events arrive wrapped in WrappedEvent
+	var underlying any = e
+	if wrapped, ok := e.(events.WrappedEvent); ok {
+		underlying = wrapped.Event
+	}
+
+	event, ok := underlying.({{.EventType}})
+	if !ok {
+		return nil
+	}
+
+	// TODO: Handle the event
+	_ = event
+{{- else}}
+	// TODO: Handle the event
+{{- end}}
+
+	return nil
+}
+`
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/zgiai/zgo/internal/infra/console"
+)
+
+// MakePolicyCommand creates a module authorization policy
+type MakePolicyCommand struct {
+	output *console.Output
+}
+
+func NewMakePolicyCommand() *MakePolicyCommand {
+	return &MakePolicyCommand{output: console.NewOutput()}
+}
+
+func (c *MakePolicyCommand) Name() string        { return "make:policy" }
+func (c *MakePolicyCommand) Description() string { return "Create a new policy in a module" }
+func (c *MakePolicyCommand) Usage() string       { return "make:policy <module> <name> [--force]" }
+
+func (c *MakePolicyCommand) Run(args []string) error {
+	names := positionalArgs(args)
+	if len(names) < 2 {
+		return fmt.Errorf("module and policy name are required")
+	}
+
+	module := toSnakeCase(names[0])
+	base := toPascalCase(toSnakeCase(names[1]))
+	if trimmed := strings.TrimSuffix(base, "Policy"); trimmed != "" {
+		base = trimmed
+	}
+	resource := toSnakeCase(base)
+
+	path := filepath.Join("internal", "modules", module, resource+"_policy.go")
+	if err := generateModuleFile(c.output, path, policyTemplate, map[string]string{
+		"Package":    module,
+		"PolicyName": base + "Policy",
+		"Resource":   resource,
+	}, slices.Contains(args, "--force")); err != nil {
+		return err
+	}
+
+	c.output.Success("Policy created: %s (%s.*)", path, resource)
+	return nil
+}
+
+const policyTemplate = `package {{.Package}}
+
+import (
+	"context"
+
+	"github.com/zgiai/zgo/pkg/authz"
+)
+
+// {{.PolicyName}} authorizes actions on {{.Resource}} resources.
+// Register it with authz.Register("{{.Resource}}", {{.PolicyName}}{}) and guard
+// routes with authz.Authorize("{{.Resource}}.update", loader).
+type {{.PolicyName}} struct{}
+
+// Abilities returns the policy's abilities keyed by action
+func (p {{.PolicyName}}) Abilities() map[string]authz.PolicyFunc {
+	return map[string]authz.PolicyFunc{
+		"view":   p.View,
+		"update": p.Update,
+		"delete": p.Delete,
+	}
+}
+
+// View reports whether actor may view resource
+func (p {{.PolicyName}}) View(ctx context.Context, actor authz.Actor, resource any) (bool, error) {
+	// TODO: Decide who may view
+	return false, nil
+}
+
+// Update reports whether actor may update resource
+func (p {{.PolicyName}}) Update(ctx context.Context, actor authz.Actor, resource any) (bool, error) {
+	// TODO: Decide who may update, e.g. only the owner
+	return false, nil
+}
+
+// Delete reports whether actor may delete resource
+func (p {{.PolicyName}}) Delete(ctx context.Context, actor authz.Actor, resource any) (bool, error) {
+	// TODO: Decide who may delete
+	return false, nil
+}
+`
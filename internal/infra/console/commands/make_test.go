@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/console"
+)
+
+func TestMakeTestCommand_GeneratesStubs(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cmd := &MakeTestCommand{output: console.NewOutput()}
+
+	tests := []struct {
+		args []string
+		path string
+		want []string
+	}{
+		{[]string{"order_item"}, "service_test.go", []string{"MockRepository", "TestService_Get", "*OrderItem"}},
+		{[]string{"order_item", "--type=handler"}, "handler_test.go", []string{"MockService", "TestHandler_Get", "/order_items/1"}},
+		{[]string{"--type", "repository", "order_item"}, "repository_test.go", []string{"TestRepository_CreateAndFind", "AutoMigrate(&OrderItem{})"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			require.NoError(t, cmd.Run(tt.args))
+
+			path := filepath.Join("internal", "modules", "order_item", tt.path)
+			src, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			file, err := parser.ParseFile(token.NewFileSet(), path, src, 0)
+			require.NoError(t, err, "generated stub should be valid Go")
+			assert.Equal(t, "order_item", file.Name.Name)
+			for _, s := range tt.want {
+				assert.Contains(t, string(src), s)
+			}
+		})
+	}
+}
+
+func TestMakeTestCommand_Force(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cmd := &MakeTestCommand{output: console.NewOutput()}
+
+	path := filepath.Join("internal", "modules", "invoice", "service_test.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("package invoice\n"), 0644))
+
+	assert.Error(t, cmd.Run([]string{"invoice"}), "existing file should not be overwritten")
+	src, _ := os.ReadFile(path)
+	assert.Equal(t, "package invoice\n", string(src))
+
+	require.NoError(t, cmd.Run([]string{"invoice", "--force"}))
+	src, _ = os.ReadFile(path)
+	assert.Contains(t, string(src), "TestService_Get")
+}
+
+func TestMakeTestCommand_UnknownType(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cmd := &MakeTestCommand{output: console.NewOutput()}
+
+	assert.Error(t, cmd.Run([]string{"invoice", "--type=model"}))
+	assert.Error(t, cmd.Run(nil))
+}
+
+// eventNameOf returns the string literal returned by EventName in src
+func eventNameOf(t *testing.T, path string, src []byte) string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), path, src, 0)
+	require.NoError(t, err, "generated event should be valid Go")
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "EventName" || fn.Recv == nil {
+			continue
+		}
+		ret := fn.Body.List[0].(*ast.ReturnStmt)
+		lit := ret.Results[0].(*ast.BasicLit)
+		name, err := strconv.Unquote(lit.Value)
+		require.NoError(t, err)
+		return name
+	}
+	t.Fatal("no EventName method generated")
+	return ""
+}
+
+func TestMakeEventAndListener(t *testing.T) {
+	t.Chdir(t.TempDir())
+	makeEvent := &MakeEventCommand{output: console.NewOutput()}
+	makeListener := &MakeListenerCommand{output: console.NewOutput()}
+
+	require.NoError(t, makeEvent.Run([]string{"order", "OrderShipped"}))
+	require.NoError(t, makeListener.Run([]string{"order", "SendShippingEmail", "--event", "OrderShippedEvent"}))
+
+	eventPath := filepath.Join("internal", "modules", "order", "order_shipped_event.go")
+	src, err := os.ReadFile(eventPath)
+	require.NoError(t, err)
+	assert.Equal(t, "order.order_shipped", eventNameOf(t, eventPath, src))
+	assert.Contains(t, string(src), "type OrderShippedEvent struct")
+	formatted, err := format.Source(src)
+	require.NoError(t, err)
+	assert.Equal(t, string(formatted), string(src), "generated event should be gofmt-clean")
+
+	listenerPath := filepath.Join("internal", "modules", "order", "send_shipping_email_listener.go")
+	src, err = os.ReadFile(listenerPath)
+	require.NoError(t, err)
+	file, err := parser.ParseFile(token.NewFileSet(), listenerPath, src, 0)
+	require.NoError(t, err, "generated listener should be valid Go")
+	assert.Equal(t, "order", file.Name.Name)
+	assert.Contains(t, string(src), "func SendShippingEmail(ctx context.Context, e events.Event) error")
+	assert.Contains(t, string(src), "underlying.(OrderShippedEvent)")
+	assert.Contains(t, string(src), `bus.Subscribe("order.order_shipped", SendShippingEmail)`)
+	formatted, err = format.Source(src)
+	require.NoError(t, err)
+	assert.Equal(t, string(formatted), string(src), "generated listener should be gofmt-clean")
+}
+
+func TestMakeListener_WithoutEvent(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cmd := &MakeListenerCommand{output: console.NewOutput()}
+
+	require.NoError(t, cmd.Run([]string{"audit", "record_activity"}))
+
+	path := filepath.Join("internal", "modules", "audit", "record_activity_listener.go")
+	src, err := os.ReadFile(path)
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), path, src, 0)
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "func RecordActivity(")
+	assert.NotContains(t, string(src), "WrappedEvent")
+}
+
+func TestMakeEvent_RefusesOverwriteWithoutForce(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cmd := &MakeEventCommand{output: console.NewOutput()}
+
+	path := filepath.Join("internal", "modules", "order", "shipped_event.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("package order\n"), 0644))
+
+	assert.Error(t, cmd.Run([]string{"order", "Shipped"}))
+	src, _ := os.ReadFile(path)
+	assert.Equal(t, "package order\n", string(src))
+
+	require.NoError(t, cmd.Run([]string{"order", "Shipped", "--force"}))
+	src, _ = os.ReadFile(path)
+	assert.Contains(t, string(src), `return "order.shipped"`)
+
+	assert.Error(t, cmd.Run([]string{"order"}), "event name is required")
+}
+
+func TestMakePolicy(t *testing.T) {
+	t.Chdir(t.TempDir())
+	cmd := &MakePolicyCommand{output: console.NewOutput()}
+
+	require.NoError(t, cmd.Run([]string{"blog", "BlogPostPolicy"}))
+
+	path := filepath.Join("internal", "modules", "blog", "blog_post_policy.go")
+	src, err := os.ReadFile(path)
+	require.NoError(t, err)
+	file, err := parser.ParseFile(token.NewFileSet(), path, src, 0)
+	require.NoError(t, err, "generated policy should be valid Go")
+	assert.Equal(t, "blog", file.Name.Name)
+	assert.Contains(t, string(src), "type BlogPostPolicy struct{}")
+	assert.Contains(t, string(src), `authz.Register("blog_post", BlogPostPolicy{})`)
+	formatted, err := format.Source(src)
+	require.NoError(t, err)
+	assert.Equal(t, string(formatted), string(src), "generated policy should be gofmt-clean")
+
+	assert.Error(t, cmd.Run([]string{"blog", "BlogPost"}), "existing policy should not be overwritten")
+	require.NoError(t, cmd.Run([]string{"blog", "BlogPost", "--force"}))
+	assert.Error(t, cmd.Run([]string{"blog"}), "policy name is required")
+}
@@ -2,6 +2,7 @@
 package commands
 
 import (
+	"context"
 	"os"
 	"slices"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 	"github.com/zgiai/zgo/internal/infra/config"
 	"github.com/zgiai/zgo/internal/infra/console"
 	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/internal/infra/events"
 	"github.com/zgiai/zgo/internal/infra/migration"
 	"gorm.io/gorm"
 )
@@ -39,16 +41,21 @@ func createMigrator(cfg *config.Config) (*migration.Migrator, error) {
 		return nil, nil
 	}
 
+	return newMigrator(db, nil), nil
+}
+
+// newMigrator creates a Migrator for an open connection with all registered migrations.
+func newMigrator(db *gorm.DB, eventBus *events.EventBus) *migration.Migrator {
 	// Create repository
 	repo := migration.NewDatabaseRepository(db, "migrations")
 
 	// Create migrator
-	migrator := migration.NewMigrator(repo, db, nil)
+	migrator := migration.NewMigrator(repo, db, eventBus)
 
 	// Register all migrations from the registry
 	migrator.RegisterMany(migrations.All())
 
-	return migrator, nil
+	return migrator
 }
 
 // isProduction checks if the application is running in production.
@@ -70,17 +77,30 @@ func NewMigrateCommand() *MigrateCommand {
 func (c *MigrateCommand) Name() string        { return "db:migrate" }
 func (c *MigrateCommand) Description() string { return "Run database migrations" }
 func (c *MigrateCommand) Usage() string {
-	return "db:migrate [--pretend] [--step] [--force]"
+	return "db:migrate [--fresh] [--seed] [--pretend] [--step] [--force]"
+}
+
+// migrateFlags holds the parsed db:migrate flags.
+type migrateFlags struct {
+	pretend bool
+	step    bool
+	force   bool
+	fresh   bool
+	seed    bool
 }
 
 func (c *MigrateCommand) Run(args []string) error {
 	// Parse flags
-	pretend := slices.Contains(args, "--pretend")
-	step := slices.Contains(args, "--step")
-	force := slices.Contains(args, "--force")
+	flags := migrateFlags{
+		pretend: slices.Contains(args, "--pretend"),
+		step:    slices.Contains(args, "--step"),
+		force:   slices.Contains(args, "--force"),
+		fresh:   slices.Contains(args, "--fresh"),
+		seed:    slices.Contains(args, "--seed"),
+	}
 
 	// Check production environment
-	if isProduction() && !force {
+	if isProduction() && !flags.force {
 		c.output.Error("Cannot run migrations in production without --force flag")
 		return nil
 	}
@@ -92,33 +112,64 @@ func (c *MigrateCommand) Run(args []string) error {
 		return err
 	}
 
-	// Create migrator
-	migrator, err := createMigrator(cfg)
+	// Dropping tables requires explicit confirmation
+	if flags.fresh && !flags.force && !flags.pretend {
+		c.output.Warning("--fresh will DROP ALL TABLES in database '%s'", cfg.Database.Name)
+		if !c.output.Confirm("Continue?", false) {
+			c.output.Info("Operation cancelled")
+			return nil
+		}
+	}
+
+	// Connect to DB
+	db, err := database.NewDB(cfg)
 	if err != nil {
-		c.output.Error("Failed to create migrator: %v", err)
+		c.output.Error("Failed to connect to database: %v", err)
 		return err
 	}
-	if migrator == nil {
+	if db == nil {
 		c.output.Warning("Database is disabled in config")
 		return nil
 	}
 
+	return c.migrate(db, flags)
+}
+
+// migrate runs migrations against db, printing each migration as it is applied.
+func (c *MigrateCommand) migrate(db *gorm.DB, flags migrateFlags) error {
+	eventBus := events.NewEventBus()
+	if !flags.pretend {
+		eventBus.Subscribe(migration.EventMigrationEnded, func(ctx context.Context, e events.Event) error {
+			if ended, ok := e.(*migration.MigrationEnded); ok && ended.Method == "up" {
+				c.output.Success("Migrated: %s", ended.Migration)
+			}
+			return nil
+		})
+	}
+	migrator := newMigrator(db, eventBus)
+
 	// Set output writer for pretend mode
-	if pretend {
+	if flags.pretend {
 		migrator.SetOutput(&consoleOutputWriter{output: c.output})
 		c.output.Info("Running migrations in pretend mode...")
 	} else {
 		c.output.Info("Running migrations...")
 	}
 
-	// Run migrations
 	opts := migration.MigratorOptions{
-		Pretend: pretend,
-		Step:    step,
-		Force:   force,
+		Pretend: flags.pretend,
+		Step:    flags.step,
+		Force:   flags.force,
 	}
 
-	executed, err := migrator.Run(opts)
+	var executed []string
+	var err error
+	if flags.fresh && !flags.pretend {
+		c.output.Warning("Dropping all tables...")
+		executed, err = migrator.Fresh(opts)
+	} else {
+		executed, err = migrator.Run(opts)
+	}
 	if err != nil {
 		c.output.Error("Migration failed: %v", err)
 		return err
@@ -126,15 +177,20 @@ func (c *MigrateCommand) Run(args []string) error {
 
 	if len(executed) == 0 {
 		c.output.Info("Nothing to migrate")
-		return nil
+	} else {
+		c.output.Success("Migrations completed (%d migrations)", len(executed))
 	}
 
-	// Display executed migrations
-	for _, name := range executed {
-		c.output.Success("Migrated: %s", name)
+	// Run seeders if requested
+	if flags.seed && !flags.pretend {
+		c.output.Info("Running seeders...")
+		if err := runSeeders(db); err != nil {
+			c.output.Error("Seeding failed: %v", err)
+			return err
+		}
+		c.output.Success("Seeders completed")
 	}
 
-	c.output.Success("Migrations completed (%d migrations)", len(executed))
 	return nil
 }
 
@@ -154,35 +210,38 @@ func (c *RollbackCommand) Usage() string {
 	return "db:rollback [--step=N] [--batch=N] [--pretend]"
 }
 
-func (c *RollbackCommand) Run(args []string) error {
-	// Parse flags
-	pretend := slices.Contains(args, "--pretend")
-	steps := 0
-	batch := 0
-
-	// Parse --step=N or --step N
-	for i, arg := range args {
-		if val, found := strings.CutPrefix(arg, "--step="); found {
-			if n, err := strconv.Atoi(val); err == nil {
-				steps = n
-			}
-		} else if arg == "--step" && i+1 < len(args) {
-			if n, err := strconv.Atoi(args[i+1]); err == nil {
-				steps = n
+// parseRollbackOptions reads the rollback flags: --step=N reverts the last
+// N batches and --batch=N one specific batch; with neither, the last batch
+// is reverted. Both accept "--flag N" as well.
+func parseRollbackOptions(args []string) migration.RollbackOptions {
+	opts := migration.NewRollbackOptions()
+	opts.Pretend = slices.Contains(args, "--pretend")
+
+	intFlag := func(i int, arg, name string) (int, bool) {
+		val, found := strings.CutPrefix(arg, name+"=")
+		if !found {
+			if arg != name || i+1 >= len(args) {
+				return 0, false
 			}
+			val = args[i+1]
 		}
+		n, err := strconv.Atoi(val)
+		return n, err == nil
+	}
 
-		// Parse --batch=N or --batch N
-		if val, found := strings.CutPrefix(arg, "--batch="); found {
-			if n, err := strconv.Atoi(val); err == nil {
-				batch = n
-			}
-		} else if arg == "--batch" && i+1 < len(args) {
-			if n, err := strconv.Atoi(args[i+1]); err == nil {
-				batch = n
-			}
+	for i, arg := range args {
+		if n, ok := intFlag(i, arg, "--step"); ok {
+			opts.Batches = n
+		}
+		if n, ok := intFlag(i, arg, "--batch"); ok {
+			opts.Batch = n
 		}
 	}
+	return opts
+}
+
+func (c *RollbackCommand) Run(args []string) error {
+	opts := parseRollbackOptions(args)
 
 	// Load config
 	cfg, err := config.Load()
@@ -203,20 +262,13 @@ func (c *RollbackCommand) Run(args []string) error {
 	}
 
 	// Set output writer for pretend mode
-	if pretend {
+	if opts.Pretend {
 		migrator.SetOutput(&consoleOutputWriter{output: c.output})
 		c.output.Info("Rolling back migrations in pretend mode...")
 	} else {
 		c.output.Info("Rolling back migrations...")
 	}
 
-	// Rollback migrations
-	opts := migration.RollbackOptions{
-		Steps:   steps,
-		Batch:   batch,
-		Pretend: pretend,
-	}
-
 	rolledBack, err := migrator.Rollback(opts)
 	if err != nil {
 		c.output.Error("Rollback failed: %v", err)
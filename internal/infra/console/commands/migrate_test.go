@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/migration"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"gorm.io/gorm"
+)
+
+func setupCommandDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	return dbtest.Open(t)
+}
+
+func TestMigrateCommand_RunsMigrations(t *testing.T) {
+	db := setupCommandDB(t)
+	cmd := &MigrateCommand{output: console.NewOutput()}
+
+	require.NoError(t, cmd.migrate(db, migrateFlags{}))
+
+	for _, table := range []string{"migrations", "users", "roles", "permissions", "role_permissions", "user_roles"} {
+		assert.True(t, db.Migrator().HasTable(table), "table %s should exist", table)
+	}
+
+	var ran int64
+	require.NoError(t, db.Table("migrations").Count(&ran).Error)
+	assert.Greater(t, ran, int64(0))
+
+	// Running again is a no-op
+	require.NoError(t, cmd.migrate(db, migrateFlags{}))
+	var again int64
+	require.NoError(t, db.Table("migrations").Count(&again).Error)
+	assert.Equal(t, ran, again)
+}
+
+func TestMigrateCommand_FreshAndSeed(t *testing.T) {
+	db := setupCommandDB(t)
+	cmd := &MigrateCommand{output: console.NewOutput()}
+
+	require.NoError(t, cmd.migrate(db, migrateFlags{}))
+	require.NoError(t, db.Exec("CREATE TABLE leftovers (id INTEGER)").Error)
+
+	require.NoError(t, cmd.migrate(db, migrateFlags{fresh: true, force: true, seed: true}))
+
+	assert.False(t, db.Migrator().HasTable("leftovers"), "--fresh should drop unrelated tables")
+	assert.True(t, db.Migrator().HasTable("users"))
+
+	var users int64
+	require.NoError(t, db.Table("users").Count(&users).Error)
+	assert.Greater(t, users, int64(0), "--seed should run seeders")
+}
+
+func TestMigrateCommand_PretendDoesNotCreateTables(t *testing.T) {
+	db := setupCommandDB(t)
+	cmd := &MigrateCommand{output: console.NewOutput()}
+
+	require.NoError(t, cmd.migrate(db, migrateFlags{pretend: true}))
+
+	assert.False(t, db.Migrator().HasTable("users"))
+}
+
+func TestParseRollbackOptions(t *testing.T) {
+	tests := []struct {
+		args []string
+		want migration.RollbackOptions
+	}{
+		{nil, migration.NewRollbackOptions()},
+		{[]string{"--step=2"}, migration.NewRollbackOptions().WithBatches(2)},
+		{[]string{"--step", "3", "--pretend"}, migration.NewRollbackOptions().WithBatches(3).WithPretend()},
+		{[]string{"--batch=4"}, migration.NewRollbackOptions().WithBatch(4)},
+		{[]string{"--step=x"}, migration.NewRollbackOptions()},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, parseRollbackOptions(tt.args), "args %v", tt.args)
+	}
+}
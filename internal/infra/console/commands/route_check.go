@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/middleware"
+	"github.com/zgiai/zgo/internal/infra/router"
+	"github.com/zgiai/zgo/internal/wiring"
+	"github.com/zgiai/zgo/routes"
+)
+
+// handlerFiles is where route:check looks for handler methods
+const handlerFiles = "internal/modules/*/handler.go"
+
+// ErrOrphanHandlers is returned by route:check when a handler method has no route
+var ErrOrphanHandlers = errors.New("handler methods without a route")
+
+// handlerMethod is an exported method taking only a *gin.Context, declared
+// in a module's handler.go
+type handlerMethod struct {
+	// Name matches router.RouteInfo.Handler, e.g. "user.(*Handler).List"
+	Name string
+	File string
+	Line int
+}
+
+// RouteCheckCommand reports handler methods no route is registered for
+type RouteCheckCommand struct {
+	output  *console.Output
+	pattern string
+}
+
+func NewRouteCheckCommand() *RouteCheckCommand {
+	return &RouteCheckCommand{output: console.NewOutput(), pattern: handlerFiles}
+}
+
+func (c *RouteCheckCommand) Name() string        { return "route:check" }
+func (c *RouteCheckCommand) Description() string { return "Find handler methods without a route" }
+func (c *RouteCheckCommand) Usage() string       { return "route:check" }
+
+func (c *RouteCheckCommand) Run(args []string) error {
+	gin.SetMode(gin.ReleaseMode)
+
+	// Initialize application via Wire DI
+	application, err := wiring.InitApplication()
+	if err != nil {
+		return fmt.Errorf("failed to init application: %w", err)
+	}
+
+	// Set JWT service for middleware
+	middleware.SetJWTService(application.JWTService)
+
+	r := gin.New()
+	rt := routes.Setup(r, application.Handlers)
+
+	return c.check(collectRoutes(r, rt))
+}
+
+// check reports the handler methods none of list routes to, failing when
+// there are any so CI can gate on it
+func (c *RouteCheckCommand) check(list []router.RouteInfo) error {
+	methods, err := findHandlerMethods(c.pattern)
+	if err != nil {
+		return err
+	}
+
+	orphans := orphanHandlers(methods, list)
+	if len(orphans) == 0 {
+		c.output.Success("All %d handler methods have a route", len(methods))
+		return nil
+	}
+
+	rows := make([][]string, 0, len(orphans))
+	for _, m := range orphans {
+		rows = append(rows, []string{m.Name, m.File + ":" + strconv.Itoa(m.Line)})
+	}
+	c.output.Title("Handler Methods Without a Route")
+	c.output.Table([]string{"Handler", "Location"}, rows)
+	c.output.Error("%d of %d handler methods have no route", len(orphans), len(methods))
+	return ErrOrphanHandlers
+}
+
+// findHandlerMethods parses the files matching pattern and returns their
+// exported methods shaped like gin handlers, sorted by name
+func findHandlerMethods(pattern string) ([]handlerMethod, error) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	methods := make([]handlerMethod, 0)
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() || !isGinHandler(fn.Type) {
+				continue
+			}
+			methods = append(methods, handlerMethod{
+				Name: file.Name.Name + "." + receiverName(fn.Recv.List[0].Type) + "." + fn.Name.Name,
+				File: filepath.ToSlash(path),
+				Line: fset.Position(fn.Pos()).Line,
+			})
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+	return methods, nil
+}
+
+// isGinHandler reports whether fn is func(*gin.Context) with no results
+func isGinHandler(fn *ast.FuncType) bool {
+	if fn.Results != nil || len(fn.Params.List) != 1 || len(fn.Params.List[0].Names) > 1 {
+		return false
+	}
+	star, ok := fn.Params.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Context" {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "gin"
+}
+
+// receiverName renders a receiver type the way the runtime names methods:
+// "(*Handler)" for pointers, "Handler" for values
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return "(*" + receiverName(star.X) + ")"
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// orphanHandlers returns the methods no route in list is handled by
+func orphanHandlers(methods []handlerMethod, list []router.RouteInfo) []handlerMethod {
+	routed := make(map[string]bool, len(list))
+	for _, route := range list {
+		// Routes added on gin directly carry the full import path
+		name := route.Handler[strings.LastIndex(route.Handler, "/")+1:]
+		routed[strings.TrimSuffix(name, "-fm")] = true
+	}
+
+	orphans := make([]handlerMethod, 0)
+	for _, m := range methods {
+		if !routed[m.Name] {
+			orphans = append(orphans, m)
+		}
+	}
+	return orphans
+}
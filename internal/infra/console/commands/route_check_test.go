@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/router"
+)
+
+const blogHandler = `package blog
+
+import "github.com/gin-gonic/gin"
+
+type Handler struct{}
+
+func (h *Handler) Name() string { return "blog" }
+
+func (h *Handler) List(c *gin.Context) {}
+
+func (h *Handler) Show(c *gin.Context) {}
+
+func (h *Handler) Publish(c *gin.Context) {}
+
+func (h *Handler) bind(c *gin.Context) {}
+
+func Health(c *gin.Context) {}
+`
+
+func writeBlogModule(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "blog")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "handler.go"), []byte(blogHandler), 0o644))
+	return filepath.Join(filepath.Dir(dir), "*", "handler.go")
+}
+
+func TestRouteCheck_ReportsUnwiredHandler(t *testing.T) {
+	cmd := &RouteCheckCommand{output: console.NewOutput(), pattern: writeBlogModule(t)}
+	list := []router.RouteInfo{
+		{Method: "GET", Path: "/posts", Handler: "blog.(*Handler).List"},
+		// Registered on gin directly, named by the runtime
+		{Method: "POST", Path: "/posts/:id/publish", Handler: "github.com/acme/app/internal/modules/blog.(*Handler).Publish-fm"},
+	}
+
+	var err error
+	out := captureStdout(t, func() { err = cmd.check(list) })
+
+	assert.ErrorIs(t, err, ErrOrphanHandlers)
+	assert.Contains(t, out, "blog.(*Handler).Show")
+	assert.Contains(t, out, "handler.go:11")
+	assert.NotContains(t, out, "(*Handler).List")
+	assert.NotContains(t, out, "(*Handler).Publish")
+	assert.NotContains(t, out, "(*Handler).Name")
+	assert.NotContains(t, out, "bind")
+
+	list = append(list, router.RouteInfo{Method: "GET", Path: "/posts/:id", Handler: "blog.(*Handler).Show"})
+	captureStdout(t, func() { err = cmd.check(list) })
+	assert.NoError(t, err)
+}
+
+func TestFindHandlerMethods_Modules(t *testing.T) {
+	methods, err := findHandlerMethods("../../../modules/*/handler.go")
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(methods))
+	for _, m := range methods {
+		names = append(names, m.Name)
+	}
+	assert.Contains(t, names, "user.(*Handler).Register")
+	assert.Contains(t, names, "permission.(*Handler).CreateRole")
+	assert.NotContains(t, names, "user.(*Handler).RegisterEvents")
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/schedule"
+	"github.com/zgiai/zgo/internal/wiring"
+	"github.com/zgiai/zgo/routes"
+)
+
+// ScheduleListCommand lists the scheduled jobs and when they run next
+type ScheduleListCommand struct {
+	output *console.Output
+}
+
+func NewScheduleListCommand() *ScheduleListCommand {
+	return &ScheduleListCommand{output: console.NewOutput()}
+}
+
+func (c *ScheduleListCommand) Name() string { return "schedule:list" }
+func (c *ScheduleListCommand) Description() string {
+	return "List scheduled jobs and their next run times"
+}
+func (c *ScheduleListCommand) Usage() string { return "schedule:list" }
+
+func (c *ScheduleListCommand) Run(args []string) error {
+	application, err := wiring.InitApplication()
+	if err != nil {
+		return fmt.Errorf("failed to init application: %w", err)
+	}
+
+	s := schedule.New()
+	routes.Schedule(s, application)
+
+	events := s.Events()
+	if len(events) == 0 {
+		c.output.Info("No scheduled jobs. Register them in routes/schedule.go.")
+		return nil
+	}
+	if !application.Config.Schedule.Enabled {
+		c.output.Warning("SCHEDULE_ENABLED is false: these jobs do not run with the server.")
+	}
+
+	c.output.Title("Scheduled Jobs")
+	c.output.Table([]string{"Name", "Schedule", "Next Run", "Options"}, scheduleRows(events, time.Now()))
+	return nil
+}
+
+// scheduleRows renders one table row per event, computing next runs from now
+func scheduleRows(events []*schedule.Event, now time.Time) [][]string {
+	rows := make([][]string, 0, len(events))
+	for _, event := range events {
+		next := "never"
+		if err := event.Err(); err != nil {
+			next = "invalid: " + err.Error()
+		} else if at := event.Next(now); !at.IsZero() {
+			next = at.Format("2006-01-02 15:04:05 MST")
+		}
+
+		var options []string
+		if event.WithoutOverlap() {
+			options = append(options, "without overlapping")
+		}
+		if event.OneServer() {
+			options = append(options, "one server")
+		}
+		opts := strings.Join(options, ", ")
+		if opts == "" {
+			opts = "-"
+		}
+
+		rows = append(rows, []string{event.Name(), event.Expression(), next, opts})
+	}
+	return rows
+}
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zgiai/zgo/internal/infra/schedule"
+)
+
+func TestScheduleRows(t *testing.T) {
+	s := schedule.New()
+	noop := func(ctx context.Context) error { return nil }
+	s.Schedule("@every 15m", noop).Named("cache:warm")
+	s.Call("report", noop).DailyAt(25, 0)
+
+	now := time.Date(2026, 1, 14, 10, 7, 0, 0, time.UTC)
+	rows := scheduleRows(s.Events(), now)
+
+	assert.Equal(t, []string{"cache:warm", "@every 15m", "2026-01-14 10:15:00 UTC", "without overlapping, one server"}, rows[0])
+	assert.Equal(t, "report", rows[1][0])
+	assert.Equal(t, "0 25 * * *", rows[1][1])
+	assert.Contains(t, rows[1][2], "invalid")
+	assert.Equal(t, "-", rows[1][3])
+}
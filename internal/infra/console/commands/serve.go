@@ -1,9 +1,12 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
 
@@ -11,9 +14,9 @@ import (
 	"github.com/zgiai/zgo/internal/infra/config"
 	"github.com/zgiai/zgo/internal/infra/console"
 	"github.com/zgiai/zgo/internal/infra/middleware"
+	"github.com/zgiai/zgo/internal/infra/router"
 	"github.com/zgiai/zgo/internal/wiring"
 	"github.com/zgiai/zgo/routes"
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
@@ -66,14 +69,7 @@ func (c *ServeCommand) Run(args []string) error {
 	r := gin.Default()
 
 	// CORS
-	corsConfig := cors.Config{
-		AllowOrigins:     cfg.CORS.AllowOrigins,
-		AllowMethods:     cfg.CORS.AllowMethods,
-		AllowHeaders:     cfg.CORS.AllowHeaders,
-		ExposeHeaders:    cfg.CORS.ExposeHeaders,
-		AllowCredentials: cfg.CORS.AllowCredentials,
-	}
-	r.Use(cors.New(corsConfig))
+	r.Use(middleware.CORSWithConfig(middleware.CORSConfigFrom(cfg.CORS)))
 
 	// Register routes
 	routes.Setup(r, application.Handlers)
@@ -166,7 +162,9 @@ func NewRouteListCommand() *RouteListCommand {
 
 func (c *RouteListCommand) Name() string        { return "route:list" }
 func (c *RouteListCommand) Description() string { return "List all registered routes" }
-func (c *RouteListCommand) Usage() string       { return "route:list" }
+func (c *RouteListCommand) Usage() string {
+	return "route:list [--json] [--filter=pattern] [--method=GET]"
+}
 
 func (c *RouteListCommand) Run(args []string) error {
 	gin.SetMode(gin.ReleaseMode)
@@ -181,17 +179,83 @@ func (c *RouteListCommand) Run(args []string) error {
 	middleware.SetJWTService(application.JWTService)
 
 	r := gin.New()
-	routes.Setup(r, application.Handlers)
+	rt := routes.Setup(r, application.Handlers)
 
-	c.output.Title("Registered Routes")
+	list := filterRoutes(collectRoutes(r, rt), flagValue(args, "filter"), flagValue(args, "method"))
+	if slices.Contains(args, "--json") {
+		return c.printJSON(list)
+	}
+	c.printTable(list)
+	return nil
+}
 
-	headers := []string{"Method", "Path", "Handler"}
-	rows := make([][]string, 0)
+func (c *RouteListCommand) printTable(list []router.RouteInfo) {
+	c.output.Title("Registered Routes")
 
-	for _, route := range r.Routes() {
-		rows = append(rows, []string{route.Method, route.Path, route.Handler})
+	headers := []string{"Method", "Path", "Handler", "Middleware"}
+	rows := make([][]string, 0, len(list))
+	for _, route := range list {
+		mw := strings.Join(route.Middleware, ", ")
+		if mw == "" {
+			mw = "-"
+		}
+		rows = append(rows, []string{route.Method, route.Path, route.Handler, mw})
 	}
 
 	c.output.Table(headers, rows)
+}
+
+func (c *RouteListCommand) printJSON(list []router.RouteInfo) error {
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
 	return nil
 }
+
+// collectRoutes lists every route gin serves, taking names and middleware
+// from the fluent router for the routes registered through it
+func collectRoutes(engine *gin.Engine, rt *router.Router) []router.RouteInfo {
+	known := make(map[string]router.RouteInfo)
+	for _, info := range rt.List() {
+		for _, method := range strings.Split(info.Method, "|") {
+			known[method+" "+info.Path] = info
+		}
+	}
+
+	list := make([]router.RouteInfo, 0)
+	for _, route := range engine.Routes() {
+		info := router.RouteInfo{Method: route.Method, Path: route.Path, Handler: route.Handler, Middleware: []string{}}
+		lookup := route.Method
+		if lookup == http.MethodHead {
+			// GET routes register their HEAD twin automatically
+			if _, ok := known[lookup+" "+route.Path]; !ok {
+				lookup = http.MethodGet
+			}
+		}
+		if r, ok := known[lookup+" "+route.Path]; ok {
+			info.Name = r.Name
+			info.Handler = r.Handler
+			info.Middleware = r.Middleware
+		}
+		list = append(list, info)
+	}
+	return list
+}
+
+// filterRoutes keeps routes whose path contains pattern and whose method
+// matches method (case-insensitive); empty filters match everything
+func filterRoutes(list []router.RouteInfo, pattern, method string) []router.RouteInfo {
+	filtered := make([]router.RouteInfo, 0, len(list))
+	for _, route := range list {
+		if pattern != "" && !strings.Contains(route.Path, pattern) {
+			continue
+		}
+		if method != "" && !strings.EqualFold(route.Method, method) {
+			continue
+		}
+		filtered = append(filtered, route)
+	}
+	return filtered
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/router"
+)
+
+func listProfile(c *gin.Context) { c.Status(http.StatusOK) }
+
+func listHealth(c *gin.Context) { c.Status(http.StatusOK) }
+
+func newRouteListRouter() (*gin.Engine, *router.Router) {
+	engine := gin.New()
+	rt := router.New(engine)
+	rt.MiddlewareGroup("auth", func(c *gin.Context) { c.Next() })
+
+	rt.GET("/health", listHealth)
+	rt.Group("/v1", func(api *router.Router) {
+		api.WithMiddleware("auth")
+		api.GET("/profile", listProfile).Name("user.profile")
+		api.PUT("/profile", listProfile)
+	})
+	return engine, rt
+}
+
+func TestRouteListCommand_JSON(t *testing.T) {
+	engine, rt := newRouteListRouter()
+	cmd := &RouteListCommand{output: console.NewOutput()}
+
+	out := captureStdout(t, func() {
+		require.NoError(t, cmd.printJSON(collectRoutes(engine, rt)))
+	})
+
+	var list []router.RouteInfo
+	require.NoError(t, json.Unmarshal([]byte(out), &list))
+
+	byRoute := make(map[string]router.RouteInfo)
+	for _, r := range list {
+		byRoute[r.Method+" "+r.Path] = r
+	}
+
+	profile, ok := byRoute["GET /v1/profile"]
+	require.True(t, ok, "GET /v1/profile is listed")
+	assert.Equal(t, "user.profile", profile.Name)
+	assert.Equal(t, "commands.listProfile", profile.Handler)
+	assert.Equal(t, []string{"auth"}, profile.Middleware)
+
+	assert.Equal(t, []string{"auth"}, byRoute["HEAD /v1/profile"].Middleware, "HEAD inherits from GET")
+	assert.Empty(t, byRoute["GET /health"].Middleware, "unauthenticated route has no middleware")
+}
+
+func TestFilterRoutes(t *testing.T) {
+	engine, rt := newRouteListRouter()
+	list := collectRoutes(engine, rt)
+
+	filtered := filterRoutes(list, "/v1", "put")
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "/v1/profile", filtered[0].Path)
+	assert.Equal(t, http.MethodPut, filtered[0].Method)
+
+	assert.Len(t, filterRoutes(list, "", ""), len(list))
+	assert.Empty(t, filterRoutes(list, "/missing", ""))
+}
@@ -0,0 +1,213 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/middleware"
+	"github.com/zgiai/zgo/internal/infra/router"
+	"github.com/zgiai/zgo/internal/wiring"
+	"github.com/zgiai/zgo/routes"
+)
+
+const (
+	// swaggerMainFile holds the general API annotations (@title, @BasePath)
+	swaggerMainFile = "cmd/server/main.go"
+
+	// swaggerOutput is where swagger:gen writes the spec by default
+	swaggerOutput = "docs/swagger.json"
+)
+
+// SwaggerGenCommand generates the OpenAPI spec from handler annotations
+type SwaggerGenCommand struct {
+	output *console.Output
+}
+
+func NewSwaggerGenCommand() *SwaggerGenCommand {
+	return &SwaggerGenCommand{output: console.NewOutput()}
+}
+
+func (c *SwaggerGenCommand) Name() string        { return "swagger:gen" }
+func (c *SwaggerGenCommand) Description() string { return "Generate the OpenAPI spec from annotations" }
+func (c *SwaggerGenCommand) Usage() string       { return "swagger:gen [--output=docs/swagger.json]" }
+
+func (c *SwaggerGenCommand) Run(args []string) error {
+	path := flagValue(args, "output")
+	if path == "" {
+		path = swaggerOutput
+	}
+
+	doc, err := parseSwagger(".")
+	if err != nil {
+		return err
+	}
+	if err := c.write(doc, path); err != nil {
+		return err
+	}
+
+	c.output.Success("Generated %s (%d paths)", path, len(doc.Paths.Paths))
+	return nil
+}
+
+// write saves doc to path and reads it back, so a broken spec fails here
+// rather than in Swagger UI
+func (c *SwaggerGenCommand) write(doc *spec.Swagger, path string) error {
+	data, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to encode spec: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write spec: %w", err)
+	}
+
+	_, err = loadSwagger(path)
+	return err
+}
+
+// SwaggerValidateCommand fails when registered routes lack annotations
+type SwaggerValidateCommand struct {
+	output *console.Output
+}
+
+func NewSwaggerValidateCommand() *SwaggerValidateCommand {
+	return &SwaggerValidateCommand{output: console.NewOutput()}
+}
+
+func (c *SwaggerValidateCommand) Name() string { return "swagger:validate" }
+func (c *SwaggerValidateCommand) Description() string {
+	return "Check that every API route is documented"
+}
+func (c *SwaggerValidateCommand) Usage() string { return "swagger:validate" }
+
+func (c *SwaggerValidateCommand) Run(args []string) error {
+	gin.SetMode(gin.ReleaseMode)
+
+	doc, err := parseSwagger(".")
+	if err != nil {
+		return err
+	}
+
+	application, err := wiring.InitApplication()
+	if err != nil {
+		return fmt.Errorf("failed to init application: %w", err)
+	}
+	middleware.SetJWTService(application.JWTService)
+
+	r := gin.New()
+	rt := routes.Setup(r, application.Handlers)
+
+	missing := undocumentedRoutes(collectRoutes(r, rt), doc)
+	if len(missing) == 0 {
+		c.output.Success("All routes under %s are documented", doc.BasePath)
+		return nil
+	}
+
+	c.output.Error("%d route(s) have no Swagger annotations:", len(missing))
+	rows := make([][]string, 0, len(missing))
+	for _, route := range missing {
+		rows = append(rows, []string{route.Method, route.Path, route.Handler})
+	}
+	c.output.Table([]string{"Method", "Path", "Handler"}, rows)
+	return fmt.Errorf("%d undocumented route(s)", len(missing))
+}
+
+// parseSwagger builds the spec from the annotations under dir
+func parseSwagger(dir string) (*spec.Swagger, error) {
+	parser := swag.New(swag.SetDebugger(log.New(io.Discard, "", 0)))
+	parser.ParseInternal = true
+	if err := parser.ParseAPI(dir, swaggerMainFile, 100); err != nil {
+		return nil, fmt.Errorf("failed to parse annotations: %w", err)
+	}
+	return parser.GetSwagger(), nil
+}
+
+// loadSwagger reads and decodes a spec file
+func loadSwagger(path string) (*spec.Swagger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+	var doc spec.Swagger
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid spec %s: %w", path, err)
+	}
+	if doc.Swagger != "2.0" {
+		return nil, fmt.Errorf("invalid spec %s: unsupported swagger version %q", path, doc.Swagger)
+	}
+	if doc.Paths == nil {
+		doc.Paths = &spec.Paths{}
+	}
+	return &doc, nil
+}
+
+// ginParam matches gin path parameters (:id, *filepath)
+var ginParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+// undocumentedRoutes returns the routes under the spec's base path that have
+// no documented operation. Automatic HEAD routes are skipped.
+func undocumentedRoutes(list []router.RouteInfo, doc *spec.Swagger) []router.RouteInfo {
+	base := strings.TrimSuffix(doc.BasePath, "/")
+	var missing []router.RouteInfo
+	for _, route := range list {
+		if route.Method == http.MethodHead {
+			continue
+		}
+		path, ok := strings.CutPrefix(route.Path, base)
+		if !ok || (path != "" && !strings.HasPrefix(path, "/")) {
+			continue
+		}
+		if path == "" {
+			path = "/"
+		}
+		path = ginParam.ReplaceAllString(path, "{$1}")
+
+		if !isDocumented(doc, route.Method, path) {
+			missing = append(missing, route)
+		}
+	}
+	return missing
+}
+
+// isDocumented reports whether the spec describes method on path
+func isDocumented(doc *spec.Swagger, method, path string) bool {
+	if doc.Paths == nil {
+		return false
+	}
+	item, ok := doc.Paths.Paths[path]
+	if !ok {
+		return false
+	}
+
+	var op *spec.Operation
+	switch method {
+	case http.MethodGet:
+		op = item.Get
+	case http.MethodPost:
+		op = item.Post
+	case http.MethodPut:
+		op = item.Put
+	case http.MethodPatch:
+		op = item.Patch
+	case http.MethodDelete:
+		op = item.Delete
+	case http.MethodOptions:
+		op = item.Options
+	case http.MethodHead:
+		op = item.Head
+	}
+	return op != nil
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/router"
+)
+
+const swaggerMainSource = `package main
+
+// @title Test API
+// @version 1.0
+// @BasePath /v1
+
+func main() {}
+`
+
+const swaggerHandlerSource = `package handlers
+
+// Profile returns the current user
+// @Summary Get profile
+// @Success 200
+// @Router /profile [get]
+func Profile() {}
+`
+
+// writeSwaggerProject lays out a tiny annotated project in a temp dir
+func writeSwaggerProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		swaggerMainFile:       swaggerMainSource,
+		"handlers/profile.go": swaggerHandlerSource,
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+	}
+	return dir
+}
+
+func TestSwaggerValidate_ReportsUndocumentedRoute(t *testing.T) {
+	doc, err := parseSwagger(writeSwaggerProject(t))
+	require.NoError(t, err)
+	assert.Equal(t, "/v1", doc.BasePath)
+
+	engine, rt := newRouteListRouter()
+	missing := undocumentedRoutes(collectRoutes(engine, rt), doc)
+
+	// GET /v1/profile is documented, HEAD is automatic and /health is outside the base path
+	require.Len(t, missing, 1)
+	assert.Equal(t, "PUT", missing[0].Method)
+	assert.Equal(t, "/v1/profile", missing[0].Path)
+}
+
+func TestUndocumentedRoutes_PathParams(t *testing.T) {
+	doc := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		BasePath: "/v1",
+		Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+			"/users/{id}": {PathItemProps: spec.PathItemProps{Get: &spec.Operation{}}},
+		}},
+	}}
+
+	missing := undocumentedRoutes([]router.RouteInfo{
+		{Method: "GET", Path: "/v1/users/:id"},
+		{Method: "DELETE", Path: "/v1/users/:id"},
+		{Method: "GET", Path: "/v1beta/users"},
+	}, doc)
+
+	require.Len(t, missing, 1)
+	assert.Equal(t, "DELETE", missing[0].Method)
+}
+
+func TestSwaggerGen_WritesValidSpec(t *testing.T) {
+	doc, err := parseSwagger(writeSwaggerProject(t))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "docs", "swagger.json")
+	cmd := NewSwaggerGenCommand()
+	require.NoError(t, cmd.write(doc, path))
+
+	written, err := loadSwagger(path)
+	require.NoError(t, err)
+	assert.Contains(t, written.Paths.Paths, "/profile")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"swagger": "3.0"}`), 0644))
+	_, err = loadSwagger(path)
+	assert.Error(t, err)
+}
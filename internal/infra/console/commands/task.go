@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/zgiai/zgo/internal/app"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/container"
+	"github.com/zgiai/zgo/internal/infra/task"
+	"github.com/zgiai/zgo/internal/wiring"
+	"github.com/zgiai/zgo/routes"
+)
+
+// TaskCommand runs a registered one-shot task
+type TaskCommand struct {
+	output   *console.Output
+	boot     func() (*app.Application, error)
+	registry *task.Registry
+}
+
+func NewTaskCommand() *TaskCommand {
+	return &TaskCommand{output: console.NewOutput(), boot: wiring.InitApplication, registry: task.Global()}
+}
+
+func (c *TaskCommand) Name() string        { return "task:run" }
+func (c *TaskCommand) Description() string { return "Run a maintenance task" }
+func (c *TaskCommand) Usage() string       { return "task:run <name> [--arg key=value ...]" }
+
+func (c *TaskCommand) Run(args []string) error {
+	names := positionalArgs(args, "arg")
+	if len(names) == 0 {
+		return fmt.Errorf("task name is required, see task:list")
+	}
+	taskArgs, err := task.ParseArgs(flagValues(args, "arg"))
+	if err != nil {
+		return err
+	}
+
+	if err := bootTasks(c.boot, c.registry); err != nil {
+		return err
+	}
+	t, ok := c.registry.Get(names[0])
+	if !ok {
+		return fmt.Errorf("task %q is not registered, see task:list", names[0])
+	}
+
+	// Ctrl-C cancels the task's context so it can stop cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c.output.Info("Running %s...", t.Name())
+	start := time.Now()
+	err = t.Run(ctx, taskArgs)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		c.output.Error("%s failed after %s: %v", t.Name(), elapsed, err)
+		return err
+	}
+	c.output.Success("%s finished in %s", t.Name(), elapsed)
+	return nil
+}
+
+// TaskListCommand lists the registered tasks
+type TaskListCommand struct {
+	output   *console.Output
+	boot     func() (*app.Application, error)
+	registry *task.Registry
+}
+
+func NewTaskListCommand() *TaskListCommand {
+	return &TaskListCommand{output: console.NewOutput(), boot: wiring.InitApplication, registry: task.Global()}
+}
+
+func (c *TaskListCommand) Name() string        { return "task:list" }
+func (c *TaskListCommand) Description() string { return "List maintenance tasks" }
+func (c *TaskListCommand) Usage() string       { return "task:list" }
+
+func (c *TaskListCommand) Run(args []string) error {
+	if err := bootTasks(c.boot, c.registry); err != nil {
+		return err
+	}
+
+	tasks := c.registry.Tasks()
+	if len(tasks) == 0 {
+		c.output.Info("No tasks. Register them in routes/tasks.go.")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(tasks))
+	for _, t := range tasks {
+		rows = append(rows, []string{t.Name(), t.Description()})
+	}
+	c.output.Title("Tasks")
+	c.output.Table([]string{"Name", "Description"}, rows)
+	return nil
+}
+
+// bootTasks initializes the application the way serve does, so tasks can
+// resolve the database and module services, then registers its tasks
+func bootTasks(boot func() (*app.Application, error), registry *task.Registry) error {
+	application, err := boot()
+	if err != nil {
+		return fmt.Errorf("failed to init application: %w", err)
+	}
+
+	application.Register(container.App())
+	if application.Handlers != nil {
+		for _, m := range application.Handlers.Modules() {
+			if err := m.Init(); err != nil {
+				log.Printf("Warning: Module %s failed to initialize: %v", m.Name(), err)
+			}
+		}
+	}
+
+	routes.Tasks(registry, application)
+	return nil
+}
+
+// flagValues returns every value of a repeatable flag, given as --name=value
+// or --name value
+func flagValues(args []string, name string) []string {
+	prefix := "--" + name
+	var values []string
+	for i := 0; i < len(args); i++ {
+		if val, found := strings.CutPrefix(args[i], prefix+"="); found {
+			values = append(values, val)
+		} else if args[i] == prefix && i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+			values = append(values, args[i+1])
+			i++
+		}
+	}
+	return values
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/app"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/container"
+	"github.com/zgiai/zgo/internal/infra/task"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"gorm.io/gorm"
+)
+
+// newTaskCommand returns a task:run command on an application backed by
+// db, with its own registry
+func newTaskCommand(t *testing.T, db *gorm.DB) (*TaskCommand, *task.Registry) {
+	t.Helper()
+	t.Cleanup(container.Reset)
+
+	cfg := &config.Config{JWT: config.JWTConfig{VerifyExpire: 24 * time.Hour}}
+	registry := task.NewRegistry()
+	return &TaskCommand{
+		output:   console.NewOutput(),
+		boot:     func() (*app.Application, error) { return &app.Application{Config: cfg, DB: db}, nil },
+		registry: registry,
+	}, registry
+}
+
+func TestTaskCommand_RunsTaskWithArgs(t *testing.T) {
+	db := setupCommandDB(t)
+	require.NoError(t, db.AutoMigrate(&user.UserPO{}))
+	cmd, registry := newTaskCommand(t, db)
+
+	var got task.Args
+	registry.Register("users:create", func(ctx context.Context, args task.Args) error {
+		got = args
+		// Tasks resolve the database from the container, as in serve
+		conn := container.MustResolveAs[*gorm.DB](container.ServiceDB)
+		return conn.WithContext(ctx).Create(&user.UserPO{
+			Username: args.String("username", ""),
+			Email:    args.String("email", ""),
+			Password: "x",
+		}).Error
+	})
+
+	require.NoError(t, cmd.Run([]string{"users:create", "--arg", "username=alice", "--arg=email=alice@example.com"}))
+	assert.Equal(t, task.Args{"username": "alice", "email": "alice@example.com"}, got)
+
+	var count int64
+	require.NoError(t, db.Model(&user.UserPO{}).Where("username = ?", "alice").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestTaskCommand_Errors(t *testing.T) {
+	cmd, registry := newTaskCommand(t, setupCommandDB(t))
+	boom := errors.New("boom")
+	registry.Register("fails", func(ctx context.Context, args task.Args) error { return boom })
+
+	assert.ErrorIs(t, cmd.Run([]string{"fails"}), boom)
+	assert.ErrorContains(t, cmd.Run([]string{"missing"}), `task "missing" is not registered`)
+	assert.Error(t, cmd.Run(nil), "task name is required")
+	assert.ErrorContains(t, cmd.Run([]string{"fails", "--arg", "novalue"}), "want key=value")
+}
+
+func TestTaskCommand_CleanupExpiredTokens(t *testing.T) {
+	db := setupCommandDB(t)
+	require.NoError(t, db.AutoMigrate(&user.UserPO{}))
+	cmd, _ := newTaskCommand(t, db)
+
+	old := time.Now().Add(-48 * time.Hour)
+	resentAt := time.Now().Add(-time.Hour)
+	pending := int(domain.UserStatusPending)
+	active := int(domain.UserStatusActive)
+	users := []*user.UserPO{
+		{Username: "expired", Email: "expired@example.com", Password: "x", Status: pending, CreatedAt: old},
+		{Username: "fresh", Email: "fresh@example.com", Password: "x", Status: pending},
+		{Username: "resent", Email: "resent@example.com", Password: "x", Status: pending, CreatedAt: old, VerificationSentAt: &resentAt},
+		{Username: "verified", Email: "verified@example.com", Password: "x", Status: active, CreatedAt: old},
+	}
+	require.NoError(t, db.Create(users).Error)
+
+	remaining := func() []string {
+		var names []string
+		require.NoError(t, db.Model(&user.UserPO{}).Order("username").Pluck("username", &names).Error)
+		return names
+	}
+
+	require.NoError(t, cmd.Run([]string{"cleanup:expired-tokens", "--arg", "dry-run=true"}))
+	assert.Equal(t, []string{"expired", "fresh", "resent", "verified"}, remaining(), "dry run deletes nothing")
+
+	// A resent link keeps the account until that link expires
+	require.NoError(t, cmd.Run([]string{"cleanup:expired-tokens"}))
+	assert.Equal(t, []string{"fresh", "resent", "verified"}, remaining())
+
+	require.NoError(t, cmd.Run([]string{"cleanup:expired-tokens", "--arg", "older-than=0s"}))
+	assert.Equal(t, []string{"verified"}, remaining())
+}
+
+func TestTaskListCommand(t *testing.T) {
+	t.Cleanup(container.Reset)
+	registry := task.NewRegistry()
+	cmd := &TaskListCommand{
+		output:   console.NewOutput(),
+		boot:     func() (*app.Application, error) { return &app.Application{Config: &config.Config{}}, nil },
+		registry: registry,
+	}
+
+	require.NoError(t, cmd.Run(nil))
+	tasks := registry.Tasks()
+	require.NotEmpty(t, tasks)
+	assert.Equal(t, "cleanup:expired-tokens", tasks[0].Name())
+	assert.NotEmpty(t, tasks[0].Description())
+}
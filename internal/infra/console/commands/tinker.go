@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/container"
+	"github.com/zgiai/zgo/internal/modules/permission"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"github.com/zgiai/zgo/internal/wiring"
+	"github.com/zgiai/zgo/pkg/query"
+)
+
+// tinkerPrompt is printed before each line is read
+const tinkerPrompt = "zgo> "
+
+// errTinkerUsage reports a malformed tinker line
+var errTinkerUsage = errors.New("usage")
+
+// TinkerCommand opens a prompt for inspecting data through the application
+// services, without writing a throwaway program
+type TinkerCommand struct {
+	in  io.Reader
+	out io.Writer
+}
+
+func NewTinkerCommand() *TinkerCommand {
+	return &TinkerCommand{in: os.Stdin, out: os.Stdout}
+}
+
+func (c *TinkerCommand) Name() string        { return "tinker" }
+func (c *TinkerCommand) Description() string { return "Interact with the application services" }
+func (c *TinkerCommand) Usage() string       { return "tinker" }
+
+func (c *TinkerCommand) Run(args []string) error {
+	application, err := wiring.InitApplication()
+	if err != nil {
+		return fmt.Errorf("failed to init application: %w", err)
+	}
+	if application.DB == nil {
+		return errors.New("tinker needs a database: DB_ENABLED is false")
+	}
+
+	// Populate the container the same way the HTTP kernel does
+	application.Register(container.App())
+	for _, m := range application.Handlers.Modules() {
+		if err := m.Init(); err != nil {
+			return fmt.Errorf("module %s failed to initialize: %w", m.Name(), err)
+		}
+	}
+
+	fmt.Fprintf(c.out, "Connected to %s (%s). Type \"help\" for commands, \"exit\" to quit.\n",
+		application.Config.Database.Name, application.Config.Database.Driver)
+	return c.repl(context.Background())
+}
+
+// repl reads commands until exit or end of input
+func (c *TinkerCommand) repl(ctx context.Context) error {
+	scanner := bufio.NewScanner(c.in)
+	for {
+		fmt.Fprint(c.out, tinkerPrompt)
+		if !scanner.Scan() {
+			fmt.Fprintln(c.out)
+			return scanner.Err()
+		}
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "exit" || fields[0] == "quit" {
+			return nil
+		}
+		if err := c.eval(ctx, fields); err != nil {
+			fmt.Fprintf(c.out, "error: %v\n", err)
+		}
+	}
+}
+
+// eval runs one built-in command
+func (c *TinkerCommand) eval(ctx context.Context, fields []string) error {
+	switch strings.Join(fields[:min(2, len(fields))], " ") {
+	case "help":
+		c.help()
+		return nil
+	case "user find":
+		if len(fields) != 3 {
+			return fmt.Errorf("%w: user find <id|email>", errTinkerUsage)
+		}
+		return c.userFind(ctx, fields[2])
+	case "user count":
+		return c.userCount(ctx)
+	case "user list":
+		page := 1
+		if len(fields) > 2 {
+			n, err := strconv.Atoi(fields[2])
+			if err != nil || n < 1 {
+				return fmt.Errorf("%w: user list [page]", errTinkerUsage)
+			}
+			page = n
+		}
+		return c.userList(ctx, page)
+	case "role list":
+		return c.roleList(ctx)
+	default:
+		return fmt.Errorf("unknown command %q, type \"help\" for the list", strings.Join(fields, " "))
+	}
+}
+
+func (c *TinkerCommand) help() {
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "user find <id|email>\tShow one user")
+	fmt.Fprintln(w, "user count\tCount users")
+	fmt.Fprintln(w, "user list [page]\tList users, 15 per page")
+	fmt.Fprintln(w, "role list\tList roles")
+	fmt.Fprintln(w, "exit\tLeave tinker")
+	w.Flush()
+}
+
+func (c *TinkerCommand) userFind(ctx context.Context, key string) error {
+	users, err := container.ResolveAs[user.Service](container.ServiceUser)
+	if err != nil {
+		return err
+	}
+
+	var found *domain.User
+	if id, convErr := strconv.ParseUint(key, 10, 64); convErr == nil {
+		found, err = users.GetByID(ctx, uint(id))
+	} else {
+		var matches []*domain.User
+		matches, _, err = users.List(ctx, query.New().Eq("email", key), 1, 1)
+		if err == nil && len(matches) == 0 {
+			err = domain.ErrUserNotFound
+		}
+		if err == nil {
+			found = matches[0]
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ID\t%d\n", found.ID)
+	fmt.Fprintf(w, "Username\t%s\n", found.Username)
+	fmt.Fprintf(w, "Email\t%s\n", found.Email)
+	fmt.Fprintf(w, "Status\t%d\n", found.Status)
+	fmt.Fprintf(w, "Created\t%s\n", found.CreatedAt.Format("2006-01-02 15:04:05"))
+	return w.Flush()
+}
+
+func (c *TinkerCommand) userCount(ctx context.Context) error {
+	users, err := container.ResolveAs[user.Service](container.ServiceUser)
+	if err != nil {
+		return err
+	}
+	_, total, err := users.List(ctx, nil, 1, 1)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.out, total)
+	return nil
+}
+
+func (c *TinkerCommand) userList(ctx context.Context, page int) error {
+	users, err := container.ResolveAs[user.Service](container.ServiceUser)
+	if err != nil {
+		return err
+	}
+	list, total, err := users.List(ctx, nil, page, 15)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tUsername\tEmail\tStatus")
+	for _, u := range list {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\n", u.ID, u.Username, u.Email, u.Status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "page %d, %d users in total\n", page, total)
+	return nil
+}
+
+func (c *TinkerCommand) roleList(ctx context.Context) error {
+	roles, err := container.ResolveAs[permission.Service](container.ServicePermission)
+	if err != nil {
+		return err
+	}
+	list, _, err := roles.ListRoles(ctx, 1, 100, "")
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tName\tDisplay Name\tDefault")
+	for _, r := range list {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%t\n", r.ID, r.Name, r.DisplayName, r.IsDefault)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/container"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/internal/infra/events"
+	"github.com/zgiai/zgo/internal/infra/jwt"
+	"github.com/zgiai/zgo/internal/modules/permission"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"github.com/zgiai/zgo/pkg/hash"
+)
+
+// runTinker initializes the modules against a migrated in-memory database
+// and feeds script to the prompt
+func runTinker(t *testing.T, script string) string {
+	t.Helper()
+	db := setupCommandDB(t)
+	require.NoError(t, (&MigrateCommand{output: console.NewOutput()}).migrate(db, migrateFlags{}))
+
+	cfg := &config.Config{}
+	cfg.JWT.Secret = "testing-secret"
+	cfg.JWT.Expire = time.Hour
+	cfg.Auth.HashDriver = string(hash.AlgorithmBcrypt)
+	jwtService, err := jwt.NewService(cfg)
+	require.NoError(t, err)
+
+	container.Reset()
+	t.Cleanup(container.Reset)
+	permService := permission.NewService(permission.NewRepository(db))
+	userService := user.NewService(user.NewRepository(db), permService, database.NewTxManager(db), jwtService, events.NewEventBus(), cfg)
+	require.NoError(t, user.NewHandler(userService).Init())
+	require.NoError(t, permission.NewHandler(permService).Init())
+
+	var out bytes.Buffer
+	cmd := &TinkerCommand{in: strings.NewReader(script), out: &out}
+	require.NoError(t, cmd.repl(context.Background()))
+	return out.String()
+}
+
+func TestTinker_UserCommands(t *testing.T) {
+	out := runTinker(t, "user count\nuser find 1\nuser find admin@example.com\nuser find 99\nexit\n")
+
+	assert.Contains(t, out, "zgo> 1\n")
+	assert.Equal(t, 2, strings.Count(out, "admin@example.com"), "find by ID and by email")
+	assert.Contains(t, out, "error: user not found")
+}
+
+func TestTinker_RoleListAndErrors(t *testing.T) {
+	out := runTinker(t, "role list\n\nuser find\nrm -rf /\n")
+
+	for _, role := range []string{"admin", "user", "guest"} {
+		assert.Contains(t, out, role)
+	}
+	assert.Contains(t, out, "error: usage: user find <id|email>")
+	assert.Contains(t, out, `error: unknown command "rm -rf /"`)
+	assert.True(t, strings.HasSuffix(out, "zgo> \n"), "end of input leaves the prompt")
+}
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/internal/modules/permission"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"github.com/zgiai/zgo/pkg/hash"
+	"gorm.io/gorm"
+)
+
+// MakeAdminCommand creates a user with a role, prompting for missing details.
+type MakeAdminCommand struct {
+	output *console.Output
+}
+
+// NewMakeAdminCommand creates a new MakeAdminCommand instance.
+func NewMakeAdminCommand() *MakeAdminCommand {
+	return &MakeAdminCommand{output: console.NewOutput()}
+}
+
+func (c *MakeAdminCommand) Name() string        { return "user:create" }
+func (c *MakeAdminCommand) Description() string { return "Create a user, by default an admin" }
+func (c *MakeAdminCommand) Usage() string {
+	return "user:create [--username=name] [--email=address] [--password=secret] [--role=admin]"
+}
+
+// adminFlags holds the parsed user:create flags.
+type adminFlags struct {
+	username string
+	email    string
+	password string
+	role     string
+}
+
+func (c *MakeAdminCommand) Run(args []string) error {
+	flags := adminFlags{
+		username: flagValue(args, "username"),
+		email:    flagValue(args, "email"),
+		password: flagValue(args, "password"),
+		role:     flagValue(args, "role"),
+	}
+
+	// Prompt for anything not given on the command line
+	if flags.username == "" {
+		flags.username = c.output.Ask("Username", "admin")
+	}
+	if flags.email == "" {
+		flags.email = c.output.Ask("Email", "")
+	}
+	if flags.password == "" {
+		flags.password = c.output.Secret("Password")
+	}
+
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		c.output.Error("Failed to load config: %v", err)
+		return err
+	}
+
+	// Connect to DB
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		c.output.Error("Failed to connect to database: %v", err)
+		return err
+	}
+	if db == nil {
+		c.output.Warning("Database is disabled in config")
+		return nil
+	}
+
+	hasher, err := user.NewPasswordHasher(cfg.Auth)
+	if err != nil {
+		return err
+	}
+
+	return c.create(context.Background(), db, hasher, flags)
+}
+
+// create inserts the user and assigns the role in a single transaction.
+func (c *MakeAdminCommand) create(ctx context.Context, db *gorm.DB, hasher hash.Hasher, flags adminFlags) error {
+	if flags.role == "" {
+		flags.role = "admin"
+	}
+	if flags.username == "" || flags.email == "" || flags.password == "" {
+		return errors.New("username, email and password are required")
+	}
+
+	users := user.NewRepository(db)
+	roles := permission.NewRepository(db)
+
+	if existing, err := users.FindByEmailWithTrashed(ctx, flags.email); err == nil && existing != nil {
+		if existing.DeletedAt != nil {
+			return fmt.Errorf("%w: %s", domain.ErrEmailBelongsToDeletedAccount, flags.email)
+		}
+		return fmt.Errorf("%w: %s", domain.ErrEmailAlreadyExists, flags.email)
+	}
+	if existing, err := users.FindByUsername(ctx, flags.username); err == nil && existing != nil {
+		return fmt.Errorf("username already taken: %s", flags.username)
+	}
+
+	role, err := roles.FindRoleByName(ctx, flags.role)
+	if err != nil {
+		return fmt.Errorf("%w: %s (run db:seed to create the default roles)", domain.ErrRoleNotFound, flags.role)
+	}
+
+	hashed, err := hasher.Make(flags.password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	account := &domain.User{
+		Username: flags.username,
+		Email:    flags.email,
+		Password: hashed,
+		Status:   1,
+	}
+	err = database.NewTxManager(db).WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := users.Create(ctx, account); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		if err := roles.AssignRoleToUser(ctx, account.ID, role.ID); err != nil {
+			return fmt.Errorf("failed to assign role: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.output.Success("Created user %s (id %d) with role %s", account.Username, account.ID, role.Name)
+	return nil
+}
+
+// flagValue returns the value of --name=value or --name value.
+func flagValue(args []string, name string) string {
+	prefix := "--" + name
+	for i, arg := range args {
+		if val, found := strings.CutPrefix(arg, prefix+"="); found {
+			return val
+		}
+		if arg == prefix && i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+			return args[i+1]
+		}
+	}
+	return ""
+}
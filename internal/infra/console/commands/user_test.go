@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/modules/permission"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"github.com/zgiai/zgo/pkg/hash"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestMakeAdminCommand_CreatesUserWithRole(t *testing.T) {
+	db := setupCommandDB(t)
+	require.NoError(t, (&MigrateCommand{output: console.NewOutput()}).migrate(db, migrateFlags{}))
+
+	cmd := &MakeAdminCommand{output: console.NewOutput()}
+	args := []string{"--username=root", "--email", "root@example.com", "--password=s3cret-pass"}
+	flags := adminFlags{
+		username: flagValue(args, "username"),
+		email:    flagValue(args, "email"),
+		password: flagValue(args, "password"),
+		role:     flagValue(args, "role"),
+	}
+	ctx := context.Background()
+	require.NoError(t, cmd.create(ctx, db, hash.NewBcryptHasher(), flags))
+
+	created, err := user.NewRepository(db).FindByEmail(ctx, "root@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "root", created.Username)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(created.Password), []byte("s3cret-pass")))
+
+	roles, err := permission.NewRepository(db).FindRolesByUserID(ctx, created.ID)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, "admin", roles[0].Name)
+
+	// A second run with the same email fails clearly
+	err = cmd.create(ctx, db, hash.NewBcryptHasher(), adminFlags{username: "other", email: "root@example.com", password: "pw"})
+	assert.ErrorIs(t, err, domain.ErrEmailAlreadyExists)
+}
+
+func TestMakeAdminCommand_UnknownRole(t *testing.T) {
+	db := setupCommandDB(t)
+	require.NoError(t, (&MigrateCommand{output: console.NewOutput()}).migrate(db, migrateFlags{}))
+
+	cmd := &MakeAdminCommand{output: console.NewOutput()}
+	err := cmd.create(context.Background(), db, hash.NewBcryptHasher(), adminFlags{
+		username: "ops", email: "ops@example.com", password: "pw", role: "superuser",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	_, findErr := user.NewRepository(db).FindByEmail(context.Background(), "ops@example.com")
+	assert.Error(t, findErr, "no user should be created")
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/console"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/internal/infra/webhook"
+	"github.com/zgiai/zgo/pkg/encryption"
+)
+
+// WebhookListCommand lists the registered webhook endpoints
+type WebhookListCommand struct {
+	output *console.Output
+}
+
+func NewWebhookListCommand() *WebhookListCommand {
+	return &WebhookListCommand{output: console.NewOutput()}
+}
+
+func (c *WebhookListCommand) Name() string        { return "webhook:list" }
+func (c *WebhookListCommand) Description() string { return "List registered webhook endpoints" }
+func (c *WebhookListCommand) Usage() string       { return "webhook:list" }
+
+func (c *WebhookListCommand) Run(args []string) error {
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		c.output.Error("Failed to load config: %v", err)
+		return err
+	}
+
+	// Connect to DB
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		c.output.Error("Failed to connect to database: %v", err)
+		return err
+	}
+	if db == nil {
+		c.output.Warning("Database is disabled in config")
+		return nil
+	}
+
+	endpoints, err := webhook.NewRegistry(db, encryption.New(cfg.App.Key)).List(context.Background())
+	if err != nil {
+		c.output.Error("Failed to load webhook endpoints: %v", err)
+		return err
+	}
+	if len(endpoints) == 0 {
+		c.output.Info("No webhook endpoints registered.")
+		return nil
+	}
+
+	c.output.Title("Webhook Endpoints")
+	c.output.Table([]string{"ID", "Event", "URL", "Active", "Created"}, webhookRows(endpoints))
+	return nil
+}
+
+// webhookRows renders one table row per endpoint, leaving secrets out
+func webhookRows(endpoints []webhook.Endpoint) [][]string {
+	rows := make([][]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		active := "yes"
+		if !endpoint.Active {
+			active = "no"
+		}
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(endpoint.ID), 10),
+			endpoint.Event,
+			endpoint.URL,
+			active,
+			endpoint.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zgiai/zgo/internal/infra/webhook"
+)
+
+func TestWebhookRows(t *testing.T) {
+	created := time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+	rows := webhookRows([]webhook.Endpoint{
+		{ID: 1, Event: "user.*", URL: "https://example.com/hook", Secret: "encrypted", Active: true, CreatedAt: created},
+		{ID: 2, Event: "order.created", URL: "https://example.com/orders", Active: false, CreatedAt: created},
+	})
+
+	assert.Equal(t, []string{"1", "user.*", "https://example.com/hook", "yes", "2026-10-15 09:30:00"}, rows[0])
+	assert.Equal(t, "no", rows[1][3])
+}
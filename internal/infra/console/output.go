@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"os/exec"
 	"strings"
 	"text/tabwriter"
 
@@ -175,3 +176,26 @@ func (o *Output) Choice(question string, options []string, defaultIndex int) str
 	}
 	return options[defaultIndex]
 }
+
+// Secret prompts for input without echoing it, e.g. for passwords.
+// Echo is only disabled when stdin is a terminal that supports stty.
+func (o *Output) Secret(question string) string {
+	fmt.Printf("  %s: ", question)
+
+	if err := stty("-echo"); err == nil {
+		defer func() {
+			_ = stty("echo")
+			fmt.Println()
+		}()
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	return strings.TrimSpace(answer)
+}
+
+func stty(arg string) error {
+	cmd := exec.Command("stty", arg)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
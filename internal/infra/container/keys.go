@@ -5,4 +5,8 @@ const (
 	ServiceDB     = "db"
 	ServiceJWT    = "jwt"
 	ServiceEmail  = "email"
+
+	// Module services, registered by each module's Init
+	ServiceUser       = "user"
+	ServicePermission = "permission"
 )
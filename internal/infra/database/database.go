@@ -3,11 +3,15 @@ package database
 import (
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/zgiai/zgo/internal/infra/config"
 	"github.com/glebarez/sqlite"
+	gomysql "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -30,38 +34,14 @@ func initDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
 	newLogger := logger.New(
 		log.New(os.Stdout, "\r\n", log.LstdFlags),
 		logger.Config{
-			SlowThreshold:             time.Second,
+			SlowThreshold:             cfg.SlowThreshold,
 			LogLevel:                  logger.Info,
 			IgnoreRecordNotFoundError: true,
 			Colorful:                  true,
 		},
 	)
 
-	var dialector gorm.Dialector
-
-	if cfg.Driver == "sqlite" {
-		dsn := cfg.Name
-		if cfg.Memory {
-			dsn = ":memory:"
-		}
-		dialector = sqlite.Open(dsn)
-	} else {
-		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s timezone=%s",
-			cfg.Host,
-			cfg.Username,
-			cfg.Password,
-			cfg.Name,
-			cfg.Port,
-			cfg.SSLMode,
-			cfg.Timezone,
-		)
-		dialector = postgres.New(postgres.Config{
-			DSN:                  dsn,
-			PreferSimpleProtocol: true,
-		})
-	}
-
-	db, err := gorm.Open(dialector, &gorm.Config{
+	db, err := gorm.Open(newDialector(cfg), &gorm.Config{
 		Logger: newLogger,
 	})
 	if err != nil {
@@ -83,9 +63,91 @@ func initDB(cfg config.DatabaseConfig) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	// Bound every statement so a stuck query cannot hang a request forever
+	if cfg.QueryTimeout > 0 {
+		if err := WithQueryTimeout(db, cfg.QueryTimeout); err != nil {
+			return nil, fmt.Errorf("failed to register query timeout: %w", err)
+		}
+	}
+
+	// Filter models registered with RegisterTenantModels on the context tenant
+	if err := WithTenantScoping(db); err != nil {
+		return nil, fmt.Errorf("failed to register tenant scoping: %w", err)
+	}
+
+	// Route reads to replicas when configured
+	if len(cfg.Replicas) > 0 {
+		if err := registerReplicas(db, cfg); err != nil {
+			return nil, err
+		}
+	}
+
 	return db, nil
 }
 
+// newDialector returns the gorm dialector for the configured driver
+func newDialector(cfg config.DatabaseConfig) gorm.Dialector {
+	switch cfg.Driver {
+	case "sqlite":
+		dsn := cfg.Name
+		if cfg.Memory {
+			dsn = ":memory:"
+		}
+		return sqlite.Open(dsn)
+	case "mysql":
+		return mysql.Open(mysqlDSN(cfg))
+	default:
+		return postgres.New(postgres.Config{
+			DSN:                  postgresDSN(cfg),
+			PreferSimpleProtocol: true,
+		})
+	}
+}
+
+// postgresDSN builds a pgx DSN. A query timeout is also set as the
+// session's statement_timeout, so the server gives up on its side too.
+func postgresDSN(cfg config.DatabaseConfig) string {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s timezone=%s",
+		cfg.Host,
+		cfg.Username,
+		cfg.Password,
+		cfg.Name,
+		cfg.Port,
+		cfg.SSLMode,
+		cfg.Timezone,
+	)
+	if cfg.QueryTimeout > 0 {
+		dsn += fmt.Sprintf(" statement_timeout=%d", cfg.QueryTimeout.Milliseconds())
+	}
+	return dsn
+}
+
+// mysqlDSN builds a go-sql-driver DSN, falling back to utf8mb4 when charset
+// or collation are not configured. The driver formats it, so credentials
+// containing '@', ':' or '/' survive the round-trip.
+func mysqlDSN(cfg config.DatabaseConfig) string {
+	charset := cfg.Charset
+	if charset == "" {
+		charset = "utf8mb4"
+	}
+	collation := cfg.Collation
+	if collation == "" {
+		collation = "utf8mb4_unicode_ci"
+	}
+
+	mc := gomysql.NewConfig()
+	mc.User = cfg.Username
+	mc.Passwd = cfg.Password
+	mc.Net = "tcp"
+	mc.Addr = net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	mc.DBName = cfg.Name
+	mc.Collation = collation
+	mc.ParseTime = true
+	mc.Loc = time.Local
+	mc.Params = map[string]string{"charset": charset}
+	return mc.FormatDSN()
+}
+
 // NewTestDB creates an in-memory SQLite database for testing.
 // This is a convenience function for tests that need a real database.
 func NewTestDB() (*gorm.DB, error) {
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/config"
+)
+
+func TestMySQLDSN(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.DatabaseConfig
+		wantCharset   string
+		wantCollation string
+	}{
+		{
+			name: "configured charset and collation",
+			cfg: config.DatabaseConfig{
+				Host:      "db.internal",
+				Port:      3307,
+				Name:      "app",
+				Username:  "root",
+				Password:  "secret",
+				Charset:   "utf8",
+				Collation: "utf8_general_ci",
+			},
+			wantCharset:   "utf8",
+			wantCollation: "utf8_general_ci",
+		},
+		{
+			name: "defaults",
+			cfg: config.DatabaseConfig{
+				Host:     "localhost",
+				Port:     3306,
+				Name:     "app",
+				Username: "app",
+				Password: "pw",
+			},
+			wantCharset:   "utf8mb4",
+			wantCollation: "utf8mb4_unicode_ci",
+		},
+		{
+			name: "special characters in password",
+			cfg: config.DatabaseConfig{
+				Host:     "localhost",
+				Port:     3306,
+				Name:     "app",
+				Username: "app",
+				Password: "p@ss:w/rd?x&y=z",
+			},
+			wantCharset:   "utf8mb4",
+			wantCollation: "utf8mb4_unicode_ci",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The driver must read back exactly what we configured
+			parsed, err := mysql.ParseDSN(mysqlDSN(tt.cfg))
+			require.NoError(t, err)
+			assert.Equal(t, tt.cfg.Username, parsed.User)
+			assert.Equal(t, tt.cfg.Password, parsed.Passwd)
+			assert.Equal(t, "tcp", parsed.Net)
+			assert.Equal(t, net.JoinHostPort(tt.cfg.Host, strconv.Itoa(tt.cfg.Port)), parsed.Addr)
+			assert.Equal(t, tt.cfg.Name, parsed.DBName)
+			assert.Equal(t, tt.wantCharset, parsed.Params["charset"])
+			assert.Equal(t, tt.wantCollation, parsed.Collation)
+			assert.True(t, parsed.ParseTime)
+			assert.Equal(t, time.Local, parsed.Loc)
+		})
+	}
+}
+
+func TestInitDB_MySQL(t *testing.T) {
+	dsn := os.Getenv("MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_DSN not set")
+	}
+
+	parsed, err := mysql.ParseDSN(dsn)
+	require.NoError(t, err)
+	host, portStr, err := net.SplitHostPort(parsed.Addr)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	db, err := initDB(config.DatabaseConfig{
+		Driver:       "mysql",
+		Host:         host,
+		Port:         port,
+		Name:         parsed.DBName,
+		Username:     parsed.User,
+		Password:     parsed.Passwd,
+		MaxIdleConns: 2,
+		MaxOpenConns: 5,
+	})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+
+	assert.Equal(t, 5, sqlDB.Stats().MaxOpenConnections)
+
+	var one int
+	require.NoError(t, db.Raw("SELECT 1").Scan(&one).Error)
+	assert.Equal(t, 1, one)
+}
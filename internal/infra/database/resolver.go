@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/glebarez/sqlite"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+// primaryKey is the context key for forcing reads to the primary
+type primaryKey struct{}
+
+// ForcePrimary returns a copy of ctx whose reads go to the primary.
+// Use it after a write when the result must be visible immediately.
+func ForcePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// IsPrimaryForced reports whether ctx was marked by ForcePrimary
+func IsPrimaryForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+// replicaSets maps each DB's shared *gorm.Config to the replicas it reads from
+var replicaSets sync.Map
+
+// replicaSet is the connection pool dbresolver reads through. It spreads
+// queries across the current replica pools, falling back to the primary when
+// there are none, and can swap those pools while the app runs.
+type replicaSet struct {
+	primary gorm.ConnPool
+
+	mu      sync.Mutex // serializes reload and close
+	closed  bool
+	configs []config.DatabaseConfig
+	pools   atomic.Pointer[[]*sql.DB]
+}
+
+// pick returns the pool for the next query
+func (s *replicaSet) pick() gorm.ConnPool {
+	pools := *s.pools.Load()
+	if len(pools) == 0 {
+		return s.primary
+	}
+	return pools[rand.IntN(len(pools))]
+}
+
+func (s *replicaSet) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return s.pick().PrepareContext(ctx, query)
+}
+
+func (s *replicaSet) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return s.pick().ExecContext(ctx, query, args...)
+}
+
+func (s *replicaSet) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return s.pick().QueryContext(ctx, query, args...)
+}
+
+func (s *replicaSet) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return s.pick().QueryRowContext(ctx, query, args...)
+}
+
+// swap installs pools and closes the ones they replace. sql.DB.Close lets
+// queries already running on the old pools finish.
+func (s *replicaSet) swap(configs []config.DatabaseConfig, pools []*sql.DB) error {
+	s.configs = configs
+	old := s.pools.Swap(&pools)
+	if old == nil {
+		return nil
+	}
+	return closePools(*old)
+}
+
+// registerReplicas installs the dbresolver plugin so reads go to replicas
+// and writes to the primary. Each replica keeps its own connection pool.
+func registerReplicas(db *gorm.DB, cfg config.DatabaseConfig) error {
+	configs := replicaConfigs(cfg)
+	pools, err := openReplicas(configs, db.Logger)
+	if err != nil {
+		return err
+	}
+
+	set := &replicaSet{primary: db.ConnPool}
+	_ = set.swap(configs, pools)
+
+	if err := db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{connDialector(cfg.Driver, set)},
+	})); err != nil {
+		_ = closePools(pools)
+		return fmt.Errorf("failed to register replicas: %w", err)
+	}
+	replicaSets.Store(db.Config, set)
+	return nil
+}
+
+// ReloadReplicas points reads at the replicas in cfg and closes the pools
+// of the replicas they replace. Reads fall back to the primary when cfg has
+// none. It does nothing when the replicas are unchanged, and fails when db
+// started without replicas, since dbresolver cannot be added later.
+func ReloadReplicas(db *gorm.DB, cfg config.DatabaseConfig) error {
+	v, ok := replicaSets.Load(db.Config)
+	if !ok {
+		if len(cfg.Replicas) == 0 {
+			return nil
+		}
+		return errors.New("read replicas must be configured at startup to be reloaded")
+	}
+	set := v.(*replicaSet)
+
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	if set.closed {
+		return errors.New("database is closed")
+	}
+	configs := replicaConfigs(cfg)
+	if reflect.DeepEqual(configs, set.configs) {
+		return nil
+	}
+
+	pools, err := openReplicas(configs, db.Logger)
+	if err != nil {
+		return err
+	}
+	return set.swap(configs, pools)
+}
+
+// Close closes db's connection pool along with those of its read replicas
+func Close(db *gorm.DB) error {
+	var errs []error
+	if v, ok := replicaSets.LoadAndDelete(db.Config); ok {
+		set := v.(*replicaSet)
+		set.mu.Lock()
+		set.closed = true
+		errs = append(errs, set.swap(nil, nil))
+		set.mu.Unlock()
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	errs = append(errs, sqlDB.Close())
+	return errors.Join(errs...)
+}
+
+// replicaConfigs returns the full connection settings of cfg's replicas
+func replicaConfigs(cfg config.DatabaseConfig) []config.DatabaseConfig {
+	configs := make([]config.DatabaseConfig, 0, len(cfg.Replicas))
+	for _, r := range cfg.Replicas {
+		configs = append(configs, replicaDatabaseConfig(cfg, r))
+	}
+	return configs
+}
+
+// openReplicas connects to each replica. On failure the pools opened so far
+// are closed again.
+func openReplicas(configs []config.DatabaseConfig, log logger.Interface) ([]*sql.DB, error) {
+	pools := make([]*sql.DB, 0, len(configs))
+	for i, rcfg := range configs {
+		sqlDB, err := openReplica(rcfg, log)
+		if err != nil {
+			_ = closePools(pools)
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+		pools = append(pools, sqlDB)
+	}
+	return pools, nil
+}
+
+// openReplica connects to a single replica and sizes its pool
+func openReplica(rcfg config.DatabaseConfig, log logger.Interface) (*sql.DB, error) {
+	replica, err := gorm.Open(newDialector(rcfg), &gorm.Config{Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	sqlDB, err := replica.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	sqlDB.SetMaxIdleConns(rcfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(rcfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(0)
+
+	if err := sqlDB.Ping(); err != nil {
+		_ = sqlDB.Close()
+		return nil, fmt.Errorf("failed to ping: %w", err)
+	}
+	return sqlDB, nil
+}
+
+// closePools closes every pool, joining their errors
+func closePools(pools []*sql.DB) error {
+	var errs []error
+	for _, p := range pools {
+		errs = append(errs, p.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// replicaDatabaseConfig overlays a replica's settings on the primary's
+func replicaDatabaseConfig(primary config.DatabaseConfig, r config.ReplicaConfig) config.DatabaseConfig {
+	cfg := primary
+	cfg.Replicas = nil
+	if r.Host != "" {
+		cfg.Host = r.Host
+	}
+	if r.Port != 0 {
+		cfg.Port = r.Port
+	}
+	if r.Name != "" {
+		cfg.Name = r.Name
+		cfg.Memory = false
+	}
+	if r.Username != "" {
+		cfg.Username = r.Username
+	}
+	if r.Password != "" {
+		cfg.Password = r.Password
+	}
+	if r.MaxIdleConns != 0 {
+		cfg.MaxIdleConns = r.MaxIdleConns
+	}
+	if r.MaxOpenConns != 0 {
+		cfg.MaxOpenConns = r.MaxOpenConns
+	}
+	return cfg
+}
+
+// connDialector wraps an already configured pool so dbresolver reuses it
+func connDialector(driver string, conn gorm.ConnPool) gorm.Dialector {
+	switch driver {
+	case "sqlite":
+		return &sqlite.Dialector{Conn: conn}
+	case "mysql":
+		return mysql.New(mysql.Config{Conn: conn})
+	default:
+		return postgres.New(postgres.Config{Conn: conn, PreferSimpleProtocol: true})
+	}
+}
@@ -0,0 +1,198 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"gorm.io/gorm"
+)
+
+type resolverItem struct {
+	ID   uint
+	Name string
+}
+
+func TestInitDB_RegistersResolverWithReplicas(t *testing.T) {
+	dir := t.TempDir()
+	primaryPath := filepath.Join(dir, "primary.db")
+	replicaPath := filepath.Join(dir, "replica.db")
+
+	// Seed each database with distinguishable data
+	for path, name := range map[string]string{primaryPath: "from-primary", replicaPath: "from-replica"} {
+		seed, err := initDB(config.DatabaseConfig{Driver: "sqlite", Name: path})
+		require.NoError(t, err)
+		require.NoError(t, seed.AutoMigrate(&resolverItem{}))
+		require.NoError(t, seed.Create(&resolverItem{Name: name}).Error)
+	}
+
+	db, err := initDB(config.DatabaseConfig{
+		Driver:       "sqlite",
+		Name:         primaryPath,
+		MaxIdleConns: 2,
+		MaxOpenConns: 4,
+		Replicas: []config.ReplicaConfig{
+			{Name: replicaPath, MaxOpenConns: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	_, ok := db.Config.Plugins["gorm:db_resolver"]
+	assert.True(t, ok, "dbresolver should be registered")
+
+	ctx := context.Background()
+
+	// Writes go to the primary
+	require.NoError(t, db.Create(&resolverItem{Name: "written"}).Error)
+	var count int64
+	require.NoError(t, Conn(ForcePrimary(ctx), db).Model(&resolverItem{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	var read resolverItem
+	require.NoError(t, Conn(ctx, db).First(&read).Error)
+	assert.Equal(t, "from-replica", read.Name, "reads should go to the replica")
+
+	var forced resolverItem
+	require.NoError(t, Conn(ForcePrimary(ctx), db).First(&forced).Error)
+	assert.Equal(t, "from-primary", forced.Name, "ForcePrimary should read from the primary")
+}
+
+func TestInitDB_NoReplicas(t *testing.T) {
+	db, err := initDB(config.DatabaseConfig{Driver: "sqlite", Memory: true})
+	require.NoError(t, err)
+
+	_, ok := db.Config.Plugins["gorm:db_resolver"]
+	assert.False(t, ok, "single-DB setups should not register a resolver")
+}
+
+func TestReplicaDatabaseConfig_InheritsPrimary(t *testing.T) {
+	primary := config.DatabaseConfig{
+		Driver:       "postgres",
+		Host:         "primary",
+		Port:         5432,
+		Name:         "app",
+		Username:     "app",
+		Password:     "secret",
+		MaxIdleConns: 10,
+		MaxOpenConns: 100,
+	}
+
+	got := replicaDatabaseConfig(primary, config.ReplicaConfig{Host: "replica-1", MaxOpenConns: 20})
+
+	assert.Equal(t, "replica-1", got.Host)
+	assert.Equal(t, 5432, got.Port)
+	assert.Equal(t, "app", got.Username)
+	assert.Equal(t, "secret", got.Password)
+	assert.Equal(t, 10, got.MaxIdleConns)
+	assert.Equal(t, 20, got.MaxOpenConns)
+	assert.Nil(t, got.Replicas)
+}
+
+// newReplicatedDB opens a primary with one replica per name. Each database
+// holds a single row carrying its own name, so reads show where they went.
+func newReplicatedDB(t *testing.T, names ...string) (*gorm.DB, config.DatabaseConfig) {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := config.DatabaseConfig{Driver: "sqlite", Name: seedResolverDB(t, dir, "primary")}
+	for _, name := range names {
+		cfg.Replicas = append(cfg.Replicas, config.ReplicaConfig{Name: seedResolverDB(t, dir, name)})
+	}
+
+	db, err := initDB(cfg)
+	require.NoError(t, err)
+	return db, cfg
+}
+
+func seedResolverDB(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name+".db")
+	seed, err := initDB(config.DatabaseConfig{Driver: "sqlite", Name: path})
+	require.NoError(t, err)
+	require.NoError(t, seed.AutoMigrate(&resolverItem{}))
+	require.NoError(t, seed.Create(&resolverItem{Name: name}).Error)
+	require.NoError(t, Close(seed))
+	return path
+}
+
+func replicaPools(t *testing.T, db *gorm.DB) []*sql.DB {
+	t.Helper()
+	v, ok := replicaSets.Load(db.Config)
+	require.True(t, ok, "db should have a replica set")
+	return *v.(*replicaSet).pools.Load()
+}
+
+func readName(t *testing.T, db *gorm.DB) string {
+	t.Helper()
+	var item resolverItem
+	require.NoError(t, Conn(context.Background(), db).First(&item).Error)
+	return item.Name
+}
+
+func TestClose_ClosesReplicaPools(t *testing.T) {
+	db, _ := newReplicatedDB(t, "replica")
+	pools := replicaPools(t, db)
+	require.Len(t, pools, 1)
+
+	require.NoError(t, Close(db))
+
+	assert.Error(t, pools[0].Ping(), "replica pool should be closed")
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	assert.Error(t, sqlDB.Ping(), "primary pool should be closed")
+
+	_, ok := replicaSets.Load(db.Config)
+	assert.False(t, ok, "closed db should be forgotten")
+}
+
+func TestReloadReplicas_SwapsAndClosesOldPools(t *testing.T) {
+	db, cfg := newReplicatedDB(t, "replica-a")
+	defer Close(db)
+	assert.Equal(t, "replica-a", readName(t, db))
+	old := replicaPools(t, db)
+
+	// Unchanged replicas keep their pools
+	require.NoError(t, ReloadReplicas(db, cfg))
+	assert.Same(t, old[0], replicaPools(t, db)[0])
+
+	cfg.Replicas = []config.ReplicaConfig{{Name: seedResolverDB(t, t.TempDir(), "replica-b")}}
+	require.NoError(t, ReloadReplicas(db, cfg))
+
+	assert.Equal(t, "replica-b", readName(t, db))
+	assert.Error(t, old[0].Ping(), "replaced replica pool should be closed")
+
+	// Without replicas, reads fall back to the primary
+	current := replicaPools(t, db)
+	cfg.Replicas = nil
+	require.NoError(t, ReloadReplicas(db, cfg))
+
+	assert.Equal(t, "primary", readName(t, db))
+	assert.Error(t, current[0].Ping(), "removed replica pool should be closed")
+}
+
+func TestReloadReplicas_KeepsPoolsOnFailure(t *testing.T) {
+	db, cfg := newReplicatedDB(t, "replica")
+	defer Close(db)
+	old := replicaPools(t, db)
+
+	cfg.Replicas = []config.ReplicaConfig{{Name: filepath.Join(t.TempDir(), "missing", "replica.db")}}
+	assert.Error(t, ReloadReplicas(db, cfg))
+
+	assert.NoError(t, old[0].Ping(), "current replica should stay open")
+	assert.Equal(t, "replica", readName(t, db))
+}
+
+func TestReloadReplicas_RequiresStartupReplicas(t *testing.T) {
+	db, err := initDB(config.DatabaseConfig{Driver: "sqlite", Memory: true})
+	require.NoError(t, err)
+	defer Close(db)
+
+	assert.NoError(t, ReloadReplicas(db, config.DatabaseConfig{Driver: "sqlite"}))
+	assert.Error(t, ReloadReplicas(db, config.DatabaseConfig{
+		Driver:   "sqlite",
+		Replicas: []config.ReplicaConfig{{Name: "replica.db"}},
+	}))
+}
@@ -0,0 +1,259 @@
+package database
+
+import (
+	"context"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zgiai/zgo/internal/infra/events"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EventSlowQuery is published for every statement slower than the threshold
+const EventSlowQuery = "db.query_slow"
+
+const (
+	slowQueryStartKey           = "slowquery:start"
+	slowQueryCallbackBeforeName = "slowquery:before"
+	slowQueryCallbackAfterName  = "slowquery:after"
+)
+
+// redacted replaces bound values of secret columns in reported SQL
+const redacted = "[REDACTED]"
+
+// secretColumns are column name fragments whose values are never reported
+var secretColumns = []string{"password", "passwd", "secret", "token", "api_key", "apikey", "private_key", "credential"}
+
+// SlowQueryEvent describes a statement that ran longer than the threshold
+type SlowQueryEvent struct {
+	// SQL is the statement with its values inlined, secrets redacted
+	SQL        string
+	Duration   time.Duration
+	Rows       int64
+	Caller     string
+	Error      string
+	occurredAt time.Time
+}
+
+func (e SlowQueryEvent) EventName() string {
+	return EventSlowQuery
+}
+
+func (e SlowQueryEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e SlowQueryEvent) Data() any {
+	return e
+}
+
+// SlowQueryPlugin times every statement and publishes a SlowQueryEvent when
+// one takes longer than the threshold. It complements the gorm logger,
+// which only prints slow statements, with an event listeners can alert on.
+type SlowQueryPlugin struct {
+	bus       *events.EventBus
+	threshold time.Duration
+}
+
+// NewSlowQueryPlugin creates a plugin reporting statements slower than threshold
+func NewSlowQueryPlugin(bus *events.EventBus, threshold time.Duration) *SlowQueryPlugin {
+	return &SlowQueryPlugin{bus: bus, threshold: threshold}
+}
+
+// Name returns the plugin name
+func (p *SlowQueryPlugin) Name() string {
+	return "slow-query"
+}
+
+// Initialize registers the timing callbacks
+func (p *SlowQueryPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("gorm:create").Register(slowQueryCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register(slowQueryCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	if err := cb.Query().Before("gorm:query").Register(slowQueryCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register(slowQueryCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	if err := cb.Update().Before("gorm:update").Register(slowQueryCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register(slowQueryCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	if err := cb.Delete().Before("gorm:delete").Register(slowQueryCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:delete").Register(slowQueryCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	if err := cb.Row().Before("gorm:row").Register(slowQueryCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Row().After("gorm:row").Register(slowQueryCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	if err := cb.Raw().Before("gorm:raw").Register(slowQueryCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Raw().After("gorm:raw").Register(slowQueryCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *SlowQueryPlugin) before(db *gorm.DB) {
+	db.InstanceSet(slowQueryStartKey, time.Now())
+}
+
+func (p *SlowQueryPlugin) after(db *gorm.DB) {
+	start, ok := db.InstanceGet(slowQueryStartKey)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start.(time.Time))
+	if elapsed <= p.threshold || db.Statement.SQL.Len() == 0 {
+		return
+	}
+
+	event := SlowQueryEvent{
+		SQL:        db.Dialector.Explain(db.Statement.SQL.String(), redactVars(db.Statement)...),
+		Duration:   elapsed,
+		Rows:       db.Statement.RowsAffected,
+		Caller:     caller(),
+		occurredAt: time.Now(),
+	}
+	if db.Error != nil {
+		event.Error = db.Error.Error()
+	}
+
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// Detach from the statement so a cancelled request does not drop the event
+	p.bus.PublishAsync(context.WithoutCancel(ctx), event)
+}
+
+// WithSlowQueryReporting publishes a SlowQueryEvent on bus for every
+// statement on db slower than threshold
+func WithSlowQueryReporting(db *gorm.DB, bus *events.EventBus, threshold time.Duration) error {
+	return db.Use(NewSlowQueryPlugin(bus, threshold))
+}
+
+// redactVars returns the statement's bound values with those of secret
+// columns replaced. Inserts map values to the VALUES column list, anything
+// else to the column compared or assigned right before the placeholder.
+func redactVars(stmt *gorm.Statement) []any {
+	vars := make([]any, len(stmt.Vars))
+	copy(vars, stmt.Vars)
+
+	sql := stmt.SQL.String()
+	if values, ok := stmt.Clauses["VALUES"].Expression.(clause.Values); ok &&
+		len(values.Columns) > 0 && strings.HasPrefix(strings.ToUpper(strings.TrimSpace(sql)), "INSERT") {
+		n := len(values.Columns)
+		for i := 0; i < n*len(values.Values) && i < len(vars); i++ {
+			if isSecretColumn(values.Columns[i%n].Name) {
+				vars[i] = redacted
+			}
+		}
+		return vars
+	}
+
+	next := 0
+	inQuote := false
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case c == '?':
+			if next < len(vars) && isSecretColumn(precedingColumn(sql[:i])) {
+				vars[next] = redacted
+			}
+			next++
+		case c == '$':
+			// Postgres numbers its placeholders
+			j := i + 1
+			for j < len(sql) && sql[j] >= '0' && sql[j] <= '9' {
+				j++
+			}
+			if n, err := strconv.Atoi(sql[i+1 : j]); err == nil && n >= 1 && n <= len(vars) &&
+				isSecretColumn(precedingColumn(sql[:i])) {
+				vars[n-1] = redacted
+			}
+			i = j - 1
+		}
+	}
+	return vars
+}
+
+// precedingColumn returns the column compared or assigned at the end of
+// sql, such as password in "WHERE `users`.`password` = "
+func precedingColumn(sql string) string {
+	s := strings.TrimRight(sql, " (")
+	upper := strings.ToUpper(s)
+	for _, op := range []string{" NOT IN", " IN", " NOT LIKE", " LIKE"} {
+		if strings.HasSuffix(upper, op) {
+			s = s[:len(s)-len(op)]
+			break
+		}
+	}
+	s = strings.TrimRight(strings.TrimRight(s, " =<>!"), " ")
+
+	start := len(s)
+	for start > 0 && isIdentChar(s[start-1]) {
+		start--
+	}
+	ident := strings.Trim(s[start:], "`\"")
+	if dot := strings.LastIndexByte(ident, '.'); dot >= 0 {
+		ident = strings.Trim(ident[dot+1:], "`\"")
+	}
+	return ident
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '.' || c == '`' || c == '"' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isSecretColumn(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range secretColumns {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// caller returns the first frame outside gorm and this plugin
+func caller() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "gorm.io/") && !strings.HasSuffix(frame.File, "/slowquery.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/events"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type slowQueryAccount struct {
+	ID       uint
+	Email    string
+	Password string
+}
+
+func setupSlowQueryDB(t *testing.T, threshold time.Duration) (*gorm.DB, <-chan SlowQueryEvent) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&slowQueryAccount{}))
+
+	bus := events.NewEventBus()
+	t.Cleanup(bus.Close)
+	got := make(chan SlowQueryEvent, 16)
+	bus.Subscribe(EventSlowQuery, func(ctx context.Context, event events.Event) error {
+		if wrapped, ok := event.(events.WrappedEvent); ok {
+			if ev, ok := wrapped.Event.(SlowQueryEvent); ok {
+				got <- ev
+			}
+		}
+		return nil
+	})
+	require.NoError(t, WithSlowQueryReporting(db, bus, threshold))
+	return db, got
+}
+
+func receiveSlowQuery(t *testing.T, got <-chan SlowQueryEvent) SlowQueryEvent {
+	t.Helper()
+	select {
+	case event := <-got:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("no SlowQueryEvent published")
+		return SlowQueryEvent{}
+	}
+}
+
+func TestSlowQuery_PublishesEvent(t *testing.T) {
+	db, got := setupSlowQueryDB(t, 50*time.Millisecond)
+
+	// Fast statements are not reported
+	var n int64
+	require.NoError(t, db.Raw("SELECT 1").Scan(&n).Error)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_ = db.WithContext(ctx).Exec(slowQuery).Error
+
+	event := receiveSlowQuery(t, got)
+	assert.Equal(t, slowQuery, event.SQL)
+	assert.GreaterOrEqual(t, event.Duration, 50*time.Millisecond)
+	assert.Contains(t, event.Caller, "slowquery_test.go")
+	assert.NotEmpty(t, event.Error)
+
+	select {
+	case extra := <-got:
+		t.Fatalf("unexpected event for %q", extra.SQL)
+	default:
+	}
+}
+
+func TestSlowQuery_RedactsSecrets(t *testing.T) {
+	db, got := setupSlowQueryDB(t, time.Nanosecond)
+
+	require.NoError(t, db.Create(&slowQueryAccount{Email: "alice@example.com", Password: "hunter2"}).Error)
+	event := receiveSlowQuery(t, got)
+	assert.Contains(t, event.SQL, "alice@example.com")
+	assert.Contains(t, event.SQL, redacted)
+	assert.NotContains(t, event.SQL, "hunter2")
+	assert.Equal(t, int64(1), event.Rows)
+
+	var account slowQueryAccount
+	require.NoError(t, db.Where("email = ? AND password = ?", "alice@example.com", "hunter2").First(&account).Error)
+	event = receiveSlowQuery(t, got)
+	assert.Contains(t, event.SQL, "alice@example.com")
+	assert.NotContains(t, event.SQL, "hunter2")
+
+	require.NoError(t, db.Model(&account).Update("password", "correct horse").Error)
+	event = receiveSlowQuery(t, got)
+	assert.NotContains(t, event.SQL, "correct horse")
+}
+
+func TestPrecedingColumn(t *testing.T) {
+	cases := map[string]string{
+		"SELECT * FROM users WHERE email = ":                "email",
+		"SELECT * FROM `users` WHERE `users`.`password` = ": "password",
+		`UPDATE "users" SET "api_token"=`:                   "api_token",
+		"SELECT * FROM users WHERE id IN (":                 "id",
+		"SELECT * FROM users WHERE name LIKE ":              "name",
+	}
+	for sql, want := range cases {
+		assert.Equal(t, want, precedingColumn(sql), sql)
+	}
+}
@@ -0,0 +1,211 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TenantColumn is the column tenant-scoped tables are filtered on
+const TenantColumn = "tenant_id"
+
+const tenantCallbackName = "tenant:scope"
+
+// ErrNoTenant is added to statements on tenant-scoped models whose context
+// has no tenant and was not marked with WithoutTenant
+var ErrNoTenant = errors.New("no tenant in context for tenant-scoped query")
+
+// TenantModel adds the tenant column to a model. Embed it and register the
+// model with RegisterTenantModels:
+//
+//	type Project struct {
+//		ID uint
+//		database.TenantModel
+//		Name string
+//	}
+//
+//	database.RegisterTenantModels(&Project{})
+type TenantModel struct {
+	TenantID string `gorm:"size:64;not null;index" json:"tenant_id"`
+}
+
+// tenantKey is the context key for the current tenant
+type tenantKey struct{}
+
+// withoutTenantKey is the context key marking system access across tenants
+type withoutTenantKey struct{}
+
+// WithTenant returns a copy of ctx whose queries on tenant-scoped models
+// only see rows of tenant
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// WithoutTenant returns a copy of ctx whose queries on tenant-scoped models
+// see the rows of every tenant. It is for system callers such as console
+// commands, seeders and jobs that work across tenants.
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutTenantKey{}, true)
+}
+
+// isTenantBypassed reports whether ctx was marked by WithoutTenant
+func isTenantBypassed(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	bypassed, _ := ctx.Value(withoutTenantKey{}).(bool)
+	return bypassed
+}
+
+// TenantFromContext returns the tenant set by WithTenant
+func TenantFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok && tenant != ""
+}
+
+// TenantScope filters a query on the tenant in ctx. Models registered with
+// RegisterTenantModels get it automatically; use it directly for the rest:
+//
+//	db.WithContext(ctx).Scopes(database.TenantScope(ctx)).Find(&rows)
+//
+// Without a tenant in ctx the query fails with ErrNoTenant, unless ctx was
+// marked with WithoutTenant.
+func TenantScope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		tenant, ok := TenantFromContext(ctx)
+		if !ok {
+			if !isTenantBypassed(ctx) {
+				_ = db.AddError(ErrNoTenant)
+			}
+			return db
+		}
+		return db.Where(tenantCondition(tenant))
+	}
+}
+
+func tenantCondition(tenant string) clause.Expression {
+	return clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: TenantColumn}, Value: tenant}
+}
+
+var (
+	tenantModelsMu sync.RWMutex
+	tenantModels   = make(map[reflect.Type]bool)
+)
+
+// RegisterTenantModels marks models as tenant-scoped. Queries, updates and
+// deletes on them are filtered on the context tenant, and creates stamp it
+// on the new rows. Each model needs a tenant_id column, usually by
+// embedding TenantModel.
+func RegisterTenantModels(models ...any) {
+	tenantModelsMu.Lock()
+	defer tenantModelsMu.Unlock()
+	for _, model := range models {
+		tenantModels[modelType(model)] = true
+	}
+}
+
+// IsTenantModel reports whether model was registered with RegisterTenantModels
+func IsTenantModel(model any) bool {
+	return isTenantType(modelType(model))
+}
+
+func isTenantType(t reflect.Type) bool {
+	tenantModelsMu.RLock()
+	defer tenantModelsMu.RUnlock()
+	return tenantModels[t]
+}
+
+func modelType(model any) reflect.Type {
+	t := reflect.TypeOf(model)
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		t = t.Elem()
+	}
+	return t
+}
+
+// TenantPlugin applies TenantScope to every statement on a registered
+// model. Statements without a tenant in their context fail with
+// ErrNoTenant; system callers opt out with WithoutTenant. Raw and Exec are
+// left alone.
+type TenantPlugin struct{}
+
+// NewTenantPlugin creates the tenant scoping plugin
+func NewTenantPlugin() *TenantPlugin {
+	return &TenantPlugin{}
+}
+
+// Name returns the plugin name
+func (p *TenantPlugin) Name() string {
+	return "tenant-scope"
+}
+
+// Initialize registers the tenant callbacks
+func (p *TenantPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("gorm:create").Register(tenantCallbackName, p.stamp); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register(tenantCallbackName, p.filter); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register(tenantCallbackName, p.filter); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register(tenantCallbackName, p.filter); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register(tenantCallbackName, p.filter); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// tenant returns the tenant a statement on a registered model runs for
+func (p *TenantPlugin) tenant(db *gorm.DB) (string, bool) {
+	stmt := db.Statement
+	if stmt.Schema == nil || !isTenantType(stmt.Schema.ModelType) {
+		return "", false
+	}
+	tenant, ok := TenantFromContext(stmt.Context)
+	if !ok {
+		if !isTenantBypassed(stmt.Context) {
+			_ = db.AddError(ErrNoTenant)
+		}
+		return "", false
+	}
+	if stmt.Schema.LookUpField(TenantColumn) == nil {
+		_ = db.AddError(fmt.Errorf("tenant model %s has no %s column", stmt.Schema.Name, TenantColumn))
+		return "", false
+	}
+	return tenant, true
+}
+
+// filter restricts a statement to the rows of the context tenant
+func (p *TenantPlugin) filter(db *gorm.DB) {
+	if tenant, ok := p.tenant(db); ok {
+		db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{tenantCondition(tenant)}})
+	}
+}
+
+// stamp sets the context tenant on the rows being created, overriding any
+// tenant the caller set so rows cannot be written into another tenant
+func (p *TenantPlugin) stamp(db *gorm.DB) {
+	if tenant, ok := p.tenant(db); ok {
+		db.Statement.SetColumn(TenantColumn, tenant, true)
+	}
+}
+
+// WithTenantScoping installs the TenantPlugin on db
+func WithTenantScoping(db *gorm.DB) error {
+	return db.Use(NewTenantPlugin())
+}
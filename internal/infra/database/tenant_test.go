@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"gorm.io/gorm"
+)
+
+type tenantNote struct {
+	ID uint
+	TenantModel
+	Body string
+}
+
+// sharedNote has a tenant column but is not registered
+type sharedNote struct {
+	ID       uint
+	TenantID string
+	Body     string
+}
+
+func setupTenantDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := dbtest.Open(t)
+	require.NoError(t, WithTenantScoping(db))
+	require.NoError(t, db.AutoMigrate(&tenantNote{}, &sharedNote{}))
+	RegisterTenantModels(&tenantNote{})
+	return db
+}
+
+func TestTenantFromContext(t *testing.T) {
+	_, ok := TenantFromContext(context.Background())
+	assert.False(t, ok)
+
+	_, ok = TenantFromContext(WithTenant(context.Background(), ""))
+	assert.False(t, ok, "an empty tenant is no tenant")
+
+	tenant, ok := TenantFromContext(WithTenant(context.Background(), "acme"))
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+}
+
+func TestRegisterTenantModels(t *testing.T) {
+	RegisterTenantModels(&tenantNote{})
+
+	assert.True(t, IsTenantModel(&tenantNote{}))
+	assert.True(t, IsTenantModel(tenantNote{}))
+	assert.True(t, IsTenantModel(&[]*tenantNote{}))
+	assert.False(t, IsTenantModel(&sharedNote{}))
+}
+
+func TestTenantPlugin_FiltersQueriesOnContextTenant(t *testing.T) {
+	db := setupTenantDB(t)
+	acme := WithTenant(context.Background(), "acme")
+	globex := WithTenant(context.Background(), "globex")
+
+	// Creates are stamped with the context tenant, whatever the caller set
+	a := &tenantNote{Body: "acme plan"}
+	require.NoError(t, db.WithContext(acme).Create(a).Error)
+	assert.Equal(t, "acme", a.TenantID)
+	g := []*tenantNote{{Body: "globex plan"}, {Body: "globex budget", TenantModel: TenantModel{TenantID: "acme"}}}
+	require.NoError(t, db.WithContext(globex).Create(&g).Error)
+	assert.Equal(t, "globex", g[1].TenantID)
+
+	var notes []tenantNote
+	require.NoError(t, db.WithContext(acme).Find(&notes).Error)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "acme plan", notes[0].Body)
+
+	var count int64
+	require.NoError(t, db.WithContext(globex).Model(&tenantNote{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	// System callers opt out to see every row
+	require.NoError(t, db.WithContext(WithoutTenant(context.Background())).Find(&notes).Error)
+	assert.Len(t, notes, 3)
+}
+
+func TestTenantPlugin_FailsClosedWithoutTenant(t *testing.T) {
+	db := setupTenantDB(t)
+	require.NoError(t, db.WithContext(WithTenant(context.Background(), "acme")).Create(&tenantNote{Body: "acme plan"}).Error)
+	ctx := context.Background()
+
+	var notes []tenantNote
+	assert.ErrorIs(t, db.WithContext(ctx).Find(&notes).Error, ErrNoTenant)
+	assert.Empty(t, notes)
+
+	var count int64
+	assert.ErrorIs(t, db.WithContext(ctx).Model(&tenantNote{}).Count(&count).Error, ErrNoTenant)
+	assert.ErrorIs(t, db.WithContext(ctx).Create(&tenantNote{Body: "orphan"}).Error, ErrNoTenant)
+	assert.ErrorIs(t, db.WithContext(ctx).Model(&tenantNote{}).Where("1 = 1").Update("body", "x").Error, ErrNoTenant)
+	assert.ErrorIs(t, db.WithContext(ctx).Where("1 = 1").Delete(&tenantNote{}).Error, ErrNoTenant)
+
+	// Nothing was written or changed
+	system := WithoutTenant(ctx)
+	require.NoError(t, db.WithContext(system).Find(&notes).Error)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "acme plan", notes[0].Body)
+
+	// Unregistered models are not affected
+	assert.NoError(t, db.WithContext(ctx).Find(&[]sharedNote{}).Error)
+}
+
+func TestTenantPlugin_CrossTenantAccessFindsNothing(t *testing.T) {
+	db := setupTenantDB(t)
+	acme := WithTenant(context.Background(), "acme")
+	globex := WithTenant(context.Background(), "globex")
+
+	secret := &tenantNote{Body: "globex secret"}
+	require.NoError(t, db.WithContext(globex).Create(secret).Error)
+
+	var found tenantNote
+	err := db.WithContext(acme).First(&found, secret.ID).Error
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "err = %v", err)
+
+	result := db.WithContext(acme).Model(&tenantNote{}).Where("id = ?", secret.ID).Update("body", "stolen")
+	require.NoError(t, result.Error)
+	assert.Zero(t, result.RowsAffected)
+
+	result = db.WithContext(acme).Delete(&tenantNote{}, secret.ID)
+	require.NoError(t, result.Error)
+	assert.Zero(t, result.RowsAffected)
+
+	require.NoError(t, db.WithContext(globex).First(&found, secret.ID).Error)
+	assert.Equal(t, "globex secret", found.Body)
+}
+
+func TestTenantScope(t *testing.T) {
+	db := setupTenantDB(t)
+	require.NoError(t, db.Create([]*sharedNote{
+		{TenantID: "acme", Body: "a"},
+		{TenantID: "globex", Body: "g"},
+	}).Error)
+
+	// Unregistered models are only filtered when asked
+	var notes []sharedNote
+	acme := WithTenant(context.Background(), "acme")
+	require.NoError(t, db.WithContext(acme).Find(&notes).Error)
+	assert.Len(t, notes, 2)
+
+	require.NoError(t, db.WithContext(acme).Scopes(TenantScope(acme)).Find(&notes).Error)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "a", notes[0].Body)
+
+	err := db.Scopes(TenantScope(context.Background())).Find(&notes).Error
+	assert.ErrorIs(t, err, ErrNoTenant)
+
+	system := WithoutTenant(context.Background())
+	require.NoError(t, db.Scopes(TenantScope(system)).Find(&notes).Error)
+	assert.Len(t, notes, 2)
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	timeoutCancelKey          = "timeout:cancel"
+	timeoutCallbackBeforeName = "timeout:before"
+	timeoutCallbackAfterName  = "timeout:after"
+)
+
+// QueryTimeoutPlugin bounds every statement by a timeout derived from the
+// statement's context. A caller deadline that is already shorter wins, since
+// the derived context never outlives its parent.
+//
+// Row and Rows (also behind Raw().Scan) only get the timeout, not the early
+// cancel: their results are read after the callbacks return, so the context
+// is left to expire with its deadline instead.
+type QueryTimeoutPlugin struct {
+	timeout time.Duration
+}
+
+// NewQueryTimeoutPlugin creates a plugin enforcing timeout per statement
+func NewQueryTimeoutPlugin(timeout time.Duration) *QueryTimeoutPlugin {
+	return &QueryTimeoutPlugin{timeout: timeout}
+}
+
+// Name returns the plugin name
+func (p *QueryTimeoutPlugin) Name() string {
+	return "query-timeout"
+}
+
+// Initialize registers the timeout callbacks
+func (p *QueryTimeoutPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("gorm:create").Register(timeoutCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register(timeoutCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	if err := cb.Query().Before("gorm:query").Register(timeoutCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register(timeoutCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	if err := cb.Update().Before("gorm:update").Register(timeoutCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register(timeoutCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	if err := cb.Delete().Before("gorm:delete").Register(timeoutCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:delete").Register(timeoutCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	if err := cb.Row().Before("gorm:row").Register(timeoutCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+
+	if err := cb.Raw().Before("gorm:raw").Register(timeoutCallbackBeforeName, p.before); err != nil {
+		return err
+	}
+	if err := cb.Raw().After("gorm:raw").Register(timeoutCallbackAfterName, p.after); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *QueryTimeoutPlugin) before(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= p.timeout {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	db.Statement.Context = ctx
+	db.InstanceSet(timeoutCancelKey, cancel)
+}
+
+func (p *QueryTimeoutPlugin) after(db *gorm.DB) {
+	if cancel, ok := db.InstanceGet(timeoutCancelKey); ok {
+		cancel.(context.CancelFunc)()
+	}
+}
+
+// WithQueryTimeout bounds every statement on db by timeout
+func WithQueryTimeout(db *gorm.DB, timeout time.Duration) error {
+	return db.Use(NewQueryTimeoutPlugin(timeout))
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// slowQuery counts through a recursive CTE for far longer than any test timeout
+const slowQuery = `WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c LIMIT 10000000000) SELECT max(x) FROM c`
+
+func setupTimeoutDB(t *testing.T, timeout time.Duration) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, WithQueryTimeout(db, timeout))
+	return db
+}
+
+func TestQueryTimeout_AbortsSlowQuery(t *testing.T) {
+	db := setupTimeoutDB(t, 50*time.Millisecond)
+
+	start := time.Now()
+	err := db.WithContext(context.Background()).Exec(slowQuery).Error
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// Fast statements are unaffected
+	var n int64
+	require.NoError(t, db.Raw("SELECT 1").Scan(&n).Error)
+	assert.Equal(t, int64(1), n)
+}
+
+func TestQueryTimeout_KeepsShorterCallerDeadline(t *testing.T) {
+	db := setupTimeoutDB(t, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := db.WithContext(ctx).Exec(slowQuery).Error
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.True(t, errors.Is(ctx.Err(), context.DeadlineExceeded))
+}
+
+func TestPostgresDSN_StatementTimeout(t *testing.T) {
+	cfg := config.DatabaseConfig{
+		Host:         "localhost",
+		Port:         5432,
+		Name:         "app",
+		Username:     "app",
+		Password:     "pw",
+		SSLMode:      "disable",
+		Timezone:     "UTC",
+		QueryTimeout: 5 * time.Second,
+	}
+
+	parsed, err := pgconn.ParseConfig(postgresDSN(cfg))
+	require.NoError(t, err)
+	assert.Equal(t, "5000", parsed.RuntimeParams["statement_timeout"])
+
+	cfg.QueryTimeout = 0
+	assert.NotContains(t, postgresDSN(cfg), "statement_timeout")
+}
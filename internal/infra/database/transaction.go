@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// txKey is the context key for the ambient transaction
+type txKey struct{}
+
+// WithTx returns a copy of ctx carrying tx as the ambient transaction
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the ambient transaction, if any
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
+
+// Conn returns the ambient transaction when ctx carries one, otherwise db.
+// Repositories should use it instead of db.WithContext so they join any
+// transaction started by the caller and honour ForcePrimary.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	if IsPrimaryForced(ctx) {
+		return db.WithContext(ctx).Clauses(dbresolver.Write)
+	}
+	return db.WithContext(ctx)
+}
+
+// Transaction runs fn inside a transaction on db.
+// It commits when fn returns nil and rolls back otherwise. A panic in fn
+// rolls back the transaction and is re-raised. When ctx already carries a
+// transaction, fn joins it and the outermost call decides the outcome.
+func Transaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) (err error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return fn(tx.WithContext(ctx))
+	}
+
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return fmt.Errorf("failed to begin transaction: %w", tx.Error)
+	}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+		tx.Rollback()
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// TxManager runs units of work in a transaction carried by the context,
+// so repositories using Conn participate without extra parameters
+type TxManager struct {
+	db *gorm.DB
+}
+
+// NewTxManager creates a new TxManager via Wire DI
+func NewTxManager(db *gorm.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+// WithinTransaction runs fn with a context carrying a new (or the ambient)
+// transaction. Without a database, fn runs as-is.
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.db == nil {
+		return fn(ctx)
+	}
+	return Transaction(ctx, m.db, func(tx *gorm.DB) error {
+		return fn(WithTx(ctx, tx))
+	})
+}
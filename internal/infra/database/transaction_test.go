@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"github.com/zgiai/zgo/pkg/repository"
+	"gorm.io/gorm"
+)
+
+type txUser struct {
+	ID   uint
+	Name string
+}
+
+type txUserRole struct {
+	UserID uint `gorm:"primaryKey"`
+	RoleID uint `gorm:"primaryKey"`
+}
+
+func setupTxDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	return dbtest.Open(t, &txUser{}, &txUserRole{})
+}
+
+func countRows(t *testing.T, db *gorm.DB, model any) int64 {
+	t.Helper()
+	var n int64
+	require.NoError(t, db.Model(model).Count(&n).Error)
+	return n
+}
+
+func TestTransaction_Commit(t *testing.T) {
+	db := setupTxDB(t)
+
+	err := Transaction(context.Background(), db, func(tx *gorm.DB) error {
+		return tx.Create(&txUser{Name: "alice"}).Error
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), countRows(t, db, &txUser{}))
+}
+
+func TestTransaction_RollbackOnError(t *testing.T) {
+	db := setupTxDB(t)
+	boom := errors.New("boom")
+
+	err := Transaction(context.Background(), db, func(tx *gorm.DB) error {
+		if err := tx.Create(&txUser{Name: "alice"}).Error; err != nil {
+			return err
+		}
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.Equal(t, int64(0), countRows(t, db, &txUser{}))
+}
+
+func TestTransaction_RollbackOnPanic(t *testing.T) {
+	db := setupTxDB(t)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = Transaction(context.Background(), db, func(tx *gorm.DB) error {
+			tx.Create(&txUser{Name: "alice"})
+			panic("boom")
+		})
+	})
+	assert.Equal(t, int64(0), countRows(t, db, &txUser{}))
+}
+
+func TestTxManager_AmbientTransaction(t *testing.T) {
+	db := setupTxDB(t)
+	tm := NewTxManager(db)
+
+	// Simulates a service creating a user and assigning a role through
+	// repositories that only receive ctx
+	createUser := func(ctx context.Context, u *txUser) error {
+		return Conn(ctx, db).Create(u).Error
+	}
+	assignRole := func(ctx context.Context, userID, roleID uint) error {
+		return Conn(ctx, db).Create(&txUserRole{UserID: userID, RoleID: roleID}).Error
+	}
+
+	t.Run("rolls back when a later step fails", func(t *testing.T) {
+		err := tm.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			_, ok := TxFromContext(ctx)
+			require.True(t, ok, "ctx should carry the transaction")
+
+			u := &txUser{Name: "bob"}
+			if err := createUser(ctx, u); err != nil {
+				return err
+			}
+			require.NoError(t, assignRole(ctx, u.ID, 1))
+			// Duplicate primary key fails mid-transaction
+			return assignRole(ctx, u.ID, 1)
+		})
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), countRows(t, db, &txUser{}))
+		assert.Equal(t, int64(0), countRows(t, db, &txUserRole{}))
+	})
+
+	t.Run("commits when all steps succeed", func(t *testing.T) {
+		err := tm.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			u := &txUser{Name: "carol"}
+			if err := createUser(ctx, u); err != nil {
+				return err
+			}
+			return assignRole(ctx, u.ID, 1)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), countRows(t, db, &txUser{}))
+		assert.Equal(t, int64(1), countRows(t, db, &txUserRole{}))
+	})
+
+	t.Run("nested calls join the outer transaction", func(t *testing.T) {
+		boom := errors.New("boom")
+		err := tm.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			inner := tm.WithinTransaction(ctx, func(ctx context.Context) error {
+				return createUser(ctx, &txUser{Name: "dave"})
+			})
+			require.NoError(t, inner)
+			return boom
+		})
+		assert.ErrorIs(t, err, boom)
+		assert.Equal(t, int64(1), countRows(t, db, &txUser{}), "inner write should roll back with the outer transaction")
+	})
+}
+
+func TestConn_WithoutTransaction(t *testing.T) {
+	db := setupTxDB(t)
+
+	_, ok := TxFromContext(context.Background())
+	assert.False(t, ok)
+	require.NoError(t, Conn(context.Background(), db).Create(&txUser{Name: "erin"}).Error)
+	assert.Equal(t, int64(1), countRows(t, db, &txUser{}))
+}
+
+func TestTxManager_RepositoryJoinsTransaction(t *testing.T) {
+	db := setupTxDB(t)
+	repo := repository.NewBase[txUser](db, Conn)
+	ctx := context.Background()
+
+	rollback := errors.New("rollback")
+	err := NewTxManager(db).WithinTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, repo.Create(ctx, &txUser{Name: "draft"}))
+		return rollback
+	})
+	require.ErrorIs(t, err, rollback)
+
+	assert.Equal(t, int64(0), countRows(t, db, &txUser{}))
+}
@@ -1,17 +1,18 @@
 package email
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"sync"
 
 	"github.com/zgiai/zgo/internal/infra/config"
-	"github.com/zgiai/zgo/pkg/logger"
+	"github.com/zgiai/zgo/internal/infra/lang"
 )
 
+// ErrServiceClosed is returned by SendEmail once Flush has been called
+var ErrServiceClosed = errors.New("email service is shut down")
+
 var (
 	// defaultService is kept for backward compatibility with middleware.
 	// New code should use Wire DI instead.
@@ -21,142 +22,95 @@ var (
 // Service encapsulates email sending logic with bound configuration.
 // Injected via Wire DI - no global state in new code.
 type Service struct {
-	from   string
-	apiKey string
+	from      string
+	transport Transport
+
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup // Sends still talking to the transport
 }
 
 // NewService constructs an email service for the provided configuration.
 // This is the Wire provider function.
 func NewService(cfg *config.Config) *Service {
-	svc := &Service{
-		from:   cfg.Email.From,
-		apiKey: cfg.Email.ResendAPIKey,
+	svc := &Service{from: cfg.Email.From}
+	switch {
+	case cfg.Email.DryRun:
+		// Record instead of sending, for staging and tests
+		svc.transport = Captured()
+	case cfg.Email.Driver == config.EmailDriverResend:
+		svc.transport = NewResendTransport(cfg.Email.ResendAPIKey)
+	default:
+		// Without an API key emails are logged and dropped
+		svc.transport = LogTransport{}
 	}
 	// Set as default for backward compatibility
 	defaultService = svc
 	return svc
 }
 
-// NewTestService creates an email service for testing (no-op).
+// NewServiceWithTransport creates an email service sending from `from`
+// through transport
+func NewServiceWithTransport(from string, transport Transport) *Service {
+	return &Service{from: from, transport: transport}
+}
+
+// NewTestService creates an email service for testing. Emails are
+// captured, never sent; read them back with Sent and LastSent.
 func NewTestService() *Service {
 	return &Service{
-		from:   "test@example.com",
-		apiKey: "test-api-key",
+		from:      "test@example.com",
+		transport: Captured(),
 	}
 }
 
-type EmailRequest struct {
-	From    string   `json:"from"`
-	To      []string `json:"to"`
-	Subject string   `json:"subject"`
-	Html    string   `json:"html"`
-}
-
-type EmailResponse struct {
-	ID      string `json:"id"`
-	From    string `json:"from"`
-	To      string `json:"to"`
-	Created string `json:"created"`
-	Error   string `json:"error"`
-}
-
-// SendEmail sends an email
+// SendEmail sends an email through the configured transport
 func (s *Service) SendEmail(to []string, subject, htmlContent string) error {
-	if s.apiKey == "" {
-		logger.Warn("Email service not configured, skipping email", map[string]any{
-			"to":      to,
-			"subject": subject,
-		})
-		return nil
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrServiceClosed
 	}
+	s.inflight.Add(1)
+	s.mu.Unlock()
+	defer s.inflight.Done()
 
-	logger.Info("Preparing to send email", map[string]any{
-		"from":    s.from,
-		"to":      to,
-		"subject": subject,
-	})
-
-	reqBody := EmailRequest{
+	return s.transport.Send(Message{
 		From:    s.from,
 		To:      to,
 		Subject: subject,
-		Html:    htmlContent,
-	}
-
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		logger.Error("Failed to serialize request", map[string]any{"error": err})
-		return fmt.Errorf("failed to marshal email request: %w", err)
-	}
-
-	logger.Info("Request data", map[string]any{"data": string(jsonData)})
-
-	req, err := http.NewRequest("POST", "https://api.resend.com/emails", bytes.NewBuffer(jsonData))
-	if err != nil {
-		logger.Error("Failed to create request", map[string]any{"error": err})
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+s.apiKey)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		logger.Error("Failed to send request", map[string]any{"error": err})
-		return fmt.Errorf("failed to send email: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Error("Failed to read response", map[string]any{"error": err})
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	logger.Info("Received response", map[string]any{"body": string(body)})
-
-	if resp.StatusCode == http.StatusForbidden {
-		var resendError struct {
-			Name       string `json:"name"`
-			Message    string `json:"message"`
-			StatusCode int    `json:"statusCode"`
-		}
-		if err := json.Unmarshal(body, &resendError); err != nil {
-			logger.Error("Failed to parse error response", map[string]any{"error": err})
-			return fmt.Errorf("failed to unmarshal error response: %w", err)
-		}
-		logger.Error("Resend API error", map[string]any{
-			"name":       resendError.Name,
-			"message":    resendError.Message,
-			"statusCode": resendError.StatusCode,
-		})
-		if resendError.Name == "validation_error" && strings.Contains(resendError.Message, "domain is not verified") {
-			return fmt.Errorf("recipient domain not verified, please contact admin to add domain verification")
-		}
-		return fmt.Errorf("Resend API error: %s", resendError.Message)
-	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		logger.Error("Email sending failed", map[string]any{
-			"status":   resp.StatusCode,
-			"response": string(body),
-		})
-		return fmt.Errorf("failed to send email: status code %d, response: %s", resp.StatusCode, string(body))
-	}
+		HTML:    htmlContent,
+	})
+}
 
-	var emailResp EmailResponse
-	if err := json.Unmarshal(body, &emailResp); err != nil {
-		logger.Error("Failed to parse response", map[string]any{"error": err})
-		return fmt.Errorf("failed to unmarshal response: %w", err)
+// Flush stops accepting new emails and waits for sends already handed to
+// the transport to finish, or for ctx to be done. It is the email shutdown
+// hook.
+func (s *Service) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-	if emailResp.Error != "" {
-		logger.Error("Email service error", map[string]any{"error": emailResp.Error})
-		return fmt.Errorf("email service error: %s", emailResp.Error)
+// Ping checks that the transport can deliver without sending anything.
+// Transports that do not implement Pinger always succeed.
+func (s *Service) Ping(ctx context.Context) error {
+	if pinger, ok := s.transport.(Pinger); ok {
+		return pinger.Ping(ctx)
 	}
-
-	logger.Info("Email sent successfully", map[string]any{"id": emailResp.ID})
 	return nil
 }
 
@@ -169,29 +123,75 @@ func SendEmail(to []string, subject, htmlContent string) error {
 	return defaultService.SendEmail(to, subject, htmlContent)
 }
 
+// messages are the English email texts, registered so emails read correctly
+// without a bundle on disk. lang/<locale>.json overrides them per locale.
+var messages = map[string]string{
+	"email.greeting": "Dear :username,",
+
+	"email.password_reset.subject": "Password Reset Notification",
+	"email.password_reset.intro":   "Your password has been reset. The new temporary password is:",
+	"email.password_reset.action":  "Please use this temporary password to log in and change it to your own password immediately.",
+	"email.password_reset.warning": "If this was not your action, please contact the administrator immediately.",
+
+	"email.welcome.subject": "Welcome to ZGO",
+	"email.welcome.thanks":  "Thank you for registering as our user!",
+	"email.welcome.support": "If you have any questions, please feel free to contact our support team.",
+
+	"email.verify.subject": "Verify your email address",
+	"email.verify.intro":   "Please confirm your email address to activate your account:",
+	"email.verify.button":  "Verify email",
+	"email.verify.ignore":  "If you did not register, you can ignore this email.",
+}
+
+func init() {
+	lang.Global().AddMany("en", messages)
+}
+
+// t translates an email text into the application locale
+func t(key string, replacements ...map[string]string) string {
+	return lang.T(lang.GetLocale(), key, replacements...)
+}
+
 // SendPasswordResetEmail sends a password reset notification email
 func SendPasswordResetEmail(to string, newPassword string) error {
-	subject := "Password Reset Notification"
+	subject := t("email.password_reset.subject")
 	htmlContent := fmt.Sprintf(`
-		<h2>Password Reset Notification</h2>
-		<p>Your password has been reset. The new temporary password is:</p>
+		<h2>%s</h2>
+		<p>%s</p>
 		<p style="font-size: 18px; font-weight: bold; color: #333;">%s</p>
-		<p>Please use this temporary password to log in and change it to your own password immediately.</p>
-		<p>If this was not your action, please contact the administrator immediately.</p>
-	`, newPassword)
+		<p>%s</p>
+		<p>%s</p>
+	`, subject, t("email.password_reset.intro"), newPassword,
+		t("email.password_reset.action"), t("email.password_reset.warning"))
 
 	return SendEmail([]string{to}, subject, htmlContent)
 }
 
 // SendWelcomeEmail sends a welcome email
 func SendWelcomeEmail(to string, username string) error {
-	subject := "Welcome to ZGO"
+	subject := t("email.welcome.subject")
+	htmlContent := fmt.Sprintf(`
+		<h2>%s</h2>
+		<p>%s</p>
+		<p>%s</p>
+		<p>%s</p>
+	`, subject, t("email.greeting", map[string]string{"username": username}),
+		t("email.welcome.thanks"), t("email.welcome.support"))
+
+	return SendEmail([]string{to}, subject, htmlContent)
+}
+
+// SendVerificationEmail sends the link that activates a new account
+func SendVerificationEmail(to string, username string, link string) error {
+	subject := t("email.verify.subject")
 	htmlContent := fmt.Sprintf(`
-		<h2>Welcome to ZGO</h2>
-		<p>Dear %s,</p>
-		<p>Thank you for registering as our user!</p>
-		<p>If you have any questions, please feel free to contact our support team.</p>
-	`, username)
+		<h2>%s</h2>
+		<p>%s</p>
+		<p>%s</p>
+		<p><a href="%s">%s</a></p>
+		<p>%s</p>
+	`, subject, t("email.greeting", map[string]string{"username": username}),
+		t("email.verify.intro"), link, t("email.verify.button"), t("email.verify.ignore"))
 
 	return SendEmail([]string{to}, subject, htmlContent)
 }
@@ -0,0 +1,133 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/config"
+)
+
+func TestDryRun_CapturesWelcomeEmail(t *testing.T) {
+	t.Cleanup(func() { defaultService = nil; ResetSent() })
+	ResetSent()
+
+	cfg := &config.Config{}
+	cfg.Email = config.EmailConfig{
+		Driver:       config.EmailDriverResend,
+		From:         "noreply@example.com",
+		ResendAPIKey: "re_live_key",
+		DryRun:       true,
+	}
+	svc := NewService(cfg)
+	assert.Same(t, Captured(), svc.transport, "dry run must not use the Resend transport")
+
+	require.NoError(t, SendWelcomeEmail("alice@example.com", "alice"))
+
+	msg, ok := LastSent()
+	require.True(t, ok)
+	assert.Equal(t, "noreply@example.com", msg.From)
+	assert.Equal(t, []string{"alice@example.com"}, msg.To)
+	assert.Equal(t, "Welcome to ZGO", msg.Subject)
+	assert.Contains(t, msg.HTML, "Dear alice,")
+	assert.Len(t, Sent(), 1)
+
+	ResetSent()
+	_, ok = LastSent()
+	assert.False(t, ok)
+	assert.Empty(t, Sent())
+}
+
+func TestCaptureTransport_KeepsMostRecent(t *testing.T) {
+	capture := NewCaptureTransport()
+	for i := range captureLimit + 5 {
+		require.NoError(t, capture.Send(Message{Subject: string(rune('a' + i%26))}))
+	}
+
+	sent := capture.Sent()
+	assert.Len(t, sent, captureLimit)
+	last, ok := capture.LastSent()
+	require.True(t, ok)
+	assert.Equal(t, sent[len(sent)-1], last)
+}
+
+func TestResendTransport_Send(t *testing.T) {
+	var hits atomic.Int32
+	var got EmailRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		assert.Equal(t, "/emails", r.URL.Path)
+		assert.Equal(t, "Bearer re_key", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		_, _ = w.Write([]byte(`{"id":"email_1"}`))
+	}))
+	defer server.Close()
+
+	transport := &ResendTransport{apiKey: "re_key", apiURL: server.URL}
+	err := transport.Send(Message{From: "a@example.com", To: []string{"b@example.com"}, Subject: "Hi", HTML: "<p>Hi</p>"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), hits.Load())
+	assert.Equal(t, EmailRequest{From: "a@example.com", To: []string{"b@example.com"}, Subject: "Hi", Html: "<p>Hi</p>"}, got)
+
+	// Without a key nothing is sent
+	require.NoError(t, (&ResendTransport{apiURL: server.URL}).Send(Message{To: []string{"b@example.com"}}))
+	assert.Equal(t, int32(1), hits.Load())
+}
+
+func TestResendTransport_PingReportsRejectedKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/domains", r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer re_good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"statusCode":401,"name":"validation_error","message":"API key is invalid"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	ctx := t.Context()
+	require.NoError(t, (&ResendTransport{apiKey: "re_good", apiURL: server.URL}).Ping(ctx))
+
+	svc := NewServiceWithTransport("a@example.com", &ResendTransport{apiKey: "re_bad", apiURL: server.URL})
+	assert.EqualError(t, svc.Ping(ctx), "resend API returned status 401: API key is invalid")
+}
+
+// blockingTransport holds every send until release is closed
+type blockingTransport struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (t *blockingTransport) Send(msg Message) error {
+	t.started <- struct{}{}
+	<-t.release
+	return nil
+}
+
+func TestService_FlushWaitsForInflightSends(t *testing.T) {
+	transport := &blockingTransport{started: make(chan struct{}, 1), release: make(chan struct{})}
+	svc := NewServiceWithTransport("noreply@example.com", transport)
+
+	sent := make(chan error, 1)
+	go func() { sent <- svc.SendEmail([]string{"alice@example.com"}, "Hi", "<p>Hi</p>") }()
+	<-transport.started
+
+	// Budget runs out while the send is still in progress
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, svc.Flush(ctx), context.DeadlineExceeded)
+
+	close(transport.release)
+	require.NoError(t, svc.Flush(context.Background()))
+	require.NoError(t, <-sent)
+
+	assert.ErrorIs(t, svc.SendEmail([]string{"bob@example.com"}, "Hi", "<p>Hi</p>"), ErrServiceClosed)
+}
@@ -0,0 +1,296 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/zgiai/zgo/pkg/logger"
+)
+
+// resendAPIURL is the base URL of the Resend API
+const resendAPIURL = "https://api.resend.com"
+
+// captureLimit is how many messages a CaptureTransport keeps; older ones
+// are dropped so a long-running staging server does not grow without bound
+const captureLimit = 1000
+
+// Message is one email ready to send
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	HTML    string
+}
+
+// Transport delivers messages
+type Transport interface {
+	Send(msg Message) error
+}
+
+// Pinger is implemented by transports that can check their credentials
+// and connectivity without sending a message
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// LogTransport logs messages and drops them
+type LogTransport struct{}
+
+// Send logs msg without its body
+func (LogTransport) Send(msg Message) error {
+	logger.Warn("Email service not configured, skipping email", map[string]any{
+		"to":      msg.To,
+		"subject": msg.Subject,
+	})
+	return nil
+}
+
+// CaptureTransport records messages in memory instead of sending them, for
+// MAIL_DRY_RUN and tests
+type CaptureTransport struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+// NewCaptureTransport creates an empty capture buffer
+func NewCaptureTransport() *CaptureTransport {
+	return &CaptureTransport{}
+}
+
+// Send records and logs msg
+func (t *CaptureTransport) Send(msg Message) error {
+	msg.To = slices.Clone(msg.To)
+
+	t.mu.Lock()
+	t.sent = append(t.sent, msg)
+	if len(t.sent) > captureLimit {
+		t.sent = slices.Delete(t.sent, 0, len(t.sent)-captureLimit)
+	}
+	t.mu.Unlock()
+
+	logger.Info("Email captured (dry run), not sent", map[string]any{
+		"from":    msg.From,
+		"to":      msg.To,
+		"subject": msg.Subject,
+	})
+	return nil
+}
+
+// Sent returns the captured messages, oldest first
+func (t *CaptureTransport) Sent() []Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return slices.Clone(t.sent)
+}
+
+// LastSent returns the most recent message, if any
+func (t *CaptureTransport) LastSent() (Message, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.sent) == 0 {
+		return Message{}, false
+	}
+	return t.sent[len(t.sent)-1], true
+}
+
+// Reset forgets the captured messages
+func (t *CaptureTransport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sent = nil
+}
+
+// captured is the buffer dry-run services write to
+var captured = NewCaptureTransport()
+
+// Captured returns the capture buffer shared by dry-run services
+func Captured() *CaptureTransport {
+	return captured
+}
+
+// Sent returns the messages captured in dry-run mode, oldest first.
+//
+// Example:
+//
+//	email.ResetSent()
+//	_, _ = svc.Register(ctx, req)
+//	msg, ok := email.LastSent()
+//	assert.True(t, ok)
+//	assert.Equal(t, []string{"alice@example.com"}, msg.To)
+func Sent() []Message {
+	return captured.Sent()
+}
+
+// LastSent returns the last message captured in dry-run mode
+func LastSent() (Message, bool) {
+	return captured.LastSent()
+}
+
+// ResetSent clears the messages captured in dry-run mode
+func ResetSent() {
+	captured.Reset()
+}
+
+type EmailRequest struct {
+	From    string   `json:"from"`
+	To      []string `json:"to"`
+	Subject string   `json:"subject"`
+	Html    string   `json:"html"`
+}
+
+type EmailResponse struct {
+	ID      string `json:"id"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Created string `json:"created"`
+	Error   string `json:"error"`
+}
+
+// ResendTransport sends messages through the Resend API
+type ResendTransport struct {
+	apiKey string
+	apiURL string
+}
+
+// NewResendTransport creates a transport authenticating with apiKey
+func NewResendTransport(apiKey string) *ResendTransport {
+	return &ResendTransport{apiKey: apiKey, apiURL: resendAPIURL}
+}
+
+// Send posts msg to the Resend API. Without an API key msg is logged and
+// dropped instead.
+func (t *ResendTransport) Send(msg Message) error {
+	if t.apiKey == "" {
+		return LogTransport{}.Send(msg)
+	}
+
+	logger.Info("Preparing to send email", map[string]any{
+		"from":    msg.From,
+		"to":      msg.To,
+		"subject": msg.Subject,
+	})
+
+	reqBody := EmailRequest{
+		From:    msg.From,
+		To:      msg.To,
+		Subject: msg.Subject,
+		Html:    msg.HTML,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		logger.Error("Failed to serialize request", map[string]any{"error": err})
+		return fmt.Errorf("failed to marshal email request: %w", err)
+	}
+
+	logger.Info("Request data", map[string]any{"data": string(jsonData)})
+
+	req, err := http.NewRequest("POST", t.apiURL+"/emails", bytes.NewBuffer(jsonData))
+	if err != nil {
+		logger.Error("Failed to create request", map[string]any{"error": err})
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+t.apiKey)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		logger.Error("Failed to send request", map[string]any{"error": err})
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logger.Error("Failed to read response", map[string]any{"error": err})
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	logger.Info("Received response", map[string]any{"body": string(body)})
+
+	if resp.StatusCode == http.StatusForbidden {
+		var resendError struct {
+			Name       string `json:"name"`
+			Message    string `json:"message"`
+			StatusCode int    `json:"statusCode"`
+		}
+		if err := json.Unmarshal(body, &resendError); err != nil {
+			logger.Error("Failed to parse error response", map[string]any{"error": err})
+			return fmt.Errorf("failed to unmarshal error response: %w", err)
+		}
+		logger.Error("Resend API error", map[string]any{
+			"name":       resendError.Name,
+			"message":    resendError.Message,
+			"statusCode": resendError.StatusCode,
+		})
+		if resendError.Name == "validation_error" && strings.Contains(resendError.Message, "domain is not verified") {
+			return fmt.Errorf("recipient domain not verified, please contact admin to add domain verification")
+		}
+		return fmt.Errorf("Resend API error: %s", resendError.Message)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		logger.Error("Email sending failed", map[string]any{
+			"status":   resp.StatusCode,
+			"response": string(body),
+		})
+		return fmt.Errorf("failed to send email: status code %d, response: %s", resp.StatusCode, string(body))
+	}
+
+	var emailResp EmailResponse
+	if err := json.Unmarshal(body, &emailResp); err != nil {
+		logger.Error("Failed to parse response", map[string]any{"error": err})
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	if emailResp.Error != "" {
+		logger.Error("Email service error", map[string]any{"error": emailResp.Error})
+		return fmt.Errorf("email service error: %s", emailResp.Error)
+	}
+
+	logger.Info("Email sent successfully", map[string]any{"id": emailResp.ID})
+	return nil
+}
+
+// Ping checks that the Resend API is reachable and accepts the API key.
+// It lists domains, which sends nothing. Without an API key it is a no-op.
+func (t *ResendTransport) Ping(ctx context.Context) error {
+	if t.apiKey == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.apiURL+"/domains", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+t.apiKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("resend API unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		// Resend explains rejected keys in the body, e.g. "API key is invalid"
+		var resendError struct {
+			Message string `json:"message"`
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(body, &resendError) == nil && resendError.Message != "" {
+			return fmt.Errorf("resend API returned status %d: %s", resp.StatusCode, resendError.Message)
+		}
+		return fmt.Errorf("resend API returned status %d", resp.StatusCode)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -16,6 +16,7 @@ type EventBus struct {
 	handlers   []handlerEntry
 	middleware []EventMiddleware
 	closed     bool
+	inflight   sync.WaitGroup // Async handlers still running
 }
 
 // EventMiddleware wraps event handling for cross-cutting concerns
@@ -105,8 +106,22 @@ func (b *EventBus) Publish(ctx context.Context, e events.Event) error {
 	matchingHandlers := b.findMatchingHandlers(event.EventName())
 	middleware := make([]EventMiddleware, len(b.middleware))
 	copy(middleware, b.middleware)
+
+	// Count async handlers as in flight before releasing the lock, so Drain,
+	// which closes the bus under the write lock, never waits on a WaitGroup
+	// that is still being added to
+	pending := 0
+	for _, entry := range matchingHandlers {
+		if entry.async {
+			pending++
+		}
+	}
+	b.inflight.Add(pending)
 	b.mu.RUnlock()
 
+	// Release the slots of async handlers that never start
+	defer func() { b.inflight.Add(-pending) }()
+
 	// Execute handlers in priority order
 	for _, entry := range matchingHandlers {
 		// Check context cancellation before each handler
@@ -121,7 +136,9 @@ func (b *EventBus) Publish(ctx context.Context, e events.Event) error {
 
 		if entry.async {
 			// Async handlers don't block and errors are not propagated
+			pending--
 			go func(h EventHandler, e Event) {
+				defer b.inflight.Done()
 				_ = h(ctx, e)
 			}(handler, event)
 		} else {
@@ -183,6 +200,25 @@ func (b *EventBus) Close() {
 	b.closed = true
 }
 
+// Drain closes the bus and waits for in-flight async handlers (such as
+// queued welcome emails) to finish, or for ctx to be done.
+func (b *EventBus) Drain(ctx context.Context) error {
+	b.Close()
+
+	done := make(chan struct{})
+	go func() {
+		b.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Clear removes all handlers and middleware
 func (b *EventBus) Clear() {
 	b.mu.Lock()
@@ -378,3 +378,72 @@ func TestEventBus_Clear(t *testing.T) {
 		t.Errorf("HandlerCount after Clear = %d, want 0", bus.HandlerCount())
 	}
 }
+
+func TestEventBus_Drain(t *testing.T) {
+	bus := NewEventBus()
+
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	bus.Subscribe("slow", func(ctx context.Context, e Event) error {
+		<-release
+		close(finished)
+		return nil
+	}, WithAsync())
+
+	if err := bus.Publish(context.Background(), newTestEvent("slow", "")); err != nil {
+		t.Fatalf("Publish error = %v", err)
+	}
+
+	// Budget runs out while the handler is still blocked
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bus.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Drain error = %v, want DeadlineExceeded", err)
+	}
+
+	close(release)
+	if err := bus.Drain(context.Background()); err != nil {
+		t.Errorf("Drain error = %v", err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Error("Drain returned before the async handler finished")
+	}
+
+	if err := bus.Publish(context.Background(), newTestEvent("slow", "")); !errors.Is(err, ErrEventBusClosed) {
+		t.Errorf("Publish after Drain error = %v, want ErrEventBusClosed", err)
+	}
+}
+
+func TestEventBus_DrainWhilePublishing(t *testing.T) {
+	bus := NewEventBus()
+
+	var started, finished atomic.Int32
+	bus.Subscribe("busy", func(ctx context.Context, e Event) error {
+		started.Add(1)
+		time.Sleep(time.Millisecond)
+		finished.Add(1)
+		return nil
+	}, WithAsync())
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for bus.Publish(context.Background(), newTestEvent("busy", "")) == nil {
+			}
+		}()
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if err := bus.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain error = %v", err)
+	}
+	// Every handler accepted before the bus closed has run to completion
+	if s, f := started.Load(), finished.Load(); s != f {
+		t.Errorf("Drain returned with %d of %d handlers finished", f, s)
+	}
+	wg.Wait()
+}
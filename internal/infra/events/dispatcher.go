@@ -3,6 +3,7 @@ package events
 import (
 	"context"
 	"reflect"
+	"strings"
 	"sync"
 )
 
@@ -27,9 +28,27 @@ func (f SimpleListenerFunc) Handle(ctx context.Context, event SimpleEvent) error
 type SimpleDispatcher struct {
 	mu        sync.RWMutex
 	listeners map[string][]SimpleListener
+	patterns  []patternListener
 	async     bool
 }
 
+// patternListener is a listener registered for a wildcard pattern.
+// The pattern is split into segments once at registration time.
+type patternListener struct {
+	pattern  string
+	parts    []string
+	listener SimpleListener
+}
+
+// matches reports whether the compiled pattern matches the given event segments
+func (p patternListener) matches(eventParts []string) bool {
+	// A bare "*" subscribes to every event, regardless of segment count
+	if p.pattern == "*" || p.pattern == "**" {
+		return true
+	}
+	return matchParts(p.parts, eventParts)
+}
+
 // simpleDispatcher is the global dispatcher instance
 var (
 	globalSimpleDispatcher *SimpleDispatcher
@@ -63,16 +82,83 @@ func (d *SimpleDispatcher) ListenFunc(eventName string, fn func(ctx context.Cont
 	d.Listen(eventName, SimpleListenerFunc(fn))
 }
 
+// ListenPattern registers a listener for every event matching a wildcard pattern.
+// "user.*" matches "user.created", "*.created" matches "order.created",
+// and a bare "*" matches all events.
+func (d *SimpleDispatcher) ListenPattern(pattern string, listener SimpleListener) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.patterns = append(d.patterns, patternListener{
+		pattern:  pattern,
+		parts:    strings.Split(pattern, "."),
+		listener: listener,
+	})
+}
+
+// ListenPatternFunc registers a function listener for a wildcard pattern
+func (d *SimpleDispatcher) ListenPatternFunc(pattern string, fn func(ctx context.Context, event SimpleEvent) error) {
+	d.ListenPattern(pattern, SimpleListenerFunc(fn))
+}
+
 // Subscribe registers a listener for a typed event using reflection
 func (d *SimpleDispatcher) Subscribe(eventType SimpleEvent, listener SimpleListener) {
 	d.Listen(eventType.EventName(), listener)
 }
 
-// Dispatch fires an event to all registered listeners
-func (d *SimpleDispatcher) Dispatch(ctx context.Context, event SimpleEvent) error {
+// listenersFor returns exact listeners followed by matching pattern listeners
+func (d *SimpleDispatcher) listenersFor(eventName string) []SimpleListener {
 	d.mu.RLock()
-	listeners := d.listeners[event.EventName()]
-	d.mu.RUnlock()
+	defer d.mu.RUnlock()
+
+	exact := d.listeners[eventName]
+	if len(d.patterns) == 0 {
+		return exact
+	}
+
+	listeners := make([]SimpleListener, 0, len(exact)+len(d.patterns))
+	listeners = append(listeners, exact...)
+	eventParts := strings.Split(eventName, ".")
+	for _, p := range d.patterns {
+		if p.matches(eventParts) {
+			listeners = append(listeners, p.listener)
+		}
+	}
+	return listeners
+}
+
+// On registers a typed listener under T's event name. The listener receives the
+// concrete event type; events dispatched under the same name with a different
+// type are ignored.
+//
+//	events.On(d, func(ctx context.Context, e *UserRegistered) error {
+//		return sendWelcome(ctx, e.Email)
+//	})
+func On[T SimpleEvent](d *SimpleDispatcher, fn func(ctx context.Context, event T) error) {
+	d.ListenFunc(typedEventName[T](), func(ctx context.Context, event SimpleEvent) error {
+		typed, ok := event.(T)
+		if !ok {
+			return nil
+		}
+		return fn(ctx, typed)
+	})
+}
+
+// typedEventName resolves the event name of T, allocating a value for pointer types
+func typedEventName[T SimpleEvent]() string {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil {
+		panic("events: On requires a concrete event type")
+	}
+	if t.Kind() == reflect.Ptr {
+		return reflect.New(t.Elem()).Interface().(SimpleEvent).EventName()
+	}
+	return zero.EventName()
+}
+
+// Dispatch fires an event to all registered listeners, including pattern listeners
+func (d *SimpleDispatcher) Dispatch(ctx context.Context, event SimpleEvent) error {
+	listeners := d.listenersFor(event.EventName())
 
 	for _, listener := range listeners {
 		if err := listener.Handle(ctx, event); err != nil {
@@ -84,9 +170,7 @@ func (d *SimpleDispatcher) Dispatch(ctx context.Context, event SimpleEvent) erro
 
 // DispatchAsync fires an event asynchronously to all registered listeners
 func (d *SimpleDispatcher) DispatchAsync(ctx context.Context, event SimpleEvent) {
-	d.mu.RLock()
-	listeners := d.listeners[event.EventName()]
-	d.mu.RUnlock()
+	listeners := d.listenersFor(event.EventName())
 
 	for _, listener := range listeners {
 		go func(l SimpleListener) {
@@ -105,18 +189,37 @@ func (d *SimpleDispatcher) DispatchAll(ctx context.Context, events ...SimpleEven
 	return nil
 }
 
-// HasListeners checks if an event has any listeners
+// HasListeners checks if an event has any exact or pattern listeners
 func (d *SimpleDispatcher) HasListeners(eventName string) bool {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
-	return len(d.listeners[eventName]) > 0
+	if len(d.listeners[eventName]) > 0 {
+		return true
+	}
+
+	eventParts := strings.Split(eventName, ".")
+	for _, p := range d.patterns {
+		if p.matches(eventParts) {
+			return true
+		}
+	}
+	return false
 }
 
-// Forget removes all listeners for an event
+// Forget removes all listeners for an event, and pattern listeners registered
+// with the same pattern string
 func (d *SimpleDispatcher) Forget(eventName string) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	delete(d.listeners, eventName)
+
+	patterns := d.patterns[:0]
+	for _, p := range d.patterns {
+		if p.pattern != eventName {
+			patterns = append(patterns, p)
+		}
+	}
+	d.patterns = patterns
 }
 
 // Flush removes all listeners
@@ -124,6 +227,7 @@ func (d *SimpleDispatcher) Flush() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	d.listeners = make(map[string][]SimpleListener)
+	d.patterns = nil
 }
 
 // GetSimpleEventName extracts event name from type (helper for struct events)
@@ -147,6 +251,11 @@ func ListenSimpleFunc(eventName string, fn func(ctx context.Context, event Simpl
 	GlobalSimpleDispatcher().ListenFunc(eventName, fn)
 }
 
+// ListenSimplePattern registers a pattern listener on the global simple dispatcher
+func ListenSimplePattern(pattern string, listener SimpleListener) {
+	GlobalSimpleDispatcher().ListenPattern(pattern, listener)
+}
+
 // DispatchSimple fires an event on the global simple dispatcher
 func DispatchSimple(ctx context.Context, event SimpleEvent) error {
 	return GlobalSimpleDispatcher().Dispatch(ctx, event)
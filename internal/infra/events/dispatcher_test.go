@@ -0,0 +1,190 @@
+package events
+
+import (
+	"context"
+	"testing"
+)
+
+// simpleTestEvent is a minimal SimpleEvent for dispatcher tests
+type simpleTestEvent struct {
+	name string
+}
+
+func (e simpleTestEvent) EventName() string {
+	return e.name
+}
+
+func TestSimpleDispatcher_ListenPattern(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		eventName string
+		want      bool
+	}{
+		{"prefix wildcard", "user.*", "user.created", true},
+		{"prefix wildcard other event", "user.*", "user.deleted", true},
+		{"suffix wildcard", "*.created", "order.created", true},
+		{"global wildcard", "*", "user.profile.updated", true},
+		{"global wildcard single segment", "*", "boot", true},
+		{"non-matching prefix", "user.*", "order.created", false},
+		{"non-matching suffix", "*.created", "user.deleted", false},
+		{"too many segments", "user.*", "user.profile.updated", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewSimpleDispatcher()
+
+			delivered := false
+			d.ListenPatternFunc(tt.pattern, func(ctx context.Context, event SimpleEvent) error {
+				delivered = true
+				return nil
+			})
+
+			if err := d.Dispatch(context.Background(), simpleTestEvent{name: tt.eventName}); err != nil {
+				t.Fatalf("Dispatch error: %v", err)
+			}
+			if delivered != tt.want {
+				t.Errorf("pattern %q, event %q: delivered = %v, want %v", tt.pattern, tt.eventName, delivered, tt.want)
+			}
+			if got := d.HasListeners(tt.eventName); got != tt.want {
+				t.Errorf("HasListeners(%q) = %v, want %v", tt.eventName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimpleDispatcher_ExactAndPatternListeners(t *testing.T) {
+	d := NewSimpleDispatcher()
+
+	var order []string
+	d.ListenFunc("user.created", func(ctx context.Context, event SimpleEvent) error {
+		order = append(order, "exact")
+		return nil
+	})
+	d.ListenPatternFunc("user.*", func(ctx context.Context, event SimpleEvent) error {
+		order = append(order, "user.*")
+		return nil
+	})
+	d.ListenPatternFunc("*", func(ctx context.Context, event SimpleEvent) error {
+		order = append(order, "*")
+		return nil
+	})
+
+	if err := d.Dispatch(context.Background(), simpleTestEvent{name: "user.created"}); err != nil {
+		t.Fatalf("Dispatch error: %v", err)
+	}
+
+	want := []string{"exact", "user.*", "*"}
+	if len(order) != len(want) {
+		t.Fatalf("called %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("order[%d] = %q, want %q", i, order[i], want[i])
+		}
+	}
+}
+
+func TestSimpleDispatcher_ForgetAndFlushPatterns(t *testing.T) {
+	d := NewSimpleDispatcher()
+	d.ListenPatternFunc("user.*", func(ctx context.Context, event SimpleEvent) error { return nil })
+	d.ListenPatternFunc("*.created", func(ctx context.Context, event SimpleEvent) error { return nil })
+
+	d.Forget("user.*")
+	if d.HasListeners("user.deleted") {
+		t.Error("Forget should remove the user.* pattern listener")
+	}
+	if !d.HasListeners("order.created") {
+		t.Error("Forget should keep unrelated pattern listeners")
+	}
+
+	d.Flush()
+	if d.HasListeners("order.created") {
+		t.Error("Flush should remove all pattern listeners")
+	}
+}
+
+// userSignedUp is a pointer-receiver event for typed listener tests
+type userSignedUp struct {
+	Email string
+}
+
+func (e *userSignedUp) EventName() string {
+	return "user.signed_up"
+}
+
+func TestOn_ReceivesConcreteType(t *testing.T) {
+	d := NewSimpleDispatcher()
+
+	var got string
+	On(d, func(ctx context.Context, e *userSignedUp) error {
+		got = e.Email
+		return nil
+	})
+
+	if !d.HasListeners("user.signed_up") {
+		t.Fatal("On should register under the event's name")
+	}
+	if err := d.Dispatch(context.Background(), &userSignedUp{Email: "a@example.com"}); err != nil {
+		t.Fatalf("Dispatch error: %v", err)
+	}
+	if got != "a@example.com" {
+		t.Errorf("Email = %q, want %q", got, "a@example.com")
+	}
+}
+
+func TestOn_ValueType(t *testing.T) {
+	d := NewSimpleDispatcher()
+
+	var got orderPlaced
+	On(d, func(ctx context.Context, e orderPlaced) error {
+		got = e
+		return nil
+	})
+
+	if err := d.Dispatch(context.Background(), orderPlaced{OrderID: 7}); err != nil {
+		t.Fatalf("Dispatch error: %v", err)
+	}
+	if got.OrderID != 7 {
+		t.Errorf("OrderID = %d, want 7", got.OrderID)
+	}
+}
+
+func TestOn_IgnoresMismatchedType(t *testing.T) {
+	d := NewSimpleDispatcher()
+
+	called := false
+	On(d, func(ctx context.Context, e *userSignedUp) error {
+		called = true
+		return nil
+	})
+
+	// Same event name, different concrete type
+	err := d.Dispatch(context.Background(), simpleTestEvent{name: "user.signed_up"})
+	if err != nil {
+		t.Fatalf("Dispatch error: %v", err)
+	}
+	if called {
+		t.Error("typed listener should ignore events of another type")
+	}
+}
+
+func TestOn_CoexistsWithListen(t *testing.T) {
+	d := NewSimpleDispatcher()
+
+	var typed, untyped bool
+	On(d, func(ctx context.Context, e *userSignedUp) error {
+		typed = true
+		return nil
+	})
+	d.ListenFunc("user.signed_up", func(ctx context.Context, event SimpleEvent) error {
+		untyped = true
+		return nil
+	})
+
+	_ = d.Dispatch(context.Background(), &userSignedUp{})
+	if !typed || !untyped {
+		t.Errorf("typed = %v, untyped = %v, want both true", typed, untyped)
+	}
+}
@@ -8,4 +8,8 @@ var (
 	ErrEventNotFound    = errors.New("event not found")
 	ErrInvalidEventType = errors.New("invalid event type")
 	ErrStoreNotEnabled  = errors.New("event store is not enabled")
+
+	ErrQueueFull         = errors.New("event queue is full")
+	ErrDispatcherRunning = errors.New("queued dispatcher workers already running")
+	ErrUnregisteredEvent = errors.New("queued event type is not registered")
 )
@@ -32,6 +32,12 @@ func matchPattern(pattern, eventName string) bool {
 	return matchParts(patternParts, eventParts)
 }
 
+// MatchPattern reports whether eventName matches a subscription pattern,
+// using the same rules as EventBus.Subscribe
+func MatchPattern(pattern, eventName string) bool {
+	return matchPattern(pattern, eventName)
+}
+
 // matchParts recursively matches pattern parts against event parts
 func matchParts(pattern, event []string) bool {
 	pi, ei := 0, 0
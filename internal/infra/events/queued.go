@@ -0,0 +1,571 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// QueuedEvent is an event waiting to be processed by a QueuedDispatcher
+type QueuedEvent struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	EventName   string     `gorm:"index;size:255" json:"event_name"`
+	Payload     string     `gorm:"type:text" json:"payload"` // JSON encoded event
+	Attempts    int        `json:"attempts"`
+	AvailableAt time.Time  `gorm:"index" json:"available_at"`
+	ReservedAt  *time.Time `json:"reserved_at"`
+	FailedAt    *time.Time `gorm:"index" json:"failed_at"`
+	LastError   string     `gorm:"type:text" json:"last_error"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	// event holds the original event for in-memory stores
+	event SimpleEvent `gorm:"-"`
+}
+
+// TableName returns the table name for GORM
+func (QueuedEvent) TableName() string {
+	return "queued_events"
+}
+
+// QueueStore persists queued events between dispatch and processing
+type QueueStore interface {
+	// Push adds an event to the queue
+	Push(ctx context.Context, qe *QueuedEvent) error
+	// Reserve claims the next available event, returning nil when none is
+	// ready. Durable stores also hand out events whose reservation expired.
+	Reserve(ctx context.Context) (*QueuedEvent, error)
+	// Delete removes a successfully processed event
+	Delete(ctx context.Context, qe *QueuedEvent) error
+	// Release puts a failed event back on the queue to retry at availableAt
+	Release(ctx context.Context, qe *QueuedEvent, availableAt time.Time) error
+	// Bury moves an event that exhausted its attempts to the dead-letter set
+	Bury(ctx context.Context, qe *QueuedEvent) error
+}
+
+// ============================================================================
+// Memory Queue Store
+// ============================================================================
+
+// MemoryQueueStore is a bounded in-memory ring buffer of queued events.
+// Events are lost on restart; use GormQueueStore for durability.
+type MemoryQueueStore struct {
+	mu      sync.Mutex
+	ring    []*QueuedEvent
+	head    int
+	size    int
+	delayed []*QueuedEvent
+	dead    []*QueuedEvent
+	nextID  uint
+}
+
+// NewMemoryQueueStore creates a ring-backed store holding up to capacity events
+func NewMemoryQueueStore(capacity int) *MemoryQueueStore {
+	if capacity <= 0 {
+		capacity = 1024
+	}
+	return &MemoryQueueStore{
+		ring: make([]*QueuedEvent, capacity),
+	}
+}
+
+// Push adds an event to the ring, failing with ErrQueueFull when at capacity
+func (s *MemoryQueueStore) Push(ctx context.Context, qe *QueuedEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size+len(s.delayed) >= len(s.ring) {
+		return ErrQueueFull
+	}
+
+	s.nextID++
+	qe.ID = s.nextID
+	qe.CreatedAt = time.Now()
+	s.enqueue(qe)
+	return nil
+}
+
+// Reserve returns the next available event, promoting due retries first
+func (s *MemoryQueueStore) Reserve(ctx context.Context) (*QueuedEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	pending := s.delayed[:0]
+	for _, qe := range s.delayed {
+		if !qe.AvailableAt.After(now) && s.size < len(s.ring) {
+			s.enqueue(qe)
+		} else {
+			pending = append(pending, qe)
+		}
+	}
+	s.delayed = pending
+
+	if s.size == 0 {
+		return nil, nil
+	}
+
+	qe := s.ring[s.head]
+	s.ring[s.head] = nil
+	s.head = (s.head + 1) % len(s.ring)
+	s.size--
+
+	qe.ReservedAt = &now
+	return qe, nil
+}
+
+// Delete is a no-op since reserved events are already removed from the ring
+func (s *MemoryQueueStore) Delete(ctx context.Context, qe *QueuedEvent) error {
+	return nil
+}
+
+// Release schedules a reserved event to become available again at availableAt
+func (s *MemoryQueueStore) Release(ctx context.Context, qe *QueuedEvent, availableAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	qe.ReservedAt = nil
+	qe.AvailableAt = availableAt
+	s.delayed = append(s.delayed, qe)
+	return nil
+}
+
+// Bury records an event in the dead-letter list
+func (s *MemoryQueueStore) Bury(ctx context.Context, qe *QueuedEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	qe.ReservedAt = nil
+	qe.FailedAt = &now
+	s.dead = append(s.dead, qe)
+	return nil
+}
+
+// Len returns the number of events waiting to be processed
+func (s *MemoryQueueStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size + len(s.delayed)
+}
+
+// DeadLetters returns events that exhausted their attempts
+func (s *MemoryQueueStore) DeadLetters() []*QueuedEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]*QueuedEvent, len(s.dead))
+	copy(result, s.dead)
+	return result
+}
+
+// enqueue appends to the ring tail; callers must hold the lock and check capacity
+func (s *MemoryQueueStore) enqueue(qe *QueuedEvent) {
+	tail := (s.head + s.size) % len(s.ring)
+	s.ring[tail] = qe
+	s.size++
+}
+
+// ============================================================================
+// GORM Queue Store
+// ============================================================================
+
+// DefaultVisibilityTimeout is how long a GormQueueStore reservation lasts
+// before the event is handed to another worker
+const DefaultVisibilityTimeout = 5 * time.Minute
+
+// GormQueueStore persists queued events to the queued_events table
+type GormQueueStore struct {
+	db                *gorm.DB
+	visibilityTimeout time.Duration
+}
+
+// NewGormQueueStore creates a new GORM-based queue store
+func NewGormQueueStore(db *gorm.DB) *GormQueueStore {
+	return &GormQueueStore{db: db, visibilityTimeout: DefaultVisibilityTimeout}
+}
+
+// WithVisibilityTimeout sets how long a reservation lasts. A worker that
+// dies mid-event never releases its row, so once the reservation is older
+// than d the event is reserved again, counting the lost run as an attempt.
+// d must exceed the longest listener run, or slow events are delivered twice.
+func (s *GormQueueStore) WithVisibilityTimeout(d time.Duration) *GormQueueStore {
+	s.visibilityTimeout = d
+	return s
+}
+
+// AutoMigrate creates the queued_events table
+func (s *GormQueueStore) AutoMigrate() error {
+	return s.db.AutoMigrate(&QueuedEvent{})
+}
+
+// Push inserts an event row
+func (s *GormQueueStore) Push(ctx context.Context, qe *QueuedEvent) error {
+	return s.db.WithContext(ctx).Create(qe).Error
+}
+
+// Reserve claims the oldest available row, including rows whose
+// reservation outlived the visibility timeout. The conditional update
+// guards against two workers reserving the same row.
+func (s *GormQueueStore) Reserve(ctx context.Context) (*QueuedEvent, error) {
+	db := s.db.WithContext(ctx)
+
+	for {
+		now := time.Now()
+		stale := now.Add(-s.visibilityTimeout)
+
+		var qe QueuedEvent
+		err := db.Where("failed_at IS NULL AND available_at <= ?", now).
+			Where("reserved_at IS NULL OR reserved_at < ?", stale).
+			Order("available_at ASC, id ASC").
+			First(&qe).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		updates := map[string]any{"reserved_at": now}
+		claim := db.Model(&QueuedEvent{}).Where("id = ?", qe.ID)
+		if qe.ReservedAt == nil {
+			claim = claim.Where("reserved_at IS NULL")
+		} else {
+			// The worker holding it is gone; its run counts as an attempt
+			qe.Attempts++
+			updates["attempts"] = qe.Attempts
+			claim = claim.Where("reserved_at = ?", *qe.ReservedAt)
+		}
+
+		result := claim.Updates(updates)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			qe.ReservedAt = &now
+			return &qe, nil
+		}
+		// Another worker won the race; try the next row
+	}
+}
+
+// Delete removes a processed event row
+func (s *GormQueueStore) Delete(ctx context.Context, qe *QueuedEvent) error {
+	return s.db.WithContext(ctx).Delete(&QueuedEvent{}, qe.ID).Error
+}
+
+// Release clears the reservation and schedules the next attempt
+func (s *GormQueueStore) Release(ctx context.Context, qe *QueuedEvent, availableAt time.Time) error {
+	qe.ReservedAt = nil
+	qe.AvailableAt = availableAt
+	return s.db.WithContext(ctx).Model(&QueuedEvent{}).Where("id = ?", qe.ID).Updates(map[string]any{
+		"attempts":     qe.Attempts,
+		"last_error":   qe.LastError,
+		"available_at": availableAt,
+		"reserved_at":  nil,
+	}).Error
+}
+
+// Bury marks a row as failed so it is no longer reserved
+func (s *GormQueueStore) Bury(ctx context.Context, qe *QueuedEvent) error {
+	now := time.Now()
+	qe.ReservedAt = nil
+	qe.FailedAt = &now
+	return s.db.WithContext(ctx).Model(&QueuedEvent{}).Where("id = ?", qe.ID).Updates(map[string]any{
+		"attempts":    qe.Attempts,
+		"last_error":  qe.LastError,
+		"failed_at":   now,
+		"reserved_at": nil,
+	}).Error
+}
+
+// DeadLetters returns rows that exhausted their attempts
+func (s *GormQueueStore) DeadLetters(ctx context.Context) ([]QueuedEvent, error) {
+	var events []QueuedEvent
+	err := s.db.WithContext(ctx).Where("failed_at IS NOT NULL").Order("id ASC").Find(&events).Error
+	return events, err
+}
+
+// ============================================================================
+// Queued Dispatcher
+// ============================================================================
+
+// defaultQueueMaxBackoff caps retry delays unless WithQueueBackoff sets a cap
+const defaultQueueMaxBackoff = time.Minute
+
+// QueueOption configures a QueuedDispatcher
+type QueueOption func(*queueOptions)
+
+// queueOptions holds queued dispatcher configuration
+type queueOptions struct {
+	maxAttempts  int
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	pollInterval time.Duration
+	onDeadLetter func(qe *QueuedEvent, err error)
+}
+
+// WithQueueMaxAttempts sets how many times an event is tried before dead-lettering
+func WithQueueMaxAttempts(n int) QueueOption {
+	return func(o *queueOptions) {
+		o.maxAttempts = n
+	}
+}
+
+// WithQueueBackoff sets the base and maximum retry delay (doubled per attempt).
+// A maximum of zero or less keeps the default of one minute.
+func WithQueueBackoff(base, max time.Duration) QueueOption {
+	return func(o *queueOptions) {
+		o.backoff = base
+		o.maxBackoff = max
+	}
+}
+
+// WithQueuePollInterval sets how often idle workers poll the store
+func WithQueuePollInterval(d time.Duration) QueueOption {
+	return func(o *queueOptions) {
+		o.pollInterval = d
+	}
+}
+
+// WithDeadLetterHandler sets a callback invoked when an event is buried
+func WithDeadLetterHandler(fn func(qe *QueuedEvent, err error)) QueueOption {
+	return func(o *queueOptions) {
+		o.onDeadLetter = fn
+	}
+}
+
+// QueuedDispatcher persists events to a QueueStore and delivers them to the
+// listeners of a SimpleDispatcher from a pool of workers, retrying failures
+// with exponential backoff. Listeners may run more than once for the same
+// event when retried, so they should be idempotent.
+type QueuedDispatcher struct {
+	dispatcher *SimpleDispatcher
+	store      QueueStore
+	opts       queueOptions
+
+	mu      sync.RWMutex
+	types   map[string]reflect.Type
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	notify  chan struct{}
+	running bool
+}
+
+// NewQueuedDispatcher creates a queued dispatcher delivering to the given dispatcher
+func NewQueuedDispatcher(dispatcher *SimpleDispatcher, store QueueStore, opts ...QueueOption) *QueuedDispatcher {
+	options := queueOptions{
+		maxAttempts:  3,
+		backoff:      time.Second,
+		maxBackoff:   defaultQueueMaxBackoff,
+		pollInterval: time.Second,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &QueuedDispatcher{
+		dispatcher: dispatcher,
+		store:      store,
+		opts:       options,
+		types:      make(map[string]reflect.Type),
+		notify:     make(chan struct{}, 1),
+	}
+}
+
+// Register records an event type so persisted payloads can be decoded after a restart
+func (q *QueuedDispatcher) Register(events ...SimpleEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, e := range events {
+		q.types[e.EventName()] = reflect.TypeOf(e)
+	}
+}
+
+// DispatchQueued persists an event for background processing
+func (q *QueuedDispatcher) DispatchQueued(ctx context.Context, event SimpleEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode queued event %s: %w", event.EventName(), err)
+	}
+
+	qe := &QueuedEvent{
+		EventName:   event.EventName(),
+		Payload:     string(payload),
+		AvailableAt: time.Now(),
+		event:       event,
+	}
+	if err := q.store.Push(ctx, qe); err != nil {
+		return err
+	}
+
+	// Wake an idle worker without blocking
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// StartWorkers launches n workers that process queued events until Shutdown
+func (q *QueuedDispatcher) StartWorkers(ctx context.Context, n int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.running {
+		return ErrDispatcherRunning
+	}
+	if n <= 0 {
+		n = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	q.cancel = cancel
+	q.running = true
+
+	for i := 0; i < n; i++ {
+		q.wg.Add(1)
+		go q.work(ctx)
+	}
+	return nil
+}
+
+// Shutdown stops polling and waits for in-flight events to finish
+func (q *QueuedDispatcher) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.running {
+		q.mu.Unlock()
+		return nil
+	}
+	q.cancel()
+	q.running = false
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work is the worker loop
+func (q *QueuedDispatcher) work(ctx context.Context) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(q.opts.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain everything available before going idle
+		for ctx.Err() == nil {
+			qe, err := q.store.Reserve(ctx)
+			if err != nil || qe == nil {
+				break
+			}
+			// In-flight events finish even if shutdown is requested meanwhile
+			q.process(context.WithoutCancel(ctx), qe)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+// process delivers one event and records the outcome in the store
+func (q *QueuedDispatcher) process(ctx context.Context, qe *QueuedEvent) {
+	qe.Attempts++
+
+	err := q.deliver(ctx, qe)
+	if err == nil {
+		_ = q.store.Delete(ctx, qe)
+		return
+	}
+
+	qe.LastError = err.Error()
+	if qe.Attempts >= q.opts.maxAttempts || errors.Is(err, ErrUnregisteredEvent) {
+		_ = q.store.Bury(ctx, qe)
+		if q.opts.onDeadLetter != nil {
+			q.opts.onDeadLetter(qe, err)
+		}
+		return
+	}
+
+	_ = q.store.Release(ctx, qe, time.Now().Add(q.backoff(qe.Attempts)))
+}
+
+// deliver decodes the event if needed and dispatches it to listeners
+func (q *QueuedDispatcher) deliver(ctx context.Context, qe *QueuedEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic recovered in queued listener: %v", r)
+		}
+	}()
+
+	event := qe.event
+	if event == nil {
+		event, err = q.decode(qe)
+		if err != nil {
+			return err
+		}
+		qe.event = event
+	}
+	return q.dispatcher.Dispatch(ctx, event)
+}
+
+// decode rebuilds a registered event from its JSON payload
+func (q *QueuedDispatcher) decode(qe *QueuedEvent) (SimpleEvent, error) {
+	q.mu.RLock()
+	t, ok := q.types[qe.EventName]
+	q.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnregisteredEvent, qe.EventName)
+	}
+
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+	ptr := reflect.New(t)
+	if err := json.Unmarshal([]byte(qe.Payload), ptr.Interface()); err != nil {
+		return nil, fmt.Errorf("decode queued event %s: %w", qe.EventName, err)
+	}
+
+	if isPtr {
+		return ptr.Interface().(SimpleEvent), nil
+	}
+	return ptr.Elem().Interface().(SimpleEvent), nil
+}
+
+// backoff returns the delay before the given attempt is retried
+func (q *QueuedDispatcher) backoff(attempt int) time.Duration {
+	maxDelay := q.opts.maxBackoff
+	if maxDelay <= 0 {
+		maxDelay = defaultQueueMaxBackoff
+	}
+
+	// Double one step at a time and stop at the cap, so no attempt count
+	// can overflow the delay
+	delay := q.opts.backoff
+	for i := 1; i < attempt; i++ {
+		if delay > maxDelay/2 {
+			return maxDelay
+		}
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,258 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// orderPlaced is a JSON-serializable event for queued dispatcher tests
+type orderPlaced struct {
+	OrderID int `json:"order_id"`
+}
+
+func (e orderPlaced) EventName() string {
+	return "order.placed"
+}
+
+// waitFor polls cond until it holds or the timeout elapses
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("condition not met before timeout")
+}
+
+func newFlakyDispatcher(failures int32, calls, successes *int32) *SimpleDispatcher {
+	d := NewSimpleDispatcher()
+	d.ListenFunc("order.placed", func(ctx context.Context, event SimpleEvent) error {
+		n := atomic.AddInt32(calls, 1)
+		if n <= failures {
+			return errors.New("temporary failure")
+		}
+		atomic.AddInt32(successes, 1)
+		return nil
+	})
+	return d
+}
+
+func TestQueuedDispatcher_RetriesUntilSuccess(t *testing.T) {
+	var calls, successes int32
+	store := NewMemoryQueueStore(16)
+	q := NewQueuedDispatcher(newFlakyDispatcher(2, &calls, &successes), store,
+		WithQueueMaxAttempts(5),
+		WithQueueBackoff(time.Millisecond, 10*time.Millisecond),
+		WithQueuePollInterval(time.Millisecond),
+	)
+
+	ctx := context.Background()
+	if err := q.StartWorkers(ctx, 2); err != nil {
+		t.Fatalf("StartWorkers error: %v", err)
+	}
+	if err := q.DispatchQueued(ctx, orderPlaced{OrderID: 1}); err != nil {
+		t.Fatalf("DispatchQueued error: %v", err)
+	}
+
+	waitFor(t, time.Second, func() bool { return atomic.LoadInt32(&successes) == 1 })
+	if err := q.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("calls = %d, want 3", got)
+	}
+	if got := atomic.LoadInt32(&successes); got != 1 {
+		t.Errorf("successes = %d, want 1", got)
+	}
+	if store.Len() != 0 {
+		t.Errorf("store.Len() = %d, want 0", store.Len())
+	}
+	if len(store.DeadLetters()) != 0 {
+		t.Error("event should not be dead-lettered")
+	}
+}
+
+func TestQueuedDispatcher_DeadLetter(t *testing.T) {
+	var calls, successes int32
+	store := NewMemoryQueueStore(16)
+
+	var buried atomic.Pointer[QueuedEvent]
+	q := NewQueuedDispatcher(newFlakyDispatcher(100, &calls, &successes), store,
+		WithQueueMaxAttempts(3),
+		WithQueueBackoff(time.Millisecond, time.Millisecond),
+		WithQueuePollInterval(time.Millisecond),
+		WithDeadLetterHandler(func(qe *QueuedEvent, err error) {
+			buried.Store(qe)
+		}),
+	)
+
+	ctx := context.Background()
+	_ = q.StartWorkers(ctx, 1)
+	defer q.Shutdown(ctx)
+
+	if err := q.DispatchQueued(ctx, orderPlaced{OrderID: 2}); err != nil {
+		t.Fatalf("DispatchQueued error: %v", err)
+	}
+
+	waitFor(t, time.Second, func() bool { return buried.Load() != nil })
+
+	qe := buried.Load()
+	if qe.Attempts != 3 {
+		t.Errorf("Attempts = %d, want 3", qe.Attempts)
+	}
+	if qe.LastError != "temporary failure" {
+		t.Errorf("LastError = %q, want %q", qe.LastError, "temporary failure")
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("calls = %d, want 3", got)
+	}
+	if len(store.DeadLetters()) != 1 {
+		t.Errorf("DeadLetters() = %d, want 1", len(store.DeadLetters()))
+	}
+}
+
+func TestMemoryQueueStore_Full(t *testing.T) {
+	store := NewMemoryQueueStore(1)
+	ctx := context.Background()
+
+	if err := store.Push(ctx, &QueuedEvent{EventName: "a"}); err != nil {
+		t.Fatalf("first Push error: %v", err)
+	}
+	if err := store.Push(ctx, &QueuedEvent{EventName: "b"}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("second Push error = %v, want ErrQueueFull", err)
+	}
+}
+
+func TestQueuedDispatcher_GormStore(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	store := NewGormQueueStore(db)
+	if err := store.AutoMigrate(); err != nil {
+		t.Fatalf("AutoMigrate error: %v", err)
+	}
+
+	var calls, successes int32
+	var received atomic.Int64
+	d := newFlakyDispatcher(2, &calls, &successes)
+	d.ListenFunc("order.placed", func(ctx context.Context, event SimpleEvent) error {
+		// Persisted events are decoded back into their registered type
+		received.Store(int64(event.(orderPlaced).OrderID))
+		return nil
+	})
+
+	q := NewQueuedDispatcher(d, store,
+		WithQueueMaxAttempts(5),
+		WithQueueBackoff(time.Millisecond, 10*time.Millisecond),
+		WithQueuePollInterval(time.Millisecond),
+	)
+	q.Register(orderPlaced{})
+
+	ctx := context.Background()
+	if err := q.DispatchQueued(ctx, orderPlaced{OrderID: 42}); err != nil {
+		t.Fatalf("DispatchQueued error: %v", err)
+	}
+
+	// Simulate a restart: a fresh dispatcher only sees the persisted row
+	q = NewQueuedDispatcher(d, store,
+		WithQueueMaxAttempts(5),
+		WithQueueBackoff(time.Millisecond, 10*time.Millisecond),
+		WithQueuePollInterval(time.Millisecond),
+	)
+	q.Register(orderPlaced{})
+	_ = q.StartWorkers(ctx, 1)
+
+	waitFor(t, 2*time.Second, func() bool { return received.Load() == 42 })
+	if err := q.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&successes); got != 1 {
+		t.Errorf("successes = %d, want 1", got)
+	}
+
+	var remaining int64
+	db.Model(&QueuedEvent{}).Count(&remaining)
+	if remaining != 0 {
+		t.Errorf("remaining rows = %d, want 0", remaining)
+	}
+}
+
+func TestGormQueueStore_ReclaimsStaleReservation(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	store := NewGormQueueStore(db).WithVisibilityTimeout(50 * time.Millisecond)
+	if err := store.AutoMigrate(); err != nil {
+		t.Fatalf("AutoMigrate error: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := store.Push(ctx, &QueuedEvent{EventName: "order.placed", Payload: "{}", AvailableAt: time.Now()}); err != nil {
+		t.Fatalf("Push error: %v", err)
+	}
+
+	// The first worker dies without releasing its reservation
+	first, err := store.Reserve(ctx)
+	if err != nil || first == nil {
+		t.Fatalf("Reserve = %v, %v", first, err)
+	}
+	if qe, _ := store.Reserve(ctx); qe != nil {
+		t.Fatal("a live reservation must not be handed out again")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	second, err := store.Reserve(ctx)
+	if err != nil || second == nil {
+		t.Fatalf("Reserve after timeout = %v, %v", second, err)
+	}
+	if second.ID != first.ID || second.Attempts != 1 {
+		t.Errorf("got event %d with %d attempts, want event %d with 1", second.ID, second.Attempts, first.ID)
+	}
+	if qe, _ := store.Reserve(ctx); qe != nil {
+		t.Fatal("the reclaimed event must stay reserved")
+	}
+}
+
+func TestQueuedDispatcher_BackoffIsCapped(t *testing.T) {
+	tests := []struct {
+		name     string
+		max      time.Duration
+		attempt  int
+		expected time.Duration
+	}{
+		{"first attempt", time.Minute, 1, time.Second},
+		{"doubles", time.Minute, 3, 4 * time.Second},
+		{"capped", 10 * time.Second, 5, 10 * time.Second},
+		{"past the shift width", time.Minute, 64, time.Minute},
+		{"many attempts", time.Minute, 1000, time.Minute},
+		{"no cap uses the default", 0, 1000, defaultQueueMaxBackoff},
+		{"negative cap uses the default", -time.Second, 70, defaultQueueMaxBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewQueuedDispatcher(NewSimpleDispatcher(), NewMemoryQueueStore(1), WithQueueBackoff(time.Second, tt.max))
+			if got := q.backoff(tt.attempt); got != tt.expected {
+				t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.expected)
+			}
+		})
+	}
+}
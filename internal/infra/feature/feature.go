@@ -0,0 +1,277 @@
+// Package feature decides whether a feature flag is on for the current
+// user. Defaults come from FEATURE_FLAGS, either on, off or a percentage
+// rollout; overrides in the feature_overrides table switch a flag for one
+// user or for everyone without a deploy.
+package feature
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zgiai/zgo/internal/infra/cache"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Everyone is the user ID of overrides that apply to all users
+const Everyone uint = 0
+
+// ErrNoStore is returned when overrides are changed without a database
+var ErrNoStore = errors.New("feature: overrides need a database")
+
+// Override is one feature_overrides row, forcing a flag on or off for a
+// user, or for everyone when UserID is Everyone
+type Override struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Flag      string    `gorm:"size:100;not null;uniqueIndex:idx_feature_overrides_flag_user" json:"flag"`
+	UserID    uint      `gorm:"not null;default:0;uniqueIndex:idx_feature_overrides_flag_user" json:"user_id"`
+	Enabled   bool      `gorm:"not null" json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the database table name
+func (Override) TableName() string {
+	return "feature_overrides"
+}
+
+// State describes a flag for listing
+type State struct {
+	Name string
+	// Percentage is the configured share of users the flag is on for
+	Percentage int
+	// Override is the override for everyone, nil when there is none
+	Override *bool
+	// UserOverrides counts the users with an override of their own
+	UserOverrides int64
+}
+
+// Manager evaluates feature flags
+type Manager struct {
+	db       *gorm.DB
+	defaults map[string]int
+	cache    cache.Store
+	cacheTTL time.Duration
+}
+
+// NewManager creates a manager with the given flag defaults, each the
+// percentage of users the flag is on for. db holds the overrides and may be
+// nil, in which case only the defaults apply.
+func NewManager(db *gorm.DB, defaults map[string]int) *Manager {
+	if defaults == nil {
+		defaults = make(map[string]int)
+	}
+	return &Manager{db: db, defaults: defaults}
+}
+
+// WithCache caches override lookups in store for ttl. Changes made through
+// the manager invalidate the entries; with a per-process store, changes
+// made elsewhere show up once ttl has passed.
+func (m *Manager) WithCache(store cache.Store, ttl time.Duration) *Manager {
+	if ttl > 0 {
+		m.cache = store
+		m.cacheTTL = ttl
+	}
+	return m
+}
+
+var (
+	global   *Manager
+	globalMu sync.RWMutex
+)
+
+// Global returns the manager used by IsEnabled and Middleware. Until
+// SetGlobal is called every flag is off.
+func Global() *Manager {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	if global == nil {
+		return NewManager(nil, nil)
+	}
+	return global
+}
+
+// SetGlobal sets the manager used by IsEnabled and Middleware.
+// This should be called during application initialization.
+func SetGlobal(m *Manager) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	global = m
+}
+
+// IsEnabled reports whether flag is on for the user carried by ctx
+//
+// Example:
+//
+//	if feature.IsEnabled(ctx, "new_checkout") {
+//	    return s.newCheckout(ctx, order)
+//	}
+func IsEnabled(ctx context.Context, flag string) bool {
+	return Global().IsEnabled(ctx, flag)
+}
+
+// IsEnabled reports whether flag is on for the user carried by ctx. The
+// user's override wins, then the override for everyone, then the default.
+// A percentage rollout puts each user in a stable bucket, so a user keeps
+// the same answer while the percentage stays the same or grows; guests only
+// see flags that are on for everyone. Unknown flags are off.
+func (m *Manager) IsEnabled(ctx context.Context, flag string) bool {
+	userID, signedIn := UserFromContext(ctx)
+	if signedIn {
+		if enabled, ok := m.override(ctx, flag, userID); ok {
+			return enabled
+		}
+	}
+	if enabled, ok := m.override(ctx, flag, Everyone); ok {
+		return enabled
+	}
+
+	percentage := m.defaults[flag]
+	switch {
+	case percentage >= 100:
+		return true
+	case percentage <= 0 || !signedIn:
+		return false
+	default:
+		return bucket(flag, userID) < percentage
+	}
+}
+
+// SetOverride forces flag on or off for userID, or for everyone
+func (m *Manager) SetOverride(ctx context.Context, flag string, userID uint, enabled bool) error {
+	if m.db == nil {
+		return ErrNoStore
+	}
+	override := Override{Flag: flag, UserID: userID, Enabled: enabled}
+	err := m.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "flag"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&override).Error
+	if err != nil {
+		return err
+	}
+	m.forget(ctx, flag, userID)
+	return nil
+}
+
+// ClearOverride removes the override of flag for userID, or for everyone,
+// so the default applies again
+func (m *Manager) ClearOverride(ctx context.Context, flag string, userID uint) error {
+	if m.db == nil {
+		return ErrNoStore
+	}
+	err := m.db.WithContext(ctx).Where("flag = ? AND user_id = ?", flag, userID).Delete(&Override{}).Error
+	if err != nil {
+		return err
+	}
+	m.forget(ctx, flag, userID)
+	return nil
+}
+
+// Flags lists the configured flags and those with overrides, by name
+func (m *Manager) Flags(ctx context.Context) ([]State, error) {
+	states := make(map[string]*State, len(m.defaults))
+	for name, percentage := range m.defaults {
+		states[name] = &State{Name: name, Percentage: percentage}
+	}
+
+	if m.db != nil {
+		var overrides []Override
+		if err := m.db.WithContext(ctx).Find(&overrides).Error; err != nil {
+			return nil, err
+		}
+		for _, o := range overrides {
+			state, ok := states[o.Flag]
+			if !ok {
+				state = &State{Name: o.Flag}
+				states[o.Flag] = state
+			}
+			if o.UserID == Everyone {
+				enabled := o.Enabled
+				state.Override = &enabled
+			} else {
+				state.UserOverrides++
+			}
+		}
+	}
+
+	list := make([]State, 0, len(states))
+	for _, state := range states {
+		list = append(list, *state)
+	}
+	slices.SortFunc(list, func(a, b State) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return list, nil
+}
+
+// Cached override states; strings survive every cache store as they are
+const (
+	cachedOn   = "on"
+	cachedOff  = "off"
+	cachedNone = "none"
+)
+
+// override returns the override of flag for userID, if there is one
+func (m *Manager) override(ctx context.Context, flag string, userID uint) (enabled bool, ok bool) {
+	if m.db == nil {
+		return false, false
+	}
+
+	key := overrideCacheKey(flag, userID)
+	if m.cache != nil {
+		if val, err := m.cache.Get(ctx, key); err == nil {
+			switch val {
+			case cachedOn:
+				return true, true
+			case cachedOff:
+				return false, true
+			case cachedNone:
+				return false, false
+			}
+		}
+	}
+
+	var o Override
+	err := m.db.WithContext(ctx).Where("flag = ? AND user_id = ?", flag, userID).Take(&o).Error
+	state := cachedNone
+	switch {
+	case err == nil && o.Enabled:
+		state, enabled, ok = cachedOn, true, true
+	case err == nil:
+		state, enabled, ok = cachedOff, false, true
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		// Fall back to the default without caching the failure
+		return false, false
+	}
+
+	if m.cache != nil {
+		_ = m.cache.Put(ctx, key, state, m.cacheTTL)
+	}
+	return enabled, ok
+}
+
+func (m *Manager) forget(ctx context.Context, flag string, userID uint) {
+	if m.cache != nil {
+		_ = m.cache.Forget(ctx, overrideCacheKey(flag, userID))
+	}
+}
+
+func overrideCacheKey(flag string, userID uint) string {
+	return fmt.Sprintf("feature:%s:%d", flag, userID)
+}
+
+// bucket places a user in 0-99 for flag. Hashing the flag name with the ID
+// keeps rollouts of different flags from hitting the same users.
+func bucket(flag string, userID uint) int {
+	h := fnv.New32a()
+	h.Write([]byte(flag + ":" + strconv.FormatUint(uint64(userID), 10)))
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,130 @@
+package feature
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/cache"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"gorm.io/gorm"
+)
+
+func setupFeatureDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	return dbtest.Open(t, &Override{})
+}
+
+func TestIsEnabled_GloballyOn(t *testing.T) {
+	m := NewManager(nil, map[string]int{"new_checkout": 100, "legacy_export": 0})
+	ctx := context.Background()
+
+	assert.True(t, m.IsEnabled(ctx, "new_checkout"), "guests see flags that are on for everyone")
+	assert.True(t, m.IsEnabled(WithUser(ctx, 42), "new_checkout"))
+	assert.False(t, m.IsEnabled(WithUser(ctx, 42), "legacy_export"))
+	assert.False(t, m.IsEnabled(WithUser(ctx, 42), "unknown"))
+}
+
+func TestIsEnabled_PercentageRolloutIsStablePerUser(t *testing.T) {
+	m := NewManager(nil, map[string]int{"beta_search": 25})
+	ctx := context.Background()
+
+	enabled := 0
+	for id := uint(1); id <= 1000; id++ {
+		first := m.IsEnabled(WithUser(ctx, id), "beta_search")
+		for range 3 {
+			require.Equal(t, first, m.IsEnabled(WithUser(ctx, id), "beta_search"), "user %d", id)
+		}
+		if first {
+			enabled++
+		}
+	}
+	assert.InDelta(t, 250, enabled, 60)
+
+	// Growing the rollout keeps everyone who already had the flag
+	wider := NewManager(nil, map[string]int{"beta_search": 50})
+	for id := uint(1); id <= 1000; id++ {
+		if m.IsEnabled(WithUser(ctx, id), "beta_search") {
+			assert.True(t, wider.IsEnabled(WithUser(ctx, id), "beta_search"), "user %d", id)
+		}
+	}
+
+	assert.False(t, m.IsEnabled(ctx, "beta_search"), "guests are outside partial rollouts")
+}
+
+func TestIsEnabled_Overrides(t *testing.T) {
+	m := NewManager(setupFeatureDB(t), map[string]int{"new_checkout": 0}).
+		WithCache(cache.NewMemoryStore(), time.Minute)
+	ctx := context.Background()
+	alice, bob := WithUser(ctx, 1), WithUser(ctx, 2)
+
+	assert.False(t, m.IsEnabled(alice, "new_checkout"))
+
+	require.NoError(t, m.SetOverride(ctx, "new_checkout", 1, true))
+	assert.True(t, m.IsEnabled(alice, "new_checkout"))
+	assert.False(t, m.IsEnabled(bob, "new_checkout"))
+
+	// The override for everyone yields to a user's own
+	require.NoError(t, m.SetOverride(ctx, "new_checkout", Everyone, true))
+	require.NoError(t, m.SetOverride(ctx, "new_checkout", 1, false))
+	assert.False(t, m.IsEnabled(alice, "new_checkout"))
+	assert.True(t, m.IsEnabled(bob, "new_checkout"))
+	assert.True(t, m.IsEnabled(ctx, "new_checkout"))
+
+	require.NoError(t, m.ClearOverride(ctx, "new_checkout", Everyone))
+	assert.False(t, m.IsEnabled(bob, "new_checkout"))
+
+	states, err := m.Flags(ctx)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, "new_checkout", states[0].Name)
+	assert.Nil(t, states[0].Override)
+	assert.Equal(t, int64(1), states[0].UserOverrides)
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := NewManager(setupFeatureDB(t), map[string]int{"new_checkout": 0})
+	require.NoError(t, m.SetOverride(context.Background(), "new_checkout", 7, true))
+	SetGlobal(m)
+	t.Cleanup(func() { SetGlobal(nil) })
+
+	r := gin.New()
+	r.GET("/checkout", func(c *gin.Context) {
+		if id, err := strconv.ParseUint(c.GetHeader("X-User"), 10, 64); err == nil {
+			c.Set("userID", uint(id))
+		}
+	}, Middleware("new_checkout"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for user, want := range map[string]int{"7": http.StatusOK, "8": http.StatusNotFound, "": http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodGet, "/checkout", nil)
+		req.Header.Set("X-User", user)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, want, w.Code, "user %q", user)
+	}
+}
+
+func TestIsEnabled_FallsBackToRequestUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := NewManager(setupFeatureDB(t), map[string]int{"new_checkout": 0})
+	require.NoError(t, m.SetOverride(context.Background(), "new_checkout", 7, true))
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.False(t, m.IsEnabled(c, "new_checkout"), "guests get the default")
+
+	c.Set("userID", uint(7))
+	assert.True(t, m.IsEnabled(c, "new_checkout"))
+
+	c.Set("userID", uint(8))
+	assert.False(t, m.IsEnabled(c, "new_checkout"))
+}
@@ -0,0 +1,53 @@
+package feature
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// userKey is the context key for the user flags are evaluated for
+type userKey struct{}
+
+// WithUser returns a copy of ctx evaluating flags for userID
+func WithUser(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, userKey{}, userID)
+}
+
+// UserFromContext returns the user carried by ctx, if any. Without one set
+// by WithUser it falls back to the "userID" JWTAuth stores on the request,
+// so handlers can pass their *gin.Context straight to IsEnabled.
+func UserFromContext(ctx context.Context) (uint, bool) {
+	if userID, ok := ctx.Value(userKey{}).(uint); ok {
+		return userID, true
+	}
+	userID, ok := ctx.Value("userID").(uint)
+	return userID, ok
+}
+
+// Middleware responds 404 unless flag is on, so a route behind a disabled
+// flag looks like it does not exist. It evaluates the flag for the user set
+// by JWTAuth, and for guests on routes without it.
+//
+// Example:
+//
+//	api.GET("/checkout/v2", feature.Middleware("new_checkout"), h.CheckoutV2)
+func Middleware(flag string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if userID, ok := c.Get("userID"); ok {
+			if id, ok := userID.(uint); ok {
+				ctx = WithUser(ctx, id)
+				c.Request = c.Request.WithContext(ctx)
+			}
+		}
+
+		if !IsEnabled(ctx, flag) {
+			response.NotFound(c, "Not found")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,23 @@
+package health
+
+import (
+	"context"
+
+	"github.com/zgiai/zgo/internal/infra/email"
+)
+
+// Email creates a checker that validates the email transport without
+// sending a message: for Resend it authenticates against the API, so a
+// revoked or mistyped key shows up before the first real send fails.
+// Transports with nothing to check, like the log and dry-run ones, are up.
+func Email(svc *email.Service) Checker {
+	return func(ctx context.Context) CheckResult {
+		if err := svc.Ping(ctx); err != nil {
+			return CheckResult{
+				Status:  StatusDown,
+				Message: err.Error(),
+			}
+		}
+		return CheckResult{Status: StatusUp}
+	}
+}
@@ -37,6 +37,7 @@ type Checker func(ctx context.Context) CheckResult
 type Health struct {
 	mu       sync.RWMutex
 	checkers map[string]Checker
+	stopped  bool
 }
 
 // New creates a new Health instance
@@ -60,6 +61,15 @@ func (h *Health) Unregister(name string) {
 	delete(h.checkers, name)
 }
 
+// Stop makes every later Check report a single "shutdown" result that is
+// down, without running the checkers: readiness probes fail while the
+// application shuts down and no check touches a database being closed.
+func (h *Health) Stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stopped = true
+}
+
 // Check runs all health checks
 func (h *Health) Check(ctx context.Context) map[string]CheckResult {
 	h.mu.RLock()
@@ -67,8 +77,15 @@ func (h *Health) Check(ctx context.Context) map[string]CheckResult {
 	for k, v := range h.checkers {
 		checkers[k] = v
 	}
+	stopped := h.stopped
 	h.mu.RUnlock()
 
+	if stopped {
+		return map[string]CheckResult{
+			"shutdown": {Status: StatusDown, Message: "shutting down", Timestamp: time.Now()},
+		}
+	}
+
 	results := make(map[string]CheckResult, len(checkers))
 	var wg sync.WaitGroup
 	var mu sync.Mutex
@@ -194,6 +211,18 @@ func Custom(check func(ctx context.Context) error) Checker {
 	}
 }
 
+// NonCritical reports a failing checker as degraded rather than down, so an
+// optional dependency never fails the readiness probe
+func NonCritical(checker Checker) Checker {
+	return func(ctx context.Context) CheckResult {
+		result := checker(ctx)
+		if result.Status == StatusDown {
+			result.Status = StatusDegraded
+		}
+		return result
+	}
+}
+
 // Timeout wraps a checker with a timeout
 func Timeout(checker Checker, timeout time.Duration) Checker {
 	return func(ctx context.Context) CheckResult {
@@ -354,11 +383,33 @@ func (h *Health) ReadinessHandler() gin.HandlerFunc {
 	}
 }
 
+// RouteOption configures RegisterRoutes
+type RouteOption func(*routeOptions)
+
+type routeOptions struct {
+	prometheus bool
+}
+
+// WithPrometheus also registers PrometheusHandler at /metrics/health when enabled
+func WithPrometheus(enabled bool) RouteOption {
+	return func(o *routeOptions) {
+		o.prometheus = enabled
+	}
+}
+
 // RegisterRoutes registers health check routes on the engine
-func (h *Health) RegisterRoutes(r *gin.Engine) {
+func (h *Health) RegisterRoutes(r *gin.Engine, opts ...RouteOption) {
+	var o routeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	r.GET("/health", h.Handler())
 	r.GET("/health/live", LivenessHandler())
 	r.GET("/health/ready", h.ReadinessHandler())
+	if o.prometheus {
+		r.GET("/metrics/health", h.PrometheusHandler())
+	}
 }
 
 // --- Global Instance ---
@@ -415,6 +466,6 @@ func LivenessHandler() gin.HandlerFunc {
 }
 
 // RegisterRoutes registers health check routes using the global instance
-func RegisterRoutes(r *gin.Engine) {
-	globalHealth.RegisterRoutes(r)
+func RegisterRoutes(r *gin.Engine, opts ...RouteOption) {
+	globalHealth.RegisterRoutes(r, opts...)
 }
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	checkUpDesc = prometheus.NewDesc(
+		"health_check_up",
+		"Whether a health check passed (1) or failed (0)",
+		[]string{"name"}, nil,
+	)
+	checkDurationDesc = prometheus.NewDesc(
+		"health_check_duration_seconds",
+		"Duration of the last run of a health check",
+		[]string{"name"}, nil,
+	)
+	upDesc = prometheus.NewDesc(
+		"health_up",
+		"Whether all health checks passed (1) or any failed (0)",
+		nil, nil,
+	)
+)
+
+// collector runs the health checks on every scrape.
+// It holds no state between scrapes, so concurrent scrapes are safe.
+type collector struct {
+	health  *Health
+	timeout time.Duration
+}
+
+// Describe implements prometheus.Collector
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- checkUpDesc
+	ch <- checkDurationDesc
+	ch <- upDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	overall := 1.0
+	for name, result := range c.health.Check(ctx) {
+		up := 1.0
+		if result.Status == StatusDown {
+			up = 0
+			overall = 0
+		}
+		ch <- prometheus.MustNewConstMetric(checkUpDesc, prometheus.GaugeValue, up, name)
+		ch <- prometheus.MustNewConstMetric(checkDurationDesc, prometheus.GaugeValue, result.Duration.Seconds(), name)
+	}
+	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, overall)
+}
+
+// PrometheusHandler returns a Gin handler exposing check results as gauges:
+//
+//	health_check_up{name="redis"} 1
+//	health_check_duration_seconds{name="redis"} 0.0012
+//	health_up 1
+//
+// Degraded checks count as up.
+func (h *Health) PrometheusHandler() gin.HandlerFunc {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&collector{health: h, timeout: 5 * time.Second})
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+
+	return func(c *gin.Context) {
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// PrometheusHandler returns a Prometheus handler using the global health instance
+func PrometheusHandler() gin.HandlerFunc {
+	return globalHealth.PrometheusHandler()
+}
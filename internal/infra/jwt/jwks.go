@@ -0,0 +1,141 @@
+package jwt
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/zgiai/zgo/internal/infra/config"
+)
+
+// JWK is an RSA public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS is a JSON Web Key Set, as served from /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// AddSigningKey registers an RSA key under kid and signs new tokens with it.
+// The previous signing key stays registered, so tokens it issued keep
+// verifying while they expire - that is how keys are rotated.
+func (s *Service) AddSigningKey(kid string, key *rsa.PrivateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addKey(kid, &key.PublicKey)
+	s.signingKey = key
+	s.signingKID = kid
+}
+
+// AddVerificationKey registers an RSA public key under kid for verifying tokens only
+func (s *Service) AddVerificationKey(kid string, key *rsa.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addKey(kid, key)
+}
+
+func (s *Service) addKey(kid string, key *rsa.PublicKey) {
+	if s.keys == nil {
+		s.keys = make(map[string]*rsa.PublicKey)
+	}
+	s.keys[kid] = key
+}
+
+// JWKS returns the registered RSA public keys, ordered by kid.
+// It is empty while the service signs with HS256.
+func (s *Service) JWKS() JWKS {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := JWKS{Keys: make([]JWK, 0, len(s.keys))}
+	for kid, key := range s.keys {
+		set.Keys = append(set.Keys, JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			Kid: kid,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].Kid < set.Keys[j].Kid
+	})
+	return set
+}
+
+// JWKSHandler returns a Gin handler serving the JWKS document
+//
+// Example:
+//
+//	r.GET("/.well-known/jwks.json", jwtService.JWKSHandler())
+func (s *Service) JWKSHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, s.JWKS())
+	}
+}
+
+// Thumbprint returns the RFC 7638 thumbprint of key, used as its kid when
+// none is configured so the same key always gets the same kid
+func Thumbprint(key *rsa.PublicKey) string {
+	// Members in lexicographic order, no whitespace, as the RFC requires
+	canonical := fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`,
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+	)
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// loadKeys reads the signing key and extra verification keys from PEM files
+func (s *Service) loadKeys(cfg config.JWTConfig) error {
+	data, err := os.ReadFile(cfg.PrivateKeyFile)
+	if err != nil {
+		return fmt.Errorf("read JWT private key: %w", err)
+	}
+	private, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+	if err != nil {
+		return fmt.Errorf("parse JWT private key: %w", err)
+	}
+	kid := cfg.KeyID
+	if kid == "" {
+		kid = Thumbprint(&private.PublicKey)
+	}
+
+	for _, file := range cfg.PublicKeyFiles {
+		data, err := os.ReadFile(file.Path)
+		if err != nil {
+			return fmt.Errorf("read JWT public key: %w", err)
+		}
+		public, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return fmt.Errorf("parse JWT public key %s: %w", file.Path, err)
+		}
+		publicKID := file.ID
+		if publicKID == "" {
+			publicKID = Thumbprint(public)
+		}
+		s.AddVerificationKey(publicKID, public)
+	}
+
+	// Added last so a rotated-out key listed under the same kid cannot replace it
+	s.AddSigningKey(kid, private)
+	return nil
+}
@@ -0,0 +1,157 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/config"
+)
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func TestJWKS(t *testing.T) {
+	old, current := generateKey(t), generateKey(t)
+	svc := NewTestService()
+	assert.Empty(t, svc.JWKS().Keys)
+
+	svc.AddSigningKey("2024-01", old)
+	svc.AddSigningKey("2024-06", current)
+
+	data, err := json.Marshal(svc.JWKS())
+	require.NoError(t, err)
+
+	var doc struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Len(t, doc.Keys, 2)
+	assert.Equal(t, "2024-01", doc.Keys[0]["kid"])
+	assert.Equal(t, "2024-06", doc.Keys[1]["kid"])
+
+	jwk := doc.Keys[1]
+	assert.Equal(t, "RSA", jwk["kty"])
+	assert.Equal(t, "sig", jwk["use"])
+	assert.Equal(t, "RS256", jwk["alg"])
+	assert.Equal(t, "AQAB", jwk["e"])
+
+	// The modulus round-trips to the registered public key
+	n, err := base64.RawURLEncoding.DecodeString(jwk["n"])
+	require.NoError(t, err)
+	assert.Equal(t, 0, new(big.Int).SetBytes(n).Cmp(current.N))
+}
+
+func TestRS256KeyRotation(t *testing.T) {
+	old, current := generateKey(t), generateKey(t)
+	svc := NewTestService()
+
+	svc.AddSigningKey("old", old)
+	oldToken, err := svc.GenerateToken(1, "alice")
+	require.NoError(t, err)
+
+	svc.AddSigningKey("current", current)
+	token, err := svc.GenerateToken(2, "bob")
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", parsed.Method.Alg())
+	assert.Equal(t, "current", parsed.Header["kid"])
+
+	// Tokens from both keys verify against the key named by their kid
+	claims, err := svc.ParseToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), claims.UserID)
+
+	claims, err = svc.ParseToken(oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), claims.UserID)
+
+	// A verifier that only knows the public keys accepts them too
+	verifier := NewTestService()
+	verifier.AddVerificationKey("old", &old.PublicKey)
+	verifier.AddVerificationKey("current", &current.PublicKey)
+	_, err = verifier.ParseToken(token)
+	assert.NoError(t, err)
+	_, err = verifier.GenerateToken(3, "carol")
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+}
+
+func TestRS256RejectsUnknownKeys(t *testing.T) {
+	svc := NewTestService()
+	svc.AddSigningKey("current", generateKey(t))
+
+	other := NewTestService()
+	other.AddSigningKey("other", generateKey(t))
+	token, err := other.GenerateToken(1, "alice")
+	require.NoError(t, err)
+	_, err = svc.ParseToken(token)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	// Once RSA keys are registered, HS256 tokens are no longer accepted
+	hmac := NewTestService()
+	token, err = hmac.GenerateToken(1, "alice")
+	require.NoError(t, err)
+	_, err = svc.ParseToken(token)
+	assert.Error(t, err)
+}
+
+func TestNewServiceLoadsRSAKeys(t *testing.T) {
+	dir := t.TempDir()
+	current, old := generateKey(t), generateKey(t)
+
+	privatePath := filepath.Join(dir, "current.pem")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(current),
+	}), 0o600))
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&old.PublicKey)
+	require.NoError(t, err)
+	publicPath := filepath.Join(dir, "old.pub")
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicDER,
+	}), 0o600))
+
+	svc, err := NewService(&config.Config{JWT: config.JWTConfig{
+		Expire:         time.Hour,
+		Algorithm:      config.JWTAlgorithmRS256,
+		PrivateKeyFile: privatePath,
+		PublicKeyFiles: []config.JWTKeyFile{{ID: "old", Path: publicPath}},
+	}})
+	require.NoError(t, err)
+
+	var kids []string
+	for _, key := range svc.JWKS().Keys {
+		kids = append(kids, key.Kid)
+	}
+	assert.ElementsMatch(t, []string{"old", Thumbprint(&current.PublicKey)}, kids)
+
+	token, err := svc.GenerateToken(1, "alice")
+	require.NoError(t, err)
+	_, err = svc.ParseToken(token)
+	assert.NoError(t, err)
+
+	_, err = NewService(&config.Config{JWT: config.JWTConfig{
+		Algorithm:      config.JWTAlgorithmRS256,
+		PrivateKeyFile: filepath.Join(dir, "missing.pem"),
+	}})
+	assert.Error(t, err)
+}
@@ -1,27 +1,52 @@
 package jwt
 
 import (
+	"crypto/rsa"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/kv"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // Service provides JWT helpers bound to a configuration instance.
 // Injected via Wire DI - no global state.
+//
+// Tokens are signed with HS256 using the shared secret until an RSA key is
+// added, after which they are signed with RS256 and carry the key's kid.
 type Service struct {
 	secret string
 	expire time.Duration
+
+	mu sync.RWMutex
+	// keys holds the RS256 verification keys by kid
+	keys map[string]*rsa.PublicKey
+	// signingKey signs new tokens under signingKID
+	signingKey *rsa.PrivateKey
+	signingKID string
+
+	// revocations marks users whose tokens are revoked; nil uses kv.Default
+	revocations kv.Store
 }
 
 // NewService constructs a JWT service using the provided configuration.
+// With JWT_ALGORITHM=RS256 it loads the signing key and any extra
+// verification keys from their PEM files.
 // This is the Wire provider function.
-func NewService(cfg *config.Config) *Service {
-	return &Service{
+func NewService(cfg *config.Config) (*Service, error) {
+	s := &Service{
 		secret: cfg.JWT.Secret,
 		expire: cfg.JWT.ExpireDuration(),
 	}
+	if cfg.JWT.Algorithm == config.JWTAlgorithmRS256 {
+		if err := s.loadKeys(cfg.JWT); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
 }
 
 // NewTestService creates a JWT service for testing with default values.
@@ -32,19 +57,62 @@ func NewTestService() *Service {
 	}
 }
 
-// Claims represents custom JWT claims
+const (
+	// PurposeEmailVerification marks tokens sent in email verification links
+	PurposeEmailVerification = "email_verification"
+
+	// PurposeTwoFactor marks the challenge tokens Login returns to users with
+	// two-factor authentication, exchanged for an access token with a code
+	PurposeTwoFactor = "two_factor"
+)
+
+var (
+	// ErrTokenExpired is returned for well-formed tokens past their expiry
+	ErrTokenExpired = jwt.ErrTokenExpired
+
+	// ErrWrongPurpose is returned when a token is used for something it was not issued for
+	ErrWrongPurpose = errors.New("token purpose mismatch")
+
+	// ErrUnknownKey is returned for RS256 tokens whose kid matches no registered key
+	ErrUnknownKey = errors.New("unknown signing key")
+
+	// ErrNoSigningKey is returned when signing with only verification keys registered
+	ErrNoSigningKey = errors.New("no signing key configured")
+)
+
+// Claims represents custom JWT claims.
+// Purpose is empty for access tokens and set for single-use tokens such as
+// verification links, so one can never stand in for the other.
+// TenantID binds the token to one tenant when the application is
+// multi-tenant.
 type Claims struct {
 	UserID   uint   `json:"user_id"`
 	Username string `json:"username"`
+	Purpose  string `json:"purpose,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IssuedTime returns the iat claim, or the zero time for tokens without one
+func (c *Claims) IssuedTime() time.Time {
+	if c.IssuedAt == nil {
+		return time.Time{}
+	}
+	return c.IssuedAt.Time
+}
+
 // GenerateToken generates a JWT token
 func (s *Service) GenerateToken(userID uint, username string) (string, error) {
+	return s.GenerateTenantToken(userID, username, "")
+}
+
+// GenerateTenantToken generates a JWT token that is only valid for tenant
+func (s *Service) GenerateTenantToken(userID uint, username, tenant string) (string, error) {
 	now := time.Now()
 	claims := Claims{
 		UserID:   userID,
 		Username: username,
+		TenantID: tenant,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.expire)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -52,18 +120,95 @@ func (s *Service) GenerateToken(userID uint, username string) (string, error) {
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.secret))
+	return s.sign(claims)
+}
+
+// GeneratePurposeToken generates a token for a single purpose, bound to subject
+// (e.g. the email address being verified) and valid for ttl
+func (s *Service) GeneratePurposeToken(userID uint, subject, purpose string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID:  userID,
+		Purpose: purpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+
+	return s.sign(claims)
 }
 
-// ParseToken parses and validates a JWT token
+// ParsePurposeToken parses a token issued by GeneratePurposeToken for purpose
+func (s *Service) ParsePurposeToken(tokenString, purpose string) (*Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != purpose {
+		return nil, ErrWrongPurpose
+	}
+	return claims, nil
+}
+
+// ParseToken parses and validates an access token
 func (s *Service) ParseToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, ErrWrongPurpose
+	}
+	return claims, nil
+}
+
+// sign signs claims with the active RSA key, or the shared secret when there is none
+func (s *Service) sign(claims Claims) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.keys) == 0 {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(s.secret))
+	}
+	if s.signingKey == nil {
+		return "", ErrNoSigningKey
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.signingKID
+	return token.SignedString(s.signingKey)
+}
+
+// verificationKey picks the key for a token: the shared secret for HS256,
+// or the RSA key named by the token's kid once RSA keys are registered
+func (s *Service) verificationKey(token *jwt.Token) (interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.keys) == 0 {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return []byte(s.secret), nil
-	})
+	}
+
+	if token.Method != jwt.SigningMethodRS256 {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+	return key, nil
+}
+
+// parse verifies the signature and expiry of a token
+func (s *Service) parse(tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.verificationKey)
 
 	if err != nil {
 		return nil, err
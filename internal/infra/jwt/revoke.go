@@ -0,0 +1,63 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zgiai/zgo/internal/infra/kv"
+	"github.com/zgiai/zgo/pkg/logger"
+)
+
+// revokedUserKey is the kv key marking the tokens of a user as revoked
+func revokedUserKey(userID uint) string {
+	return fmt.Sprintf("jwt:revoked:user:%d", userID)
+}
+
+func (s *Service) revocationStore() kv.Store {
+	if s.revocations != nil {
+		return s.revocations
+	}
+	return kv.Default()
+}
+
+// revokedAll marks every token of a user as revoked, whenever issued
+var revokedAll = []byte("all")
+
+// RevokeUserTokens rejects every access token of userID, issued before or
+// after, until RestoreUserTokens. Use it when an account is suspended. The
+// mark expires with the longest-lived token, since by then every token
+// handed out before the suspension has expired too.
+func (s *Service) RevokeUserTokens(ctx context.Context, userID uint) error {
+	return s.revocationStore().Set(ctx, revokedUserKey(userID), revokedAll, s.expire)
+}
+
+// RestoreUserTokens accepts tokens of userID issued from now on. Tokens
+// issued before stay revoked until they expire, so re-enabling an account
+// does not bring back the tokens it had when it was suspended.
+func (s *Service) RestoreUserTokens(ctx context.Context, userID uint) error {
+	// Issue times are whole seconds; a token issued later this second is new
+	cutoff := time.Now().Truncate(time.Second).Unix()
+	return s.revocationStore().Set(ctx, revokedUserKey(userID), []byte(strconv.FormatInt(cutoff, 10)), s.expire)
+}
+
+// UserTokensRevoked reports whether a token of userID issued at issuedAt
+// was revoked. Tokens are accepted when the store cannot be reached, so an
+// outage does not sign every user out.
+func (s *Service) UserTokensRevoked(ctx context.Context, userID uint, issuedAt time.Time) bool {
+	mark, err := s.revocationStore().Get(ctx, revokedUserKey(userID))
+	if err != nil {
+		if !errors.Is(err, kv.ErrNotFound) {
+			logger.Warn("Failed to check token revocation", map[string]any{"user_id": userID, "error": err})
+		}
+		return false
+	}
+
+	cutoff, err := strconv.ParseInt(string(mark), 10, 64)
+	if err != nil {
+		return true // Revoked outright
+	}
+	return issuedAt.Unix() < cutoff
+}
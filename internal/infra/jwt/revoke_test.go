@@ -0,0 +1,49 @@
+package jwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/kv"
+)
+
+func TestRevokeUserTokens(t *testing.T) {
+	svc := NewTestService()
+	svc.revocations = kv.NewMemoryStore()
+	ctx := context.Background()
+	issued := time.Now().Add(-time.Minute)
+
+	assert.False(t, svc.UserTokensRevoked(ctx, 1, issued))
+
+	require.NoError(t, svc.RevokeUserTokens(ctx, 1))
+	assert.True(t, svc.UserTokensRevoked(ctx, 1, issued))
+	assert.True(t, svc.UserTokensRevoked(ctx, 1, time.Now().Add(time.Minute)), "tokens issued while suspended are revoked too")
+	assert.False(t, svc.UserTokensRevoked(ctx, 2, issued), "other users are unaffected")
+}
+
+func TestRestoreUserTokens_KeepsOldTokensRevoked(t *testing.T) {
+	svc := NewTestService()
+	svc.revocations = kv.NewMemoryStore()
+	ctx := context.Background()
+	issued := time.Now().Add(-time.Minute)
+
+	require.NoError(t, svc.RevokeUserTokens(ctx, 1))
+	require.NoError(t, svc.RestoreUserTokens(ctx, 1))
+
+	assert.True(t, svc.UserTokensRevoked(ctx, 1, issued), "tokens from before the suspension stay revoked")
+	assert.True(t, svc.UserTokensRevoked(ctx, 1, time.Time{}), "tokens without an issue time are not trusted")
+	assert.False(t, svc.UserTokensRevoked(ctx, 1, time.Now()), "tokens issued after re-enabling are accepted")
+}
+
+func TestRevokeUserTokens_ExpiresWithTokens(t *testing.T) {
+	svc := NewTestService()
+	svc.expire = 50 * time.Millisecond
+	svc.revocations = kv.NewMemoryStore()
+	ctx := context.Background()
+
+	require.NoError(t, svc.RevokeUserTokens(ctx, 1))
+	assert.Eventually(t, func() bool { return !svc.UserTokensRevoked(ctx, 1, time.Time{}) }, time.Second, 10*time.Millisecond)
+}
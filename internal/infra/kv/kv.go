@@ -0,0 +1,68 @@
+// Package kv is the small key-value store shared by rate limiting,
+// idempotency and similar features that keep counters and short-lived
+// markers. Backing them all with one Store lets a single Redis connection
+// share their state across instances.
+package kv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned by Get when a key is missing or expired
+var ErrNotFound = errors.New("kv: key not found")
+
+// Store is a key-value store with per-key expiry. Every method is safe for
+// concurrent use, and Incr is atomic across all clients of the store.
+type Store interface {
+	// Get returns the value of key, or ErrNotFound
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores value under key for ttl. A ttl of 0 keeps it until deleted.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Incr adds 1 to the counter under key and returns the new value. A key
+	// that does not exist yet starts from 0 and expires after ttl; an
+	// existing key keeps its expiry.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// Expire makes an existing key expire after ttl, or never for a ttl of 0.
+	// Missing keys are left alone.
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+}
+
+var (
+	defaultMu    sync.RWMutex
+	defaultStore Store
+)
+
+// Default returns the application's store, an in-memory one until
+// SetDefault is called
+func Default() Store {
+	defaultMu.RLock()
+	store := defaultStore
+	defaultMu.RUnlock()
+	if store != nil {
+		return store
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultStore == nil {
+		defaultStore = NewMemoryStore()
+	}
+	return defaultStore
+}
+
+// SetDefault sets the store returned by Default.
+// This should be called during application initialization.
+func SetDefault(store Store) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultStore = store
+}
@@ -0,0 +1,142 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// backends returns every Store to run the shared tests against. The Redis
+// store is included when REDIS_TEST_ADDR points at a server.
+func backends(t *testing.T) map[string]Store {
+	t.Helper()
+	stores := map[string]Store{"memory": NewMemoryStore()}
+
+	addr := os.Getenv("REDIS_TEST_ADDR")
+	if addr == "" {
+		t.Log("REDIS_TEST_ADDR not set, skipping the Redis store")
+		return stores
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+	require.NoError(t, client.Ping(context.Background()).Err(), "REDIS_TEST_ADDR")
+
+	stores["redis"] = NewRedisStore(client, WithPrefix(fmt.Sprintf("kvtest:%d:", time.Now().UnixNano())))
+	return stores
+}
+
+func TestStore_SetGetDelete(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			_, err := store.Get(ctx, "missing")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			require.NoError(t, store.Set(ctx, "greeting", []byte("hello"), 0))
+			value, err := store.Get(ctx, "greeting")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("hello"), value)
+
+			require.NoError(t, store.Delete(ctx, "greeting"))
+			require.NoError(t, store.Delete(ctx, "greeting"), "deleting twice is fine")
+			_, err = store.Get(ctx, "greeting")
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestStore_IncrIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	const workers, perWorker = 20, 50
+
+	for name, store := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			seen := make([]int64, 0, workers*perWorker)
+			var mu sync.Mutex
+			for range workers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range perWorker {
+						n, err := store.Incr(ctx, "hits", time.Minute)
+						assert.NoError(t, err)
+						mu.Lock()
+						seen = append(seen, n)
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			// Every increment got a distinct value, ending at the total
+			unique := make(map[int64]bool, len(seen))
+			for _, n := range seen {
+				unique[n] = true
+			}
+			assert.Len(t, unique, workers*perWorker)
+
+			value, err := store.Get(ctx, "hits")
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprint(workers*perWorker), string(value))
+		})
+	}
+}
+
+func TestStore_TTLExpiry(t *testing.T) {
+	ctx := context.Background()
+	const ttl = 100 * time.Millisecond
+
+	for name, store := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, store.Set(ctx, "session", []byte("x"), ttl))
+			n, err := store.Incr(ctx, "counter", ttl)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), n)
+
+			// A later Incr keeps the expiry of the first
+			time.Sleep(ttl / 2)
+			n, err = store.Incr(ctx, "counter", time.Hour)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), n)
+
+			// Expire extends a key; a ttl of 0 removes its expiry
+			require.NoError(t, store.Set(ctx, "extended", []byte("x"), ttl))
+			require.NoError(t, store.Expire(ctx, "extended", time.Hour))
+			require.NoError(t, store.Set(ctx, "kept", []byte("x"), ttl))
+			require.NoError(t, store.Expire(ctx, "kept", 0))
+
+			time.Sleep(ttl)
+
+			_, err = store.Get(ctx, "session")
+			assert.ErrorIs(t, err, ErrNotFound)
+			n, err = store.Incr(ctx, "counter", ttl)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), n, "an expired counter starts over")
+
+			_, err = store.Get(ctx, "extended")
+			assert.NoError(t, err)
+			_, err = store.Get(ctx, "kept")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	t.Cleanup(func() { SetDefault(nil) })
+
+	SetDefault(nil)
+	assert.IsType(t, &MemoryStore{}, Default())
+	assert.Same(t, Default(), Default())
+
+	custom := NewMemoryStore()
+	SetDefault(custom)
+	assert.Same(t, custom, Default())
+}
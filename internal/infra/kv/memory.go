@@ -0,0 +1,137 @@
+package kv
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often MemoryStore drops expired keys on write
+const sweepInterval = time.Minute
+
+// MemoryStore is an in-process Store. State is lost on restart and not
+// shared between instances.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time // zero means never
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries:   make(map[string]memoryEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Get returns the value of key
+func (s *MemoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.lookup(key, time.Now())
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), e.value...), nil
+}
+
+// Set stores value under key for ttl
+func (s *MemoryStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	s.entries[key] = memoryEntry{value: append([]byte(nil), value...), expiresAt: expiry(now, ttl)}
+	return nil
+}
+
+// Incr adds 1 to the counter under key
+func (s *MemoryStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	e, ok := s.lookup(key, now)
+	if !ok {
+		e = memoryEntry{value: []byte("0"), expiresAt: expiry(now, ttl)}
+	}
+	n, err := strconv.ParseInt(string(e.value), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	n++
+	e.value = strconv.AppendInt(nil, n, 10)
+	s.entries[key] = e
+	return n, nil
+}
+
+// Delete removes key
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// Expire makes an existing key expire after ttl
+func (s *MemoryStore) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.lookup(key, now)
+	if !ok {
+		return nil
+	}
+	e.expiresAt = expiry(now, ttl)
+	s.entries[key] = e
+	return nil
+}
+
+// lookup returns the live entry for key, dropping it when expired
+func (s *MemoryStore) lookup(key string, now time.Time) (memoryEntry, bool) {
+	e, ok := s.entries[key]
+	if ok && e.expired(now) {
+		delete(s.entries, key)
+		return memoryEntry{}, false
+	}
+	return e, ok
+}
+
+// sweep drops expired entries at most once per sweepInterval
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	for key, e := range s.entries {
+		if e.expired(now) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+func expiry(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
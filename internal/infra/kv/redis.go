@@ -0,0 +1,89 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a Store in Redis, shared by every instance using the same
+// server and prefix
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// RedisOption configures the Redis store
+type RedisOption func(*RedisStore)
+
+// WithPrefix sets a key prefix for all keys
+func WithPrefix(prefix string) RedisOption {
+	return func(s *RedisStore) {
+		s.prefix = prefix
+	}
+}
+
+// NewRedisStore creates a store on client. Keys are prefixed with "kv:"
+// unless WithPrefix says otherwise.
+func NewRedisStore(client *redis.Client, opts ...RedisOption) *RedisStore {
+	s := &RedisStore{
+		client: client,
+		prefix: "kv:",
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Client returns the underlying connection, for features such as the rate
+// limiter that run their own scripts on the same server
+func (s *RedisStore) Client() *redis.Client {
+	return s.client
+}
+
+// incrScript increments a counter and sets its expiry in the same step, so
+// a crash between the two cannot leave a counter that never expires
+var incrScript = redis.NewScript(`
+local n = redis.call("INCR", KEYS[1])
+if n == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return n
+`)
+
+// Get returns the value of key
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	val, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	return val, err
+}
+
+// Set stores value under key for ttl
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, s.prefix+key, value, max(ttl, 0)).Err()
+}
+
+// Incr adds 1 to the counter under key
+func (s *RedisStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrScript.Run(ctx, s.client, []string{s.prefix + key}, ttl.Milliseconds()).Int64()
+}
+
+// Delete removes key
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
+
+// Expire makes an existing key expire after ttl
+func (s *RedisStore) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return s.client.Persist(ctx, s.prefix+key).Err()
+	}
+	return s.client.PExpire(ctx, s.prefix+key, ttl).Err()
+}
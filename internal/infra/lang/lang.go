@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Translator handles i18n translations
@@ -86,19 +88,23 @@ func (t *Translator) Load(locale string) error {
 	}
 
 	for _, basePath := range t.paths {
-		// Load JSON files: lang/en.json or lang/en/*.json
-		jsonFile := filepath.Join(basePath, locale+".json")
-		if err := t.loadJSONFile(locale, jsonFile); err != nil && !os.IsNotExist(err) {
-			return err
+		// Load bundle files: lang/en.json, lang/en.yaml or lang/en/*.{json,yaml,yml}
+		for _, ext := range bundleExtensions {
+			file := filepath.Join(basePath, locale+ext)
+			if err := t.loadFile(locale, file); err != nil && !os.IsNotExist(err) {
+				return err
+			}
 		}
 
 		// Load from directory
 		dirPath := filepath.Join(basePath, locale)
 		if info, err := os.Stat(dirPath); err == nil && info.IsDir() {
-			files, _ := filepath.Glob(filepath.Join(dirPath, "*.json"))
-			for _, file := range files {
-				if err := t.loadJSONFile(locale, file); err != nil {
-					return err
+			for _, ext := range bundleExtensions {
+				files, _ := filepath.Glob(filepath.Join(dirPath, "*"+ext))
+				for _, file := range files {
+					if err := t.loadFile(locale, file); err != nil {
+						return err
+					}
 				}
 			}
 		}
@@ -108,15 +114,23 @@ func (t *Translator) Load(locale string) error {
 	return nil
 }
 
-// loadJSONFile loads translations from a JSON file
-func (t *Translator) loadJSONFile(locale, filename string) error {
+// bundleExtensions are the translation file types Load reads
+var bundleExtensions = []string{".json", ".yaml", ".yml"}
+
+// loadFile loads translations from a JSON or YAML file
+func (t *Translator) loadFile(locale, filename string) error {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return err
 	}
 
 	var translations map[string]interface{}
-	if err := json.Unmarshal(data, &translations); err != nil {
+	if filepath.Ext(filename) == ".json" {
+		err = json.Unmarshal(data, &translations)
+	} else {
+		err = yaml.Unmarshal(data, &translations)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to parse %s: %w", filename, err)
 	}
 
@@ -165,6 +179,28 @@ func (t *Translator) Get(key string, replacements ...map[string]string) string {
 	return key
 }
 
+// GetFor retrieves a translation in locale, loading its bundles on first use.
+// Missing keys fall back to the fallback locale, then to the key itself.
+func (t *Translator) GetFor(locale, key string, replacements ...map[string]string) string {
+	t.mu.RLock()
+	fallback := t.fallback
+	t.mu.RUnlock()
+
+	for _, l := range []string{locale, fallback} {
+		if l == "" {
+			continue
+		}
+		if err := t.Load(l); err != nil {
+			continue
+		}
+		if value := t.getForLocale(l, key); value != "" {
+			return t.replace(value, replacements...)
+		}
+	}
+
+	return t.replace(key, replacements...)
+}
+
 // getForLocale retrieves a translation for a specific locale
 func (t *Translator) getForLocale(locale, key string) string {
 	t.mu.RLock()
@@ -276,6 +312,11 @@ func Get(key string, replacements ...map[string]string) string {
 	return Global().Get(key, replacements...)
 }
 
+// T retrieves a translation in the given locale from the global translator
+func T(locale, key string, replacements ...map[string]string) string {
+	return Global().GetFor(locale, key, replacements...)
+}
+
 // Trans is an alias for Get
 func Trans(key string, replacements ...map[string]string) string {
 	return Get(key, replacements...)
@@ -0,0 +1,74 @@
+package lang
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{"en", "zh-CN"}
+
+	tests := []struct {
+		header string
+		want   string
+		ok     bool
+	}{
+		{"zh-CN,zh;q=0.9,en;q=0.8", "zh-CN", true},
+		{"en;q=0.5, zh-cn;q=0.9", "zh-CN", true},
+		{"zh-TW", "zh-CN", true},    // base language match
+		{"en-GB", "en", true},       // regional tag falls back to base
+		{"fr, de;q=0.8", "", false}, // nothing supported
+		{"zh;q=0, en", "en", true},  // q=0 means not acceptable
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Negotiate(tt.header, supported)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Negotiate(%q) = %q, %v, want %q, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestGetFor_Fallback(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "en.json"), `{"greeting": "Hello :name", "auth": {"failed": "Login failed"}}`)
+	writeFile(t, filepath.Join(dir, "zh.yaml"), "greeting: \"你好 :name\"\n")
+
+	tr := New("en", "en")
+	tr.AddPath(dir)
+
+	if got := tr.GetFor("zh", "greeting", map[string]string{"name": "Ada"}); got != "你好 Ada" {
+		t.Errorf("zh greeting = %q", got)
+	}
+	// Missing in zh, found in the fallback locale
+	if got := tr.GetFor("zh", "auth.failed"); got != "Login failed" {
+		t.Errorf("zh auth.failed = %q, want fallback", got)
+	}
+	// Missing everywhere returns the key
+	if got := tr.GetFor("zh", "Unknown message"); got != "Unknown message" {
+		t.Errorf("unknown key = %q", got)
+	}
+	// Unknown locale uses the fallback
+	if got := tr.GetFor("fr", "greeting", map[string]string{"name": "Ada"}); got != "Hello Ada" {
+		t.Errorf("fr greeting = %q", got)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if got := FromContext(context.Background()); got != GetLocale() {
+		t.Errorf("FromContext without locale = %q, want global %q", got, GetLocale())
+	}
+	if got := FromContext(WithLocale(context.Background(), "zh")); got != "zh" {
+		t.Errorf("FromContext = %q, want zh", got)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,91 @@
+package lang
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type localeKey struct{}
+
+// WithLocale returns a copy of ctx carrying the request locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the locale stored by WithLocale, or the global locale
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok && locale != "" {
+		return locale
+	}
+	return GetLocale()
+}
+
+// Normalize lowercases a locale tag and uses "-" as the separator (zh_CN -> zh-cn)
+func Normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// Match returns the supported locale for tag, comparing case-insensitively
+// and falling back from a regional tag to its base language (en-GB -> en)
+func Match(tag string, supported []string) (string, bool) {
+	tag = Normalize(tag)
+	if tag == "" {
+		return "", false
+	}
+
+	base, _, _ := strings.Cut(tag, "-")
+	var baseMatch string
+	for _, locale := range supported {
+		normalized := Normalize(locale)
+		if normalized == tag {
+			return locale, true
+		}
+		if baseMatch == "" && (normalized == base || strings.HasPrefix(normalized, base+"-")) {
+			baseMatch = locale
+		}
+	}
+	return baseMatch, baseMatch != ""
+}
+
+// Negotiate picks the best supported locale for an Accept-Language header,
+// honouring q-values. It returns false when nothing matches.
+func Negotiate(acceptLanguage string, supported []string) (string, bool) {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{tag: tag, q: q})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	for _, c := range candidates {
+		if locale, ok := Match(c.tag, supported); ok {
+			return locale, true
+		}
+	}
+	return "", false
+}
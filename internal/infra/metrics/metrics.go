@@ -120,13 +120,46 @@ var (
 	)
 )
 
+// unmatchedPath labels requests that matched no route, so scans of random
+// URLs cannot create unbounded label values
+const unmatchedPath = "unmatched"
+
+// MiddlewareOptions configures the HTTP metrics middleware
+type MiddlewareOptions struct {
+	// SkipPaths are request paths that are not recorded, such as probes
+	SkipPaths []string
+}
+
+// DefaultMiddlewareOptions skips the health and metrics endpoints
+func DefaultMiddlewareOptions() MiddlewareOptions {
+	return MiddlewareOptions{
+		SkipPaths: []string{"/health", "/health/live", "/health/ready", "/metrics", "/metrics/health"},
+	}
+}
+
 // Middleware returns a Gin middleware for HTTP metrics
 func Middleware() gin.HandlerFunc {
+	return MiddlewareWithConfig(DefaultMiddlewareOptions())
+}
+
+// MiddlewareWithConfig returns a Gin middleware for HTTP metrics. Requests
+// are labelled with the route template (/users/:id), never the raw path.
+func MiddlewareWithConfig(opts MiddlewareOptions) gin.HandlerFunc {
+	skip := make(map[string]bool, len(opts.SkipPaths))
+	for _, path := range opts.SkipPaths {
+		skip[path] = true
+	}
+
 	return func(c *gin.Context) {
+		if skip[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
 		start := time.Now()
 		path := c.FullPath()
 		if path == "" {
-			path = c.Request.URL.Path
+			path = unmatchedPath
 		}
 		method := c.Request.Method
 
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newMetricsRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/users/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	r.GET("/health", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	return r
+}
+
+func serve(r *gin.Engine, path string) {
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+}
+
+func TestMiddleware_LabelsRouteTemplate(t *testing.T) {
+	r := newMetricsRouter()
+	counter := httpRequestsTotal.WithLabelValues("GET", "/users/:id", "200")
+	before := testutil.ToFloat64(counter)
+
+	serve(r, "/users/1")
+	serve(r, "/users/2")
+
+	if got := testutil.ToFloat64(counter) - before; got != 2 {
+		t.Errorf("http_requests_total{path=/users/:id} grew by %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", "/users/1", "200")); got != 0 {
+		t.Errorf("raw path was used as a label (%v requests)", got)
+	}
+}
+
+func TestMiddleware_UnmatchedRoutesShareALabel(t *testing.T) {
+	r := newMetricsRouter()
+	counter := httpRequestsTotal.WithLabelValues("GET", unmatchedPath, "404")
+	before := testutil.ToFloat64(counter)
+
+	serve(r, "/random/a")
+	serve(r, "/random/b")
+
+	if got := testutil.ToFloat64(counter) - before; got != 2 {
+		t.Errorf("unmatched requests grew by %v, want 2", got)
+	}
+}
+
+func TestMiddleware_SkipsPaths(t *testing.T) {
+	r := newMetricsRouter()
+	counter := httpRequestsTotal.WithLabelValues("GET", "/health", "200")
+	before := testutil.ToFloat64(counter)
+
+	serve(r, "/health")
+
+	if got := testutil.ToFloat64(counter) - before; got != 0 {
+		t.Errorf("skipped path was recorded %v times", got)
+	}
+}
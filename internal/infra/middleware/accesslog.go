@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"math/rand/v2"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/logger"
+)
+
+// AccessLogOptions holds AccessLog middleware configuration
+type AccessLogOptions struct {
+	// SampleRate is the fraction of successful (< 400) responses to log,
+	// between 0 and 1. 4xx and 5xx responses are always logged, so 0 logs
+	// failures only.
+	// Default: 1 (log everything)
+	SampleRate float64
+
+	// SkipPaths are exact paths that are never logged
+	// Default: health and metrics endpoints
+	SkipPaths []string
+
+	// Sample decides whether a successful request is logged
+	// Default: random sampling at SampleRate
+	Sample func(rate float64) bool
+}
+
+// DefaultAccessLogOptions returns default configuration
+func DefaultAccessLogOptions() AccessLogOptions {
+	return AccessLogOptions{
+		SampleRate: 1,
+		SkipPaths:  []string{"/health", "/health/live", "/health/ready", "/metrics"},
+	}
+}
+
+// AccessLog returns middleware that logs one structured entry per request
+// with method, path, status, latency, response size, client IP and request ID.
+// 5xx are logged as errors and 4xx as warnings.
+func AccessLog(opts AccessLogOptions) gin.HandlerFunc {
+	// Set defaults
+	opts.SampleRate = min(max(opts.SampleRate, 0), 1)
+	if opts.Sample == nil {
+		opts.Sample = func(rate float64) bool { return rand.Float64() < rate }
+	}
+
+	skip := make(map[string]struct{}, len(opts.SkipPaths))
+	for _, p := range opts.SkipPaths {
+		skip[p] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if _, ok := skip[path]; ok {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		raw := redactQuery(c.Request.URL.RawQuery)
+
+		// Process request
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < 400 && opts.SampleRate < 1 && !opts.Sample(opts.SampleRate) {
+			return
+		}
+
+		latency := time.Since(start)
+		if raw != "" {
+			path = path + "?" + raw
+		}
+
+		fields := map[string]any{
+			"method":         c.Request.Method,
+			"path":           path,
+			"status":         status,
+			"latency":        latency.String(),
+			"latency_ms":     float64(latency.Microseconds()) / 1000,
+			"latency_bucket": latencyBucket(latency),
+			"bytes":          max(c.Writer.Size(), 0),
+			"client_ip":      ClientIP(c),
+		}
+		if id := GetRequestID(c); id != "" {
+			fields["request_id"] = id
+		}
+		if len(c.Errors) > 0 {
+			fields["errors"] = c.Errors.String()
+		}
+
+		log := logger.FromContext(c.Request.Context())
+		switch {
+		case status >= 500:
+			log.Error("HTTP Request", fields)
+		case status >= 400:
+			log.Warning("HTTP Request", fields)
+		default:
+			log.Info("HTTP Request", fields)
+		}
+	}
+}
+
+// redactedQueryParams are query parameters that carry credentials, such as
+// the ?token= accepted on WebSocket handshakes
+var redactedQueryParams = map[string]bool{"token": true}
+
+// redactQuery replaces the values of credential query parameters so they
+// never reach the logs. Other parameters are kept as sent.
+func redactQuery(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	parts := strings.Split(raw, "&")
+	for i, part := range parts {
+		key, _, _ := strings.Cut(part, "=")
+		if name, err := url.QueryUnescape(key); err == nil && redactedQueryParams[strings.ToLower(name)] {
+			parts[i] = key + "=[REDACTED]"
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// latencyBucket groups latencies for dashboards and alerting
+func latencyBucket(d time.Duration) string {
+	switch {
+	case d < 10*time.Millisecond:
+		return "<10ms"
+	case d < 100*time.Millisecond:
+		return "<100ms"
+	case d < 500*time.Millisecond:
+		return "<500ms"
+	case d < time.Second:
+		return "<1s"
+	default:
+		return ">=1s"
+	}
+}
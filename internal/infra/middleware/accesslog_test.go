@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/logger"
+)
+
+func newAccessLogRouter(opts AccessLogOptions) *gin.Engine {
+	r := gin.New()
+	r.Use(RequestID())
+	r.Use(AccessLog(opts))
+	r.GET("/ok", func(c *gin.Context) { c.String(http.StatusOK, "hello") })
+	r.GET("/fail", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+	r.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func (h *captureHandler) count(msg string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := 0
+	for _, e := range h.entries {
+		if e.Message == msg {
+			n++
+		}
+	}
+	return n
+}
+
+func TestAccessLog_Fields(t *testing.T) {
+	logs := captureLogs(t)
+	r := newAccessLogRouter(DefaultAccessLogOptions())
+
+	req := httptest.NewRequest(http.MethodGet, "/ok?page=2", nil)
+	req.Header.Set("X-Request-ID", "req-42")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	entry := logs.find("HTTP Request")
+	if entry == nil {
+		t.Fatal("expected an access log entry")
+	}
+	want := map[string]any{
+		"method":     "GET",
+		"path":       "/ok?page=2",
+		"status":     200,
+		"bytes":      5,
+		"request_id": "req-42",
+	}
+	for k, v := range want {
+		if entry.Context[k] != v {
+			t.Errorf("%s = %v, want %v", k, entry.Context[k], v)
+		}
+	}
+	for _, k := range []string{"latency", "latency_ms", "latency_bucket", "client_ip"} {
+		if _, ok := entry.Context[k]; !ok {
+			t.Errorf("missing field %s", k)
+		}
+	}
+}
+
+func TestAccessLog_RedactsTokenQuery(t *testing.T) {
+	logs := captureLogs(t)
+	r := newAccessLogRouter(DefaultAccessLogOptions())
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok?room=1&token=eyJsecret&Token=x", nil))
+
+	entry := logs.find("HTTP Request")
+	if entry == nil {
+		t.Fatal("expected an access log entry")
+	}
+	if got, want := entry.Context["path"], "/ok?room=1&token=[REDACTED]&Token=[REDACTED]"; got != want {
+		t.Errorf("path = %v, want %v", got, want)
+	}
+}
+
+func TestAccessLog_ErrorsAlwaysLogged(t *testing.T) {
+	logs := captureLogs(t)
+	r := newAccessLogRouter(AccessLogOptions{
+		SampleRate: 0.01,
+		Sample:     func(float64) bool { return false },
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	entry := logs.find("HTTP Request")
+	if entry == nil {
+		t.Fatal("expected 500 to be logged regardless of sampling")
+	}
+	if entry.Level != logger.LevelError {
+		t.Errorf("level = %v, want error", entry.Level)
+	}
+}
+
+func TestAccessLog_SamplesSuccesses(t *testing.T) {
+	logs := captureLogs(t)
+	r := newAccessLogRouter(AccessLogOptions{SampleRate: 0.2})
+
+	const total = 1000
+	for i := 0; i < total; i++ {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	}
+
+	logged := logs.count("HTTP Request")
+	if logged == 0 || logged >= total {
+		t.Errorf("logged %d of %d requests, want a sampled fraction", logged, total)
+	}
+}
+
+func TestAccessLog_ZeroRateLogsFailuresOnly(t *testing.T) {
+	logs := captureLogs(t)
+	r := newAccessLogRouter(AccessLogOptions{SampleRate: 0})
+
+	for i := 0; i < 100; i++ {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	}
+	if n := logs.count("HTTP Request"); n != 0 {
+		t.Errorf("logged %d successful requests at rate 0, want none", n)
+	}
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	if n := logs.count("HTTP Request"); n != 1 {
+		t.Errorf("logged %d requests, want only the 500", n)
+	}
+}
+
+func TestAccessLog_SkipPaths(t *testing.T) {
+	logs := captureLogs(t)
+	r := newAccessLogRouter(DefaultAccessLogOptions())
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	if logs.find("HTTP Request") != nil {
+		t.Error("expected /health to be skipped")
+	}
+}
@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// authorizer resolves roles and permissions for the authorization middleware.
+// Set via SetAuthorizer during application initialization.
+var authorizer domain.Authorizer
+
+// SetAuthorizer sets the authorizer for middleware use.
+// This should be called during application initialization.
+func SetAuthorizer(a domain.Authorizer) {
+	authorizer = a
+}
+
+// accessKey is the request context key for the cached user access
+type accessKey struct{}
+
+// userAccess caches a user's roles and permissions for one request
+type userAccess struct {
+	userID      uint
+	roles       []string
+	permissions []string
+	rolesLoaded bool
+	permsLoaded bool
+}
+
+// RequireRole creates middleware that allows the request when the
+// authenticated user has any of the given roles.
+// Must run after JWTAuth; requires SetAuthorizer to be called first.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		access, ok := loadAccess(c)
+		if !ok {
+			return
+		}
+
+		if !access.rolesLoaded {
+			names, err := authorizer.UserRoleNames(c.Request.Context(), access.userID)
+			if err != nil {
+				response.Error(c, http.StatusInternalServerError, "Failed to load user roles")
+				c.Abort()
+				return
+			}
+			access.roles, access.rolesLoaded = names, true
+		}
+
+		if !containsAny(access.roles, roles) {
+			response.Forbidden(c, "Insufficient role")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission creates middleware that allows the request when the
+// authenticated user holds any of the given permissions through their roles.
+// Must run after JWTAuth; requires SetAuthorizer to be called first.
+func RequirePermission(perms ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		access, ok := loadAccess(c)
+		if !ok {
+			return
+		}
+
+		if !access.permsLoaded {
+			names, err := authorizer.UserPermissionNames(c.Request.Context(), access.userID)
+			if err != nil {
+				response.Error(c, http.StatusInternalServerError, "Failed to load user permissions")
+				c.Abort()
+				return
+			}
+			access.permissions, access.permsLoaded = names, true
+		}
+
+		if !containsAny(access.permissions, perms) {
+			response.Forbidden(c, "Insufficient permission")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// loadAccess returns the cached access for the authenticated user, attaching
+// a fresh one to the request context on first use. It aborts the request and
+// returns false when authorization cannot proceed.
+func loadAccess(c *gin.Context) (*userAccess, bool) {
+	if authorizer == nil {
+		response.Error(c, http.StatusInternalServerError, "Authorizer not initialized")
+		c.Abort()
+		return nil, false
+	}
+
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		response.Unauthorized(c, "Authentication required")
+		c.Abort()
+		return nil, false
+	}
+
+	ctx := c.Request.Context()
+	if access, ok := ctx.Value(accessKey{}).(*userAccess); ok && access.userID == userID {
+		return access, true
+	}
+
+	access := &userAccess{userID: userID}
+	c.Request = c.Request.WithContext(context.WithValue(ctx, accessKey{}, access))
+	return access, true
+}
+
+func containsAny(have, want []string) bool {
+	for _, w := range want {
+		if slices.Contains(have, w) {
+			return true
+		}
+	}
+	return false
+}
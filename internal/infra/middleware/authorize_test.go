@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/router"
+)
+
+// fakeAuthorizer serves fixed roles and permissions and counts lookups
+type fakeAuthorizer struct {
+	roles       map[uint][]string
+	permissions map[uint][]string
+	err         error
+	roleCalls   int
+	permCalls   int
+}
+
+func (a *fakeAuthorizer) UserRoleNames(ctx context.Context, userID uint) ([]string, error) {
+	a.roleCalls++
+	return a.roles[userID], a.err
+}
+
+func (a *fakeAuthorizer) UserPermissionNames(ctx context.Context, userID uint) ([]string, error) {
+	a.permCalls++
+	return a.permissions[userID], a.err
+}
+
+func useAuthorizer(t *testing.T, a *fakeAuthorizer) {
+	t.Helper()
+	prev := authorizer
+	SetAuthorizer(a)
+	t.Cleanup(func() { SetAuthorizer(prev) })
+}
+
+// authenticateAs stands in for JWTAuth using the X-User-ID test header
+func authenticateAs(c *gin.Context) {
+	switch c.GetHeader("X-User-ID") {
+	case "1":
+		c.Set("userID", uint(1))
+	case "2":
+		c.Set("userID", uint(2))
+	}
+	c.Next()
+}
+
+func newAuthorizeRouter(guards ...gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(authenticateAs)
+	r.Use(guards...)
+	r.GET("/admin", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	return r
+}
+
+func newTestAuthorizer() *fakeAuthorizer {
+	return &fakeAuthorizer{
+		roles: map[uint][]string{
+			1: {"admin", "user"},
+			2: {"user"},
+		},
+		permissions: map[uint][]string{
+			1: {"users.delete", "users.view"},
+			2: {"users.view"},
+		},
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	useAuthorizer(t, newTestAuthorizer())
+	r := newAuthorizeRouter(RequireRole("admin", "editor"))
+
+	tests := []struct {
+		name   string
+		userID string
+		want   int
+	}{
+		{"has role", "1", http.StatusOK},
+		{"lacks role", "2", http.StatusForbidden},
+		{"unauthenticated", "", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(r, "/admin", "10.0.0.1:1234", map[string]string{"X-User-ID": tt.userID})
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	useAuthorizer(t, newTestAuthorizer())
+	r := newAuthorizeRouter(RequirePermission("users.delete"))
+
+	if w := doRequest(r, "/admin", "10.0.0.1:1234", map[string]string{"X-User-ID": "1"}); w.Code != http.StatusOK {
+		t.Errorf("granted: status = %d, want 200", w.Code)
+	}
+	if w := doRequest(r, "/admin", "10.0.0.1:1234", map[string]string{"X-User-ID": "2"}); w.Code != http.StatusForbidden {
+		t.Errorf("denied: status = %d, want 403", w.Code)
+	}
+}
+
+func TestRequireRole_CachesLookupsPerRequest(t *testing.T) {
+	a := newTestAuthorizer()
+	useAuthorizer(t, a)
+	r := newAuthorizeRouter(
+		RequireRole("user"),
+		RequireRole("admin"),
+		RequirePermission("users.view"),
+		RequirePermission("users.delete"),
+	)
+
+	if w := doRequest(r, "/admin", "10.0.0.1:1234", map[string]string{"X-User-ID": "1"}); w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if a.roleCalls != 1 || a.permCalls != 1 {
+		t.Errorf("lookups = %d roles, %d permissions; want 1 each", a.roleCalls, a.permCalls)
+	}
+
+	// A new request loads again
+	doRequest(r, "/admin", "10.0.0.1:1234", map[string]string{"X-User-ID": "1"})
+	if a.roleCalls != 2 {
+		t.Errorf("role lookups = %d, want 2 after a second request", a.roleCalls)
+	}
+}
+
+func TestRequireRole_AuthorizerError(t *testing.T) {
+	a := newTestAuthorizer()
+	a.err = errors.New("database down")
+	useAuthorizer(t, a)
+	r := newAuthorizeRouter(RequireRole("admin"))
+
+	if w := doRequest(r, "/admin", "10.0.0.1:1234", map[string]string{"X-User-ID": "1"}); w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+}
+
+func TestRequireRole_RouterAlias(t *testing.T) {
+	useAuthorizer(t, newTestAuthorizer())
+	engine := gin.New()
+	engine.Use(authenticateAs)
+	r := router.New(engine)
+	r.AliasMiddleware("role:admin", RequireRole("admin"))
+
+	r.Group("", func(g *router.Router) {
+		g.WithMiddleware("role:admin")
+		g.GET("/admin", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	})
+
+	if w := doRequest(engine, "/admin", "10.0.0.1:1234", map[string]string{"X-User-ID": "1"}); w.Code != http.StatusOK {
+		t.Errorf("admin: status = %d, want 200", w.Code)
+	}
+	if w := doRequest(engine, "/admin", "10.0.0.1:1234", map[string]string{"X-User-ID": "2"}); w.Code != http.StatusForbidden {
+		t.Errorf("user: status = %d, want 403", w.Code)
+	}
+}
@@ -1,12 +1,19 @@
 package middleware
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
-	"github.com/zgiai/zgo/internal/infra/config"
 	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/pkg/response"
 )
 
+// originalBodyKey stores the unwrapped request body so a route group can
+// replace the global limit instead of nesting inside it
+const originalBodyKey = "middleware.body_limit.original"
+
 // BodyLimitConfig holds body limit middleware configuration
 type BodyLimitConfig struct {
 	// MaxSize is the maximum allowed request body size in bytes
@@ -27,59 +34,69 @@ func DefaultBodyLimitConfig() BodyLimitConfig {
 }
 
 // BodyLimitFromConfig returns body limit middleware using global config
-// Uses MIDDLEWARE_BODY_LIMIT_MB env var (in megabytes)
+// Uses SERVER_MAX_BODY_BYTES (falling back to MIDDLEWARE_BODY_LIMIT_MB)
 func BodyLimitFromConfig() gin.HandlerFunc {
-	maxSize := int64(10 * 1024 * 1024) // default 10MB
-	if config.GlobalConfig != nil && config.GlobalConfig.Middleware.BodyLimit > 0 {
-		maxSize = config.GlobalConfig.Middleware.BodyLimit
+	maxSize := DefaultBodyLimitConfig().MaxSize
+	if config.GlobalConfig != nil && config.GlobalConfig.Server.MaxBodyBytes > 0 {
+		maxSize = config.GlobalConfig.Server.MaxBodyBytes
 	}
-	return BodyLimit(maxSize)
+	return MaxBodySize(maxSize)
 }
 
 // BodyLimit returns body limit middleware with size in bytes
 func BodyLimit(maxSize int64) gin.HandlerFunc {
-	return BodyLimitWithConfig(BodyLimitConfig{
-		MaxSize:      maxSize,
-		ErrorMessage: "Request body too large",
-	})
+	return MaxBodySize(maxSize)
+}
+
+// MaxBodySize caps the request body at limit bytes.
+// The body is wrapped with http.MaxBytesReader, so binding fails once the
+// cap is crossed and the request ends with 413 Request Entity Too Large.
+// Nothing is rejected before the handler reads, which lets a route group
+// applying MaxBodySize again replace the global limit, larger or smaller:
+//
+//	r.Use(middleware.MaxBodySize(cfg.Server.MaxBodyBytes))
+//	uploads := r.Group("/uploads", middleware.MaxBodySize(100<<20))
+func MaxBodySize(limit int64) gin.HandlerFunc {
+	return BodyLimitWithConfig(BodyLimitConfig{MaxSize: limit})
 }
 
 // BodyLimitWithConfig returns body limit middleware with custom config
 func BodyLimitWithConfig(cfg BodyLimitConfig) gin.HandlerFunc {
+	defaults := DefaultBodyLimitConfig()
 	if cfg.MaxSize <= 0 {
-		cfg.MaxSize = 4 * 1024 * 1024
+		cfg.MaxSize = defaults.MaxSize
 	}
 	if cfg.ErrorMessage == "" {
-		cfg.ErrorMessage = "Request body too large"
+		cfg.ErrorMessage = defaults.ErrorMessage
 	}
 
 	return func(c *gin.Context) {
-		// Check Content-Length header first (fast path)
-		if c.Request.ContentLength > cfg.MaxSize {
-			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "BODY_TOO_LARGE",
-					"message": cfg.ErrorMessage,
-				},
-			})
-			return
+		body := c.Request.Body
+		if original, ok := c.Get(originalBodyKey); ok {
+			body = original.(io.ReadCloser)
+		} else {
+			c.Set(originalBodyKey, body)
+		}
+		if body != nil && body != http.NoBody {
+			c.Request.Body = http.MaxBytesReader(c.Writer, body, cfg.MaxSize)
 		}
-
-		// Wrap the body with a size limiter
-		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, cfg.MaxSize)
 
 		c.Next()
 
-		// Check if we hit the limit during body read
-		if c.Errors.Last() != nil && c.Errors.Last().Error() == "http: request body too large" {
-			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "BODY_TOO_LARGE",
-					"message": cfg.ErrorMessage,
-				},
-			})
+		// Handlers that record the read error without responding still get a 413
+		if !c.Writer.Written() && isBodyTooLarge(c) {
+			response.Abort(c, http.StatusRequestEntityTooLarge, cfg.ErrorMessage)
+		}
+	}
+}
+
+// isBodyTooLarge reports whether a handler hit the body limit
+func isBodyTooLarge(c *gin.Context) bool {
+	for _, e := range c.Errors {
+		var maxErr *http.MaxBytesError
+		if errors.As(e.Err, &maxErr) {
+			return true
 		}
 	}
+	return false
 }
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+func newBodyLimitRouter(limit int64, extra ...gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(extra...)
+	r.Use(MaxBodySize(limit))
+	bind := func(c *gin.Context) {
+		var payload map[string]any
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			response.BadRequest(c, "Invalid request", err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	}
+	r.POST("/echo", bind)
+	r.Group("/uploads", MaxBodySize(4096)).POST("", bind)
+	return r
+}
+
+func jsonBody(size int) *strings.Reader {
+	return strings.NewReader(`{"data":"` + strings.Repeat("x", size) + `"}`)
+}
+
+func TestMaxBodySize_RejectsOversizedBody(t *testing.T) {
+	r := newBodyLimitRouter(64)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/echo", jsonBody(256)))
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("Expected status 413, got %d", w.Code)
+	}
+	var body response.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected response envelope: %v", err)
+	}
+	if body.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected envelope code 413, got %d", body.Code)
+	}
+}
+
+func TestMaxBodySize_UnknownLength(t *testing.T) {
+	r := newBodyLimitRouter(64)
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", jsonBody(256))
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d", w.Code)
+	}
+}
+
+func TestMaxBodySize_AllowsBodyWithinLimit(t *testing.T) {
+	r := newBodyLimitRouter(64)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/echo", jsonBody(8)))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+}
+
+func TestMaxBodySize_GroupOverridesGlobalLimit(t *testing.T) {
+	r := newBodyLimitRouter(64)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/uploads", jsonBody(1024)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected group limit to allow body, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/uploads", jsonBody(8192)))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d", w.Code)
+	}
+}
+
+func TestMaxBodySize_WithCompression(t *testing.T) {
+	opts := DefaultCompressOptions()
+	opts.MinLength = 1
+	r := newBodyLimitRouter(64, Compress(opts))
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", jsonBody(256))
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("Expected status 413, got %d", w.Code)
+	}
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Expected gzip response, got %q", w.Header().Get("Content-Encoding"))
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	var body response.ErrorResponse
+	if err := json.NewDecoder(zr).Decode(&body); err != nil {
+		t.Fatalf("Expected response envelope: %v", err)
+	}
+	if body.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected envelope code 413, got %d", body.Code)
+	}
+}
@@ -1,17 +1,20 @@
 package middleware
 
 import (
+	"bufio"
 	"compress/gzip"
 	"io"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/gin-gonic/gin"
 )
 
-// CompressConfig holds Compress middleware configuration
-type CompressConfig struct {
+// CompressOptions holds Compress middleware configuration
+type CompressOptions struct {
 	// Level is the compression level (1-9, where 9 is best compression)
 	// Default: gzip.DefaultCompression
 	Level int
@@ -20,109 +23,91 @@ type CompressConfig struct {
 	// Default: 1024 bytes
 	MinLength int
 
+	// ExcludedContentTypes are content type prefixes that are never compressed
+	// Default: images, video, audio, archives and event streams
+	ExcludedContentTypes []string
+
 	// ExcludedExtensions are file extensions to skip compression
-	// Default: [".png", ".gif", ".jpeg", ".jpg", ".webp", ".ico"]
+	// Default: [".png", ".gif", ".jpeg", ".jpg", ".webp", ".ico", ".woff", ".woff2"]
 	ExcludedExtensions []string
 
 	// ExcludedPaths are paths to skip compression
 	ExcludedPaths []string
 }
 
-// DefaultCompressConfig returns default compression configuration
-func DefaultCompressConfig() CompressConfig {
-	return CompressConfig{
-		Level:              gzip.DefaultCompression,
-		MinLength:          1024,
+// DefaultCompressOptions returns default compression configuration
+func DefaultCompressOptions() CompressOptions {
+	return CompressOptions{
+		Level:     gzip.DefaultCompression,
+		MinLength: 1024,
+		ExcludedContentTypes: []string{
+			"image/", "video/", "audio/",
+			"application/zip", "application/gzip", "application/x-gzip",
+			"text/event-stream",
+		},
 		ExcludedExtensions: []string{".png", ".gif", ".jpeg", ".jpg", ".webp", ".ico", ".woff", ".woff2"},
 		ExcludedPaths:      []string{},
 	}
 }
 
-// gzipWriter wraps http.ResponseWriter with gzip compression
-type gzipWriter struct {
-	gin.ResponseWriter
-	writer *gzip.Writer
-}
-
-func (g *gzipWriter) Write(data []byte) (int, error) {
-	return g.writer.Write(data)
-}
-
-func (g *gzipWriter) WriteString(s string) (int, error) {
-	return g.writer.Write([]byte(s))
-}
-
-// gzip writer pool
-var gzipWriterPool = sync.Pool{
-	New: func() interface{} {
-		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
-		return w
-	},
-}
-
-// Compress returns Compress middleware with default config
-func Compress() gin.HandlerFunc {
-	return CompressWithConfig(DefaultCompressConfig())
-}
+// Compress returns gzip compression middleware.
+// Responses are buffered up to MinLength bytes before deciding whether to
+// compress, so small bodies and already-encoded responses pass through as-is.
+func Compress(opts CompressOptions) gin.HandlerFunc {
+	// Set defaults
+	defaults := DefaultCompressOptions()
+	if opts.Level == 0 || opts.Level < gzip.HuffmanOnly || opts.Level > gzip.BestCompression {
+		opts.Level = defaults.Level
+	}
+	if opts.MinLength <= 0 {
+		opts.MinLength = defaults.MinLength
+	}
+	if opts.ExcludedContentTypes == nil {
+		opts.ExcludedContentTypes = defaults.ExcludedContentTypes
+	}
+	if opts.ExcludedExtensions == nil {
+		opts.ExcludedExtensions = defaults.ExcludedExtensions
+	}
 
-// CompressWithConfig returns Compress middleware with custom config
-func CompressWithConfig(cfg CompressConfig) gin.HandlerFunc {
-	// Build excluded extensions map
-	excludedExt := make(map[string]bool)
-	for _, ext := range cfg.ExcludedExtensions {
-		excludedExt[ext] = true
+	pool := &sync.Pool{
+		New: func() any {
+			w, _ := gzip.NewWriterLevel(io.Discard, opts.Level)
+			return w
+		},
 	}
 
 	return func(c *gin.Context) {
-		// Check if client accepts gzip
-		if !strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
-			c.Next()
-			return
-		}
-
-		// Check excluded paths
 		path := c.Request.URL.Path
-		for _, excluded := range cfg.ExcludedPaths {
+		for _, excluded := range opts.ExcludedPaths {
 			if strings.HasPrefix(path, excluded) {
 				c.Next()
 				return
 			}
 		}
-
-		// Check excluded extensions
-		for ext := range excludedExt {
+		for _, ext := range opts.ExcludedExtensions {
 			if strings.HasSuffix(path, ext) {
 				c.Next()
 				return
 			}
 		}
 
-		// Get gzip writer from pool
-		gz := gzipWriterPool.Get().(*gzip.Writer)
-		gz.Reset(c.Writer)
+		// The representation depends on Accept-Encoding whether or not we compress
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
 
-		// Create wrapped writer
-		gzw := &gzipWriter{
-			ResponseWriter: c.Writer,
-			writer:         gz,
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
 		}
 
-		// Set headers
-		c.Header("Content-Encoding", "gzip")
-		c.Header("Vary", "Accept-Encoding")
-
-		// Replace writer
-		c.Writer = gzw
-
-		defer func() {
-			gz.Close()
-			gzipWriterPool.Put(gz)
-		}()
+		cw := &compressWriter{
+			ResponseWriter: c.Writer,
+			opts:           &opts,
+			pool:           pool,
+		}
+		c.Writer = cw
+		defer cw.close()
 
 		c.Next()
-
-		// Remove Content-Length as it's now different
-		c.Header("Content-Length", "")
 	}
 }
 
@@ -131,5 +116,140 @@ func NoCompress(c *gin.Context) {
 	c.Header("Content-Encoding", "identity")
 }
 
-// compressedResponseWriter implements http.ResponseWriter
-var _ http.ResponseWriter = (*gzipWriter)(nil)
+// acceptsGzip reports whether an Accept-Encoding header allows gzip
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		// An explicit q=0 means "not acceptable"
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// compressWriter buffers the start of the body and switches to gzip once
+// it is large enough and of a compressible type
+type compressWriter struct {
+	gin.ResponseWriter
+	opts *CompressOptions
+	pool *sync.Pool
+
+	buf     []byte
+	decided bool
+	gz      *gzip.Writer
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.gz != nil {
+			return w.gz.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf = append(w.buf, data...)
+	if len(w.buf) >= w.opts.MinLength {
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Written reports true once the handler has produced output, even while it is buffered
+func (w *compressWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+// Flush sends buffered data so streaming handlers keep working
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return w.ResponseWriter.Hijack()
+}
+
+// decide picks compressed or plain output and writes the buffered data
+func (w *compressWriter) decide() error {
+	w.decided = true
+
+	if w.shouldCompress() {
+		header := w.ResponseWriter.Header()
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+
+		w.gz = w.pool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+	buf := w.buf
+	w.buf = nil
+	if w.gz != nil {
+		_, err := w.gz.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+func (w *compressWriter) shouldCompress() bool {
+	if len(w.buf) < w.opts.MinLength {
+		return false
+	}
+	switch w.ResponseWriter.Status() {
+	case http.StatusNoContent, http.StatusNotModified:
+		return false
+	}
+
+	header := w.ResponseWriter.Header()
+	// Never double-encode
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(w.buf)
+	}
+	for _, excluded := range w.opts.ExcludedContentTypes {
+		if strings.HasPrefix(contentType, excluded) {
+			return false
+		}
+	}
+	return true
+}
+
+// close flushes any remaining output and returns the gzip writer to the pool
+func (w *compressWriter) close() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		w.pool.Put(w.gz)
+		w.gz = nil
+	}
+}
@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/router"
+)
+
+type compressItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func largePayload() []compressItem {
+	items := make([]compressItem, 200)
+	for i := range items {
+		items[i] = compressItem{ID: i, Name: "user-" + strings.Repeat("x", 16)}
+	}
+	return items
+}
+
+func newCompressRouter(opts CompressOptions) *gin.Engine {
+	r := gin.New()
+	r.Use(Compress(opts))
+	r.GET("/large", func(c *gin.Context) {
+		c.JSON(http.StatusOK, largePayload())
+	})
+	r.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	r.GET("/image", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", bytes.Repeat([]byte{0x89}, 4096))
+	})
+	r.GET("/encoded", func(c *gin.Context) {
+		c.Header("Content-Encoding", "br")
+		c.Data(http.StatusOK, "application/json", bytes.Repeat([]byte("a"), 4096))
+	})
+	r.GET("/stream", func(c *gin.Context) {
+		for i := 0; i < 3; i++ {
+			c.Writer.WriteString(strings.Repeat("chunk", 300))
+			c.Writer.Flush()
+		}
+	})
+	return r
+}
+
+func gunzip(t *testing.T, body []byte) []byte {
+	t.Helper()
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("read gzip body: %v", err)
+	}
+	return data
+}
+
+func TestCompress_GzipsLargeJSON(t *testing.T) {
+	r := newCompressRouter(DefaultCompressOptions())
+
+	w := doRequest(r, "/large", "10.0.0.1:1234", map[string]string{"Accept-Encoding": "gzip, deflate"})
+
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("Content-Encoding = %q, want gzip", got)
+	}
+	if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Errorf("Vary = %q, want Accept-Encoding", got)
+	}
+
+	want, _ := json.Marshal(largePayload())
+	if got := gunzip(t, w.Body.Bytes()); !bytes.Equal(got, want) {
+		t.Errorf("decompressed body does not match the original JSON")
+	}
+}
+
+func TestCompress_SkipsWhenNotAccepted(t *testing.T) {
+	r := newCompressRouter(DefaultCompressOptions())
+
+	for _, accept := range []string{"", "br", "gzip;q=0"} {
+		w := doRequest(r, "/large", "10.0.0.1:1234", map[string]string{"Accept-Encoding": accept})
+		if got := w.Header().Get("Content-Encoding"); got != "" {
+			t.Errorf("Accept-Encoding %q: Content-Encoding = %q, want none", accept, got)
+		}
+		if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+			t.Errorf("Accept-Encoding %q: Vary = %q, want Accept-Encoding", accept, got)
+		}
+	}
+}
+
+func TestCompress_SkipsSmallExcludedAndEncoded(t *testing.T) {
+	r := newCompressRouter(DefaultCompressOptions())
+	gzipHeader := map[string]string{"Accept-Encoding": "gzip"}
+
+	w := doRequest(r, "/small", "10.0.0.1:1234", gzipHeader)
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("small body: Content-Encoding = %q, want none", got)
+	}
+	if w.Body.String() != `{"ok":true}` {
+		t.Errorf("small body = %q", w.Body.String())
+	}
+
+	w = doRequest(r, "/image", "10.0.0.1:1234", gzipHeader)
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("image: Content-Encoding = %q, want none", got)
+	}
+	if w.Body.Len() != 4096 {
+		t.Errorf("image body length = %d, want 4096", w.Body.Len())
+	}
+
+	w = doRequest(r, "/encoded", "10.0.0.1:1234", gzipHeader)
+	if got := w.Header().Get("Content-Encoding"); got != "br" {
+		t.Errorf("pre-encoded: Content-Encoding = %q, want br", got)
+	}
+}
+
+func TestCompress_StreamingFlush(t *testing.T) {
+	r := newCompressRouter(DefaultCompressOptions())
+
+	w := doRequest(r, "/stream", "10.0.0.1:1234", map[string]string{"Accept-Encoding": "gzip"})
+
+	if !w.Flushed {
+		t.Error("Flush should reach the underlying writer")
+	}
+	if got := string(gunzip(t, w.Body.Bytes())); got != strings.Repeat("chunk", 900) {
+		t.Errorf("decompressed stream length = %d, want %d", len(got), 5*900)
+	}
+}
+
+func TestCompress_NamedMiddlewareGroup(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+	r.MiddlewareGroup("compress", Compress(CompressOptions{MinLength: 16}))
+
+	r.Group("", func(g *router.Router) {
+		g.WithMiddleware("compress")
+		g.GET("/compressed", func(c *gin.Context) { c.String(http.StatusOK, strings.Repeat("a", 64)) })
+	})
+	r.GET("/plain", func(c *gin.Context) { c.String(http.StatusOK, strings.Repeat("a", 64)) })
+
+	headers := map[string]string{"Accept-Encoding": "gzip"}
+	if w := doRequest(engine, "/compressed", "10.0.0.1:1234", headers); w.Header().Get("Content-Encoding") != "gzip" {
+		t.Error("/compressed should be gzipped")
+	}
+	if w := doRequest(engine, "/plain", "10.0.0.1:1234", headers); w.Header().Get("Content-Encoding") != "" {
+		t.Error("/plain should not be compressed")
+	}
+}
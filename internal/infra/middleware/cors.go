@@ -3,7 +3,10 @@ package middleware
 import (
 	"net/http"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/zgiai/zgo/internal/infra/config"
 	"github.com/gin-gonic/gin"
@@ -28,11 +31,13 @@ type CORSConfig struct {
 	// Default: ["Content-Length"]
 	ExposeHeaders []string
 
-	// AllowCredentials indicates whether the request can include user credentials
-	// Default: true
+	// AllowCredentials indicates whether the request can include user credentials.
+	// It is ignored when AllowOrigins contains "*".
+	// Default: false
 	AllowCredentials bool
 
-	// MaxAge indicates how long (in seconds) the results of a preflight request can be cached
+	// MaxAge indicates how long (in seconds) the results of a preflight request can be cached.
+	// 0 leaves it to the browser, which caches for a few seconds.
 	// Default: 86400 (24 hours)
 	MaxAge int
 }
@@ -51,7 +56,7 @@ func DefaultCORSConfig() CORSConfig {
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS", "HEAD"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"},
 		ExposeHeaders:    []string{"Content-Length", "Content-Type"},
-		AllowCredentials: true,
+		AllowCredentials: false,
 		MaxAge:           86400,
 	}
 }
@@ -60,19 +65,46 @@ func DefaultCORSConfig() CORSConfig {
 func CORS() gin.HandlerFunc {
 	// Try to use global config
 	if config.GlobalConfig != nil {
-		return CORSWithConfig(CORSConfig{
-			AllowOrigins:     config.GlobalConfig.CORS.AllowOrigins,
-			AllowMethods:     config.GlobalConfig.CORS.AllowMethods,
-			AllowHeaders:     config.GlobalConfig.CORS.AllowHeaders,
-			ExposeHeaders:    config.GlobalConfig.CORS.ExposeHeaders,
-			AllowCredentials: config.GlobalConfig.CORS.AllowCredentials,
-			MaxAge:           86400,
-		})
+		return CORSWithConfig(CORSConfigFrom(config.GlobalConfig.CORS))
 	}
 	return CORSWithConfig(DefaultCORSConfig())
 }
 
-// CORSWithConfig returns CORS middleware with custom config
+// CORSConfigFrom builds the middleware configuration from the app config
+func CORSConfigFrom(cfg config.CORSConfig) CORSConfig {
+	return CORSConfig{
+		AllowOrigins:     cfg.AllowOrigins,
+		AllowMethods:     cfg.AllowMethods,
+		AllowHeaders:     cfg.AllowHeaders,
+		ExposeHeaders:    cfg.ExposeHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           int(cfg.MaxAge / time.Second),
+	}
+}
+
+// corsHeaders are the response headers a CORS policy sets
+var corsHeaders = []string{
+	"Access-Control-Allow-Origin",
+	"Access-Control-Allow-Methods",
+	"Access-Control-Allow-Headers",
+	"Access-Control-Allow-Credentials",
+	"Access-Control-Expose-Headers",
+	"Access-Control-Max-Age",
+}
+
+// CORSWithConfig returns CORS middleware with custom config.
+//
+// Policies nest: one attached to a route group replaces the global policy
+// for the group's routes. Preflight requests only reach a group's policy
+// when the path has an OPTIONS route, otherwise the global policy answers:
+//
+//	docs := middleware.CORSWithConfig(middleware.CORSConfig{AllowOrigins: []string{"*"}})
+//	engine.GET("/docs/*any", docs, handler)
+//	engine.OPTIONS("/docs/*any", docs)
+//
+// A wildcard origin is always answered with "*" and never with the request's
+// own origin, so AllowCredentials has no effect there: browsers refuse to
+// send credentials to "*". List the trusted origins to allow credentials.
 func CORSWithConfig(cfg CORSConfig) gin.HandlerFunc {
 	// Build origin matcher
 	matcher := buildOriginMatcher(cfg.AllowOrigins)
@@ -81,8 +113,18 @@ func CORSWithConfig(cfg CORSConfig) gin.HandlerFunc {
 	allowMethodsStr := strings.Join(cfg.AllowMethods, ", ")
 	allowHeadersStr := strings.Join(cfg.AllowHeaders, ", ")
 	exposeHeadersStr := strings.Join(cfg.ExposeHeaders, ", ")
+	maxAgeStr := ""
+	if cfg.MaxAge > 0 {
+		maxAgeStr = strconv.Itoa(cfg.MaxAge)
+	}
 
 	return func(c *gin.Context) {
+		// An outer policy may already have answered; this one replaces it
+		header := c.Writer.Header()
+		for _, h := range corsHeaders {
+			header.Del(h)
+		}
+
 		origin := c.GetHeader("Origin")
 
 		// If no origin header, skip CORS
@@ -96,43 +138,61 @@ func CORSWithConfig(cfg CORSConfig) gin.HandlerFunc {
 		if matcher.allowAll {
 			allowOrigin = "*"
 		} else if matcher.matches(origin) {
-			allowOrigin = origin // Return actual origin for pattern matches
+			allowOrigin = origin // Reflect the origin for patterns and credentials
 		}
+		if allowOrigin != "*" && !slices.Contains(header.Values("Vary"), "Origin") {
+			header.Add("Vary", "Origin")
+		}
+
+		preflight := c.Request.Method == http.MethodOptions
 
 		// If origin not allowed, skip CORS headers
 		if allowOrigin == "" {
+			if preflight {
+				respondPreflight(c)
+				return
+			}
 			c.Next()
 			return
 		}
 
+		c.Header("Access-Control-Allow-Origin", allowOrigin)
+		if cfg.AllowCredentials && allowOrigin != "*" {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
 		// Handle preflight request
-		if c.Request.Method == http.MethodOptions {
-			c.Header("Access-Control-Allow-Origin", allowOrigin)
+		if preflight {
 			c.Header("Access-Control-Allow-Methods", allowMethodsStr)
 			c.Header("Access-Control-Allow-Headers", allowHeadersStr)
-			c.Header("Access-Control-Max-Age", "86400")
-
-			if cfg.AllowCredentials && allowOrigin != "*" {
-				c.Header("Access-Control-Allow-Credentials", "true")
+			if maxAgeStr != "" {
+				c.Header("Access-Control-Max-Age", maxAgeStr)
 			}
-
-			c.AbortWithStatus(http.StatusNoContent)
+			respondPreflight(c)
 			return
 		}
 
 		// Handle simple/actual request
-		c.Header("Access-Control-Allow-Origin", allowOrigin)
 		if exposeHeadersStr != "" {
 			c.Header("Access-Control-Expose-Headers", exposeHeadersStr)
 		}
-		if cfg.AllowCredentials && allowOrigin != "*" {
-			c.Header("Access-Control-Allow-Credentials", "true")
-		}
 
 		c.Next()
 	}
 }
 
+// respondPreflight ends a preflight request with 204. When the path has an
+// OPTIONS route, the rest of the chain runs first, so a policy on the
+// route's group can replace this one.
+func respondPreflight(c *gin.Context) {
+	if c.FullPath() != "" {
+		c.Next()
+	}
+	if !c.Writer.Written() {
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
 // buildOriginMatcher creates a matcher from origin patterns
 func buildOriginMatcher(origins []string) *originMatcher {
 	m := &originMatcher{
@@ -216,3 +216,125 @@ func TestCORS_WildcardSubdomain(t *testing.T) {
 		}
 	}
 }
+
+func corsRequest(router http.Handler, method, path, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Origin", origin)
+	if method == http.MethodOptions {
+		req.Header.Set("Access-Control-Request-Method", "POST")
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORS_PreflightMaxAge(t *testing.T) {
+	router := gin.New()
+	router.Use(CORSWithConfig(CORSConfig{
+		AllowOrigins: []string{"http://localhost:3000"},
+		AllowMethods: []string{"GET", "POST"},
+		MaxAge:       600,
+	}))
+	router.POST("/test", func(c *gin.Context) {})
+
+	w := corsRequest(router, http.MethodOptions, "/test", "http://localhost:3000")
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("Expected Access-Control-Max-Age 600, got %q", got)
+	}
+
+	// A disallowed origin gets no CORS headers, and the handler never runs
+	w = corsRequest(router, http.MethodOptions, "/test", "http://evil.com")
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected no Access-Control-Allow-Origin, got %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "" {
+		t.Errorf("Expected no Access-Control-Max-Age, got %q", got)
+	}
+
+	// No max age leaves caching to the browser
+	router = gin.New()
+	router.Use(CORSWithConfig(CORSConfig{AllowOrigins: []string{"*"}}))
+	w = corsRequest(router, http.MethodOptions, "/test", "http://localhost:3000")
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "" {
+		t.Errorf("Expected no Access-Control-Max-Age, got %q", got)
+	}
+}
+
+func TestCORS_WildcardWithCredentialsNeverReflectsOrigin(t *testing.T) {
+	router := gin.New()
+	router.Use(CORSWithConfig(CORSConfig{
+		AllowOrigins:     []string{"*"},
+		AllowCredentials: true,
+	}))
+	router.GET("/test", func(c *gin.Context) {
+		c.String(200, "ok")
+	})
+
+	// Reflecting the origin would let any site make credentialed requests
+	w := corsRequest(router, http.MethodGet, "/test", "http://evil.com")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Expected *, got %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Expected no credentials header, got %q", got)
+	}
+}
+
+func TestCORS_RouteGroupOverridesGlobalPolicy(t *testing.T) {
+	router := gin.New()
+	router.Use(CORSWithConfig(CORSConfig{
+		AllowOrigins:     []string{"http://app.example.com"},
+		AllowCredentials: true,
+		MaxAge:           3600,
+	}))
+	router.GET("/api/users", func(c *gin.Context) {
+		c.String(200, "ok")
+	})
+
+	public := CORSWithConfig(CORSConfig{
+		AllowOrigins: []string{"*"},
+		AllowMethods: []string{"GET", "OPTIONS"},
+		MaxAge:       60,
+	})
+	docs := router.Group("/docs", public)
+	docs.GET("/openapi.json", func(c *gin.Context) {
+		c.String(200, "{}")
+	})
+	docs.OPTIONS("/openapi.json")
+
+	// The docs accept any origin, without credentials
+	w := corsRequest(router, http.MethodGet, "/docs/openapi.json", "http://elsewhere.com")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Expected *, got %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Expected no credentials header, got %q", got)
+	}
+
+	w = corsRequest(router, http.MethodOptions, "/docs/openapi.json", "http://app.example.com")
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, got %d", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "60" {
+		t.Errorf("Expected the docs max age 60, got %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Expected *, got %q", got)
+	}
+
+	// Everything else keeps the global policy
+	w = corsRequest(router, http.MethodGet, "/api/users", "http://elsewhere.com")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected no CORS header, got %q", got)
+	}
+	w = corsRequest(router, http.MethodOptions, "/api/users", "http://app.example.com")
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "3600" {
+		t.Errorf("Expected the global max age 3600, got %q", got)
+	}
+}
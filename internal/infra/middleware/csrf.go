@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// csrfTokenKey is the gin context key for the request's CSRF token
+const csrfTokenKey = "csrf_token"
+
+// csrfTokenBytes is the amount of randomness in a token
+const csrfTokenBytes = 32
+
+// CSRFOptions holds CSRF middleware configuration
+type CSRFOptions struct {
+	// CookieName is the cookie carrying the token. It is readable from
+	// JavaScript so the front end can copy it into the header.
+	// Default: "XSRF-TOKEN"
+	CookieName string
+
+	// HeaderName is the header unsafe requests must echo the token in
+	// Default: "X-CSRF-Token"
+	HeaderName string
+
+	// CookiePath and CookieDomain scope the cookie
+	// Default: "/" and the request host
+	CookiePath   string
+	CookieDomain string
+
+	// Secure limits the cookie to HTTPS
+	// Default: false
+	Secure bool
+
+	// SameSite is the cookie's SameSite attribute
+	// Default: http.SameSiteLaxMode
+	SameSite http.SameSite
+
+	// MaxAge is how long the browser keeps the cookie
+	// Default: 12 hours
+	MaxAge time.Duration
+
+	// ExemptPaths are never checked. An entry ending in "*" matches every
+	// path with that prefix, e.g. "/v1/webhooks/*".
+	// Default: none
+	ExemptPaths []string
+}
+
+// DefaultCSRFOptions returns default configuration
+func DefaultCSRFOptions() CSRFOptions {
+	return CSRFOptions{
+		CookieName: "XSRF-TOKEN",
+		HeaderName: "X-CSRF-Token",
+		CookiePath: "/",
+		SameSite:   http.SameSiteLaxMode,
+		MaxAge:     12 * time.Hour,
+	}
+}
+
+// CSRF returns middleware guarding cookie-authenticated routes against
+// cross-site request forgery with the double-submit cookie pattern. A
+// client without a token cookie is issued a random one; POST, PUT, PATCH and
+// DELETE must send the same token in the header, which another site cannot
+// read and so cannot copy. Mismatches get a 403.
+//
+// Requests with a Bearer Authorization header pass unchecked: browsers
+// never attach one on their own, so a forged request cannot carry it.
+//
+// Example:
+//
+//	r.Use(middleware.CSRF(middleware.DefaultCSRFOptions()))
+//
+//	// In the SPA: read the XSRF-TOKEN cookie and send it back
+//	fetch("/v1/users/profile", {method: "PUT", headers: {"X-CSRF-Token": token}})
+func CSRF(opts CSRFOptions) gin.HandlerFunc {
+	// Set defaults
+	defaults := DefaultCSRFOptions()
+	if opts.CookieName == "" {
+		opts.CookieName = defaults.CookieName
+	}
+	if opts.HeaderName == "" {
+		opts.HeaderName = defaults.HeaderName
+	}
+	if opts.CookiePath == "" {
+		opts.CookiePath = defaults.CookiePath
+	}
+	if opts.SameSite == 0 {
+		opts.SameSite = defaults.SameSite
+	}
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = defaults.MaxAge
+	}
+
+	return func(c *gin.Context) {
+		token, err := c.Cookie(opts.CookieName)
+		issued := err != nil || !validCSRFToken(token)
+		if issued {
+			token = newCSRFToken()
+		}
+		c.Set(csrfTokenKey, token)
+
+		// Issue the cookie even on a rejection so the client can retry
+		if issued {
+			http.SetCookie(c.Writer, &http.Cookie{
+				Name:     opts.CookieName,
+				Value:    token,
+				Path:     opts.CookiePath,
+				Domain:   opts.CookieDomain,
+				MaxAge:   int(opts.MaxAge.Seconds()),
+				Secure:   opts.Secure,
+				HttpOnly: false,
+				SameSite: opts.SameSite,
+			})
+		}
+
+		if !isSafeMethod(c.Request.Method) && !csrfExempt(c, opts.ExemptPaths) {
+			// A freshly issued token cannot have been sent back yet
+			sent := c.GetHeader(opts.HeaderName)
+			if issued || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+				response.Forbidden(c, "Invalid CSRF token")
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// CSRFToken returns the CSRF token of the request, for pages and SPA
+// bootstrap endpoints that hand it to the front end instead of having it
+// read the cookie. Empty outside the CSRF middleware.
+//
+// Example:
+//
+//	api.GET("/csrf-token", func(c *gin.Context) {
+//	    response.Success(c, gin.H{"token": middleware.CSRFToken(c)})
+//	})
+func CSRFToken(c *gin.Context) string {
+	return c.GetString(csrfTokenKey)
+}
+
+// csrfExempt reports whether the request skips the token check
+func csrfExempt(c *gin.Context, exemptPaths []string) bool {
+	if scheme, _, ok := strings.Cut(c.GetHeader("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		return true
+	}
+
+	path := c.Request.URL.Path
+	for _, exempt := range exemptPaths {
+		if prefix, ok := strings.CutSuffix(exempt, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == exempt {
+			return true
+		}
+	}
+	return false
+}
+
+func newCSRFToken() string {
+	b := make([]byte, csrfTokenBytes)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// validCSRFToken rejects cookie values the middleware cannot have issued
+func validCSRFToken(token string) bool {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	return err == nil && len(b) == csrfTokenBytes
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCSRFRouter(opts CSRFOptions) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(CSRF(opts))
+	r.GET("/csrf-token", func(c *gin.Context) {
+		c.String(http.StatusOK, CSRFToken(c))
+	})
+	r.PUT("/profile", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	r.POST("/hooks/stripe", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	return r
+}
+
+// fetchCSRFToken performs a safe request and returns the issued cookie
+func fetchCSRFToken(t *testing.T, r http.Handler) *http.Cookie {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/csrf-token", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for GET, got %d", w.Code)
+	}
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == "XSRF-TOKEN" {
+			if w.Body.String() != cookie.Value {
+				t.Errorf("Expected CSRFToken %q to match the cookie %q", w.Body.String(), cookie.Value)
+			}
+			return cookie
+		}
+	}
+	t.Fatal("Expected an XSRF-TOKEN cookie")
+	return nil
+}
+
+func putProfile(r http.Handler, cookie *http.Cookie, header, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/profile", nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	if header != "" {
+		req.Header.Set("X-CSRF-Token", header)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCSRF_AcceptsMatchingToken(t *testing.T) {
+	r := newCSRFRouter(DefaultCSRFOptions())
+	cookie := fetchCSRFToken(t, r)
+
+	w := putProfile(r, cookie, cookie.Value, "")
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204 with a matching token, got %d", w.Code)
+	}
+	if len(w.Result().Cookies()) != 0 {
+		t.Error("Expected a valid token to be kept, not reissued")
+	}
+}
+
+func TestCSRF_RejectsMissingOrWrongToken(t *testing.T) {
+	r := newCSRFRouter(DefaultCSRFOptions())
+	cookie := fetchCSRFToken(t, r)
+	other := fetchCSRFToken(t, r)
+
+	cases := map[string]*httptest.ResponseRecorder{
+		"no header":      putProfile(r, cookie, "", ""),
+		"no cookie":      putProfile(r, nil, cookie.Value, ""),
+		"wrong header":   putProfile(r, cookie, other.Value, ""),
+		"basic auth":     putProfile(r, cookie, "", "Basic YWxpY2U6c2VjcmV0"),
+		"forged cookie":  putProfile(r, &http.Cookie{Name: "XSRF-TOKEN", Value: "x"}, "x", ""),
+		"nothing at all": putProfile(r, nil, "", ""),
+	}
+	for name, w := range cases {
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected 403, got %d", name, w.Code)
+		}
+	}
+}
+
+func TestCSRF_SkipsBearerTokensAndExemptPaths(t *testing.T) {
+	opts := DefaultCSRFOptions()
+	opts.ExemptPaths = []string{"/hooks/*"}
+	r := newCSRFRouter(opts)
+
+	if w := putProfile(r, nil, "", "Bearer eyJhbGciOiJIUzI1NiJ9.e30.sig"); w.Code != http.StatusNoContent {
+		t.Errorf("Expected bearer request to pass, got %d", w.Code)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/hooks/stripe", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected exempt path to pass, got %d", w.Code)
+	}
+}
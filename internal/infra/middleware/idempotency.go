@@ -0,0 +1,361 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/kv"
+	"github.com/zgiai/zgo/pkg/logger"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// IdempotentResponse is a stored response replayed for retried requests
+type IdempotentResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// IdempotencyStore keeps first responses and in-flight markers per key.
+// KVIdempotencyStore shares keys across instances through a kv.Store.
+type IdempotencyStore interface {
+	// Get returns the stored response for key, or nil when there is none
+	Get(ctx context.Context, key string) (*IdempotentResponse, error)
+
+	// Put stores the response for key for ttl
+	Put(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration) error
+
+	// Lock marks key as in flight for at most ttl. It returns false when
+	// another request already holds the key.
+	Lock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// Unlock clears the in-flight marker for key
+	Unlock(ctx context.Context, key string) error
+}
+
+// IdempotencyOptions holds Idempotency middleware configuration
+type IdempotencyOptions struct {
+	// Store holds responses and in-flight markers
+	// Default: in-memory store
+	Store IdempotencyStore
+
+	// Header carries the client's idempotency key
+	// Default: "Idempotency-Key"
+	Header string
+
+	// TTL is how long a response is replayed for
+	// Default: 24 hours
+	TTL time.Duration
+
+	// LockTTL bounds how long a request may hold its key, so a crashed
+	// request does not block retries forever
+	// Default: 1 minute
+	LockTTL time.Duration
+
+	// ScopeFunc identifies the caller, so keys from different users never collide
+	// Default: authenticated user ID, falling back to client IP
+	ScopeFunc func(*gin.Context) string
+}
+
+// DefaultIdempotencyOptions returns default configuration
+func DefaultIdempotencyOptions() IdempotencyOptions {
+	return IdempotencyOptions{
+		Header:    "Idempotency-Key",
+		TTL:       24 * time.Hour,
+		LockTTL:   time.Minute,
+		ScopeFunc: idempotencyScope,
+	}
+}
+
+// Idempotency returns middleware that makes unsafe requests (POST, PUT,
+// PATCH, DELETE) carrying an Idempotency-Key header safe to retry.
+// The first response for a key, route and user is stored and replayed for
+// retries; a retry arriving while the first request is still running gets
+// 409 Conflict. Requests without the header pass through untouched.
+//
+// Example:
+//
+//	auth.POST("/register", middleware.Idempotency(middleware.NewKVIdempotencyStore(kv.Default())), h.Register)
+func Idempotency(store IdempotencyStore) gin.HandlerFunc {
+	opts := DefaultIdempotencyOptions()
+	opts.Store = store
+	return IdempotencyWithOptions(opts)
+}
+
+// IdempotencyWithOptions returns idempotency middleware with custom options
+func IdempotencyWithOptions(opts IdempotencyOptions) gin.HandlerFunc {
+	defaults := DefaultIdempotencyOptions()
+	if opts.Store == nil {
+		opts.Store = NewMemoryIdempotencyStore()
+	}
+	if opts.Header == "" {
+		opts.Header = defaults.Header
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = defaults.TTL
+	}
+	if opts.LockTTL <= 0 {
+		opts.LockTTL = defaults.LockTTL
+	}
+	if opts.ScopeFunc == nil {
+		opts.ScopeFunc = defaults.ScopeFunc
+	}
+
+	return func(c *gin.Context) {
+		idemKey := c.GetHeader(opts.Header)
+		if idemKey == "" || isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		key := fmt.Sprintf("idempotency:%s:%s %s:%s", opts.ScopeFunc(c), c.Request.Method, route, idemKey)
+
+		if replayIdempotent(c, opts.Store, key) {
+			return
+		}
+
+		locked, err := opts.Store.Lock(ctx, key, opts.LockTTL)
+		if err != nil {
+			// Fail open: a broken store should not take the API down
+			logger.Error("idempotency store error", map[string]any{"error": err})
+			c.Next()
+			return
+		}
+		if !locked {
+			response.Abort(c, http.StatusConflict, "A request with this idempotency key is still in progress")
+			return
+		}
+		defer func() {
+			if err := opts.Store.Unlock(context.WithoutCancel(ctx), key); err != nil {
+				logger.Error("idempotency store error", map[string]any{"error": err})
+			}
+		}()
+
+		// The first request may have finished between the lookup and the lock
+		if replayIdempotent(c, opts.Store, key) {
+			return
+		}
+
+		rec := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = rec
+		c.Next()
+
+		// Server errors are not stored, so the client can retry them
+		status := rec.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		err = opts.Store.Put(context.WithoutCancel(ctx), key, &IdempotentResponse{
+			Status:      status,
+			ContentType: rec.Header().Get("Content-Type"),
+			Body:        rec.body.Bytes(),
+		}, opts.TTL)
+		if err != nil {
+			logger.Error("idempotency store error", map[string]any{"error": err})
+		}
+	}
+}
+
+// replayIdempotent writes the stored response for key, if any
+func replayIdempotent(c *gin.Context, store IdempotencyStore, key string) bool {
+	stored, err := store.Get(c.Request.Context(), key)
+	if err != nil || stored == nil {
+		return false
+	}
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(stored.Status, stored.ContentType, stored.Body)
+	c.Abort()
+	return true
+}
+
+// idempotencyScope scopes keys to the authenticated user, or the client IP
+func idempotencyScope(c *gin.Context) string {
+	if userID, ok := c.Get("userID"); ok {
+		return fmt.Sprintf("user:%v", userID)
+	}
+	return "ip:" + ClientIP(c)
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// recordingWriter records the response body while writing it through
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotencySweepInterval is how often MemoryIdempotencyStore drops
+// expired responses and locks on write
+const idempotencySweepInterval = time.Minute
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	responses map[string]idempotencyEntry
+	locks     map[string]time.Time
+	lastSweep time.Time
+}
+
+type idempotencyEntry struct {
+	resp      *IdempotentResponse
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore creates an empty in-memory store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		responses: make(map[string]idempotencyEntry),
+		locks:     make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+// Get returns the stored response for key
+func (s *MemoryIdempotencyStore) Get(ctx context.Context, key string) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.responses[key]
+	if !ok {
+		return nil, nil
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(s.responses, key)
+		return nil, nil
+	}
+	return entry.resp, nil
+}
+
+// Put stores the response for key
+func (s *MemoryIdempotencyStore) Put(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration) error {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+	s.responses[key] = idempotencyEntry{resp: resp, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// Lock marks key as in flight
+func (s *MemoryIdempotencyStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+	if until, ok := s.locks[key]; ok && now.Before(until) {
+		return false, nil
+	}
+	s.locks[key] = now.Add(ttl)
+	return true, nil
+}
+
+// Unlock clears the in-flight marker for key
+func (s *MemoryIdempotencyStore) Unlock(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.locks, key)
+	return nil
+}
+
+// sweep drops expired responses and locks at most once per
+// idempotencySweepInterval, so keys that are never retried do not pile up
+func (s *MemoryIdempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < idempotencySweepInterval {
+		return
+	}
+	for key, entry := range s.responses {
+		if !now.Before(entry.expiresAt) {
+			delete(s.responses, key)
+		}
+	}
+	for key, until := range s.locks {
+		if !now.Before(until) {
+			delete(s.locks, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+// KVIdempotencyStore is an IdempotencyStore backed by a kv.Store, so a
+// Redis store shares keys across instances
+type KVIdempotencyStore struct {
+	store kv.Store
+}
+
+// NewKVIdempotencyStore creates a store on top of a kv store
+func NewKVIdempotencyStore(store kv.Store) *KVIdempotencyStore {
+	return &KVIdempotencyStore{store: store}
+}
+
+// Get returns the stored response for key
+func (s *KVIdempotencyStore) Get(ctx context.Context, key string) (*IdempotentResponse, error) {
+	data, err := s.store.Get(ctx, key)
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var resp IdempotentResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Put stores the response for key
+func (s *KVIdempotencyStore) Put(ctx context.Context, key string, resp *IdempotentResponse, ttl time.Duration) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(ctx, key, data, ttl)
+}
+
+// Lock marks key as in flight. Only the request whose increment creates the
+// marker wins, and the marker expires with ttl even if Unlock never runs.
+func (s *KVIdempotencyStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	n, err := s.store.Incr(ctx, key+":lock", ttl)
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// Unlock clears the in-flight marker for key
+func (s *KVIdempotencyStore) Unlock(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, key+":lock")
+}
@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/kv"
+)
+
+func newIdempotencyRouter(store IdempotencyStore, handler gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.POST("/orders", Idempotency(store), handler)
+	return r
+}
+
+func postOrder(r http.Handler, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func testIdempotencyReplay(t *testing.T, store IdempotencyStore) {
+	var calls int32
+	r := newIdempotencyRouter(store, func(c *gin.Context) {
+		n := atomic.AddInt32(&calls, 1)
+		c.JSON(http.StatusCreated, gin.H{"order": n})
+	})
+
+	first := postOrder(r, "abc")
+	second := postOrder(r, "abc")
+
+	if first.Code != http.StatusCreated || second.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 twice, got %d and %d", first.Code, second.Code)
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Errorf("Expected replayed body %q, got %q", first.Body.String(), second.Body.String())
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected Idempotent-Replayed header on retry")
+	}
+	if calls != 1 {
+		t.Errorf("Expected handler to run once, ran %d times", calls)
+	}
+
+	postOrder(r, "other")
+	if calls != 2 {
+		t.Errorf("Expected a new key to run the handler, ran %d times", calls)
+	}
+}
+
+func TestIdempotency_ReplaysFirstResponse(t *testing.T) {
+	testIdempotencyReplay(t, NewMemoryIdempotencyStore())
+}
+
+func TestIdempotency_KVStore(t *testing.T) {
+	testIdempotencyReplay(t, NewKVIdempotencyStore(kv.NewMemoryStore()))
+}
+
+func TestKVIdempotencyStore_LockExpires(t *testing.T) {
+	store := NewKVIdempotencyStore(kv.NewMemoryStore())
+	ctx := context.Background()
+
+	if ok, err := store.Lock(ctx, "k", 50*time.Millisecond); !ok || err != nil {
+		t.Fatalf("first Lock = %v, %v; want true", ok, err)
+	}
+	if ok, _ := store.Lock(ctx, "k", 50*time.Millisecond); ok {
+		t.Fatal("second Lock succeeded while the key was held")
+	}
+
+	// A request that never unlocks releases the key after the TTL
+	time.Sleep(60 * time.Millisecond)
+	if ok, _ := store.Lock(ctx, "k", 50*time.Millisecond); !ok {
+		t.Error("Lock failed after the marker expired")
+	}
+	if err := store.Unlock(ctx, "k"); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	if ok, _ := store.Lock(ctx, "k", time.Minute); !ok {
+		t.Error("Lock failed after Unlock")
+	}
+}
+
+func TestMemoryIdempotencyStore_SweepsExpiredKeys(t *testing.T) {
+	store := NewMemoryIdempotencyStore()
+	ctx := context.Background()
+
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		store.Put(ctx, key, &IdempotentResponse{Status: http.StatusCreated}, time.Millisecond)
+		store.Lock(ctx, key, time.Millisecond)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	// The next write after the sweep interval drops everything expired
+	store.lastSweep = time.Now().Add(-idempotencySweepInterval)
+	store.Put(ctx, "live", &IdempotentResponse{Status: http.StatusCreated}, time.Hour)
+	store.Lock(ctx, "live", time.Hour)
+
+	if len(store.responses) != 1 || len(store.locks) != 1 {
+		t.Errorf("Expected only the live key to remain, got %d responses and %d locks", len(store.responses), len(store.locks))
+	}
+	if resp, _ := store.Get(ctx, "live"); resp == nil {
+		t.Error("Expected the live response to survive the sweep")
+	}
+}
+
+func TestIdempotency_WithoutKeyPassesThrough(t *testing.T) {
+	var calls int32
+	r := newIdempotencyRouter(NewMemoryIdempotencyStore(), func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.Status(http.StatusCreated)
+	})
+
+	postOrder(r, "")
+	postOrder(r, "")
+	if calls != 2 {
+		t.Errorf("Expected handler to run twice, ran %d times", calls)
+	}
+}
+
+func TestIdempotency_ServerErrorsAreNotStored(t *testing.T) {
+	var calls int32
+	r := newIdempotencyRouter(NewMemoryIdempotencyStore(), func(c *gin.Context) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			c.Status(http.StatusServiceUnavailable)
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	if w := postOrder(r, "abc"); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503, got %d", w.Code)
+	}
+	if w := postOrder(r, "abc"); w.Code != http.StatusCreated {
+		t.Errorf("Expected retry to run again and return 201, got %d", w.Code)
+	}
+}
+
+func TestIdempotency_ConcurrentInFlight(t *testing.T) {
+	for name, store := range map[string]IdempotencyStore{
+		"memory": NewMemoryIdempotencyStore(),
+		"kv":     NewKVIdempotencyStore(kv.NewMemoryStore()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			entered := make(chan struct{})
+			release := make(chan struct{})
+			r := newIdempotencyRouter(store, func(c *gin.Context) {
+				close(entered)
+				<-release
+				c.Status(http.StatusCreated)
+			})
+
+			done := make(chan *httptest.ResponseRecorder)
+			go func() { done <- postOrder(r, "abc") }()
+			<-entered
+
+			if w := postOrder(r, "abc"); w.Code != http.StatusConflict {
+				t.Errorf("Expected 409 while first request is in flight, got %d", w.Code)
+			}
+
+			close(release)
+			if w := <-done; w.Code != http.StatusCreated {
+				t.Errorf("Expected first request to return 201, got %d", w.Code)
+			}
+			if w := postOrder(r, "abc"); w.Code != http.StatusCreated {
+				t.Errorf("Expected replay after completion, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestIdempotency_ScopedPerUser(t *testing.T) {
+	var calls int32
+	r := gin.New()
+	r.POST("/orders", func(c *gin.Context) {
+		c.Set("userID", c.GetHeader("X-User"))
+	}, Idempotency(NewMemoryIdempotencyStore()), func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.Status(http.StatusCreated)
+	})
+
+	for i := 1; i <= 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+		req.Header.Set("Idempotency-Key", "abc")
+		req.Header.Set("X-User", strconv.Itoa(i))
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if calls != 2 {
+		t.Errorf("Expected the same key from two users to run twice, ran %d times", calls)
+	}
+}
@@ -29,7 +29,7 @@ func JWTAuth() gin.HandlerFunc {
 			return
 		}
 
-		authHeader := c.GetHeader("Authorization")
+		authHeader := authorizationHeader(c)
 		if authHeader == "" {
 			response.Error(c, http.StatusUnauthorized, "Authorization header required")
 			c.Abort()
@@ -51,6 +51,15 @@ func JWTAuth() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if jwtService.UserTokensRevoked(c.Request.Context(), claims.UserID, claims.IssuedTime()) {
+			response.Error(c, http.StatusUnauthorized, "Token has been revoked")
+			c.Abort()
+			return
+		}
+
+		if !bindTenantClaim(c, claims.TenantID) {
+			return
+		}
 
 		// Store user information in context
 		c.Set("userID", claims.UserID)
@@ -64,7 +73,7 @@ func JWTAuth() gin.HandlerFunc {
 // Use this when you have access to the JWT service instance.
 func JWTAuthWithService(svc *jwt.Service) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
+		authHeader := authorizationHeader(c)
 		if authHeader == "" {
 			response.Error(c, http.StatusUnauthorized, "Authorization header required")
 			c.Abort()
@@ -84,6 +93,15 @@ func JWTAuthWithService(svc *jwt.Service) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if svc.UserTokensRevoked(c.Request.Context(), claims.UserID, claims.IssuedTime()) {
+			response.Error(c, http.StatusUnauthorized, "Token has been revoked")
+			c.Abort()
+			return
+		}
+
+		if !bindTenantClaim(c, claims.TenantID) {
+			return
+		}
 
 		c.Set("userID", claims.UserID)
 		c.Set("username", claims.Username)
@@ -91,3 +109,18 @@ func JWTAuthWithService(svc *jwt.Service) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// authorizationHeader returns the Authorization header. Browsers cannot set
+// headers on WebSocket handshakes, so for those a ?token= query parameter
+// is accepted instead.
+func authorizationHeader(c *gin.Context) string {
+	if header := c.GetHeader("Authorization"); header != "" {
+		return header
+	}
+	if strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+		if token := c.Query("token"); token != "" {
+			return "Bearer " + token
+		}
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/jwt"
+	"github.com/zgiai/zgo/internal/infra/kv"
+)
+
+func TestJWTAuth_RejectsRevokedTokens(t *testing.T) {
+	kv.SetDefault(kv.NewMemoryStore())
+	t.Cleanup(func() { kv.SetDefault(nil) })
+
+	svc := jwt.NewTestService()
+	r := gin.New()
+	r.Use(JWTAuthWithService(svc))
+	r.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	token, err := svc.GenerateToken(7, "alice")
+	require.NoError(t, err)
+	auth := map[string]string{"Authorization": "Bearer " + token}
+
+	assert.Equal(t, http.StatusOK, doRequest(r, "/test", "10.0.0.1:1234", auth).Code)
+
+	require.NoError(t, svc.RevokeUserTokens(context.Background(), 7))
+	assert.Equal(t, http.StatusUnauthorized, doRequest(r, "/test", "10.0.0.1:1234", auth).Code)
+
+	// Re-enabling the account accepts new tokens, not the revoked ones.
+	// Issue times are whole seconds, so move past the token's.
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+	require.NoError(t, svc.RestoreUserTokens(context.Background(), 7))
+	assert.Equal(t, http.StatusUnauthorized, doRequest(r, "/test", "10.0.0.1:1234", auth).Code)
+
+	token, err = svc.GenerateToken(7, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, doRequest(r, "/test", "10.0.0.1:1234", map[string]string{"Authorization": "Bearer " + token}).Code)
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/lang"
+)
+
+// LocaleConfig holds Locale middleware configuration
+type LocaleConfig struct {
+	// Default is used when the request selects no supported locale
+	// Default: en
+	Default string
+
+	// Supported lists the locales a request may select
+	// Default: [Default]
+	Supported []string
+
+	// QueryParam overrides Accept-Language when present
+	// Default: lang
+	QueryParam string
+}
+
+// DefaultLocaleConfig returns default configuration
+func DefaultLocaleConfig() LocaleConfig {
+	return LocaleConfig{
+		Default:    "en",
+		Supported:  []string{"en"},
+		QueryParam: "lang",
+	}
+}
+
+// Locale resolves the request locale from the query parameter, then the
+// Accept-Language header, and stores it on the gin and request contexts
+func Locale(cfg LocaleConfig) gin.HandlerFunc {
+	if cfg.Default == "" {
+		cfg.Default = "en"
+	}
+	if len(cfg.Supported) == 0 {
+		cfg.Supported = []string{cfg.Default}
+	}
+	if cfg.QueryParam == "" {
+		cfg.QueryParam = "lang"
+	}
+
+	return func(c *gin.Context) {
+		locale := ResolveLocale(c, cfg)
+
+		c.Set("locale", locale)
+		c.Request = c.Request.WithContext(lang.WithLocale(c.Request.Context(), locale))
+		c.Header("Content-Language", locale)
+
+		c.Next()
+	}
+}
+
+// ResolveLocale picks the supported locale the request asks for, or the default
+func ResolveLocale(c *gin.Context, cfg LocaleConfig) string {
+	if locale, ok := lang.Match(c.Query(cfg.QueryParam), cfg.Supported); ok {
+		return locale
+	}
+	if locale, ok := lang.Negotiate(c.GetHeader("Accept-Language"), cfg.Supported); ok {
+		return locale
+	}
+	return cfg.Default
+}
+
+// GetLocale retrieves the locale set by the Locale middleware
+func GetLocale(c *gin.Context) string {
+	if locale, exists := c.Get("locale"); exists {
+		return locale.(string)
+	}
+	return lang.GetLocale()
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/lang"
+)
+
+func newLocaleRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(Locale(LocaleConfig{Default: "en", Supported: []string{"en", "zh"}}))
+	r.GET("/test", func(c *gin.Context) {
+		c.String(http.StatusOK, GetLocale(c)+","+lang.FromContext(c.Request.Context()))
+	})
+	return r
+}
+
+func TestLocale_Resolution(t *testing.T) {
+	r := newLocaleRouter()
+
+	tests := []struct {
+		name    string
+		path    string
+		headers map[string]string
+		want    string
+	}{
+		{"default", "/test", nil, "en"},
+		{"accept-language", "/test", map[string]string{"Accept-Language": "zh-CN,zh;q=0.9"}, "zh"},
+		{"query overrides header", "/test?lang=en", map[string]string{"Accept-Language": "zh"}, "en"},
+		{"unsupported query ignored", "/test?lang=fr", map[string]string{"Accept-Language": "zh"}, "zh"},
+		{"unsupported header", "/test", map[string]string{"Accept-Language": "fr"}, "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(r, tt.path, "10.0.0.1:1234", tt.headers)
+			if body := w.Body.String(); body != tt.want+","+tt.want {
+				t.Errorf("locale = %q, want %q on gin and request context", body, tt.want)
+			}
+			if got := w.Header().Get("Content-Language"); got != tt.want {
+				t.Errorf("Content-Language = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,14 +12,14 @@ func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
-		raw := c.Request.URL.RawQuery
+		raw := redactQuery(c.Request.URL.RawQuery)
 
 		// Process request
 		c.Next()
 
 		// Request processed
 		latency := time.Since(start)
-		clientIP := c.ClientIP()
+		clientIP := ClientIP(c)
 		method := c.Request.Method
 		statusCode := c.Writer.Status()
 
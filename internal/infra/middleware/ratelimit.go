@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/ratelimit"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// RateLimitOptions holds RateLimit middleware configuration
+type RateLimitOptions struct {
+	// Requests is the number of requests refilled per Window
+	// Default: 60
+	Requests int
+
+	// Window is the refill period for Requests
+	// Default: 1 minute
+	Window time.Duration
+
+	// Burst is the bucket capacity, i.e. requests allowed back to back
+	// Default: Requests
+	Burst int
+
+	// KeyFunc extracts the bucket key from the request
+	// Default: client IP
+	KeyFunc func(*gin.Context) string
+
+	// Limiter holds the buckets
+	// Default: ratelimit.TokenBucketStore built from Requests, Window and Burst
+	Limiter ratelimit.Limiter
+}
+
+// DefaultRateLimitOptions returns default configuration
+func DefaultRateLimitOptions() RateLimitOptions {
+	return RateLimitOptions{
+		Requests: 60,
+		Window:   time.Minute,
+		KeyFunc:  ClientIP,
+	}
+}
+
+// RateLimit returns token-bucket rate limiting middleware.
+// Requests over the limit receive 429 with a Retry-After header.
+func RateLimit(opts RateLimitOptions) gin.HandlerFunc {
+	// Set defaults
+	if opts.Requests <= 0 {
+		opts.Requests = 60
+	}
+	if opts.Window <= 0 {
+		opts.Window = time.Minute
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = opts.Requests
+	}
+	if opts.KeyFunc == nil {
+		opts.KeyFunc = ClientIP
+	}
+	if opts.Limiter == nil {
+		opts.Limiter = ratelimit.NewTokenBucketStore(opts.Requests, opts.Window, opts.Burst)
+	}
+
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		key := opts.KeyFunc(c)
+
+		allowed, _, resetAt := opts.Limiter.Allow(ctx, key)
+		if !allowed {
+			seconds := int(math.Ceil(time.Until(resetAt).Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			response.Abort(c, http.StatusTooManyRequests, "Too many requests")
+			return
+		}
+
+		opts.Limiter.Hit(ctx, key)
+		c.Next()
+	}
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/kv"
+	"github.com/zgiai/zgo/internal/infra/ratelimit"
+	"github.com/zgiai/zgo/internal/infra/router"
+)
+
+func newRateLimitRouter(opts RateLimitOptions) *gin.Engine {
+	r := gin.New()
+	r.Use(RateLimit(opts))
+	r.GET("/test", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	return r
+}
+
+func doRequest(h http.Handler, path, remoteAddr string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remoteAddr
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_ExceedsLimit(t *testing.T) {
+	r := newRateLimitRouter(RateLimitOptions{Requests: 3, Window: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		if w := doRequest(r, "/test", "10.0.0.1:1234", nil); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i+1, w.Code)
+		}
+	}
+
+	w := doRequest(r, "/test", "10.0.0.1:1234", nil)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", w.Code)
+	}
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 || retryAfter > 20 {
+		t.Errorf("Retry-After = %q, want 1..20 seconds", w.Header().Get("Retry-After"))
+	}
+
+	// Other clients have their own bucket
+	if w := doRequest(r, "/test", "10.0.0.2:1234", nil); w.Code != http.StatusOK {
+		t.Errorf("other IP: status = %d, want 200", w.Code)
+	}
+}
+
+func TestRateLimit_BurstAndRefill(t *testing.T) {
+	// One token every 10ms, bucket holds 2
+	r := newRateLimitRouter(RateLimitOptions{Requests: 100, Window: time.Second, Burst: 2})
+
+	doRequest(r, "/test", "10.0.0.1:1234", nil)
+	doRequest(r, "/test", "10.0.0.1:1234", nil)
+	if w := doRequest(r, "/test", "10.0.0.1:1234", nil); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429 once the burst is spent", w.Code)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if w := doRequest(r, "/test", "10.0.0.1:1234", nil); w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200 after refill", w.Code)
+	}
+}
+
+func TestRateLimit_SharedLimiter(t *testing.T) {
+	opts := RateLimitOptions{Limiter: ratelimit.NewTokenBucket(kv.NewMemoryStore(), 3, time.Minute, 3)}
+
+	// Two routes built on one limiter share the buckets
+	first, second := newRateLimitRouter(opts), newRateLimitRouter(opts)
+	for i, r := range []http.Handler{first, second, first} {
+		if w := doRequest(r, "/test", "10.0.0.1:1234", nil); w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i+1, w.Code)
+		}
+	}
+
+	w := doRequest(second, "/test", "10.0.0.1:1234", nil)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", w.Code)
+	}
+	// One token arrives every 20 seconds
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 || retryAfter > 20 {
+		t.Errorf("Retry-After = %q, want 1..20 seconds", w.Header().Get("Retry-After"))
+	}
+
+	if w := doRequest(first, "/test", "10.0.0.2:1234", nil); w.Code != http.StatusOK {
+		t.Errorf("other IP: status = %d, want 200", w.Code)
+	}
+}
+
+func TestTokenBucketStore_AllowDoesNotConsume(t *testing.T) {
+	store := ratelimit.NewTokenBucketStore(1, time.Minute, 2)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if ok, remaining, _ := store.Allow(ctx, "k"); !ok || remaining != 2 {
+			t.Fatalf("Allow = %v, %d; want allowed with 2 left", ok, remaining)
+		}
+	}
+
+	store.Hit(ctx, "k")
+	if remaining, _ := store.Hit(ctx, "k"); remaining != 0 {
+		t.Fatalf("remaining after two hits = %d, want 0", remaining)
+	}
+	ok, _, resetAt := store.Allow(ctx, "k")
+	if wait := time.Until(resetAt); ok || wait <= 0 || wait > time.Minute {
+		t.Errorf("Allow = %v, retry in %v; want denied until the next token", ok, wait)
+	}
+
+	_ = store.Reset(ctx, "k")
+	if ok, _, _ := store.Allow(ctx, "k"); !ok {
+		t.Error("Allow denied after Reset")
+	}
+}
+
+func TestRateLimit_CustomKeyFunc(t *testing.T) {
+	r := newRateLimitRouter(RateLimitOptions{
+		Requests: 1,
+		Window:   time.Minute,
+		KeyFunc:  func(c *gin.Context) string { return c.GetHeader("X-API-Key") },
+	})
+
+	doRequest(r, "/test", "10.0.0.1:1234", map[string]string{"X-API-Key": "a"})
+	if w := doRequest(r, "/test", "10.0.0.2:1234", map[string]string{"X-API-Key": "a"}); w.Code != http.StatusTooManyRequests {
+		t.Errorf("same key from another IP: status = %d, want 429", w.Code)
+	}
+	if w := doRequest(r, "/test", "10.0.0.1:1234", map[string]string{"X-API-Key": "b"}); w.Code != http.StatusOK {
+		t.Errorf("different key: status = %d, want 200", w.Code)
+	}
+}
+
+func TestRateLimit_NamedMiddlewareGroup(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+	r.MiddlewareGroup("throttle", RateLimit(RateLimitOptions{Requests: 1, Window: time.Minute}))
+
+	r.Group("", func(g *router.Router) {
+		g.WithMiddleware("throttle")
+		g.GET("/limited", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	})
+	r.GET("/open", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+
+	doRequest(engine, "/limited", "10.0.0.1:1234", nil)
+	if w := doRequest(engine, "/limited", "10.0.0.1:1234", nil); w.Code != http.StatusTooManyRequests {
+		t.Errorf("/limited: status = %d, want 429", w.Code)
+	}
+	for i := 0; i < 3; i++ {
+		if w := doRequest(engine, "/open", "10.0.0.1:1234", nil); w.Code != http.StatusOK {
+			t.Errorf("/open: status = %d, want 200", w.Code)
+		}
+	}
+}
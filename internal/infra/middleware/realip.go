@@ -7,10 +7,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// realIPKey is where the resolved client IP is always stored for ClientIP
+const realIPKey = "real_ip"
+
 // RealIPConfig holds real IP middleware configuration
 type RealIPConfig struct {
-	// TrustedProxies is a list of trusted proxy IPs or CIDR ranges
-	// If empty, all proxies are trusted
+	// TrustedProxies is a list of trusted proxy IPs or CIDR ranges.
+	// Headers are only honoured when the immediate peer is listed here;
+	// if empty, no proxy is trusted and the peer address is the client.
 	TrustedProxies []string
 
 	// Headers is a list of headers to check for real IP (in order)
@@ -31,28 +35,99 @@ func DefaultRealIPConfig() RealIPConfig {
 	return RealIPConfig{
 		TrustedProxies: []string{},
 		Headers:        []string{"X-Forwarded-For", "X-Real-IP", "CF-Connecting-IP"},
-		ContextKey:     "real_ip",
+		ContextKey:     realIPKey,
 		RecursiveCheck: true,
 	}
 }
 
-// RealIP returns real IP middleware with default config
-func RealIP() gin.HandlerFunc {
-	return RealIPWithConfig(DefaultRealIPConfig())
+// RealIP resolves the client IP behind the given trusted proxies and stores
+// it for ClientIP. Forwarding headers from any other peer are ignored, so
+// clients cannot spoof their address.
+//
+// Example:
+//
+//	r.Use(middleware.RealIP([]string{"10.0.0.0/8", "192.168.1.10"}))
+func RealIP(trustedProxies []string) gin.HandlerFunc {
+	cfg := DefaultRealIPConfig()
+	cfg.TrustedProxies = trustedProxies
+	return RealIPWithConfig(cfg)
 }
 
 // RealIPWithConfig returns real IP middleware with custom config
 func RealIPWithConfig(cfg RealIPConfig) gin.HandlerFunc {
 	if len(cfg.Headers) == 0 {
-		cfg.Headers = []string{"X-Forwarded-For", "X-Real-IP", "CF-Connecting-IP"}
+		cfg.Headers = DefaultRealIPConfig().Headers
 	}
 	if cfg.ContextKey == "" {
-		cfg.ContextKey = "real_ip"
+		cfg.ContextKey = realIPKey
+	}
+	trustedNets := parseTrustedProxies(cfg.TrustedProxies)
+
+	return func(c *gin.Context) {
+		// The peer address is the only thing the client cannot forge
+		realIP := c.RemoteIP()
+		if isTrusted(realIP, trustedNets) {
+			realIP = forwardedIP(c, cfg, trustedNets, realIP)
+		}
+
+		c.Set(realIPKey, realIP)
+		if cfg.ContextKey != realIPKey {
+			c.Set(cfg.ContextKey, realIP)
+		}
+
+		c.Next()
 	}
+}
+
+// forwardedIP reads the client IP from the first forwarding header present,
+// falling back to the peer when the header holds nothing usable
+func forwardedIP(c *gin.Context, cfg RealIPConfig, trustedNets []*net.IPNet, peer string) string {
+	for _, header := range cfg.Headers {
+		value := c.GetHeader(header)
+		if value == "" {
+			continue
+		}
 
-	// Parse trusted proxies into CIDR networks
+		// X-Forwarded-For is a comma-separated list, appended to by each hop
+		if header == HeaderXForwardedFor {
+			ips := strings.Split(value, ",")
+			if !cfg.RecursiveCheck {
+				return validIP(ips[0], peer)
+			}
+			// Walk back from the nearest hop; the first untrusted address is
+			// the client, anything left of it may have been forged
+			client := peer
+			for i := len(ips) - 1; i >= 0; i-- {
+				ip := strings.TrimSpace(ips[i])
+				if net.ParseIP(ip) == nil {
+					break
+				}
+				client = ip
+				if !isTrusted(ip, trustedNets) {
+					break
+				}
+			}
+			return client
+		}
+
+		return validIP(value, peer)
+	}
+	return peer
+}
+
+// validIP returns value when it is an IP address, fallback otherwise
+func validIP(value, fallback string) string {
+	value = strings.TrimSpace(value)
+	if net.ParseIP(value) == nil {
+		return fallback
+	}
+	return value
+}
+
+// parseTrustedProxies turns IPs and CIDR ranges into networks, skipping invalid entries
+func parseTrustedProxies(proxies []string) []*net.IPNet {
 	var trustedNets []*net.IPNet
-	for _, proxy := range cfg.TrustedProxies {
+	for _, proxy := range proxies {
 		if strings.Contains(proxy, "/") {
 			_, network, err := net.ParseCIDR(proxy)
 			if err == nil {
@@ -72,49 +147,7 @@ func RealIPWithConfig(cfg RealIPConfig) gin.HandlerFunc {
 			}
 		}
 	}
-
-	return func(c *gin.Context) {
-		// Get client IP from remote address
-		remoteIP := c.ClientIP()
-
-		// Try to get real IP from headers
-		realIP := remoteIP
-
-		for _, header := range cfg.Headers {
-			value := c.GetHeader(header)
-			if value == "" {
-				continue
-			}
-
-			// Handle X-Forwarded-For specially (comma-separated list)
-			if header == "X-Forwarded-For" {
-				ips := strings.Split(value, ",")
-				if cfg.RecursiveCheck && len(trustedNets) > 0 {
-					// Find first untrusted IP from right to left
-					for i := len(ips) - 1; i >= 0; i-- {
-						ip := strings.TrimSpace(ips[i])
-						if !isTrusted(ip, trustedNets) {
-							realIP = ip
-							break
-						}
-					}
-				} else {
-					// Just use the first (leftmost) IP
-					realIP = strings.TrimSpace(ips[0])
-				}
-				break
-			}
-
-			// For other headers, use the value directly
-			realIP = strings.TrimSpace(value)
-			break
-		}
-
-		// Store real IP in context
-		c.Set(cfg.ContextKey, realIP)
-
-		c.Next()
-	}
+	return trustedNets
 }
 
 // isTrusted checks if an IP is in the trusted networks
@@ -133,14 +166,21 @@ func isTrusted(ipStr string, trustedNets []*net.IPNet) bool {
 	return false
 }
 
-// GetRealIP retrieves the real IP from context
-func GetRealIP(c *gin.Context) string {
-	if ip, exists := c.Get("real_ip"); exists {
+// ClientIP returns the client IP resolved by RealIP, or gin's own
+// resolution when the middleware is not installed
+func ClientIP(c *gin.Context) string {
+	if ip, exists := c.Get(realIPKey); exists {
 		return ip.(string)
 	}
 	return c.ClientIP()
 }
 
+// GetRealIP retrieves the real IP from context
+// Deprecated: Use ClientIP instead.
+func GetRealIP(c *gin.Context) string {
+	return ClientIP(c)
+}
+
 // Common headers for different CDN/proxy providers
 const (
 	HeaderXForwardedFor  = "X-Forwarded-For"
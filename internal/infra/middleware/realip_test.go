@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRealIPRouter(trustedProxies []string) *gin.Engine {
+	r := gin.New()
+	r.Use(RealIP(trustedProxies))
+	r.GET("/ip", func(c *gin.Context) {
+		c.String(http.StatusOK, ClientIP(c))
+	})
+	return r
+}
+
+func TestRealIP(t *testing.T) {
+	r := newRealIPRouter([]string{"10.0.0.0/8", "192.168.1.10"})
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{
+			name:       "direct client",
+			remoteAddr: "203.0.113.7:1234",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "spoofed X-Forwarded-For from untrusted peer",
+			remoteAddr: "203.0.113.7:1234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "spoofed X-Real-IP from untrusted peer",
+			remoteAddr: "203.0.113.7:1234",
+			headers:    map[string]string{"X-Real-IP": "1.2.3.4"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy CIDR",
+			remoteAddr: "10.1.2.3:1234",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.9"},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "trusted proxy IP with X-Real-IP",
+			remoteAddr: "192.168.1.10:1234",
+			headers:    map[string]string{"X-Real-IP": "198.51.100.9"},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "chain of trusted proxies",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.9, 10.0.0.2"},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "client-forged prefix is skipped",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4, 198.51.100.9"},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "garbage header falls back to peer",
+			remoteAddr: "10.0.0.1:1234",
+			headers:    map[string]string{"X-Forwarded-For": "not-an-ip"},
+			want:       "10.0.0.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(r, "/ip", tt.remoteAddr, tt.headers)
+			if got := w.Body.String(); got != tt.want {
+				t.Errorf("client IP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRealIP_NoTrustedProxies(t *testing.T) {
+	r := newRealIPRouter(nil)
+
+	w := doRequest(r, "/ip", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "1.2.3.4"})
+	if got := w.Body.String(); got != "10.0.0.1" {
+		t.Errorf("client IP = %q, want the peer address", got)
+	}
+}
+
+func TestRealIP_FeedsRateLimit(t *testing.T) {
+	r := gin.New()
+	r.Use(RealIP([]string{"10.0.0.0/8"}))
+	r.Use(RateLimit(RateLimitOptions{Requests: 1, Window: time.Minute}))
+	r.GET("/test", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	// Two clients behind the same proxy get their own buckets
+	first := map[string]string{"X-Forwarded-For": "198.51.100.1"}
+	second := map[string]string{"X-Forwarded-For": "198.51.100.2"}
+	if w := doRequest(r, "/test", "10.0.0.1:1234", first); w.Code != http.StatusOK {
+		t.Fatalf("first client: status = %d, want 200", w.Code)
+	}
+	if w := doRequest(r, "/test", "10.0.0.1:1234", second); w.Code != http.StatusOK {
+		t.Fatalf("second client: status = %d, want 200", w.Code)
+	}
+	if w := doRequest(r, "/test", "10.0.0.1:1234", first); w.Code != http.StatusTooManyRequests {
+		t.Errorf("first client again: status = %d, want 429", w.Code)
+	}
+
+	// A direct client cannot dodge its bucket by forging the header
+	if w := doRequest(r, "/test", "203.0.113.7:1234", first); w.Code != http.StatusOK {
+		t.Fatalf("direct client: status = %d, want 200", w.Code)
+	}
+	forged := map[string]string{"X-Forwarded-For": "198.51.100.3"}
+	if w := doRequest(r, "/test", "203.0.113.7:1234", forged); w.Code != http.StatusTooManyRequests {
+		t.Errorf("forged header: status = %d, want 429", w.Code)
+	}
+}
@@ -1,26 +1,130 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"runtime/debug"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/events"
 	"github.com/zgiai/zgo/pkg/logger"
 	"github.com/zgiai/zgo/pkg/response"
-	"github.com/gin-gonic/gin"
 )
 
-// Recovery middleware handles panic recovery
+// EventPanicRecovered is published when a request handler panics
+const EventPanicRecovered = "http.panic_recovered"
+
+// PanicRecoveredEvent describes a recovered panic
+type PanicRecoveredEvent struct {
+	Error      string
+	Stack      string
+	Method     string
+	Path       string
+	RequestID  string
+	occurredAt time.Time
+}
+
+func (e PanicRecoveredEvent) EventName() string {
+	return EventPanicRecovered
+}
+
+func (e PanicRecoveredEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e PanicRecoveredEvent) Data() any {
+	return e
+}
+
+// RecoveryConfig holds Recovery middleware configuration
+type RecoveryConfig struct {
+	// Debug includes the panic value and stack trace in the response body
+	// Default: true when gin runs in debug mode
+	Debug bool
+
+	// EventBus receives a PanicRecoveredEvent for every recovered panic
+	// Default: nil (no events)
+	EventBus *events.EventBus
+}
+
+// DefaultRecoveryConfig returns default configuration
+func DefaultRecoveryConfig() RecoveryConfig {
+	return RecoveryConfig{
+		Debug: gin.IsDebugging(),
+	}
+}
+
+// recoveryResponse is the standard error envelope plus an optional stack trace
+type recoveryResponse struct {
+	response.ErrorResponse
+	Stack string `json:"stack,omitempty"`
+}
+
+// Recovery returns Recovery middleware with default config
 func Recovery() gin.HandlerFunc {
+	return RecoveryWithConfig(DefaultRecoveryConfig())
+}
+
+// RecoveryWithConfig recovers panics, logs and reports them with their stack
+// trace and responds with the standard JSON error envelope
+func RecoveryWithConfig(cfg RecoveryConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
-			if err := recover(); err != nil {
-				// Log stack trace
-				logger.Error("Panic recovered", map[string]any{"error": err})
-				logger.Debug("Stack trace", map[string]any{"stack": string(debug.Stack())})
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			stack := string(debug.Stack())
+			event := PanicRecoveredEvent{
+				Error:      fmt.Sprint(recovered),
+				Stack:      stack,
+				Method:     c.Request.Method,
+				Path:       c.Request.URL.Path,
+				RequestID:  GetRequestID(c),
+				occurredAt: time.Now(),
+			}
+
+			// Error-level logs go to the error reporter as well, so this is
+			// also the panic's one report
+			fields := map[string]any{
+				"error":  event.Error,
+				"method": event.Method,
+				"path":   event.Path,
+				"stack":  stack,
+			}
+			if event.RequestID != "" {
+				fields["request_id"] = event.RequestID
+			}
+			if userID, ok := c.Get("userID"); ok {
+				fields["user_id"] = userID
+			}
+			logger.FromContext(c.Request.Context()).Error("Panic recovered", fields)
+
+			if cfg.EventBus != nil {
+				// Detach from the request so its cancellation does not drop the event
+				cfg.EventBus.PublishAsync(context.WithoutCancel(c.Request.Context()), event)
+			}
+
+			body := recoveryResponse{
+				ErrorResponse: response.ErrorResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "Internal server error",
+				},
+			}
+			if cfg.Debug {
+				body.Error = event.Error
+				body.Stack = stack
+			}
 
-				response.Error(c, http.StatusInternalServerError, "Internal server error")
+			if c.Writer.Written() {
+				// Headers are already out; all we can do is stop the chain
 				c.Abort()
+				return
 			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, body)
 		}()
 		c.Next()
 	}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/events"
+	apperrors "github.com/zgiai/zgo/pkg/errors"
+)
+
+func newPanicRouter(cfg RecoveryConfig) *gin.Engine {
+	r := gin.New()
+	r.Use(RequestID())
+	r.Use(RecoveryWithConfig(cfg))
+	r.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	return r
+}
+
+func decodeRecoveryBody(t *testing.T, body []byte) map[string]any {
+	t.Helper()
+	var got map[string]any
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("response is not JSON: %v (%s)", err, body)
+	}
+	return got
+}
+
+func TestRecovery_ReturnsJSONEnvelope(t *testing.T) {
+	logs := captureLogs(t)
+	r := newPanicRouter(RecoveryConfig{})
+
+	w := doRequest(r, "/panic", "10.0.0.1:1234", nil)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	body := decodeRecoveryBody(t, w.Body.Bytes())
+	if body["code"] != float64(http.StatusInternalServerError) {
+		t.Errorf("code = %v, want 500", body["code"])
+	}
+	if body["message"] != "Internal server error" {
+		t.Errorf("message = %v, want %q", body["message"], "Internal server error")
+	}
+	if _, ok := body["stack"]; ok {
+		t.Error("stack should not be exposed outside debug mode")
+	}
+	if _, ok := body["error"]; ok {
+		t.Error("panic value should not be exposed outside debug mode")
+	}
+
+	entry := logs.find("Panic recovered")
+	if entry == nil {
+		t.Fatal("panic should be logged")
+	}
+	if stack, _ := entry.Context["stack"].(string); !strings.Contains(stack, "goroutine") {
+		t.Error("log entry should carry the stack trace")
+	}
+	if entry.Context["request_id"] != w.Header().Get("X-Request-ID") {
+		t.Errorf("log request_id = %v, want %q", entry.Context["request_id"], w.Header().Get("X-Request-ID"))
+	}
+}
+
+func TestRecovery_DebugIncludesStack(t *testing.T) {
+	captureLogs(t)
+	r := newPanicRouter(RecoveryConfig{Debug: true})
+
+	w := doRequest(r, "/panic", "10.0.0.1:1234", nil)
+
+	body := decodeRecoveryBody(t, w.Body.Bytes())
+	if body["error"] != "boom" {
+		t.Errorf("error = %v, want boom", body["error"])
+	}
+	if stack, _ := body["stack"].(string); stack == "" {
+		t.Error("debug mode should include the stack trace")
+	}
+}
+
+func TestRecovery_PublishesEvent(t *testing.T) {
+	captureLogs(t)
+	bus := events.NewEventBus()
+	received := make(chan PanicRecoveredEvent, 1)
+	bus.Subscribe(EventPanicRecovered, func(ctx context.Context, e events.Event) error {
+		if wrapped, ok := e.(events.WrappedEvent); ok {
+			if ev, ok := wrapped.Event.(PanicRecoveredEvent); ok {
+				received <- ev
+			}
+		}
+		return nil
+	})
+
+	r := newPanicRouter(RecoveryConfig{EventBus: bus})
+	w := doRequest(r, "/panic", "10.0.0.1:1234", nil)
+
+	select {
+	case ev := <-received:
+		if ev.Error != "boom" || ev.Path != "/panic" || ev.Method != http.MethodGet {
+			t.Errorf("event = %+v, want boom on GET /panic", ev)
+		}
+		if ev.RequestID != w.Header().Get("X-Request-ID") {
+			t.Errorf("event RequestID = %q, want %q", ev.RequestID, w.Header().Get("X-Request-ID"))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no PanicRecoveredEvent published")
+	}
+}
+
+// fakeReporter records reports in place of Sentry
+type fakeReporter struct {
+	mu      sync.Mutex
+	reports []fakeReport
+}
+
+type fakeReport struct {
+	err error
+	ctx map[string]any
+}
+
+func (r *fakeReporter) Report(err error, ctx map[string]any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, fakeReport{err: err, ctx: ctx})
+}
+
+func TestRecovery_ReportsPanicOnce(t *testing.T) {
+	captureLogs(t)
+	reporter := &fakeReporter{}
+	apperrors.SetReporter(reporter)
+	t.Cleanup(func() { apperrors.SetReporter(nil) })
+
+	r := gin.New()
+	r.Use(RequestID())
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", uint(42))
+		c.Next()
+	})
+	r.Use(RecoveryWithConfig(RecoveryConfig{}))
+	r.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w := doRequest(r, "/panic", "10.0.0.1:1234", nil)
+
+	if len(reporter.reports) != 1 {
+		t.Fatalf("reports = %d, want exactly 1", len(reporter.reports))
+	}
+	got := reporter.reports[0]
+	if got.err == nil || !strings.Contains(got.err.Error(), "boom") {
+		t.Errorf("reported error = %v, want it to mention boom", got.err)
+	}
+	if got.ctx["request_id"] != w.Header().Get("X-Request-ID") {
+		t.Errorf("report request_id = %v, want %q", got.ctx["request_id"], w.Header().Get("X-Request-ID"))
+	}
+	if got.ctx["user_id"] != uint(42) {
+		t.Errorf("report user_id = %v, want 42", got.ctx["user_id"])
+	}
+	if got.ctx["path"] != "/panic" || got.ctx["method"] != http.MethodGet {
+		t.Errorf("report context = %v, want GET /panic", got.ctx)
+	}
+}
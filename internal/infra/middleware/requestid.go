@@ -3,6 +3,7 @@ package middleware
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/zgiai/zgo/pkg/logger"
 )
 
 // RequestIDConfig holds RequestID middleware configuration
@@ -56,8 +57,9 @@ func RequestIDWithConfig(cfg RequestIDConfig) gin.HandlerFunc {
 			requestID = cfg.Generator()
 		}
 
-		// Set request ID in context
+		// Set request ID in gin and request context
 		c.Set(cfg.ContextKey, requestID)
+		c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), requestID))
 
 		// Set request ID in response header
 		c.Header(cfg.Header, requestID)
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/logger"
+)
+
+// captureHandler records log entries in memory
+type captureHandler struct {
+	mu      sync.Mutex
+	entries []*logger.Entry
+}
+
+func (h *captureHandler) Handle(ctx context.Context, entry *logger.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+func (h *captureHandler) Close() error { return nil }
+
+func (h *captureHandler) find(msg string) *logger.Entry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, e := range h.entries {
+		if e.Message == msg {
+			return e
+		}
+	}
+	return nil
+}
+
+func captureLogs(t *testing.T) *captureHandler {
+	t.Helper()
+	h := &captureHandler{}
+	l := &logger.Logger{}
+	l.AddHandler(h)
+
+	prev := logger.Default()
+	logger.SetDefault(l)
+	t.Cleanup(func() { logger.SetDefault(prev) })
+	return h
+}
+
+func newRequestIDRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(logger.GinLogger())
+	r.Use(RequestID())
+	r.GET("/test", func(c *gin.Context) {
+		logger.FromContext(c.Request.Context()).Info("handler")
+		c.String(http.StatusOK, GetRequestID(c))
+	})
+	return r
+}
+
+func TestRequestID_RoundTripsHeader(t *testing.T) {
+	logs := captureLogs(t)
+	r := newRequestIDRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("X-Request-ID"); got != "req-123" {
+		t.Errorf("X-Request-ID header = %q, want %q", got, "req-123")
+	}
+	if w.Body.String() != "req-123" {
+		t.Errorf("GetRequestID = %q, want %q", w.Body.String(), "req-123")
+	}
+
+	for _, msg := range []string{"HTTP Request", "handler"} {
+		entry := logs.find(msg)
+		if entry == nil {
+			t.Fatalf("no %q log entry captured", msg)
+		}
+		if entry.Context["request_id"] != "req-123" {
+			t.Errorf("%q log request_id = %v, want %q", msg, entry.Context["request_id"], "req-123")
+		}
+	}
+}
+
+func TestRequestID_GeneratesID(t *testing.T) {
+	logs := captureLogs(t)
+	r := newRequestIDRouter()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	id := w.Header().Get("X-Request-ID")
+	if id == "" {
+		t.Fatal("expected a generated X-Request-ID header")
+	}
+	if entry := logs.find("HTTP Request"); entry == nil || entry.Context["request_id"] != id {
+		t.Errorf("access log should carry the generated request ID %q", id)
+	}
+}
+
+func TestFromContext_WithoutRequestID(t *testing.T) {
+	logs := captureLogs(t)
+
+	logger.FromContext(context.Background()).Info("plain")
+
+	entry := logs.find("plain")
+	if entry == nil {
+		t.Fatal("no log entry captured")
+	}
+	if _, ok := entry.Context["request_id"]; ok {
+		t.Error("request_id should be absent without a request ID in context")
+	}
+}
@@ -0,0 +1,220 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/cache"
+	"github.com/zgiai/zgo/pkg/logger"
+)
+
+// responseCachePrefix namespaces response cache keys in the store
+const responseCachePrefix = "response-cache:"
+
+// CachedResponse is a stored GET response served to later requests
+type CachedResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// ResponseCacheOptions holds ResponseCache middleware configuration
+type ResponseCacheOptions struct {
+	// Store holds cached responses and tag versions
+	// Default: cache.Default()
+	Store cache.Store
+
+	// TTL is how long a response is served from the cache
+	// Default: 1 minute
+	TTL time.Duration
+
+	// Tags group the route's entries so InvalidateResponseCache can purge
+	// them when the underlying data changes
+	Tags []string
+
+	// Private keys entries by authenticated user, for responses that
+	// differ per user. Anonymous requests share one entry.
+	Private bool
+}
+
+// DefaultResponseCacheOptions returns default configuration
+func DefaultResponseCacheOptions() ResponseCacheOptions {
+	return ResponseCacheOptions{
+		TTL: time.Minute,
+	}
+}
+
+// ResponseCache returns middleware that caches successful GET responses by
+// full URL for ttl. Cached responses carry X-Cache: HIT, fresh ones
+// X-Cache: MISS. A request sending Cache-Control: no-cache skips the cached
+// copy and refreshes it. Only 2xx responses are stored, and never ones
+// setting cookies or sending Cache-Control: no-store.
+//
+// Example:
+//
+//	api.GET("/posts", middleware.ResponseCache(cache.Default(), time.Minute, "posts"), h.List)
+//	// after a post changes
+//	middleware.InvalidateResponseCache(ctx, cache.Default(), "posts")
+func ResponseCache(store cache.Store, ttl time.Duration, tags ...string) gin.HandlerFunc {
+	opts := DefaultResponseCacheOptions()
+	opts.Store = store
+	opts.TTL = ttl
+	opts.Tags = tags
+	return ResponseCacheWithOptions(opts)
+}
+
+// ResponseCacheWithOptions returns response cache middleware with custom options
+func ResponseCacheWithOptions(opts ResponseCacheOptions) gin.HandlerFunc {
+	defaults := DefaultResponseCacheOptions()
+	if opts.TTL <= 0 {
+		opts.TTL = defaults.TTL
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		store := opts.Store
+		if store == nil {
+			store = cache.Default()
+		}
+		ctx := c.Request.Context()
+
+		key, err := responseCacheKey(ctx, store, c, opts)
+		if err != nil {
+			// Fail open: a broken store should not take the API down
+			logger.Error("response cache error", map[string]any{"error": err})
+			c.Next()
+			return
+		}
+
+		if !noCache(c.Request) {
+			if cached := lookupResponse(ctx, store, key); cached != nil {
+				c.Header("X-Cache", "HIT")
+				c.Data(cached.Status, cached.ContentType, cached.Body)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Header("X-Cache", "MISS")
+		rec := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = rec
+		c.Next()
+
+		if !cacheable(rec) {
+			return
+		}
+		data, err := json.Marshal(&CachedResponse{
+			Status:      rec.Status(),
+			ContentType: rec.Header().Get("Content-Type"),
+			Body:        rec.body.Bytes(),
+		})
+		if err == nil {
+			err = store.Put(context.WithoutCancel(ctx), key, string(data), opts.TTL)
+		}
+		if err != nil {
+			logger.Error("response cache error", map[string]any{"error": err})
+		}
+	}
+}
+
+// InvalidateResponseCache purges every cached response filed under tags.
+// Each tag's version is bumped, so the old entries are never looked up
+// again and expire on their own; this works with any cache store.
+func InvalidateResponseCache(ctx context.Context, store cache.Store, tags ...string) error {
+	for _, tag := range tags {
+		if _, err := store.Increment(ctx, responseCachePrefix+"tag:"+tag, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// responseCacheKey builds the entry key from the caller scope, the current
+// version of each tag and the full request URL
+func responseCacheKey(ctx context.Context, store cache.Store, c *gin.Context, opts ResponseCacheOptions) (string, error) {
+	var b strings.Builder
+	if opts.Private {
+		if userID, ok := c.Get("userID"); ok {
+			fmt.Fprintf(&b, "user:%v", userID)
+		} else {
+			b.WriteString("guest")
+		}
+	}
+	for _, tag := range opts.Tags {
+		version, err := store.Get(ctx, responseCachePrefix+"tag:"+tag)
+		if err != nil && err != cache.ErrCacheMiss {
+			return "", err
+		}
+		fmt.Fprintf(&b, "|%s@%v", tag, version)
+	}
+	b.WriteString("|")
+	b.WriteString(c.Request.URL.RequestURI())
+
+	return fmt.Sprintf("%s%x", responseCachePrefix, sha256.Sum256([]byte(b.String()))), nil
+}
+
+// lookupResponse returns the cached response for key, or nil on a miss
+func lookupResponse(ctx context.Context, store cache.Store, key string) *CachedResponse {
+	value, err := store.Get(ctx, key)
+	if err != nil {
+		if err != cache.ErrCacheMiss {
+			logger.Error("response cache error", map[string]any{"error": err})
+		}
+		return nil
+	}
+
+	var cached CachedResponse
+	if err := decodeCachedJSON(value, &cached); err != nil {
+		logger.Error("response cache error", map[string]any{"error": err})
+		return nil
+	}
+	return &cached
+}
+
+// decodeCachedJSON decodes a JSON document read back from a cache store.
+// Memory stores return the string as written; Redis may decode the JSON.
+func decodeCachedJSON(value any, out any) error {
+	var data []byte
+	switch v := value.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, out)
+}
+
+// noCache reports whether the client asked to bypass cached copies
+func noCache(r *http.Request) bool {
+	directives := strings.ToLower(r.Header.Get("Cache-Control"))
+	return strings.Contains(directives, "no-cache") || strings.Contains(directives, "no-store") ||
+		strings.EqualFold(r.Header.Get("Pragma"), "no-cache")
+}
+
+// cacheable reports whether a recorded response may be served to others
+func cacheable(w *recordingWriter) bool {
+	status := w.Status()
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return false
+	}
+	header := w.Header()
+	if header.Get("Set-Cookie") != "" {
+		return false
+	}
+	return !strings.Contains(strings.ToLower(header.Get("Cache-Control")), "no-store")
+}
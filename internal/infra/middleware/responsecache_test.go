@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/cache"
+)
+
+// newResponseCacheRouter counts handler runs so tests can tell hits from misses
+func newResponseCacheRouter(store cache.Store) (*gin.Engine, *int) {
+	calls := 0
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if user := c.GetHeader("X-User"); user != "" {
+			c.Set("userID", user)
+		}
+	})
+	count := func() string {
+		calls++
+		return strconv.Itoa(calls)
+	}
+	r.GET("/posts", ResponseCache(store, time.Minute, "posts"), func(c *gin.Context) {
+		c.String(http.StatusOK, "posts "+count()+" "+c.Query("page"))
+	})
+	r.GET("/missing", ResponseCache(store, time.Minute), func(c *gin.Context) {
+		c.String(http.StatusNotFound, "missing "+count())
+	})
+	r.GET("/session", ResponseCache(store, time.Minute), func(c *gin.Context) {
+		c.SetCookie("session", "abc", 60, "/", "", false, true)
+		c.String(http.StatusOK, "session "+count())
+	})
+	r.GET("/me", ResponseCacheWithOptions(ResponseCacheOptions{Store: store, Private: true}), func(c *gin.Context) {
+		c.String(http.StatusOK, "me "+c.GetString("userID")+" "+count())
+	})
+	return r, &calls
+}
+
+func getCached(r http.Handler, path string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestResponseCache_HitAndMiss(t *testing.T) {
+	r, calls := newResponseCacheRouter(cache.NewMemoryStore())
+
+	first := getCached(r, "/posts?page=1")
+	second := getCached(r, "/posts?page=1")
+
+	if first.Header().Get("X-Cache") != "MISS" || second.Header().Get("X-Cache") != "HIT" {
+		t.Errorf("X-Cache = %q then %q, want MISS then HIT", first.Header().Get("X-Cache"), second.Header().Get("X-Cache"))
+	}
+	if second.Code != http.StatusOK || second.Body.String() != first.Body.String() {
+		t.Errorf("cached response = %d %q, want 200 %q", second.Code, second.Body.String(), first.Body.String())
+	}
+	if second.Header().Get("Content-Type") != first.Header().Get("Content-Type") {
+		t.Errorf("cached Content-Type = %q, want %q", second.Header().Get("Content-Type"), first.Header().Get("Content-Type"))
+	}
+	if *calls != 1 {
+		t.Errorf("handler ran %d times, want 1", *calls)
+	}
+
+	// The query string is part of the key
+	if w := getCached(r, "/posts?page=2"); w.Header().Get("X-Cache") != "MISS" {
+		t.Error("a different query should miss")
+	}
+}
+
+func TestResponseCache_NoCacheBypasses(t *testing.T) {
+	r, calls := newResponseCacheRouter(cache.NewMemoryStore())
+
+	getCached(r, "/posts")
+	w := getCached(r, "/posts", "Cache-Control", "no-cache")
+	if w.Header().Get("X-Cache") != "MISS" || w.Body.String() != "posts 2 " {
+		t.Errorf("no-cache got %q (X-Cache %q), want a fresh response", w.Body.String(), w.Header().Get("X-Cache"))
+	}
+
+	// The fresh response replaces the cached one
+	if w := getCached(r, "/posts"); w.Body.String() != "posts 2 " {
+		t.Errorf("body = %q, want the refreshed response", w.Body.String())
+	}
+	if *calls != 2 {
+		t.Errorf("handler ran %d times, want 2", *calls)
+	}
+}
+
+func TestResponseCache_SkipsUncacheableResponses(t *testing.T) {
+	r, calls := newResponseCacheRouter(cache.NewMemoryStore())
+
+	for _, path := range []string{"/missing", "/session"} {
+		getCached(r, path)
+		if w := getCached(r, path); w.Header().Get("X-Cache") != "MISS" {
+			t.Errorf("%s: second request X-Cache = %q, want MISS", path, w.Header().Get("X-Cache"))
+		}
+	}
+	if *calls != 4 {
+		t.Errorf("handler ran %d times, want 4", *calls)
+	}
+}
+
+func TestResponseCache_PrivateKeysByUser(t *testing.T) {
+	r, _ := newResponseCacheRouter(cache.NewMemoryStore())
+
+	alice := getCached(r, "/me", "X-User", "alice")
+	bob := getCached(r, "/me", "X-User", "bob")
+	if bob.Header().Get("X-Cache") != "MISS" || bob.Body.String() == alice.Body.String() {
+		t.Errorf("bob got %q, must not see alice's %q", bob.Body.String(), alice.Body.String())
+	}
+	if w := getCached(r, "/me", "X-User", "alice"); w.Body.String() != alice.Body.String() {
+		t.Errorf("alice got %q, want her cached %q", w.Body.String(), alice.Body.String())
+	}
+}
+
+func TestResponseCache_InvalidateByTag(t *testing.T) {
+	store := cache.NewMemoryStore()
+	r, calls := newResponseCacheRouter(store)
+
+	getCached(r, "/posts?page=1")
+	getCached(r, "/posts?page=2")
+	getCached(r, "/me", "X-User", "alice")
+
+	if err := InvalidateResponseCache(context.Background(), store, "posts"); err != nil {
+		t.Fatalf("InvalidateResponseCache: %v", err)
+	}
+
+	for _, path := range []string{"/posts?page=1", "/posts?page=2"} {
+		if w := getCached(r, path); w.Header().Get("X-Cache") != "MISS" {
+			t.Errorf("%s should miss after invalidation", path)
+		}
+	}
+	if w := getCached(r, "/posts?page=1"); w.Header().Get("X-Cache") != "HIT" {
+		t.Error("the refreshed entry should be cached again")
+	}
+
+	// Routes without the tag keep their entries
+	if w := getCached(r, "/me", "X-User", "alice"); w.Header().Get("X-Cache") != "HIT" {
+		t.Error("untagged entries should survive invalidation")
+	}
+	if *calls != 5 {
+		t.Errorf("handler ran %d times, want 5", *calls)
+	}
+}
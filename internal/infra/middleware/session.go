@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/session"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// SessionAuth authenticates the user recorded by session.Login, the cookie
+// counterpart of JWTAuth. It sets the same userID and username, so handlers
+// and RequireRole work with either. Must run after session.Middleware.
+//
+// Accounts whose tokens were revoked by disabling them are refused here too
+// when SetJWTService has been called.
+//
+// Example:
+//
+//	web := r.Group("/", session.Middleware(), middleware.SessionAuth())
+func SessionAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, username, ok := session.User(c)
+		if !ok {
+			response.Abort(c, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+		if jwtService != nil && jwtService.UserTokensRevoked(c.Request.Context(), userID, session.LoggedInAt(c)) {
+			session.Logout(c)
+			response.Abort(c, http.StatusUnauthorized, "Session has been revoked")
+			return
+		}
+		// Sessions carry no tenant, so they are refused on tenanted requests
+		if !bindTenantClaim(c, "") {
+			return
+		}
+
+		c.Set("userID", userID)
+		c.Set("username", username)
+
+		c.Next()
+	}
+}
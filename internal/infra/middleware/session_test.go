@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/jwt"
+	"github.com/zgiai/zgo/internal/infra/kv"
+	"github.com/zgiai/zgo/internal/infra/session"
+)
+
+func TestSessionAuth(t *testing.T) {
+	kv.SetDefault(kv.NewMemoryStore())
+	t.Cleanup(func() { kv.SetDefault(nil) })
+	svc := jwt.NewTestService()
+	SetJWTService(svc)
+	t.Cleanup(func() { SetJWTService(nil) })
+
+	r := gin.New()
+	r.Use(session.MiddlewareWithOptions(session.Options{Store: session.NewKVStore(kv.Default())}))
+	r.POST("/login", func(c *gin.Context) {
+		require.NoError(t, session.Login(c, 7, "alice"))
+		c.Status(http.StatusOK)
+	})
+	r.GET("/test", SessionAuth(), func(c *gin.Context) {
+		c.String(http.StatusOK, "%d:%s", c.GetUint("userID"), c.GetString("username"))
+	})
+
+	w := doRequest(r, "/test", "10.0.0.1:1234", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "no session")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/login", nil))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	auth := map[string]string{"Cookie": cookies[0].Name + "=" + cookies[0].Value}
+
+	w = doRequest(r, "/test", "10.0.0.1:1234", auth)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "7:alice", w.Body.String())
+
+	// Disabling the account revokes its sessions along with its tokens
+	require.NoError(t, svc.RevokeUserTokens(context.Background(), 7))
+	w = doRequest(r, "/test", "10.0.0.1:1234", auth)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
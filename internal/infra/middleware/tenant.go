@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// tenantKey stores the resolved tenant in the gin context
+const tenantKey = "tenant"
+
+// tenantPattern matches tenant IDs: letters, digits, '-' and '_', at most 64
+var tenantPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// TenantConfig holds Tenant middleware configuration
+type TenantConfig struct {
+	// Header carries the tenant ID when it does not come from the host
+	// Default: X-Tenant-ID
+	Header string
+
+	// BaseDomain enables subdomain resolution: a request to
+	// acme.example.com with BaseDomain example.com is for tenant acme.
+	// Empty disables it.
+	BaseDomain string
+
+	// Required rejects requests that name no tenant with 400. Turn it off
+	// when the tenant only comes from the JWT tenant_id claim, which JWTAuth
+	// binds after this middleware has run; queries on tenant-scoped models
+	// still fail with database.ErrNoTenant when no tenant is bound.
+	// Default: true
+	Required bool
+}
+
+// DefaultTenantConfig returns default configuration
+func DefaultTenantConfig() TenantConfig {
+	return TenantConfig{
+		Header:   "X-Tenant-ID",
+		Required: true,
+	}
+}
+
+// TenantConfigFrom builds the middleware configuration from the app config
+func TenantConfigFrom(cfg config.TenantConfig) TenantConfig {
+	return TenantConfig{
+		Header:     cfg.Header,
+		BaseDomain: cfg.BaseDomain,
+		Required:   cfg.Required,
+	}
+}
+
+// Tenant resolves the tenant a request is for, from the subdomain and then
+// the header, and puts it on the request context so database queries on
+// tenant-scoped models are filtered on it. JWTAuth later checks that the
+// token's tenant_id claim, if any, names the same tenant.
+//
+// Example:
+//
+//	r.Use(middleware.Tenant(middleware.TenantConfig{BaseDomain: "example.com"}))
+func Tenant(cfg TenantConfig) gin.HandlerFunc {
+	if cfg.Header == "" {
+		cfg.Header = DefaultTenantConfig().Header
+	}
+	baseDomain := strings.ToLower(strings.TrimPrefix(cfg.BaseDomain, "."))
+
+	return func(c *gin.Context) {
+		tenant := tenantFromHost(c.Request.Host, baseDomain)
+		if tenant == "" {
+			tenant = strings.TrimSpace(c.GetHeader(cfg.Header))
+		}
+
+		if tenant == "" {
+			if cfg.Required {
+				response.Abort(c, http.StatusBadRequest, "Tenant required")
+				return
+			}
+			c.Next()
+			return
+		}
+		if !tenantPattern.MatchString(tenant) {
+			response.Abort(c, http.StatusBadRequest, "Invalid tenant")
+			return
+		}
+
+		setTenant(c, tenant)
+		c.Next()
+	}
+}
+
+// tenantFromHost returns the subdomain of host directly under baseDomain
+func tenantFromHost(host, baseDomain string) string {
+	if baseDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	sub, ok := strings.CutSuffix(strings.ToLower(host), "."+baseDomain)
+	if !ok || sub == "" || strings.Contains(sub, ".") {
+		return ""
+	}
+	return sub
+}
+
+// bindTenantClaim applies a token's tenant_id claim to the request. A request
+// resolved to a tenant only accepts tokens issued for that tenant, so neither
+// a token for another tenant nor an untenanted token can reach its rows. It
+// reports whether the request may continue.
+func bindTenantClaim(c *gin.Context, claim string) bool {
+	if tenant, ok := database.TenantFromContext(c.Request.Context()); ok && tenant != claim {
+		response.Abort(c, http.StatusForbidden, "Token is not valid for this tenant")
+		return false
+	}
+	if claim != "" {
+		setTenant(c, claim)
+	}
+	return true
+}
+
+func setTenant(c *gin.Context, tenant string) {
+	c.Set(tenantKey, tenant)
+	c.Request = c.Request.WithContext(database.WithTenant(c.Request.Context(), tenant))
+}
+
+// GetTenant returns the tenant resolved for the request, or "" when none
+func GetTenant(c *gin.Context) string {
+	return c.GetString(tenantKey)
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/internal/infra/jwt"
+)
+
+func newTenantRouter(cfg TenantConfig, handlers ...gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(Tenant(cfg))
+	r.Use(handlers...)
+	r.GET("/test", func(c *gin.Context) {
+		tenant, _ := database.TenantFromContext(c.Request.Context())
+		c.String(http.StatusOK, GetTenant(c)+","+tenant)
+	})
+	return r
+}
+
+func doTenantRequest(r http.Handler, host string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Host = host
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestTenant_Resolution(t *testing.T) {
+	r := newTenantRouter(TenantConfig{BaseDomain: "example.com"})
+
+	tests := []struct {
+		name    string
+		host    string
+		headers map[string]string
+		want    string
+	}{
+		{"none", "example.com", nil, ""},
+		{"subdomain", "acme.example.com", nil, "acme"},
+		{"subdomain with port", "Acme.example.com:8080", nil, "acme"},
+		{"header", "example.com", map[string]string{"X-Tenant-ID": "globex"}, "globex"},
+		{"subdomain wins over header", "acme.example.com", map[string]string{"X-Tenant-ID": "globex"}, "acme"},
+		{"nested subdomain ignored", "a.b.example.com", nil, ""},
+		{"other domain ignored", "acme.example.org", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doTenantRequest(r, tt.host, tt.headers)
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.want+","+tt.want, w.Body.String(), "tenant on gin and request context")
+		})
+	}
+}
+
+func TestTenant_RejectsMissingOrInvalidTenant(t *testing.T) {
+	r := newTenantRouter(TenantConfig{Required: true})
+
+	w := doTenantRequest(r, "example.com", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = doTenantRequest(r, "example.com", map[string]string{"X-Tenant-ID": "acme' OR 1=1"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = doTenantRequest(r, "example.com", map[string]string{"X-Tenant-ID": "acme"})
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestTenant_RequiredByDefault(t *testing.T) {
+	r := newTenantRouter(DefaultTenantConfig())
+
+	w := doTenantRequest(r, "example.com", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = doTenantRequest(r, "example.com", map[string]string{"X-Tenant-ID": "acme"})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "acme,acme", w.Body.String())
+}
+
+func TestTenantConfigFrom(t *testing.T) {
+	cfg := TenantConfigFrom(config.TenantConfig{Header: "X-Org", BaseDomain: "example.com", Required: true})
+
+	assert.Equal(t, TenantConfig{Header: "X-Org", BaseDomain: "example.com", Required: true}, cfg)
+}
+
+func TestTenant_JWTClaim(t *testing.T) {
+	svc := jwt.NewTestService()
+	// The tenant may come from the token alone
+	cfg := DefaultTenantConfig()
+	cfg.Required = false
+	r := newTenantRouter(cfg, JWTAuthWithService(svc))
+
+	acmeToken, err := svc.GenerateTenantToken(1, "alice", "acme")
+	require.NoError(t, err)
+	plainToken, err := svc.GenerateToken(2, "bob")
+	require.NoError(t, err)
+
+	// The claim names the tenant when the request does not
+	w := doTenantRequest(r, "example.com", map[string]string{"Authorization": "Bearer " + acmeToken})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "acme,acme", w.Body.String())
+
+	// A token for acme cannot be used against globex
+	w = doTenantRequest(r, "example.com", map[string]string{
+		"Authorization": "Bearer " + acmeToken,
+		"X-Tenant-ID":   "globex",
+	})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Nor can a token without the claim
+	w = doTenantRequest(r, "example.com", map[string]string{
+		"Authorization": "Bearer " + plainToken,
+		"X-Tenant-ID":   "globex",
+	})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// Without a tenant on either side the request stays untenanted
+	w = doTenantRequest(r, "example.com", map[string]string{"Authorization": "Bearer " + plainToken})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, ",", w.Body.String())
+}
@@ -2,13 +2,20 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
-	"github.com/zgiai/zgo/internal/infra/config"
 	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/pkg/response"
 )
 
+// timeoutStateKey stores the outermost timeout's state so a route group can
+// replace the global deadline instead of nesting inside it
+const timeoutStateKey = "middleware.timeout.state"
+
 // TimeoutConfig holds timeout middleware configuration
 type TimeoutConfig struct {
 	// Timeout is the maximum duration for request processing
@@ -20,7 +27,7 @@ type TimeoutConfig struct {
 	ErrorMessage string
 
 	// ErrorHandler is a custom handler for timeout errors
-	// If nil, returns 503 Service Unavailable with ErrorMessage
+	// If nil, responds 504 Gateway Timeout with ErrorMessage
 	ErrorHandler func(c *gin.Context)
 }
 
@@ -33,70 +40,137 @@ func DefaultTimeoutConfig() TimeoutConfig {
 }
 
 // TimeoutFromConfig returns timeout middleware using global config
-// Uses MIDDLEWARE_REQUEST_TIMEOUT env var (in seconds)
+// Uses SERVER_REQUEST_TIMEOUT (falling back to MIDDLEWARE_REQUEST_TIMEOUT), in seconds
 func TimeoutFromConfig() gin.HandlerFunc {
-	timeout := 3 * time.Minute // default 3 minutes
-	if config.GlobalConfig != nil && config.GlobalConfig.Middleware.RequestTimeout > 0 {
-		timeout = time.Duration(config.GlobalConfig.Middleware.RequestTimeout) * time.Second
+	timeout := DefaultTimeoutConfig().Timeout
+	if config.GlobalConfig != nil && config.GlobalConfig.Server.RequestTimeout > 0 {
+		timeout = time.Duration(config.GlobalConfig.Server.RequestTimeout) * time.Second
 	}
 	return Timeout(timeout)
 }
 
-// Timeout returns timeout middleware with specified duration
+// Timeout bounds request processing to timeout.
+// The request context gets the deadline, so handlers and queries that
+// honor c.Request.Context() stop once it passes. If the handler has not
+// written anything by then, its late response is dropped and the client
+// gets 504 Gateway Timeout. A route group applying Timeout again replaces
+// the global deadline, longer or shorter:
+//
+//	r.Use(middleware.Timeout(30 * time.Second))
+//	reports := r.Group("/reports", middleware.Timeout(5*time.Minute))
+//
+// WebSocket upgrades and event streams are long-lived by design and are
+// left alone.
 func Timeout(timeout time.Duration) gin.HandlerFunc {
-	return TimeoutWithConfig(TimeoutConfig{
-		Timeout:      timeout,
-		ErrorMessage: "Request timeout",
-	})
+	return TimeoutWithConfig(TimeoutConfig{Timeout: timeout})
 }
 
 // TimeoutWithConfig returns timeout middleware with custom config
 func TimeoutWithConfig(cfg TimeoutConfig) gin.HandlerFunc {
+	defaults := DefaultTimeoutConfig()
 	if cfg.Timeout <= 0 {
-		cfg.Timeout = 30 * time.Second
+		cfg.Timeout = defaults.Timeout
 	}
 	if cfg.ErrorMessage == "" {
-		cfg.ErrorMessage = "Request timeout"
+		cfg.ErrorMessage = defaults.ErrorMessage
 	}
 
 	return func(c *gin.Context) {
-		// Create a context with timeout
-		ctx, cancel := context.WithTimeout(c.Request.Context(), cfg.Timeout)
-		defer cancel()
-
-		// Replace request context
-		c.Request = c.Request.WithContext(ctx)
+		if isLongLived(c.Request) {
+			c.Next()
+			return
+		}
 
-		// Channel to signal completion
-		done := make(chan struct{})
+		var state *timeoutState
+		if v, ok := c.Get(timeoutStateKey); ok {
+			state = v.(*timeoutState)
+		}
+		outermost := state == nil
+		if outermost {
+			state = &timeoutState{base: c.Request.Context()}
+			c.Set(timeoutStateKey, state)
+			c.Writer = &timeoutWriter{ResponseWriter: c.Writer, state: state}
+		}
 
-		// Run handler in goroutine
-		go func() {
-			c.Next()
-			close(done)
+		// Derive from the current context to keep its values, but take
+		// cancellation from the base only, so an earlier deadline is replaced
+		// rather than inherited
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), cfg.Timeout)
+		stop := context.AfterFunc(state.base, cancel)
+		defer func() {
+			stop()
+			cancel()
 		}()
+		state.ctx = ctx
+		c.Request = c.Request.WithContext(ctx)
 
-		// Wait for completion or timeout
-		select {
-		case <-done:
-			// Request completed normally
+		c.Next()
+
+		if !outermost || !state.expired() || c.Writer.Written() {
 			return
-		case <-ctx.Done():
-			// Timeout occurred
-			if ctx.Err() == context.DeadlineExceeded {
-				c.Abort()
-				if cfg.ErrorHandler != nil {
-					cfg.ErrorHandler(c)
-				} else {
-					c.JSON(http.StatusServiceUnavailable, gin.H{
-						"success": false,
-						"error": gin.H{
-							"code":    "TIMEOUT",
-							"message": cfg.ErrorMessage,
-						},
-					})
-				}
-			}
 		}
+		state.timedOut = true
+		c.Abort()
+		if cfg.ErrorHandler != nil {
+			cfg.ErrorHandler(c)
+			return
+		}
+		response.Abort(c, http.StatusGatewayTimeout, cfg.ErrorMessage)
+	}
+}
+
+// timeoutState is shared by the timeout middleware of one request
+type timeoutState struct {
+	base     context.Context // request context before any timeout applied
+	ctx      context.Context // context carrying the deadline in force
+	timedOut bool            // the 504 is being written
+}
+
+// expired reports whether the deadline in force has passed
+func (s *timeoutState) expired() bool {
+	return !s.timedOut && errors.Is(s.ctx.Err(), context.DeadlineExceeded)
+}
+
+// timeoutWriter drops a handler's response once the deadline has passed,
+// unless the handler had already started writing in time
+type timeoutWriter struct {
+	gin.ResponseWriter
+	state *timeoutState
+}
+
+// late reports whether the response should be dropped
+func (w *timeoutWriter) late() bool {
+	return !w.ResponseWriter.Written() && w.state.expired()
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	if !w.late() {
+		w.ResponseWriter.WriteHeaderNow()
 	}
 }
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	if w.late() {
+		return 0, http.ErrHandlerTimeout
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	if w.late() {
+		return 0, http.ErrHandlerTimeout
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *timeoutWriter) Flush() {
+	if !w.late() {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// isLongLived reports whether r opens a WebSocket or an event stream
+func isLongLived(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") ||
+		strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// slowHandler waits for the request context, as handlers running queries
+// or outgoing calls do, then tries to respond anyway
+func slowHandler(c *gin.Context) {
+	select {
+	case <-c.Request.Context().Done():
+		response.HandleError(c, "Query failed", c.Request.Context().Err())
+	case <-time.After(time.Second):
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	}
+}
+
+func newTimeoutRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(Timeout(20 * time.Millisecond))
+	r.GET("/slow", slowHandler)
+	r.GET("/fast", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	r.GET("/early", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+		c.Writer.WriteHeaderNow()
+		<-c.Request.Context().Done()
+	})
+	r.Group("/reports", Timeout(200*time.Millisecond)).GET("", func(c *gin.Context) {
+		time.Sleep(50 * time.Millisecond)
+		c.JSON(http.StatusOK, gin.H{"ok": c.Request.Context().Err() == nil})
+	})
+	r.GET("/stream", func(c *gin.Context) {
+		_, hasDeadline := c.Request.Context().Deadline()
+		c.JSON(http.StatusOK, gin.H{"deadline": hasDeadline})
+	})
+	return r
+}
+
+func TestTimeout_SlowHandlerGets504(t *testing.T) {
+	r := newTimeoutRouter()
+
+	start := time.Now()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("status = %d, want 504", w.Code)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("request took %v, handler should stop at the deadline", elapsed)
+	}
+
+	var body response.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body %q is not the error envelope: %v", w.Body.String(), err)
+	}
+	if body.Code != http.StatusGatewayTimeout || body.Message != "Request timeout" {
+		t.Errorf("body = %+v", body)
+	}
+}
+
+func TestTimeout_LeavesOtherResponsesAlone(t *testing.T) {
+	r := newTimeoutRouter()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("fast status = %d, want 200", w.Code)
+	}
+
+	// A response already under way is not replaced
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/early", nil))
+	if w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Errorf("early status = %d, body = %q; want 200 and no body", w.Code, w.Body.String())
+	}
+
+	// Event streams are not bounded
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Body.String() != `{"deadline":false}` {
+		t.Errorf("stream body = %s, want no deadline", w.Body.String())
+	}
+}
+
+func TestTimeout_GroupOverridesGlobalDeadline(t *testing.T) {
+	r := newTimeoutRouter()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/reports", nil))
+
+	if w.Code != http.StatusOK || w.Body.String() != `{"ok":true}` {
+		t.Errorf("status = %d, body = %s; want 200 past the global deadline", w.Code, w.Body.String())
+	}
+}
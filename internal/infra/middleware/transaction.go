@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/pkg/logger"
+	"github.com/zgiai/zgo/pkg/response"
+	"gorm.io/gorm"
+)
+
+// txDB is the database Transactional begins transactions on.
+// Set via SetDB during application initialization.
+var txDB *gorm.DB
+
+// SetDB sets the database for middleware use.
+// This should be called during application initialization.
+func SetDB(db *gorm.DB) {
+	txDB = db
+}
+
+// Transactional runs the rest of the chain in one database transaction.
+// Requires SetDB to be called first.
+//
+// The transaction is put on the request context, where repositories pick it
+// up through database.Conn and TxManager joins it. It commits when the
+// handler responds 2xx and rolls back on any other status or a panic. A
+// route already inside a transaction joins it.
+//
+// The response is held back until the transaction ends, so the client never
+// sees a success that was not committed: a failed commit is logged and
+// answered with 500 instead.
+//
+// Example:
+//
+//	orders := api.Group("/orders", middleware.Transactional())
+func Transactional() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if txDB == nil {
+			response.Abort(c, http.StatusInternalServerError, "Database not initialized")
+			return
+		}
+		runInTransaction(c, txDB)
+	}
+}
+
+// TransactionalWithDB creates transaction middleware with explicit database
+// injection. Use this when you have access to the database instance.
+func TransactionalWithDB(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		runInTransaction(c, db)
+	}
+}
+
+func runInTransaction(c *gin.Context, db *gorm.DB) {
+	ctx := c.Request.Context()
+	if _, ok := database.TxFromContext(ctx); ok {
+		c.Next()
+		return
+	}
+
+	tx := db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		response.Abort(c, http.StatusInternalServerError, "Failed to begin transaction")
+		return
+	}
+
+	// Roll back unless committed, including when a handler panics
+	writer := c.Writer
+	buffered := &txWriter{ResponseWriter: writer, status: http.StatusOK}
+	committed := false
+	defer func() {
+		c.Writer = writer
+		if !committed {
+			tx.Rollback()
+		}
+	}()
+
+	c.Writer = buffered
+	c.Request = c.Request.WithContext(database.WithTx(ctx, tx))
+	c.Next()
+	c.Writer = writer
+
+	if status := buffered.status; status >= 200 && status < 300 {
+		if err := tx.Commit().Error; err != nil {
+			logger.FromContext(ctx).Error("Failed to commit request transaction", map[string]any{
+				"error":  err.Error(),
+				"method": c.Request.Method,
+				"path":   c.Request.URL.Path,
+			})
+			header := writer.Header()
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			response.Abort(c, http.StatusInternalServerError, "Failed to commit transaction")
+			return
+		}
+		committed = true
+	}
+	buffered.flush()
+}
+
+// txWriter holds the response back until the transaction has ended
+type txWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+	wrote  bool
+}
+
+func (w *txWriter) WriteHeader(code int) {
+	if code > 0 && !w.wrote {
+		w.status = code
+	}
+}
+
+func (w *txWriter) WriteHeaderNow() {
+	w.wrote = true
+}
+
+func (w *txWriter) Write(data []byte) (int, error) {
+	w.wrote = true
+	return w.body.Write(data)
+}
+
+func (w *txWriter) WriteString(s string) (int, error) {
+	w.wrote = true
+	return w.body.WriteString(s)
+}
+
+func (w *txWriter) Status() int {
+	return w.status
+}
+
+func (w *txWriter) Size() int {
+	if !w.wrote {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *txWriter) Written() bool {
+	return w.wrote
+}
+
+// Flush is a no-op: nothing may reach the client before the commit
+func (w *txWriter) Flush() {}
+
+// flush sends the held response. A status without a body is left for gin
+// to write once the chain returns.
+func (w *txWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.wrote {
+		w.ResponseWriter.WriteHeaderNow()
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"gorm.io/gorm"
+)
+
+type txNote struct {
+	ID   uint
+	Body string
+}
+
+func setupTxRouter(t *testing.T) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+	db := dbtest.Open(t, &txNote{})
+
+	// write creates a note the way a repository would
+	write := func(ctx context.Context, body string) {
+		require.NoError(t, database.Conn(ctx, db).Create(&txNote{Body: body}).Error)
+	}
+
+	r := gin.New()
+	r.Use(gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	tx := r.Group("/", TransactionalWithDB(db))
+	tx.POST("/ok", func(c *gin.Context) {
+		write(c.Request.Context(), "ok")
+		c.String(http.StatusCreated, "created")
+	})
+	tx.POST("/fail", func(c *gin.Context) {
+		write(c.Request.Context(), "fail")
+		c.Status(http.StatusInternalServerError)
+	})
+	tx.POST("/invalid", func(c *gin.Context) {
+		write(c.Request.Context(), "invalid")
+		c.Status(http.StatusUnprocessableEntity)
+	})
+	tx.POST("/panic", func(c *gin.Context) {
+		write(c.Request.Context(), "panic")
+		panic("boom")
+	})
+	tx.POST("/nested", TransactionalWithDB(db), func(c *gin.Context) {
+		write(c.Request.Context(), "nested")
+		c.Status(http.StatusOK)
+	})
+	return r, db
+}
+
+func TestTransactional(t *testing.T) {
+	tests := []struct {
+		path      string
+		status    int
+		persisted bool
+	}{
+		{"/ok", http.StatusCreated, true},
+		{"/nested", http.StatusOK, true},
+		{"/fail", http.StatusInternalServerError, false},
+		{"/invalid", http.StatusUnprocessableEntity, false},
+		{"/panic", http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			r, db := setupTxRouter(t)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, nil))
+			require.Equal(t, tt.status, w.Code)
+
+			var count int64
+			require.NoError(t, db.Model(&txNote{}).Count(&count).Error)
+			if tt.persisted {
+				assert.Equal(t, int64(1), count, "write should be committed")
+			} else {
+				assert.Zero(t, count, "write should be rolled back")
+			}
+		})
+	}
+}
+
+func TestTransactional_SendsResponseAfterCommit(t *testing.T) {
+	r, _ := setupTxRouter(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/ok", nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "created", w.Body.String())
+}
+
+func TestTransactional_CommitFailureIsServerError(t *testing.T) {
+	db := dbtest.Open(t)
+
+	// A deferred foreign key is only checked on commit, so the insert
+	// succeeds and the commit fails
+	require.NoError(t, db.Exec("PRAGMA foreign_keys = ON").Error)
+	require.NoError(t, db.Exec("CREATE TABLE parents (id INTEGER PRIMARY KEY)").Error)
+	require.NoError(t, db.Exec("CREATE TABLE children (id INTEGER PRIMARY KEY, parent_id INTEGER REFERENCES parents(id) DEFERRABLE INITIALLY DEFERRED)").Error)
+
+	r := gin.New()
+	r.POST("/orphan", TransactionalWithDB(db), func(c *gin.Context) {
+		require.NoError(t, database.Conn(c.Request.Context(), db).Exec("INSERT INTO children (parent_id) VALUES (42)").Error)
+		c.String(http.StatusCreated, "created")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orphan", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "created")
+}
+
+func TestTransactional_RequiresDB(t *testing.T) {
+	SetDB(nil)
+	r := gin.New()
+	r.GET("/", Transactional(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
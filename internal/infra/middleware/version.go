@@ -1,20 +1,129 @@
 package middleware
 
 import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/response"
 )
 
+// apiVersionKey stores the resolved API version in the gin context
+const apiVersionKey = "api_version"
+
+// versionPattern matches version names such as v1 or v12
+var versionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// APIVersionConfig holds API version negotiation configuration
+type APIVersionConfig struct {
+	// Default is the version used when the request names none
+	// Default: "v1"
+	Default string
+
+	// Supported lists the versions served. Requests for any other version
+	// get 406 Not Acceptable. Empty accepts every version.
+	Supported []string
+
+	// Vendor names the media type clients send in Accept, as in
+	// application/vnd.<Vendor>.v2+json
+	// Default: "eogo"
+	Vendor string
+}
+
+// DefaultAPIVersionConfig returns default API version configuration
+func DefaultAPIVersionConfig() APIVersionConfig {
+	return APIVersionConfig{
+		Default: "v1",
+		Vendor:  "eogo",
+	}
+}
+
+// APIVersion resolves the API version a request asks for and stores it
+// for GetVersion. The version comes from a vendor media type in Accept
+// (application/vnd.eogo.v2+json), then from a leading path segment
+// (/v2/users), then from the default. The resolved version is echoed in
+// the API-Version response header.
+func APIVersion() gin.HandlerFunc {
+	return APIVersionWithConfig(DefaultAPIVersionConfig())
+}
+
+// APIVersionWithConfig returns API version middleware with custom config
+//
+// Example:
+//
+//	api.Use(middleware.APIVersionWithConfig(middleware.APIVersionConfig{
+//	    Supported: []string{"v1", "v2"},
+//	}))
+func APIVersionWithConfig(cfg APIVersionConfig) gin.HandlerFunc {
+	defaults := DefaultAPIVersionConfig()
+	if cfg.Default == "" {
+		cfg.Default = defaults.Default
+	}
+	if cfg.Vendor == "" {
+		cfg.Vendor = defaults.Vendor
+	}
+	mediaPrefix := "application/vnd." + strings.ToLower(cfg.Vendor) + "."
+
+	return func(c *gin.Context) {
+		version := versionFromAccept(c.GetHeader("Accept"), mediaPrefix)
+		if version == "" {
+			version = versionFromPath(c.Request.URL.Path)
+		}
+		if version == "" {
+			version = cfg.Default
+		}
+
+		if len(cfg.Supported) > 0 && !slices.Contains(cfg.Supported, version) {
+			response.Abort(c, http.StatusNotAcceptable, "Unsupported API version")
+			return
+		}
+
+		c.Set(apiVersionKey, version)
+		c.Header("API-Version", version)
+		c.Next()
+	}
+}
+
+// versionFromAccept returns the version of the first vendor media type in
+// an Accept header, e.g. "v2" for application/vnd.eogo.v2+json
+func versionFromAccept(accept, mediaPrefix string) string {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if !strings.HasPrefix(mediaType, mediaPrefix) {
+			continue
+		}
+		version, _, _ := strings.Cut(strings.TrimPrefix(mediaType, mediaPrefix), "+")
+		if versionPattern.MatchString(version) {
+			return version
+		}
+	}
+	return ""
+}
+
+// versionFromPath returns the leading version segment of a path, e.g. "v1"
+// for /v1/users
+func versionFromPath(path string) string {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if versionPattern.MatchString(segment) {
+		return segment
+	}
+	return ""
+}
+
 // VersionMiddleware handles API versioning
 func VersionMiddleware(version string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Set("api_version", version)
+		c.Set(apiVersionKey, version)
 		c.Next()
 	}
 }
 
 // GetVersion gets the current API version
 func GetVersion(c *gin.Context) string {
-	if v, exists := c.Get("api_version"); exists {
+	if v, exists := c.Get(apiVersionKey); exists {
 		return v.(string)
 	}
 	return "v1" // Default version
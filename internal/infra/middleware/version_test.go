@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newVersionRouter(cfg APIVersionConfig) *gin.Engine {
+	r := gin.New()
+	r.Use(APIVersionWithConfig(cfg))
+	echo := func(c *gin.Context) { c.String(http.StatusOK, GetVersion(c)) }
+	r.GET("/v1/users", echo)
+	r.GET("/v2/users", echo)
+	r.GET("/users", echo)
+	return r
+}
+
+func TestAPIVersion_Resolution(t *testing.T) {
+	r := newVersionRouter(DefaultAPIVersionConfig())
+
+	tests := []struct {
+		name   string
+		path   string
+		accept string
+		want   string
+	}{
+		{"default", "/users", "", "v1"},
+		{"path prefix", "/v2/users", "", "v2"},
+		{"accept header", "/users", "application/vnd.eogo.v2+json", "v2"},
+		{"accept wins over path", "/v1/users", "application/vnd.eogo.v2+json", "v2"},
+		{"accept among others", "/users", "text/html, application/vnd.eogo.v3+json;q=0.9", "v3"},
+		{"other vendor ignored", "/v2/users", "application/vnd.acme.v5+json", "v2"},
+		{"plain json ignored", "/users", "application/json", "v1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Body.String() != tt.want {
+				t.Errorf("version = %q, want %q", w.Body.String(), tt.want)
+			}
+			if got := w.Header().Get("API-Version"); got != tt.want {
+				t.Errorf("API-Version header = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIVersion_RejectsUnsupported(t *testing.T) {
+	r := newVersionRouter(APIVersionConfig{Supported: []string{"v1"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("Accept", "application/vnd.eogo.v2+json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotAcceptable {
+		t.Errorf("status = %d, want 406", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/users", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "v1" {
+		t.Errorf("status = %d, body = %q; want 200 and v1", w.Code, w.Body.String())
+	}
+}
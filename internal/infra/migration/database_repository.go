@@ -76,6 +76,30 @@ func (r *databaseRepository) GetLast() ([]MigrationRecord, error) {
 	return r.GetMigrationsByBatch(lastBatch)
 }
 
+// GetLastBatches returns migrations from the last N batch numbers ordered
+// by batch descending, then by migration name descending.
+func (r *databaseRepository) GetLastBatches(batches int) ([]MigrationRecord, error) {
+	var numbers []int
+	err := r.db.Table(r.tableName).
+		Distinct("batch").
+		Order("batch DESC").
+		Limit(batches).
+		Pluck("batch", &numbers).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return []MigrationRecord{}, nil
+	}
+
+	var records []MigrationRecord
+	err = r.db.Table(r.tableName).
+		Where("batch >= ?", numbers[len(numbers)-1]).
+		Order("batch DESC, migration DESC").
+		Find(&records).Error
+	return records, err
+}
+
 // getLastBatchNumber returns the highest batch number, or 0 if no migrations exist.
 func (r *databaseRepository) getLastBatchNumber() (int, error) {
 	var max *int
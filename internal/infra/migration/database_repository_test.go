@@ -500,3 +500,32 @@ func TestDatabaseRepository_DefaultTableName(t *testing.T) {
 	repo := NewDatabaseRepository(db, "")
 	assert.Equal(t, "migrations", repo.GetTable())
 }
+
+func TestDatabaseRepository_GetLastBatches(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+
+	require.NoError(t, repo.Log("migration_a", 1))
+	require.NoError(t, repo.Log("migration_b", 2))
+	require.NoError(t, repo.Log("migration_c", 2))
+	require.NoError(t, repo.Log("migration_d", 3))
+
+	records, err := repo.GetLastBatches(2)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "migration_d", records[0].Migration)
+	assert.Equal(t, "migration_c", records[1].Migration)
+	assert.Equal(t, "migration_b", records[2].Migration)
+
+	// Asking for more batches than exist returns everything
+	records, err = repo.GetLastBatches(10)
+	require.NoError(t, err)
+	assert.Len(t, records, 4)
+}
+
+func TestDatabaseRepository_GetLastBatches_Empty(t *testing.T) {
+	repo, _ := setupTestRepository(t)
+
+	records, err := repo.GetLastBatches(1)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
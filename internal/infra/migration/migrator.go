@@ -309,6 +309,7 @@ func (c *sqlCollector) Trace(_ context.Context, _ time.Time, fc func() (sql stri
 // By default, it rolls back the last batch of migrations.
 // With opts.Steps > 0, it rolls back exactly N migrations.
 // With opts.Batch > 0, it rolls back all migrations in that specific batch.
+// With opts.Batches > 0, it rolls back all migrations in the last N batches.
 func (m *Migrator) Rollback(opts RollbackOptions) ([]string, error) {
 	// Get migrations to rollback based on options
 	var migrations []MigrationRecord
@@ -320,6 +321,9 @@ func (m *Migrator) Rollback(opts RollbackOptions) ([]string, error) {
 	} else if opts.Batch > 0 {
 		// Rollback by specific batch
 		migrations, err = m.repository.GetMigrationsByBatch(opts.Batch)
+	} else if opts.Batches > 0 {
+		// Rollback the last N batches
+		migrations, err = m.repository.GetLastBatches(opts.Batches)
 	} else {
 		// Rollback last batch (default)
 		migrations, err = m.repository.GetLast()
@@ -999,3 +999,61 @@ func TestMigrator_NilEventBus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"test_migration"}, executed)
 }
+
+func TestMigrator_RollbackByBatches(t *testing.T) {
+	migrator, db, _ := setupMigratorTest(t)
+
+	reverted := make(map[string]bool)
+	for _, name := range []string{"001_first", "002_second", "003_third"} {
+		name := name
+		m := newTestMigration()
+		m.downFunc = func(db *gorm.DB) error {
+			reverted[name] = true
+			return nil
+		}
+		migrator.Register(name, m)
+	}
+
+	// Step mode records each migration in its own batch
+	executed, err := migrator.Run(NewMigratorOptions().WithStep())
+	require.NoError(t, err)
+	require.Len(t, executed, 3)
+
+	batches, err := migrator.Repository().GetMigrationBatches()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"001_first": 1, "002_second": 2, "003_third": 3}, batches)
+
+	rolledBack, err := migrator.Rollback(NewRollbackOptions().WithBatches(1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"003_third"}, rolledBack)
+	assert.Equal(t, map[string]bool{"003_third": true}, reverted)
+
+	ran, err := migrator.Repository().GetRan()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"001_first", "002_second"}, ran)
+
+	var count int64
+	require.NoError(t, db.Table("migrations").Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestMigrator_RollbackByBatches_MultipleMigrationsPerBatch(t *testing.T) {
+	migrator, _, _ := setupMigratorTest(t)
+
+	migrator.Register("001_first", newTestMigration())
+	_, err := migrator.Run(NewMigratorOptions())
+	require.NoError(t, err)
+
+	migrator.Register("002_second", newTestMigration())
+	migrator.Register("003_third", newTestMigration())
+	_, err = migrator.Run(NewMigratorOptions())
+	require.NoError(t, err)
+
+	rolledBack, err := migrator.Rollback(NewRollbackOptions().WithBatches(1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"003_third", "002_second"}, rolledBack)
+
+	ran, err := migrator.Repository().GetRan()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"001_first"}, ran)
+}
@@ -23,6 +23,10 @@ type RollbackOptions struct {
 	// If > 0, rolls back all migrations in that batch.
 	Batch int
 
+	// Batches specifies the number of most recent batches to rollback.
+	// If > 0, rolls back every migration in the last N batches (db:rollback --step=N).
+	Batches int
+
 	// Pretend shows SQL statements without executing them
 	Pretend bool
 }
@@ -75,6 +79,12 @@ func (o RollbackOptions) WithBatch(batch int) RollbackOptions {
 	return o
 }
 
+// WithBatches returns rollback options that revert the last N batches.
+func (o RollbackOptions) WithBatches(batches int) RollbackOptions {
+	o.Batches = batches
+	return o
+}
+
 // WithPretend returns rollback options with pretend mode enabled.
 func (o RollbackOptions) WithPretend() RollbackOptions {
 	o.Pretend = true
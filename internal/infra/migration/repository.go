@@ -25,6 +25,10 @@ type Repository interface {
 	// GetLast returns migrations from the last (highest) batch number.
 	GetLast() ([]MigrationRecord, error)
 
+	// GetLastBatches returns migrations from the last N batch numbers ordered
+	// by batch descending, then by migration name descending.
+	GetLastBatches(batches int) ([]MigrationRecord, error)
+
 	// GetMigrationBatches returns all migrations with their batch numbers
 	// as a map where key is migration name and value is batch number.
 	GetMigrationBatches() (map[string]int, error)
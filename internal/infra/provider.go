@@ -1,6 +1,7 @@
 package infra
 
 import (
+	"github.com/zgiai/zgo/internal/domain"
 	"github.com/zgiai/zgo/internal/infra/config"
 	"github.com/zgiai/zgo/internal/infra/database"
 	"github.com/zgiai/zgo/internal/infra/email"
@@ -18,6 +19,8 @@ var ProviderSet = wire.NewSet(
 
 	// Database - depends on Config
 	database.NewDB,
+	database.NewTxManager,
+	wire.Bind(new(domain.Transactor), new(*database.TxManager)),
 
 	// JWT Service - depends on Config
 	jwt.NewService,
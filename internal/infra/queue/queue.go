@@ -15,6 +15,13 @@ type Job interface {
 	Handle(ctx context.Context) error
 }
 
+// JobWithName allows a job to choose the name it is registered and
+// serialized under, instead of its type name
+type JobWithName interface {
+	Job
+	Name() string
+}
+
 // JobWithQueue allows a job to specify its queue
 type JobWithQueue interface {
 	Job
@@ -64,12 +71,21 @@ type JobPayload struct {
 	CreatedAt  time.Time       `json:"created_at"`
 }
 
+var (
+	// ErrQueueEmpty is returned by Pop when no job is ready
+	ErrQueueEmpty = errors.New("queue is empty")
+
+	// ErrDriverClosed is returned when pushing to a closed driver
+	ErrDriverClosed = errors.New("driver is closed")
+)
+
 // Manager manages queue operations
 type Manager struct {
-	mu           sync.RWMutex
-	drivers      map[string]Driver
-	defaultQueue string
-	jobRegistry  map[string]reflect.Type
+	mu            sync.RWMutex
+	drivers       map[string]Driver
+	defaultDriver string
+	defaultQueue  string
+	jobRegistry   map[string]reflect.Type
 }
 
 var (
@@ -80,17 +96,21 @@ var (
 // Global returns the global queue manager
 func Global() *Manager {
 	once.Do(func() {
-		manager = &Manager{
-			drivers:      make(map[string]Driver),
-			defaultQueue: "default",
-			jobRegistry:  make(map[string]reflect.Type),
-		}
-		// Register default sync driver
-		manager.drivers["sync"] = NewSyncDriver()
+		manager = NewManager()
 	})
 	return manager
 }
 
+// NewManager creates a manager using the sync driver by default
+func NewManager() *Manager {
+	return &Manager{
+		drivers:       map[string]Driver{"sync": NewSyncDriver()},
+		defaultDriver: "sync",
+		defaultQueue:  "default",
+		jobRegistry:   make(map[string]reflect.Type),
+	}
+}
+
 // SetDefaultQueue sets the default queue name
 func (m *Manager) SetDefaultQueue(queue string) {
 	m.mu.Lock()
@@ -112,9 +132,23 @@ func (m *Manager) Driver(name string) Driver {
 	return m.drivers[name]
 }
 
+// SetDefaultDriver selects the registered driver jobs are dispatched to
+func (m *Manager) SetDefaultDriver(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.drivers[name]; !ok {
+		return fmt.Errorf("unknown queue driver: %s", name)
+	}
+	m.defaultDriver = name
+	return nil
+}
+
 // DefaultDriver returns the default driver
 func (m *Manager) DefaultDriver() Driver {
-	return m.Driver("sync")
+	m.mu.RLock()
+	name := m.defaultDriver
+	m.mu.RUnlock()
+	return m.Driver(name)
 }
 
 // RegisterJob registers a job type for deserialization
@@ -125,7 +159,19 @@ func (m *Manager) RegisterJob(job Job) {
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
-	m.jobRegistry[t.Name()] = t
+	m.jobRegistry[jobName(job)] = t
+}
+
+// jobName returns the name a job is registered under
+func jobName(job Job) string {
+	if jn, ok := job.(JobWithName); ok {
+		return jn.Name()
+	}
+	t := reflect.TypeOf(job)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
 }
 
 // createJob creates a job instance from type name
@@ -187,11 +233,6 @@ func (m *Manager) LaterTo(ctx context.Context, queue string, delay time.Duration
 
 // serializeJob serializes a job to JSON
 func (m *Manager) serializeJob(job Job) ([]byte, error) {
-	t := reflect.TypeOf(job)
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
-	}
-
 	data, err := json.Marshal(job)
 	if err != nil {
 		return nil, err
@@ -203,7 +244,7 @@ func (m *Manager) serializeJob(job Job) ([]byte, error) {
 	}
 
 	payload := JobPayload{
-		Type:       t.Name(),
+		Type:       jobName(job),
 		Data:       data,
 		Attempts:   0,
 		MaxRetries: maxRetries,
@@ -335,7 +376,7 @@ func (d *SyncDriver) Pop(ctx context.Context, queue string) ([]byte, error) {
 
 	jobs := d.queues[queue]
 	if len(jobs) == 0 {
-		return nil, errors.New("queue is empty")
+		return nil, ErrQueueEmpty
 	}
 
 	payload := jobs[0]
@@ -365,12 +406,15 @@ func (d *SyncDriver) Close() error {
 
 // --- Memory Driver (stores jobs for worker processing) ---
 
-// MemoryDriver stores jobs in memory for async processing
+// MemoryDriver stores jobs in memory for async processing.
+// Its channels are never closed, so a Push racing Clear or Close can not
+// send on a closed channel; Close is signalled through done instead.
 type MemoryDriver struct {
-	mu      sync.Mutex
-	queues  map[string]chan []byte
-	bufSize int
-	closed  bool
+	mu        sync.Mutex
+	queues    map[string]chan []byte
+	bufSize   int
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 // NewMemoryDriver creates a new memory driver
@@ -378,6 +422,7 @@ func NewMemoryDriver(bufferSize int) *MemoryDriver {
 	return &MemoryDriver{
 		queues:  make(map[string]chan []byte),
 		bufSize: bufferSize,
+		done:    make(chan struct{}),
 	}
 }
 
@@ -396,13 +441,17 @@ func (d *MemoryDriver) getQueue(name string) chan []byte {
 
 // Push adds a job to the queue
 func (d *MemoryDriver) Push(ctx context.Context, queue string, payload []byte) error {
-	if d.closed {
-		return errors.New("driver is closed")
+	select {
+	case <-d.done:
+		return ErrDriverClosed
+	default:
 	}
 
 	select {
 	case d.getQueue(queue) <- payload:
 		return nil
+	case <-d.done:
+		return ErrDriverClosed
 	case <-ctx.Done():
 		return ctx.Err()
 	}
@@ -417,11 +466,20 @@ func (d *MemoryDriver) PushDelayed(ctx context.Context, queue string, payload []
 	return nil
 }
 
-// Pop retrieves the next job
+// Pop retrieves the next job. After Close it still hands out jobs that
+// were already queued, then fails with ErrDriverClosed.
 func (d *MemoryDriver) Pop(ctx context.Context, queue string) ([]byte, error) {
+	ch := d.getQueue(queue)
 	select {
-	case payload := <-d.getQueue(queue):
+	case payload := <-ch:
 		return payload, nil
+	case <-d.done:
+		select {
+		case payload := <-ch:
+			return payload, nil
+		default:
+			return nil, ErrDriverClosed
+		}
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
@@ -432,26 +490,20 @@ func (d *MemoryDriver) Size(ctx context.Context, queue string) (int64, error) {
 	return int64(len(d.getQueue(queue))), nil
 }
 
-// Clear clears the queue
+// Clear drops the jobs waiting in the queue
 func (d *MemoryDriver) Clear(ctx context.Context, queue string) error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	if ch, ok := d.queues[queue]; ok {
-		close(ch)
-		d.queues[queue] = make(chan []byte, d.bufSize)
+	ch := d.getQueue(queue)
+	for {
+		select {
+		case <-ch:
+		default:
+			return nil
+		}
 	}
-	return nil
 }
 
-// Close closes all queues
+// Close stops the driver: Push fails with ErrDriverClosed from now on
 func (d *MemoryDriver) Close() error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	d.closed = true
-	for _, ch := range d.queues {
-		close(ch)
-	}
+	d.closeOnce.Do(func() { close(d.done) })
 	return nil
 }
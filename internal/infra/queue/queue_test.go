@@ -0,0 +1,263 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// runs counts handled jobs per test, since jobs are rebuilt from JSON
+var runs sync.Map
+
+// releases holds the channel each slowJob waits on
+var releases sync.Map
+
+// newKey returns a key unique to this run of the test
+func newKey(t *testing.T) string {
+	return fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano())
+}
+
+func runCounter(key string) *atomic.Int32 {
+	v, _ := runs.LoadOrStore(key, new(atomic.Int32))
+	return v.(*atomic.Int32)
+}
+
+// flakyJob fails until it has been attempted FailTimes times
+type flakyJob struct {
+	Key       string `json:"key"`
+	FailTimes int32  `json:"fail_times"`
+}
+
+func (j *flakyJob) Name() string { return "test.flaky" }
+
+func (j *flakyJob) Handle(ctx context.Context) error {
+	if runCounter(j.Key).Add(1) <= j.FailTimes {
+		return errors.New("temporary failure")
+	}
+	return nil
+}
+
+// slowJob blocks until its release channel is closed
+type slowJob struct {
+	Key string `json:"key"`
+}
+
+func (j *slowJob) Handle(ctx context.Context) error {
+	runCounter(j.Key).Add(1)
+	release, _ := releases.Load(j.Key)
+	<-release.(chan struct{})
+	return nil
+}
+
+// startWorker runs a worker over a fresh manager and memory driver
+func startWorker(t *testing.T, config WorkerConfig) (*Manager, *Worker) {
+	t.Helper()
+	m := NewManager()
+	m.RegisterJob(&flakyJob{})
+	m.RegisterJob(&slowJob{})
+	m.RegisterDriver("memory", NewMemoryDriver(16))
+	if err := m.SetDefaultDriver("memory"); err != nil {
+		t.Fatalf("SetDefaultDriver failed: %v", err)
+	}
+
+	config.Sleep = 5 * time.Millisecond
+	w := NewWorker(config)
+	w.SetManager(m)
+	w.SetDriver(m.DefaultDriver())
+	if err := w.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	t.Cleanup(w.Stop)
+	return m, w
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWorker_RetriesFailedJob(t *testing.T) {
+	var failed atomic.Bool
+	config := DefaultWorkerConfig()
+	config.Backoff = func(attempt int) time.Duration { return 10 * time.Millisecond }
+	config.FailedJobHandler = func(ctx context.Context, payload *JobPayload, err error) {
+		failed.Store(true)
+	}
+	m, _ := startWorker(t, config)
+
+	key := newKey(t)
+	if err := m.Dispatch(context.Background(), &flakyJob{Key: key, FailTimes: 1}); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+
+	waitFor(t, func() bool { return runCounter(key).Load() == 2 })
+	time.Sleep(50 * time.Millisecond)
+	if got := runCounter(key).Load(); got != 2 {
+		t.Errorf("job ran %d times, want 2", got)
+	}
+	if failed.Load() {
+		t.Error("a job that eventually succeeded was dead-lettered")
+	}
+}
+
+func TestWorker_DeadLettersAfterMaxRetries(t *testing.T) {
+	dead := make(chan *JobPayload, 1)
+	config := DefaultWorkerConfig()
+	config.Backoff = func(attempt int) time.Duration { return time.Millisecond }
+	config.FailedJobHandler = func(ctx context.Context, payload *JobPayload, err error) {
+		dead <- payload
+	}
+	m, _ := startWorker(t, config)
+
+	key := newKey(t)
+	if err := m.Dispatch(context.Background(), &flakyJob{Key: key, FailTimes: 100}); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+
+	select {
+	case payload := <-dead:
+		if payload.Type != "test.flaky" {
+			t.Errorf("payload type = %q, want the job's Name()", payload.Type)
+		}
+		if payload.Attempts != payload.MaxRetries {
+			t.Errorf("dead-lettered after %d attempts, want %d", payload.Attempts, payload.MaxRetries)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("job was never dead-lettered")
+	}
+}
+
+func TestWorker_DelayedJobDoesNotRunEarly(t *testing.T) {
+	m, _ := startWorker(t, DefaultWorkerConfig())
+
+	key := newKey(t)
+	start := time.Now()
+	if err := m.Later(context.Background(), 150*time.Millisecond, &flakyJob{Key: key}); err != nil {
+		t.Fatalf("Later failed: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if got := runCounter(key).Load(); got != 0 {
+		t.Fatalf("delayed job ran after %v", time.Since(start))
+	}
+
+	waitFor(t, func() bool { return runCounter(key).Load() == 1 })
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("delayed job ran after %v, want at least 150ms", elapsed)
+	}
+}
+
+func TestWorker_ShutdownWaitsForRunningJob(t *testing.T) {
+	m, w := startWorker(t, DefaultWorkerConfig())
+
+	key := newKey(t)
+	releaseSlow := make(chan struct{})
+	releases.Store(key, releaseSlow)
+	if err := m.Dispatch(context.Background(), &slowJob{Key: key}); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	waitFor(t, func() bool { return runCounter(key).Load() == 1 })
+
+	// The job is still running, so a short deadline expires
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := w.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown() = %v, want DeadlineExceeded", err)
+	}
+
+	close(releaseSlow)
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("workers did not exit after the job finished")
+	}
+}
+
+func TestWorker_ShutdownIdle(t *testing.T) {
+	_, w := startWorker(t, DefaultWorkerConfig())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := w.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() = %v, want nil for an idle worker", err)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 5*time.Second)
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestManager_SetDefaultDriver(t *testing.T) {
+	m := NewManager()
+	if err := m.SetDefaultDriver("redis"); err == nil {
+		t.Error("expected an error for an unregistered driver")
+	}
+	if _, ok := m.DefaultDriver().(*SyncDriver); !ok {
+		t.Errorf("default driver = %T, want *SyncDriver", m.DefaultDriver())
+	}
+}
+
+func TestMemoryDriver_PushRacingClearAndClose(t *testing.T) {
+	d := NewMemoryDriver(4)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				pushCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+				err := d.Push(pushCtx, "default", []byte("job"))
+				cancel()
+				if errors.Is(err, ErrDriverClosed) {
+					return
+				}
+			}
+		}()
+	}
+
+	for range 50 {
+		if err := d.Clear(ctx, "default"); err != nil {
+			t.Fatalf("Clear failed: %v", err)
+		}
+	}
+	if err := d.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	wg.Wait()
+
+	if err := d.Push(ctx, "default", []byte("late")); !errors.Is(err, ErrDriverClosed) {
+		t.Errorf("Push after Close = %v, want ErrDriverClosed", err)
+	}
+	// Jobs queued before Close are still handed out, then the queue reports closed
+	for {
+		if _, err := d.Pop(ctx, "default"); err != nil {
+			if !errors.Is(err, ErrDriverClosed) {
+				t.Errorf("Pop after Close = %v, want ErrDriverClosed", err)
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// migrateDelayedScript moves due jobs from the delayed sorted set onto the
+// ready list in one step, so two workers never both take the same job
+var migrateDelayedScript = redis.NewScript(`
+local jobs = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, 100)
+for _, job in ipairs(jobs) do
+	redis.call('ZREM', KEYS[1], job)
+	redis.call('RPUSH', KEYS[2], job)
+end
+return #jobs
+`)
+
+// RedisDriver keeps each queue in a Redis list, with delayed jobs in a sorted
+// set scored by the time they become due
+type RedisDriver struct {
+	client *redis.Client
+	prefix string
+	block  time.Duration
+}
+
+// RedisDriverOption configures the Redis driver
+type RedisDriverOption func(*RedisDriver)
+
+// WithRedisPrefix sets the key prefix for queue lists
+func WithRedisPrefix(prefix string) RedisDriverOption {
+	return func(d *RedisDriver) {
+		d.prefix = prefix
+	}
+}
+
+// WithRedisBlock sets how long Pop waits for a job before reporting an empty queue
+func WithRedisBlock(timeout time.Duration) RedisDriverOption {
+	return func(d *RedisDriver) {
+		d.block = timeout
+	}
+}
+
+// NewRedisDriver creates a Redis driver. The client is not closed by Close.
+func NewRedisDriver(client *redis.Client, opts ...RedisDriverOption) *RedisDriver {
+	d := &RedisDriver{
+		client: client,
+		prefix: "queues:",
+		block:  time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+func (d *RedisDriver) readyKey(queue string) string {
+	return d.prefix + queue
+}
+
+func (d *RedisDriver) delayedKey(queue string) string {
+	return d.prefix + queue + ":delayed"
+}
+
+// Push appends a job to the queue
+func (d *RedisDriver) Push(ctx context.Context, queue string, payload []byte) error {
+	return d.client.RPush(ctx, d.readyKey(queue), payload).Err()
+}
+
+// PushDelayed stores a job until delay has passed
+func (d *RedisDriver) PushDelayed(ctx context.Context, queue string, payload []byte, delay time.Duration) error {
+	if delay <= 0 {
+		return d.Push(ctx, queue, payload)
+	}
+
+	due := time.Now().Add(delay).UnixMilli()
+	return d.client.ZAdd(ctx, d.delayedKey(queue), redis.Z{
+		Score:  float64(due),
+		Member: payload,
+	}).Err()
+}
+
+// Pop moves due delayed jobs onto the queue, then waits up to the block
+// timeout for the next job
+func (d *RedisDriver) Pop(ctx context.Context, queue string) ([]byte, error) {
+	keys := []string{d.delayedKey(queue), d.readyKey(queue)}
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if err := migrateDelayedScript.Run(ctx, d.client, keys, now).Err(); err != nil {
+		return nil, err
+	}
+
+	result, err := d.client.BLPop(ctx, d.block, d.readyKey(queue)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrQueueEmpty
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// BLPOP returns the key followed by the value
+	return []byte(result[1]), nil
+}
+
+// Size returns the number of ready and delayed jobs
+func (d *RedisDriver) Size(ctx context.Context, queue string) (int64, error) {
+	ready, err := d.client.LLen(ctx, d.readyKey(queue)).Result()
+	if err != nil {
+		return 0, err
+	}
+	delayed, err := d.client.ZCard(ctx, d.delayedKey(queue)).Result()
+	if err != nil {
+		return 0, err
+	}
+	return ready + delayed, nil
+}
+
+// Clear removes all ready and delayed jobs
+func (d *RedisDriver) Clear(ctx context.Context, queue string) error {
+	return d.client.Del(ctx, d.readyKey(queue), d.delayedKey(queue)).Err()
+}
+
+// Close is a no-op; the client belongs to the caller
+func (d *RedisDriver) Close() error {
+	return nil
+}
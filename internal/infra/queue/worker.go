@@ -25,7 +25,11 @@ type WorkerConfig struct {
 	// Stop after processing this many jobs (0 = unlimited)
 	MaxJobs int
 
-	// Handler for failed jobs
+	// Delay before retry n (1-based); jobs implementing JobWithRetry
+	// choose their own. Defaults to ExponentialBackoff(time.Second, 5*time.Minute).
+	Backoff func(attempt int) time.Duration
+
+	// Handler for jobs that failed after their last retry (dead letters)
 	FailedJobHandler func(ctx context.Context, payload *JobPayload, err error)
 
 	// Handler called before job processing
@@ -43,6 +47,18 @@ func DefaultWorkerConfig() WorkerConfig {
 		Sleep:       time.Second,
 		Timeout:     60 * time.Second,
 		MaxJobs:     0,
+		Backoff:     ExponentialBackoff(time.Second, 5*time.Minute),
+	}
+}
+
+// ExponentialBackoff doubles the delay from base on each attempt, up to max
+func ExponentialBackoff(base, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		delay := base
+		for i := 1; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+		return min(delay, max)
 	}
 }
 
@@ -55,6 +71,9 @@ type Worker struct {
 	wg      sync.WaitGroup
 	running bool
 	mu      sync.Mutex
+
+	// cancelPop interrupts workers blocked waiting for a job
+	cancelPop context.CancelFunc
 }
 
 // NewWorker creates a new worker
@@ -68,6 +87,9 @@ func NewWorker(config WorkerConfig) *Worker {
 	if config.Timeout == 0 {
 		config.Timeout = 60 * time.Second
 	}
+	if config.Backoff == nil {
+		config.Backoff = ExponentialBackoff(time.Second, 5*time.Minute)
+	}
 
 	return &Worker{
 		config:  config,
@@ -82,6 +104,11 @@ func (w *Worker) SetDriver(driver Driver) {
 	w.driver = driver
 }
 
+// SetManager sets the manager whose registered jobs the worker runs
+func (w *Worker) SetManager(manager *Manager) {
+	w.manager = manager
+}
+
 // Start starts the worker
 func (w *Worker) Start(ctx context.Context) error {
 	w.mu.Lock()
@@ -90,11 +117,13 @@ func (w *Worker) Start(ctx context.Context) error {
 		return nil
 	}
 	w.running = true
+	popCtx, cancel := context.WithCancel(ctx)
+	w.cancelPop = cancel
 	w.mu.Unlock()
 
 	for i := 0; i < w.config.Concurrency; i++ {
 		w.wg.Add(1)
-		go w.work(ctx, i)
+		go w.work(ctx, popCtx, i)
 	}
 
 	return nil
@@ -102,20 +131,39 @@ func (w *Worker) Start(ctx context.Context) error {
 
 // Stop stops the worker gracefully
 func (w *Worker) Stop() {
+	_ = w.Shutdown(context.Background())
+}
+
+// Shutdown stops taking new jobs and waits for running ones to finish.
+// If ctx ends first, Shutdown returns its error and the running jobs are
+// left to finish on their own.
+func (w *Worker) Shutdown(ctx context.Context) error {
 	w.mu.Lock()
 	if !w.running {
 		w.mu.Unlock()
-		return
+		return nil
 	}
 	w.running = false
+	close(w.stop)
+	w.cancelPop()
 	w.mu.Unlock()
 
-	close(w.stop)
-	w.wg.Wait()
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // work is the main worker loop
-func (w *Worker) work(ctx context.Context, id int) {
+func (w *Worker) work(ctx, popCtx context.Context, id int) {
 	defer w.wg.Done()
 
 	jobsProcessed := 0
@@ -133,10 +181,13 @@ func (w *Worker) work(ctx context.Context, id int) {
 			}
 
 			// Try to get a job
-			payload, err := w.driver.Pop(ctx, w.config.Queue)
+			payload, err := w.driver.Pop(popCtx, w.config.Queue)
 			if err != nil {
 				// Queue empty or error, sleep and retry
-				time.Sleep(w.config.Sleep)
+				select {
+				case <-time.After(w.config.Sleep):
+				case <-popCtx.Done():
+				}
 				continue
 			}
 
@@ -192,7 +243,7 @@ func (w *Worker) processJob(ctx context.Context, data []byte) {
 		// Check if we should retry
 		if payload.Attempts < payload.MaxRetries {
 			// Get retry delay
-			retryDelay := time.Second * time.Duration(payload.Attempts)
+			retryDelay := w.config.Backoff(payload.Attempts)
 			if jr, ok := job.(JobWithRetry); ok {
 				retryDelay = jr.RetryDelay()
 			}
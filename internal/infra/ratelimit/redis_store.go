@@ -23,10 +23,10 @@ const (
 	pingInterval       = 100 * time.Millisecond
 )
 
-// RedisStore implements a distributed rate limiter using Redis
+// RedisStore implements a distributed token-bucket rate limiter using Redis
 // with automatic fallback to local limiter when Redis is unavailable
 type RedisStore struct {
-	rate   int           // tokens per second
+	rate   float64       // tokens per second
 	burst  int           // max burst size (capacity)
 	window time.Duration // rate limit window
 	client *infraRedis.Client
@@ -66,14 +66,8 @@ func NewRedisStore(cfg RedisStoreConfig) *RedisStore {
 		cfg.Client = infraRedis.Default()
 	}
 
-	// Calculate rate per second
-	ratePerSecond := float64(cfg.Rate) / cfg.Window.Seconds()
-	if ratePerSecond < 1 {
-		ratePerSecond = 1
-	}
-
 	return &RedisStore{
-		rate:           int(ratePerSecond),
+		rate:           float64(cfg.Rate) / cfg.Window.Seconds(),
 		burst:          cfg.Burst,
 		window:         cfg.Window,
 		client:         cfg.Client,
@@ -83,45 +77,24 @@ func NewRedisStore(cfg RedisStoreConfig) *RedisStore {
 	}
 }
 
-// Allow checks if a request is allowed
+// Allow checks if a request is allowed without taking a token. When it is
+// not, resetAt is when the next token arrives.
 func (s *RedisStore) Allow(ctx context.Context, key string) (bool, int, time.Time) {
 	now := time.Now()
-	resetAt := now.Add(s.window)
-
-	// If Redis is down, use local fallback
-	if atomic.LoadUint32(&s.redisAlive) == 0 {
-		return s.allowLocal(key, now, resetAt)
-	}
 
-	allowed, remaining := s.allowRedis(ctx, key, now)
+	allowed, tokens := s.take(ctx, key, now, false)
 	if !allowed {
-		return false, 0, resetAt
+		return false, 0, now.Add(s.refill(1 - tokens))
 	}
-
-	return true, remaining, resetAt
+	return true, int(tokens), now.Add(s.refill(float64(s.burst) - tokens))
 }
 
-// Hit records a hit for the given key
+// Hit takes a token for the given key. resetAt is when the bucket is full.
 func (s *RedisStore) Hit(ctx context.Context, key string) (int, time.Time) {
 	now := time.Now()
-	resetAt := now.Add(s.window)
-
-	// If Redis is down, use local fallback
-	if atomic.LoadUint32(&s.redisAlive) == 0 {
-		limiter := s.getLocalLimiter(key)
-		if limiter.Allow() {
-			return s.burst - int(limiter.Tokens()), resetAt
-		}
-		return 0, resetAt
-	}
-
-	// For Redis, Allow already consumes the token
-	allowed, remaining := s.allowRedis(ctx, key, now)
-	if !allowed {
-		return 0, resetAt
-	}
 
-	return remaining, resetAt
+	_, tokens := s.take(ctx, key, now, true)
+	return int(tokens), now.Add(s.refill(float64(s.burst) - tokens))
 }
 
 // Reset resets the limiter for a key
@@ -152,49 +125,52 @@ func (s *RedisStore) Close() error {
 	return nil
 }
 
-// allowRedis checks if request is allowed using Redis
-func (s *RedisStore) allowRedis(ctx context.Context, key string, now time.Time) (bool, int) {
-	if s.client == nil {
-		allowed, remaining, _ := s.allowLocal(key, now, now.Add(s.window))
-		return allowed, remaining
+// take checks key's bucket for a token, taking it when consume is set, and
+// returns the tokens left
+func (s *RedisStore) take(ctx context.Context, key string, now time.Time, consume bool) (bool, float64) {
+	// If Redis is down, use local fallback
+	if s.client == nil || atomic.LoadUint32(&s.redisAlive) == 0 {
+		return s.takeLocal(key, now, consume)
 	}
 
 	tokenKey := s.tokenKey(key)
 	tsKey := s.timestampKey(key)
 
+	flag := 0
+	if consume {
+		flag = 1
+	}
 	result, err := s.script.Run(ctx, s.client.Raw(), []string{tokenKey, tsKey},
 		s.rate,
 		s.burst,
-		now.Unix(),
+		float64(now.UnixMicro())/1e6,
 		1, // requested tokens
-	).Result()
+		flag,
+	).Int64Slice()
 
-	if err != nil {
+	if err != nil || len(result) != 2 {
 		logger.Warningf("rate limiter redis error: %v, falling back to local limiter", err)
 		s.startMonitor()
-		allowed, remaining, _ := s.allowLocal(key, now, now.Add(s.window))
-		return allowed, remaining
+		return s.takeLocal(key, now, consume)
 	}
 
-	// Lua returns 1 for allowed, nil/false for denied
-	allowed, ok := result.(int64)
-	if !ok {
-		logger.Warningf("unexpected redis script result: %v, falling back to local limiter", result)
-		s.startMonitor()
-		allowed, remaining, _ := s.allowLocal(key, now, now.Add(s.window))
-		return allowed, remaining
-	}
-
-	return allowed == 1, s.burst - 1 // approximate remaining
+	return result[0] == 1, float64(result[1]) / 1000
 }
 
-// allowLocal uses local rate limiter as fallback
-func (s *RedisStore) allowLocal(key string, now time.Time, resetAt time.Time) (bool, int, time.Time) {
+// takeLocal uses local rate limiter as fallback
+func (s *RedisStore) takeLocal(key string, now time.Time, consume bool) (bool, float64) {
 	limiter := s.getLocalLimiter(key)
-	if limiter.AllowN(now, 1) {
-		return true, s.burst - 1, resetAt
+	if consume {
+		allowed := limiter.AllowN(now, 1)
+		return allowed, max(limiter.TokensAt(now), 0)
 	}
-	return false, 0, resetAt
+	tokens := limiter.TokensAt(now)
+	return tokens >= 1, max(tokens, 0)
+}
+
+// refill returns how long the bucket takes to gain tokens
+func (s *RedisStore) refill(tokens float64) time.Duration {
+	return time.Duration(tokens / s.rate * float64(time.Second))
 }
 
 // getLocalLimiter returns or creates a local limiter for the key
@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zgiai/zgo/internal/infra/kv"
+	infraRedis "github.com/zgiai/zgo/internal/infra/redis"
+	"golang.org/x/time/rate"
+)
+
+// NewTokenBucket returns a token-bucket Limiter refilling requests tokens per
+// window into buckets holding burst tokens, kept wherever store keeps its
+// keys: a Redis store shares the buckets across instances through the
+// token-bucket script, any other store keeps them in process.
+func NewTokenBucket(store kv.Store, requests int, window time.Duration, burst int) Limiter {
+	if rs, ok := store.(*kv.RedisStore); ok {
+		return NewRedisStore(RedisStoreConfig{
+			Rate:   requests,
+			Burst:  burst,
+			Window: window,
+			Client: infraRedis.Wrap(rs.Client()),
+		})
+	}
+	return NewTokenBucketStore(requests, window, burst)
+}
+
+// TokenBucketStore is an in-memory Limiter refilling a bucket of Burst
+// tokens at Rate tokens per Window. Unlike MemoryStore's fixed window, a
+// client can never send more than Burst requests back to back.
+type TokenBucketStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	limit     rate.Limit
+	burst     int
+	idle      time.Duration
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewTokenBucketStore creates a store refilling requests tokens per window
+// into buckets holding burst tokens. A burst of 0 means requests.
+func NewTokenBucketStore(requests int, window time.Duration, burst int) *TokenBucketStore {
+	if requests <= 0 {
+		requests = 60
+	}
+	if window <= 0 {
+		window = time.Minute
+	}
+	if burst <= 0 {
+		burst = requests
+	}
+
+	interval := window / time.Duration(requests)
+	return &TokenBucketStore{
+		buckets: make(map[string]*bucket),
+		limit:   rate.Every(interval),
+		burst:   burst,
+		// A bucket idle this long is full again and can be dropped
+		idle:      interval * time.Duration(burst),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow reports whether key has a token left. When it has none, resetAt is
+// when the next token arrives.
+func (s *TokenBucketStore) Allow(ctx context.Context, key string) (bool, int, time.Time) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens := s.bucket(key, now).TokensAt(now)
+	if tokens < 1 {
+		return false, 0, now.Add(s.refill(1 - tokens))
+	}
+	return true, int(tokens), now.Add(s.refill(float64(s.burst) - tokens))
+}
+
+// Hit takes a token from key's bucket. resetAt is when the bucket is full.
+func (s *TokenBucketStore) Hit(ctx context.Context, key string) (int, time.Time) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	limiter := s.bucket(key, now)
+	limiter.AllowN(now, 1)
+
+	tokens := max(limiter.TokensAt(now), 0)
+	return int(tokens), now.Add(s.refill(float64(s.burst) - tokens))
+}
+
+// Reset refills the bucket for key
+func (s *TokenBucketStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	delete(s.buckets, key)
+	s.mu.Unlock()
+	return nil
+}
+
+// bucket returns the limiter for key, sweeping idle buckets first.
+// The caller holds s.mu.
+func (s *TokenBucketStore) bucket(key string, now time.Time) *rate.Limiter {
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(s.limit, s.burst)}
+		s.buckets[key] = b
+	}
+	b.lastSeen = now
+	return b.limiter
+}
+
+// refill returns how long the bucket takes to gain tokens
+func (s *TokenBucketStore) refill(tokens float64) time.Duration {
+	return time.Duration(tokens / float64(s.limit) * float64(time.Second))
+}
+
+// sweep drops idle buckets at most once per idle period
+func (s *TokenBucketStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.idle {
+		return
+	}
+	for key, b := range s.buckets {
+		if now.Sub(b.lastSeen) >= s.idle {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
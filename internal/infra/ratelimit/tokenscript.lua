@@ -1,19 +1,22 @@
 -- Token bucket rate limiting using Redis
 -- KEYS[1]: tokens key
 -- KEYS[2]: timestamp key
--- ARGV[1]: rate (tokens per second)
+-- ARGV[1]: rate (tokens per second, may be fractional)
 -- ARGV[2]: capacity (burst size)
--- ARGV[3]: current timestamp (unix seconds)
+-- ARGV[3]: current timestamp (unix seconds, may be fractional)
 -- ARGV[4]: requested tokens
+-- ARGV[5]: 1 to take the tokens, 0 to only check for them
+-- Returns {allowed (1 or 0), tokens left in thousandths}
 
 local rate = tonumber(ARGV[1])
 local capacity = tonumber(ARGV[2])
 local now = tonumber(ARGV[3])
 local requested = tonumber(ARGV[4])
+local take = tonumber(ARGV[5]) == 1
 
 -- Calculate TTL as 2x the time to fill the bucket
 local fill_time = capacity / rate
-local ttl = math.floor(fill_time * 2)
+local ttl = math.ceil(fill_time * 2)
 if ttl < 1 then
     ttl = 1
 end
@@ -38,17 +41,18 @@ local filled_tokens = math.min(capacity, last_tokens + (delta * rate))
 local allowed = filled_tokens >= requested
 local new_tokens = filled_tokens
 
-if allowed then
+if allowed and take then
     new_tokens = filled_tokens - requested
-end
 
--- Update Redis with new values
-redis.call("setex", KEYS[1], ttl, new_tokens)
-redis.call("setex", KEYS[2], ttl, now)
+    -- Update Redis with new values
+    redis.call("setex", KEYS[1], ttl, tostring(new_tokens))
+    redis.call("setex", KEYS[2], ttl, tostring(now))
+end
 
--- Return 1 if allowed, 0 if not
+-- Redis truncates Lua numbers to integers, so report thousandths of a token
+local left = math.floor(new_tokens * 1000)
 if allowed then
-    return 1
+    return {1, left}
 else
-    return 0
+    return {0, left}
 end
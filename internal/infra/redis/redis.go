@@ -61,6 +61,12 @@ func Connect(cfg Config) (*Client, error) {
 	return client, nil
 }
 
+// Wrap returns a Client on an existing connection without making it the
+// default
+func Wrap(rdb *redis.Client) *Client {
+	return &Client{rdb: rdb, ctx: context.Background()}
+}
+
 // Default returns the default client
 func Default() *Client {
 	return defaultClient
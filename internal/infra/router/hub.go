@@ -0,0 +1,101 @@
+package router
+
+import (
+	"sync"
+)
+
+// Hub tracks open WebSocket connections by user so messages can be pushed
+// to one user's devices or to everyone connected. It is safe for
+// concurrent use.
+type Hub struct {
+	mu    sync.RWMutex
+	conns map[uint]map[*WSConn]struct{}
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{conns: make(map[uint]map[*WSConn]struct{})}
+}
+
+// Register adds conn under its user ID. Anonymous connections are kept
+// under 0 and only receive broadcasts.
+func (h *Hub) Register(conn *WSConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	set, ok := h.conns[conn.UserID()]
+	if !ok {
+		set = make(map[*WSConn]struct{})
+		h.conns[conn.UserID()] = set
+	}
+	set[conn] = struct{}{}
+}
+
+// Unregister removes conn
+func (h *Hub) Unregister(conn *WSConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if set, ok := h.conns[conn.UserID()]; ok {
+		delete(set, conn)
+		if len(set) == 0 {
+			delete(h.conns, conn.UserID())
+		}
+	}
+}
+
+// SendToUser sends v to every connection of userID and returns how many
+// received it. Connections that fail to write are closed and removed.
+func (h *Hub) SendToUser(userID uint, v any) int {
+	h.mu.RLock()
+	targets := make([]*WSConn, 0, len(h.conns[userID]))
+	for conn := range h.conns[userID] {
+		targets = append(targets, conn)
+	}
+	h.mu.RUnlock()
+	return h.send(targets, v)
+}
+
+// Broadcast sends v to every connection and returns how many received it
+func (h *Hub) Broadcast(v any) int {
+	h.mu.RLock()
+	var targets []*WSConn
+	for _, set := range h.conns {
+		for conn := range set {
+			targets = append(targets, conn)
+		}
+	}
+	h.mu.RUnlock()
+	return h.send(targets, v)
+}
+
+// Online reports whether userID has at least one open connection
+func (h *Hub) Online(userID uint) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.conns[userID]) > 0
+}
+
+// Count returns the number of open connections
+func (h *Hub) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	n := 0
+	for _, set := range h.conns {
+		n += len(set)
+	}
+	return n
+}
+
+// send writes v to each target outside the lock, so one slow client does
+// not block registration
+func (h *Hub) send(targets []*WSConn, v any) int {
+	sent := 0
+	for _, conn := range targets {
+		if err := conn.WriteJSON(v); err != nil {
+			_ = conn.Close()
+			h.Unregister(conn)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
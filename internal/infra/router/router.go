@@ -3,10 +3,14 @@ package router
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 	"regexp"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/response"
 )
 
 // Handler is an alias for gin.HandlerFunc
@@ -24,6 +28,46 @@ type Route struct {
 	middleware  []Middleware
 	router      *Router
 	constraints map[string]string
+
+	// deprecated routes answer with Deprecation and, if set, Sunset headers
+	deprecated bool
+	sunset     time.Time
+
+	// groupMiddleware names the group middleware in effect when the route was added
+	groupMiddleware []string
+}
+
+// RouteInfo describes a registered route for listings such as route:list
+type RouteInfo struct {
+	Method     string   `json:"method"`
+	Path       string   `json:"path"`
+	Name       string   `json:"name,omitempty"`
+	Handler    string   `json:"handler"`
+	Middleware []string `json:"middleware"`
+	Deprecated bool     `json:"deprecated,omitempty"`
+	// Sunset is when a deprecated route stops working, if announced
+	Sunset *time.Time `json:"sunset,omitempty"`
+}
+
+// Info returns the route's method, path, name, handler and middleware names
+func (r *Route) Info() RouteInfo {
+	middleware := append([]string{}, r.groupMiddleware...)
+	for _, mw := range r.middleware {
+		middleware = append(middleware, funcName(mw))
+	}
+	info := RouteInfo{
+		Method:     r.method,
+		Path:       r.path,
+		Name:       r.name,
+		Handler:    funcName(r.handler),
+		Middleware: middleware,
+		Deprecated: r.deprecated,
+	}
+	if !r.sunset.IsZero() {
+		sunset := r.sunset
+		info.Sunset = &sunset
+	}
+	return info
 }
 
 // Name sets the route name for URL generation
@@ -32,6 +76,18 @@ func (r *Route) Name(name string) *Route {
 	if r.router != nil {
 		r.router.namedRoutes[name] = r
 	}
+	register(name, r.path)
+	return r
+}
+
+// Deprecated marks the route as deprecated. Its responses carry a
+// Deprecation header and, unless sunset is zero, a Sunset header with the
+// date it will be removed, so clients can migrate in time:
+//
+//	api.GET("/profile", h.ProfileV1).Deprecated(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+func (r *Route) Deprecated(sunset time.Time) *Route {
+	r.deprecated = true
+	r.sunset = sunset
 	return r
 }
 
@@ -52,10 +108,22 @@ type Router struct {
 	middlewareGroups map[string][]Middleware
 	middlewareAlias  map[string]Middleware
 	globalPatterns   map[string]string
+
+	// routes is shared by the whole router tree, in registration order
+	routes *[]*Route
+	// middlewareNames names the middleware applied to this group so far
+	middlewareNames []string
 }
 
-// New creates a new Router wrapping a gin.Engine
+// New creates a new Router wrapping a gin.Engine.
+// Requests to a known path with the wrong method get a JSON 405 with an
+// Allow header instead of a 404.
 func New(engine *gin.Engine) *Router {
+	engine.HandleMethodNotAllowed = true
+	engine.NoMethod(func(c *gin.Context) {
+		response.MethodNotAllowed(c)
+	})
+
 	return &Router{
 		engine:           engine,
 		group:            &engine.RouterGroup,
@@ -63,6 +131,7 @@ func New(engine *gin.Engine) *Router {
 		middlewareGroups: make(map[string][]Middleware),
 		middlewareAlias:  make(map[string]Middleware),
 		globalPatterns:   make(map[string]string),
+		routes:           &[]*Route{},
 	}
 }
 
@@ -78,14 +147,16 @@ func (r *Router) Group(prefix string, fn func(*Router)) *Router {
 		middlewareGroups: r.middlewareGroups,
 		middlewareAlias:  r.middlewareAlias,
 		globalPatterns:   r.globalPatterns,
+		routes:           r.routes,
+		middlewareNames:  append([]string{}, r.middlewareNames...),
 	}
 	fn(child)
 	return r
 }
 
 // Prefix creates a group with only prefix (no callback)
-func (r *Router) Prefix(prefix string) *Router {
-	return &Router{
+func (r *Router) Prefix(prefix string) *RouteGroup {
+	return &RouteGroup{Router: &Router{
 		engine:           r.engine,
 		group:            r.group.Group(prefix),
 		prefix:           r.prefix + prefix,
@@ -95,12 +166,56 @@ func (r *Router) Prefix(prefix string) *Router {
 		middlewareGroups: r.middlewareGroups,
 		middlewareAlias:  r.middlewareAlias,
 		globalPatterns:   r.globalPatterns,
-	}
+		routes:           r.routes,
+		middlewareNames:  append([]string{}, r.middlewareNames...),
+	}}
+}
+
+// RouteGroup is a prefix with its own middleware stack that can be passed
+// around and extended from several places, e.g. an /admin group shared by
+// multiple modules:
+//
+//	admin := r.Prefix("/admin").WithMiddleware("auth", "role:admin")
+//	admin.Group(func(g *router.Router) { ... })
+type RouteGroup struct {
+	*Router
+}
+
+// Prefix creates a nested group under this group's prefix and middleware
+func (g *RouteGroup) Prefix(prefix string) *RouteGroup {
+	return g.Router.Prefix(prefix)
+}
+
+// Middleware adds middleware to all routes later registered in this group
+func (g *RouteGroup) Middleware(middleware ...Middleware) *RouteGroup {
+	g.Router.Middleware(middleware...)
+	return g
+}
+
+// Use is an alias for Middleware (gin-style)
+func (g *RouteGroup) Use(middleware ...Middleware) *RouteGroup {
+	return g.Middleware(middleware...)
+}
+
+// WithMiddleware applies named middleware groups or aliases to this group
+func (g *RouteGroup) WithMiddleware(names ...string) *RouteGroup {
+	g.Router.WithMiddleware(names...)
+	return g
+}
+
+// Group registers routes in this group. It may be called any number of
+// times; every call shares the group's prefix and middleware.
+func (g *RouteGroup) Group(fn func(*Router)) *RouteGroup {
+	g.Router.Group("", fn)
+	return g
 }
 
 // Middleware adds middleware to all routes in this group
 func (r *Router) Middleware(middleware ...Middleware) *Router {
 	r.middleware = append(r.middleware, middleware...)
+	for _, mw := range middleware {
+		r.middlewareNames = append(r.middlewareNames, funcName(mw))
+	}
 	// Apply to gin group
 	r.group.Use(middleware...)
 	return r
@@ -111,7 +226,8 @@ func (r *Router) Use(middleware ...Middleware) *Router {
 	return r.Middleware(middleware...)
 }
 
-// GET registers a GET route
+// GET registers a GET route. A matching HEAD route is registered
+// automatically, so HEAD only needs registering for paths without a GET.
 func (r *Router) GET(path string, handler Handler) *Route {
 	return r.addRoute(http.MethodGet, path, handler)
 }
@@ -156,34 +272,57 @@ func (r *Router) Any(path string, handler Handler) *Route {
 // Match registers a route for specific HTTP methods
 func (r *Router) Match(methods []string, path string, handler Handler) *Route {
 	route := &Route{
-		method:  strings.Join(methods, "|"),
-		path:    r.prefix + path,
-		handler: handler,
-		router:  r,
+		method:          strings.Join(methods, "|"),
+		path:            r.prefix + path,
+		handler:         handler,
+		router:          r,
+		groupMiddleware: append([]string{}, r.middlewareNames...),
 	}
+	*r.routes = append(*r.routes, route)
 	for _, method := range methods {
 		r.group.Handle(method, path, handler)
 	}
+	if containsMethod(methods, http.MethodGet) && !containsMethod(methods, http.MethodHead) {
+		r.group.Handle(http.MethodHead, path, handler)
+	}
 	return route
 }
 
 // addRoute adds a route to the router
 func (r *Router) addRoute(method, path string, handler Handler) *Route {
 	route := &Route{
-		method:      method,
-		path:        r.prefix + path,
-		handler:     handler,
-		router:      r,
-		constraints: make(map[string]string),
+		method:          method,
+		path:            r.prefix + path,
+		handler:         handler,
+		router:          r,
+		constraints:     make(map[string]string),
+		groupMiddleware: append([]string{}, r.middlewareNames...),
 	}
+	*r.routes = append(*r.routes, route)
 	// Register with a wrapper that will apply constraints
-	r.group.Handle(method, path, route.wrapHandler())
+	handle := route.wrapHandler()
+	r.group.Handle(method, path, handle)
+	if method == http.MethodGet {
+		r.group.Handle(http.MethodHead, path, handle)
+	}
 	return route
 }
 
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
 // wrapHandler wraps the handler with constraint middleware
 func (rt *Route) wrapHandler() Handler {
 	return func(c *gin.Context) {
+		if rt.deprecated {
+			response.Deprecate(c, rt.sunset)
+		}
 		// Apply constraints
 		for param, pattern := range rt.constraints {
 			value := c.Param(param)
@@ -337,6 +476,12 @@ func (r *Router) Fallback(handler Handler) {
 	r.engine.NoRoute(handler)
 }
 
+// MethodNotAllowed replaces the default JSON 405 handler.
+// The Allow header is already set when the handler runs.
+func (r *Router) MethodNotAllowed(handler Handler) {
+	r.engine.NoMethod(handler)
+}
+
 // Static serves static files
 func (r *Router) Static(relativePath, root string) {
 	r.group.Static(relativePath, root)
@@ -356,6 +501,31 @@ func (r *Router) Routes() map[string]string {
 	return routes
 }
 
+// List describes every route registered through the router tree, in
+// registration order
+func (r *Router) List() []RouteInfo {
+	list := make([]RouteInfo, 0, len(*r.routes))
+	for _, route := range *r.routes {
+		list = append(list, route.Info())
+	}
+	return list
+}
+
+// funcName returns the package-qualified name of fn without the import path
+// or the ".funcN" suffix of closures, e.g. "middleware.JWTAuth"
+func funcName(fn any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	for {
+		i := strings.LastIndex(name, ".func")
+		if i < 0 || strings.Trim(name[i+len(".func"):], "0123456789.") != "" {
+			break
+		}
+		name = name[:i]
+	}
+	return strings.TrimSuffix(name, "-fm")
+}
+
 // ============================================
 // Middleware Groups & Aliases
 // ============================================
@@ -380,7 +550,10 @@ func (r *Router) WithMiddleware(names ...string) *Router {
 			middlewares = append(middlewares, group...)
 		} else if alias, ok := r.middlewareAlias[name]; ok {
 			middlewares = append(middlewares, alias)
+		} else {
+			continue
 		}
+		r.middlewareNames = append(r.middlewareNames, name)
 	}
 	if len(middlewares) > 0 {
 		r.group.Use(middlewares...)
@@ -0,0 +1,78 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrRouteNotFound is returned by URL for names that were never registered
+	ErrRouteNotFound = errors.New("route not found")
+
+	// ErrMissingParam is returned by URL when a path parameter has no value
+	ErrMissingParam = errors.New("missing route parameter")
+)
+
+// registry maps route names to their full path patterns.
+// It is filled by Route.Name so URLs can be built anywhere in the app.
+var registry = struct {
+	mu     sync.RWMutex
+	routes map[string]string
+}{routes: make(map[string]string)}
+
+func register(name, path string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.routes[name] = path
+}
+
+// URL generates the path for a named route.
+// Params fill :name and *name segments; any others become the query string.
+//
+// Example:
+//
+//	url, err := router.URL("users.show", map[string]string{"id": "42"})
+//	// "/v1/users/42"
+func URL(name string, params map[string]string) (string, error) {
+	registry.mu.RLock()
+	pattern, ok := registry.routes[name]
+	registry.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrRouteNotFound, name)
+	}
+
+	used := make(map[string]bool)
+	segments := strings.Split(pattern, "/")
+	for i, seg := range segments {
+		if seg == "" || (seg[0] != ':' && seg[0] != '*') {
+			continue
+		}
+		key := seg[1:]
+		value, ok := params[key]
+		if !ok || value == "" {
+			return "", fmt.Errorf("%w: %q for route %s", ErrMissingParam, key, name)
+		}
+		used[key] = true
+		if seg[0] == '*' {
+			// Catch-all values are paths themselves
+			segments[i] = strings.TrimPrefix(value, "/")
+		} else {
+			segments[i] = url.PathEscape(value)
+		}
+	}
+	path := strings.Join(segments, "/")
+
+	query := url.Values{}
+	for k, v := range params {
+		if !used[k] {
+			query.Set(k, v)
+		}
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path, nil
+}
@@ -0,0 +1,186 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// DefaultWSMaxMessageBytes caps incoming WebSocket messages
+const DefaultWSMaxMessageBytes = 1 << 20
+
+// WSHandler handles an upgraded WebSocket connection. The connection is
+// closed when the handler returns.
+type WSHandler func(conn *WSConn)
+
+// WSOptions configures a WebSocket route
+type WSOptions struct {
+	// CheckOrigin accepts or rejects the handshake. Browsers send Origin on
+	// every WebSocket request and do not apply CORS to them, so this is what
+	// stops other sites from connecting with a user's cookies.
+	// Default: no Origin header, or one matching the request host
+	CheckOrigin func(r *http.Request) bool
+
+	// MaxMessageBytes caps incoming messages
+	// Default: DefaultWSMaxMessageBytes
+	MaxMessageBytes int
+}
+
+// WSConn is an upgraded WebSocket connection. Writes are serialised, so
+// WriteJSON may be called from several goroutines (e.g. a Hub broadcast
+// while the handler replies).
+type WSConn struct {
+	ws       *websocket.Conn
+	request  *http.Request
+	userID   uint
+	username string
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// WS registers a GET route that upgrades to a WebSocket and hands the
+// connection to handler. Group middleware runs before the upgrade, so
+// routes behind "auth" see the authenticated user on the connection.
+//
+// Example:
+//
+//	r.Group("", func(auth *router.Router) {
+//	    auth.WithMiddleware("auth")
+//	    auth.WS("/ws/notifications", func(conn *router.WSConn) {
+//	        hub.Register(conn)
+//	        defer hub.Unregister(conn)
+//	        conn.Wait()
+//	    }).Name("ws.notifications")
+//	})
+func (r *Router) WS(path string, handler WSHandler, opts ...WSOptions) *Route {
+	return r.GET(path, Upgrade(handler, opts...))
+}
+
+// Upgrade returns a gin handler that upgrades the request to a WebSocket.
+// Requests that are not WebSocket handshakes get a 400.
+func Upgrade(handler WSHandler, opts ...WSOptions) gin.HandlerFunc {
+	opt := WSOptions{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.CheckOrigin == nil {
+		opt.CheckOrigin = sameOrigin
+	}
+	if opt.MaxMessageBytes <= 0 {
+		opt.MaxMessageBytes = DefaultWSMaxMessageBytes
+	}
+
+	return func(c *gin.Context) {
+		server := websocket.Server{
+			Handshake: func(_ *websocket.Config, req *http.Request) error {
+				if !opt.CheckOrigin(req) {
+					return websocket.ErrBadWebSocketOrigin
+				}
+				return nil
+			},
+			Handler: func(ws *websocket.Conn) {
+				ws.MaxPayloadBytes = opt.MaxMessageBytes
+				// The server's read/write timeouts are meant for requests,
+				// not for a connection that stays open
+				_ = ws.SetDeadline(time.Time{})
+
+				conn := newWSConn(ws, c)
+				defer conn.Close()
+				handler(conn)
+			},
+		}
+		server.ServeHTTP(c.Writer, c.Request)
+		c.Abort()
+	}
+}
+
+func newWSConn(ws *websocket.Conn, c *gin.Context) *WSConn {
+	conn := &WSConn{
+		ws:      ws,
+		request: c.Request,
+		closed:  make(chan struct{}),
+	}
+	if id, ok := c.Get("userID"); ok {
+		conn.userID, _ = id.(uint)
+	}
+	conn.username = c.GetString("username")
+	return conn
+}
+
+// UserID returns the authenticated user's ID, or 0 on public routes
+func (c *WSConn) UserID() uint {
+	return c.userID
+}
+
+// Username returns the authenticated user's name, or ""
+func (c *WSConn) Username() string {
+	return c.username
+}
+
+// Request returns the handshake request
+func (c *WSConn) Request() *http.Request {
+	return c.request
+}
+
+// Context returns the handshake request's context
+func (c *WSConn) Context() context.Context {
+	return c.request.Context()
+}
+
+// ReadJSON reads the next message and decodes it into v
+func (c *WSConn) ReadJSON(v any) error {
+	return websocket.JSON.Receive(c.ws, v)
+}
+
+// WriteJSON sends v as a JSON text message
+func (c *WSConn) WriteJSON(v any) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return websocket.JSON.Send(c.ws, v)
+}
+
+// Close closes the connection. It is safe to call more than once.
+func (c *WSConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		err = c.ws.Close()
+	})
+	return err
+}
+
+// Done is closed once the connection is closed
+func (c *WSConn) Done() <-chan struct{} {
+	return c.closed
+}
+
+// Wait discards incoming messages until the client disconnects. Handlers
+// that only push (e.g. notifications) call it to keep the connection open.
+func (c *WSConn) Wait() {
+	var discard []byte
+	for websocket.Message.Receive(c.ws, &discard) == nil {
+	}
+	_ = c.Close()
+}
+
+// sameOrigin accepts requests without an Origin header (non-browser
+// clients) and those whose Origin host matches the request host
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
@@ -0,0 +1,228 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Spec reports when a schedule fires
+type Spec interface {
+	// Next returns the first activation strictly after t, or the zero time
+	// if the schedule never fires again
+	Next(t time.Time) time.Time
+}
+
+// searchYears bounds the search for the next activation, so impossible
+// dates such as "0 0 30 2 *" end instead of looping forever
+const searchYears = 5
+
+// shorthands maps the @-prefixed cron aliases to their five-field form
+var shorthands = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a standard five-field cron expression
+// ("minute hour day-of-month month day-of-week") or one of the shorthands
+// @yearly, @monthly, @weekly, @daily, @hourly and "@every <duration>".
+//
+// Fields accept *, numbers, ranges (1-5), lists (1,15) and steps (*/10,
+// 0-30/5). Months and weekdays may also be named (JAN, MON); Sunday is 0
+// or 7. As in standard cron, when both day fields are restricted a day
+// matching either of them fires.
+func Parse(spec string) (Spec, error) {
+	spec = strings.TrimSpace(spec)
+
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("schedule: invalid @every duration in %q: %w", spec, err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("schedule: @every interval must be at least 1s, got %s", d)
+		}
+		return every{interval: d}, nil
+	}
+	if expanded, ok := shorthands[spec]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("schedule: cron expression %q must have 5 fields, got %d", spec, len(fields))
+	}
+
+	var (
+		c   cron
+		err error
+	)
+	if c.minute, err = parseField(fields[0], minutes); err != nil {
+		return nil, err
+	}
+	if c.hour, err = parseField(fields[1], hours); err != nil {
+		return nil, err
+	}
+	if c.dom, err = parseField(fields[2], daysOfMonth); err != nil {
+		return nil, err
+	}
+	if c.month, err = parseField(fields[3], months); err != nil {
+		return nil, err
+	}
+	if c.dow, err = parseField(fields[4], daysOfWeek); err != nil {
+		return nil, err
+	}
+	// 7 is another name for Sunday
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.anyDom = strings.HasPrefix(fields[2], "*")
+	c.anyDow = strings.HasPrefix(fields[4], "*")
+	return c, nil
+}
+
+// every fires at fixed intervals aligned to the zero time, so @every 5m
+// runs at :00, :05, :10 on every instance regardless of when it started
+type every struct {
+	interval time.Duration
+}
+
+func (e every) Next(t time.Time) time.Time {
+	return t.Truncate(e.interval).Add(e.interval)
+}
+
+// cron holds each field as a bit set of the values it allows
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	anyDom, anyDow                bool
+}
+
+func (c cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + searchYears
+
+	for t.Year() <= limit {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's day rule: an unrestricted day field defers to
+// the other one, two restricted fields match if either does
+func (c cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.anyDom || c.anyDow {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// bounds describes the values one cron field accepts
+type bounds struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minutes     = bounds{name: "minute", min: 0, max: 59}
+	hours       = bounds{name: "hour", min: 0, max: 23}
+	daysOfMonth = bounds{name: "day of month", min: 1, max: 31}
+	months      = bounds{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	daysOfWeek = bounds{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// parseField turns one comma-separated field into a bit set
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = b.min, b.max
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = b.value(from); err != nil {
+				return 0, err
+			}
+			if hi, err = b.value(to); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("schedule: invalid %s range %q", b.name, rangePart)
+			}
+		default:
+			v, err := b.value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			lo, hi = v, v
+			// "5/15" means every 15 starting at 5
+			if hasStep {
+				hi = b.max
+			}
+		}
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("schedule: invalid %s step %q", b.name, stepPart)
+			}
+			step = n
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a number or name and checks it is in range
+func (b bounds) value(s string) (int, error) {
+	v, ok := b.names[strings.ToLower(s)]
+	if !ok {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("schedule: invalid %s %q", b.name, s)
+		}
+		v = n
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("schedule: %s %d out of range %d-%d", b.name, v, b.min, b.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,91 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Next(t *testing.T) {
+	// Wednesday
+	from := time.Date(2026, 1, 14, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 1, 14, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 14, 10, 15, 0, 0, time.UTC)},
+		{"5-10/5 * * * *", time.Date(2026, 1, 14, 10, 10, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2026, 1, 15, 2, 30, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan,jul *", time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either one matches
+		{"0 0 20 * 5", time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 1, 14, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 5m", time.Date(2026, 1, 14, 10, 10, 0, 0, time.UTC)},
+		{"@every 30s", time.Date(2026, 1, 14, 10, 8, 0, 0, time.UTC)},
+		// Never fires
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		spec, err := Parse(tt.spec)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if got := spec.Next(from); !got.Equal(tt.want) {
+			t.Errorf("Parse(%q).Next() = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"10-5 * * * *",
+		"*/0 * * * *",
+		"x * * * *",
+		"@every",
+		"@every 10ms",
+		"@every soon",
+		"@fortnightly",
+	} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestEvent_NextUsesTimezone(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	event := Call("report", nil).DailyAt(9, 0)
+	event.timezone = loc
+
+	from := time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC)
+	want := time.Date(2026, 1, 14, 9, 0, 0, 0, loc)
+	if got := event.Next(from); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+	if !event.IsDue(want.Add(30 * time.Second)) {
+		t.Error("IsDue() at the scheduled minute = false")
+	}
+	if event.IsDue(want.Add(time.Minute)) {
+		t.Error("IsDue() a minute later = true")
+	}
+
+	if err := Call("bad", nil).DailyAt(25, 0).Err(); err == nil {
+		t.Error("Err() for hour 25 = nil")
+	}
+}
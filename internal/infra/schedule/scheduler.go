@@ -2,11 +2,36 @@ package schedule
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"reflect"
+	"runtime"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/zgiai/zgo/internal/infra/cache"
 )
 
+// oneServerLockTTL is how long an OnOneServer run claims its slot. It
+// outlives the slot itself so instances with skewed clocks still skip it.
+const oneServerLockTTL = 10 * time.Minute
+
+// maxSleep caps how long the runner sleeps, so events registered while it
+// waits are picked up
+const maxSleep = time.Minute
+
+// Clock tells the scheduler the time; tests substitute a fake one
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
 // Task represents a schedulable task
 type Task interface {
 	Run(ctx context.Context) error
@@ -66,7 +91,9 @@ func Call(name string, fn func(ctx context.Context) error) *Event {
 
 // --- Schedule Methods ---
 
-// Cron sets a custom cron expression
+// Cron sets a custom schedule, either a five-field cron expression or a
+// shorthand such as "@daily" or "@every 10m" (see Parse). It replaces the
+// schedule built by the other methods.
 func (e *Event) Cron(expression string) *Event {
 	e.expression = expression
 	return e
@@ -259,6 +286,15 @@ func (e *Event) WithoutOverlapping() *Event {
 	return e
 }
 
+// OnOneServer runs the task on only one instance per scheduled minute when
+// several share a cache. The first instance to claim the minute in the
+// default cache store runs it; use a Redis store, since memory store locks
+// are local to each process.
+func (e *Event) OnOneServer() *Event {
+	e.onOneServer = true
+	return e
+}
+
 // RunInBackground runs the task in a goroutine
 func (e *Event) RunInBackground() *Event {
 	e.runInBackground = true
@@ -298,45 +334,78 @@ func (e *Event) OnFailure(fn func(error)) *Event {
 	return e
 }
 
+// Named sets the event name, which also keys its OnOneServer lock
+func (e *Event) Named(name string) *Event {
+	e.name = name
+	return e
+}
+
 // Name returns the event name
 func (e *Event) Name() string {
 	return e.name
 }
 
-// IsDue checks if the event is due to run
-func (e *Event) IsDue(t time.Time) bool {
-	t = t.In(e.timezone)
-
-	// Check minute
-	if !matchField(e.minute, t.Minute()) {
-		return false
+// Expression returns the event's schedule as given to Cron, or the cron
+// expression built by the fluent methods
+func (e *Event) Expression() string {
+	if e.expression != "" {
+		return e.expression
 	}
+	return strings.Join([]string{e.minute, e.hour, e.dayOfMonth, e.month, e.dayOfWeek}, " ")
+}
 
-	// Check hour
-	if !matchField(e.hour, t.Hour()) {
-		return false
-	}
+// Err reports whether the schedule is invalid; an invalid event never runs
+func (e *Event) Err() error {
+	_, err := Parse(e.Expression())
+	return err
+}
 
-	// Check day of month
-	if !matchField(e.dayOfMonth, t.Day()) {
-		return false
-	}
+// WithoutOverlap reports whether the event skips runs while one is in progress
+func (e *Event) WithoutOverlap() bool {
+	return e.withoutOverlap
+}
 
-	// Check month
-	if !matchField(e.month, int(t.Month())) {
-		return false
-	}
+// OneServer reports whether the event runs on one instance per slot
+func (e *Event) OneServer() bool {
+	return e.onOneServer
+}
 
-	// Check day of week
-	if !matchField(e.dayOfWeek, int(t.Weekday())) {
-		return false
+// Next returns the first run time after t in the event's timezone, or the
+// zero time if the schedule is invalid or never fires again
+func (e *Event) Next(t time.Time) time.Time {
+	spec, err := Parse(e.Expression())
+	if err != nil {
+		return time.Time{}
 	}
+	return spec.Next(t.In(e.timezone))
+}
 
-	return true
+// IsDue checks if the event is due to run in the minute containing t
+func (e *Event) IsDue(t time.Time) bool {
+	minute := t.Truncate(time.Minute)
+	next := e.Next(minute.Add(-time.Nanosecond))
+	return !next.IsZero() && next.Before(minute.Add(time.Minute))
 }
 
 // Run executes the event
 func (e *Event) Run(ctx context.Context) error {
+	return e.run(ctx, time.Now())
+}
+
+// run executes the event for the slot scheduled at at
+func (e *Event) run(ctx context.Context, at time.Time) error {
+	if e.onOneServer {
+		// The lock is left to expire so later instances skip this slot too
+		key := fmt.Sprintf("schedule:%s:%s", e.name, at.In(e.timezone).Format("20060102150405"))
+		_, acquired, err := cache.Lock(ctx, key, oneServerLockTTL)
+		if err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+	}
+
 	// Check overlap
 	if e.withoutOverlap {
 		e.mutex.Lock()
@@ -397,6 +466,20 @@ type Scheduler struct {
 	events  []*Event
 	stop    chan struct{}
 	running bool
+	clock   Clock
+
+	// jobs tracks runs started by Start, so Shutdown can wait for them
+	jobs sync.WaitGroup
+}
+
+// Option configures a Scheduler
+type Option func(*Scheduler)
+
+// WithClock replaces the wall clock, for tests
+func WithClock(clock Clock) Option {
+	return func(s *Scheduler) {
+		s.clock = clock
+	}
 }
 
 var (
@@ -413,11 +496,16 @@ func Global() *Scheduler {
 }
 
 // New creates a new scheduler
-func New() *Scheduler {
-	return &Scheduler{
+func New(opts ...Option) *Scheduler {
+	s := &Scheduler{
 		events: make([]*Event, 0),
 		stop:   make(chan struct{}),
+		clock:  realClock{},
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // Register registers an event with the scheduler
@@ -435,6 +523,39 @@ func (s *Scheduler) Call(name string, fn func(ctx context.Context) error) *Event
 	return event
 }
 
+// Schedule registers job to run on spec, a cron expression or shorthand
+// such as "@every 5m" (see Parse). The event is named after the job
+// function, skips a run while the previous one is still going and runs on
+// one instance per slot; chain Named or other options to adjust it.
+//
+// Example:
+//
+//	s.Schedule("@every 1h", tokens.PruneExpired)
+//	s.Schedule("30 2 * * *", reports.SendDaily).Named("reports:daily")
+func (s *Scheduler) Schedule(spec string, job func(ctx context.Context) error) *Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event := Call(s.uniqueName(funcName(job)), job).Cron(spec).WithoutOverlapping().OnOneServer()
+	s.events = append(s.events, event)
+	return event
+}
+
+// uniqueName suffixes name with a counter if another event already uses
+// it. Registration order is the same on every instance, so the names and
+// their locks agree across instances.
+func (s *Scheduler) uniqueName(name string) string {
+	taken := make(map[string]bool, len(s.events))
+	for _, event := range s.events {
+		taken[event.name] = true
+	}
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s#%d", name, i)
+	}
+	return unique
+}
+
 // Events returns all registered events
 func (s *Scheduler) Events() []*Event {
 	s.mu.RLock()
@@ -458,15 +579,18 @@ func (s *Scheduler) DueEvents(t time.Time) []*Event {
 
 // Run runs all due events
 func (s *Scheduler) Run(ctx context.Context) {
-	now := time.Now()
+	now := s.clock.Now()
 	for _, event := range s.DueEvents(now) {
-		if err := event.Run(ctx); err != nil {
+		if err := event.run(ctx, now.Truncate(time.Minute)); err != nil {
 			log.Printf("Scheduled task '%s' failed: %v", event.Name(), err)
 		}
 	}
 }
 
-// Start starts the scheduler loop
+// Start runs events at their scheduled times until ctx is done or Stop is
+// called. It sleeps until the next event is due and starts each run in its
+// own goroutine, so a slow job does not delay the others; ctx is passed to
+// the jobs.
 func (s *Scheduler) Start(ctx context.Context) {
 	s.mu.Lock()
 	if s.running {
@@ -474,27 +598,70 @@ func (s *Scheduler) Start(ctx context.Context) {
 		return
 	}
 	s.running = true
+	stop := s.stop
 	s.mu.Unlock()
 
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
-
-	// Run immediately for any due tasks
-	s.Run(ctx)
-
+	next := make(map[*Event]time.Time)
 	for {
+		now := s.clock.Now()
+		wake := now.Add(maxSleep)
+		for _, event := range s.Events() {
+			at, ok := next[event]
+			if !ok {
+				at = event.Next(now)
+			}
+			if !at.IsZero() && !at.After(now) {
+				s.dispatch(ctx, event, at)
+				at = event.Next(now)
+			}
+			next[event] = at
+			if !at.IsZero() && at.Before(wake) {
+				wake = at
+			}
+		}
+
 		select {
 		case <-ctx.Done():
+			s.Stop()
 			return
-		case <-s.stop:
+		case <-stop:
 			return
-		case <-ticker.C:
-			s.Run(ctx)
+		case <-s.clock.After(wake.Sub(now)):
+		}
+	}
+}
+
+// dispatch runs event for the slot at in the background
+func (s *Scheduler) dispatch(ctx context.Context, event *Event, at time.Time) {
+	s.jobs.Add(1)
+	go func() {
+		defer s.jobs.Done()
+		if err := event.run(ctx, at); err != nil {
+			log.Printf("Scheduled task '%s' failed: %v", event.Name(), err)
 		}
+	}()
+}
+
+// Shutdown stops the scheduler and waits for running jobs to finish or
+// ctx to expire, whichever comes first
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		s.jobs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
-// Stop stops the scheduler
+// Stop stops the scheduler loop; runs already started carry on
 func (s *Scheduler) Stop() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -521,9 +688,9 @@ func Register(event *Event) *Scheduler {
 	return Global().Register(event)
 }
 
-// Schedule creates and registers a new event
-func Schedule(name string, fn func(ctx context.Context) error) *Event {
-	return Global().Call(name, fn)
+// Schedule registers job on the global scheduler (see Scheduler.Schedule)
+func Schedule(spec string, job func(ctx context.Context) error) *Event {
+	return Global().Schedule(spec, job)
 }
 
 // Run runs due events on the global scheduler
@@ -559,53 +726,17 @@ func parseTime(timeStr string) (hour, minute int) {
 	return
 }
 
-func matchField(field string, value int) bool {
-	if field == "*" {
-		return true
-	}
-
-	// Handle step values (*/n)
-	if len(field) > 2 && field[0] == '*' && field[1] == '/' {
-		step := int(field[2] - '0')
-		if len(field) > 3 {
-			step = step*10 + int(field[3]-'0')
-		}
-		return value%step == 0
-	}
-
-	// Handle ranges (n-m)
-	for i := 0; i < len(field); i++ {
-		if field[i] == '-' {
-			start := parseNum(field[:i])
-			end := parseNum(field[i+1:])
-			return value >= start && value <= end
-		}
-	}
-
-	// Handle lists (n,m,...)
-	for i := 0; i < len(field); {
-		j := i
-		for j < len(field) && field[j] != ',' {
-			j++
-		}
-		if parseNum(field[i:j]) == value {
-			return true
-		}
-		i = j + 1
-	}
-
-	return false
-}
-
-func parseNum(s string) int {
-	if len(s) == 0 {
-		return 0
-	}
-	n := 0
-	for _, c := range s {
-		if c >= '0' && c <= '9' {
-			n = n*10 + int(c-'0')
+// funcName returns the package-qualified name of fn without the import
+// path or the ".funcN" suffix of closures, e.g. "tokens.PruneExpired"
+func funcName(fn any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	for {
+		i := strings.LastIndex(name, ".func")
+		if i < 0 || strings.Trim(name[i+len(".func"):], "0123456789.") != "" {
+			break
 		}
+		name = name[:i]
 	}
-	return n
+	return strings.TrimSuffix(name, "-fm")
 }
@@ -0,0 +1,193 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock only moves when Advance is called
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock on and fires the timers that are due
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+// waitForSleep blocks until the runner is waiting on the clock again
+func (c *fakeClock) waitForSleep(t *testing.T) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		n := len(c.waiters)
+		c.mu.Unlock()
+		if n > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("scheduler did not go back to sleep")
+}
+
+// uniqueName keeps OnOneServer locks from earlier runs of a test, which
+// live in the shared memory store, from skipping this one
+func uniqueName(name string) string {
+	return fmt.Sprintf("test:%s:%d", name, time.Now().UnixNano())
+}
+
+func waitForCount(t *testing.T, counter *atomic.Int32, want int32) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for counter.Load() != want && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := counter.Load(); got != want {
+		t.Fatalf("runs = %d, want %d", got, want)
+	}
+}
+
+func TestScheduler_EveryFiresOnSchedule(t *testing.T) {
+	clock := newFakeClock(time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC))
+	s := New(WithClock(clock))
+
+	var runs atomic.Int32
+	event := s.Schedule("@every 5m", func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}).Named(uniqueName("every"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Start(ctx)
+
+	clock.waitForSleep(t)
+	if next := event.Next(clock.Now()); !next.Equal(time.Date(2026, 1, 14, 10, 5, 0, 0, time.UTC)) {
+		t.Fatalf("Next() = %v", next)
+	}
+
+	// Nothing is due before the first slot
+	for i := 0; i < 4; i++ {
+		clock.Advance(time.Minute)
+		clock.waitForSleep(t)
+	}
+	if runs.Load() != 0 {
+		t.Fatalf("runs before 10:05 = %d, want 0", runs.Load())
+	}
+
+	clock.Advance(time.Minute)
+	waitForCount(t, &runs, 1)
+
+	clock.waitForSleep(t)
+	clock.Advance(5 * time.Minute)
+	waitForCount(t, &runs, 2)
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+}
+
+func TestScheduler_SkipsOverlappingRunsAndDrainsOnShutdown(t *testing.T) {
+	clock := newFakeClock(time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC))
+	s := New(WithClock(clock))
+
+	var runs atomic.Int32
+	release := make(chan struct{})
+	var finished atomic.Bool
+	s.Schedule("@every 1m", func(ctx context.Context) error {
+		runs.Add(1)
+		<-release
+		finished.Store(true)
+		return nil
+	}).Named(uniqueName("slow"))
+
+	go s.Start(context.Background())
+
+	clock.waitForSleep(t)
+	clock.Advance(time.Minute)
+	waitForCount(t, &runs, 1)
+
+	// The first run is still going, so the next slot is skipped
+	clock.waitForSleep(t)
+	clock.Advance(time.Minute)
+	clock.waitForSleep(t)
+	time.Sleep(20 * time.Millisecond)
+	if runs.Load() != 1 {
+		t.Fatalf("runs while the first is in progress = %d, want 1", runs.Load())
+	}
+
+	// Shutdown gives up when its context expires first ...
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Shutdown() with a running job = %v, want deadline exceeded", err)
+	}
+
+	// ... and otherwise waits for the job
+	close(release)
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if !finished.Load() {
+		t.Error("Shutdown() returned before the job finished")
+	}
+}
+
+func TestScheduler_ScheduleNamesEvents(t *testing.T) {
+	s := New()
+	job := func(ctx context.Context) error { return nil }
+
+	first := s.Schedule("@hourly", job)
+	second := s.Schedule("@daily", job)
+
+	if first.Name() != "schedule.TestScheduler_ScheduleNamesEvents" {
+		t.Errorf("Name() = %q", first.Name())
+	}
+	if second.Name() != first.Name()+"#2" {
+		t.Errorf("second Name() = %q, want a distinct name", second.Name())
+	}
+	if !first.WithoutOverlap() || !first.OneServer() {
+		t.Error("Schedule() events should skip overlaps and run on one server")
+	}
+}
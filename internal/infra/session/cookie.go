@@ -0,0 +1,135 @@
+package session
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/zgiai/zgo/pkg/encryption"
+)
+
+// maxCookieSize is the largest cookie value browsers reliably keep
+const maxCookieSize = 4096
+
+// ErrTooLarge is returned by CookieStore.Save when the session does not fit
+// in a cookie
+var ErrTooLarge = errors.New("session: too large for a cookie")
+
+// CookieStore keeps sessions in the cookie itself, signed with HMAC-SHA256
+// so clients cannot alter them. They are readable by the client unless
+// WithEncryption is used.
+//
+// Nothing is kept server-side, so a copied cookie stays valid until it
+// expires even after Flush; use the KVStore when sessions must be revocable.
+type CookieStore struct {
+	key       []byte
+	encrypter *encryption.Encrypter
+}
+
+// cookiePayload is what a cookie session carries
+type cookiePayload struct {
+	Values  map[string]json.RawMessage `json:"v"`
+	Expires int64                      `json:"e"`
+}
+
+// NewCookieStore creates a cookie store signing with secret, usually APP_KEY
+func NewCookieStore(secret string) *CookieStore {
+	return &CookieStore{key: []byte(secret)}
+}
+
+// WithEncryption also encrypts the sessions with AES-256-GCM under secret
+func (s *CookieStore) WithEncryption() *CookieStore {
+	s.encrypter = encryption.New(string(s.key))
+	return s
+}
+
+// Load verifies and decodes a cookie session. Expired sessions load empty.
+func (s *CookieStore) Load(ctx context.Context, cookie string) (*Session, error) {
+	if cookie == "" {
+		return New(), nil
+	}
+
+	data, sig, ok := strings.Cut(cookie, ".")
+	if !ok {
+		return New(), ErrInvalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.sign(data)) {
+		return New(), ErrInvalid
+	}
+
+	plain, err := s.open(data)
+	if err != nil {
+		return New(), ErrInvalid
+	}
+	var payload cookiePayload
+	if err := json.Unmarshal(plain, &payload); err != nil {
+		return New(), ErrInvalid
+	}
+	if time.Now().Unix() >= payload.Expires {
+		return New(), nil
+	}
+
+	sess := New()
+	if payload.Values != nil {
+		sess.values = payload.Values
+	}
+	return sess, nil
+}
+
+// Save encodes s into a cookie value that expires after ttl
+func (s *CookieStore) Save(ctx context.Context, sess *Session, ttl time.Duration) (string, error) {
+	plain, err := json.Marshal(cookiePayload{
+		Values:  sess.values,
+		Expires: time.Now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	data, err := s.seal(plain)
+	if err != nil {
+		return "", err
+	}
+	cookie := data + "." + base64.RawURLEncoding.EncodeToString(s.sign(data))
+	if len(cookie) > maxCookieSize {
+		return "", ErrTooLarge
+	}
+	return cookie, nil
+}
+
+// Destroy is a no-op: cookie sessions are not stored server-side
+func (s *CookieStore) Destroy(ctx context.Context, id string) error {
+	return nil
+}
+
+func (s *CookieStore) sign(data string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// seal encodes plain for the cookie, encrypting it when enabled
+func (s *CookieStore) seal(plain []byte) (string, error) {
+	if s.encrypter != nil {
+		var err error
+		if plain, err = s.encrypter.EncryptBytes(plain); err != nil {
+			return "", err
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString(plain), nil
+}
+
+// open reverses seal
+func (s *CookieStore) open(data string) ([]byte, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil || s.encrypter == nil {
+		return raw, err
+	}
+	return s.encrypter.DecryptBytes(raw)
+}
@@ -0,0 +1,84 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/zgiai/zgo/internal/infra/kv"
+)
+
+// idBytes is the amount of randomness in a session ID
+const idBytes = 32
+
+// KVStore keeps sessions server-side in a kv.Store, under a random ID that
+// is all the cookie carries. With KV_DRIVER=redis they are shared by every
+// instance.
+type KVStore struct {
+	store kv.Store
+}
+
+// NewKVStore creates a session store on store
+func NewKVStore(store kv.Store) *KVStore {
+	return &KVStore{store: store}
+}
+
+// Load returns the session with the ID in cookie. Unknown IDs get a fresh
+// session with a new ID, so a client cannot choose its own.
+func (s *KVStore) Load(ctx context.Context, cookie string) (*Session, error) {
+	if cookie == "" {
+		return New(), nil
+	}
+
+	data, err := s.store.Get(ctx, sessionKey(cookie))
+	if errors.Is(err, kv.ErrNotFound) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sess := New()
+	if err := json.Unmarshal(data, &sess.values); err != nil {
+		return New(), ErrInvalid
+	}
+	sess.id = cookie
+	return sess, nil
+}
+
+// Save writes s under its ID, assigning one to new sessions, and restarts
+// its expiry
+func (s *KVStore) Save(ctx context.Context, sess *Session, ttl time.Duration) (string, error) {
+	if sess.id == "" {
+		sess.id = newID()
+	}
+	data, err := json.Marshal(sess.values)
+	if err != nil {
+		return "", err
+	}
+	if err := s.store.Set(ctx, sessionKey(sess.id), data, ttl); err != nil {
+		return "", err
+	}
+	return sess.id, nil
+}
+
+// Destroy deletes the session with id
+func (s *KVStore) Destroy(ctx context.Context, id string) error {
+	if id == "" {
+		return nil
+	}
+	return s.store.Delete(ctx, sessionKey(id))
+}
+
+func sessionKey(id string) string {
+	return "session:" + id
+}
+
+func newID() string {
+	b := make([]byte, idBytes)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
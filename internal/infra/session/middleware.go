@@ -0,0 +1,282 @@
+package session
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/pkg/logger"
+)
+
+// contextKey stores the request's session in the gin context
+const contextKey = "session"
+
+// Keys Login records the user under
+const (
+	userIDKey     = "user_id"
+	usernameKey   = "username"
+	loggedInAtKey = "logged_in_at"
+)
+
+// Options holds session middleware configuration
+type Options struct {
+	// Store loads and saves the sessions
+	// Default: Default()
+	Store Store
+
+	// CookieName is the cookie carrying the session
+	// Default: "zgo_session"
+	CookieName string
+
+	// Path and Domain scope the cookie
+	// Default: "/" and the request host
+	Path   string
+	Domain string
+
+	// Lifetime is how long an idle session lasts. Every response to a
+	// request with a session restarts it.
+	// Default: 2 hours
+	Lifetime time.Duration
+
+	// Secure limits the cookie to HTTPS
+	// Default: false
+	Secure bool
+
+	// SameSite is the cookie's SameSite attribute
+	// Default: http.SameSiteLaxMode
+	SameSite http.SameSite
+}
+
+// DefaultOptions returns default configuration
+func DefaultOptions() Options {
+	return Options{
+		CookieName: "zgo_session",
+		Path:       "/",
+		Lifetime:   2 * time.Hour,
+		SameSite:   http.SameSiteLaxMode,
+	}
+}
+
+// Middleware loads the session with the default store and the SESSION_*
+// settings
+func Middleware() gin.HandlerFunc {
+	opts := DefaultOptions()
+	if cfg := config.GlobalConfig; cfg != nil {
+		opts.CookieName = cfg.Session.Cookie
+		opts.Lifetime = cfg.Session.Lifetime
+		opts.Secure = cfg.Session.Secure
+	}
+	return MiddlewareWithOptions(opts)
+}
+
+// MiddlewareWithOptions loads the request's session from its cookie and
+// saves it, setting the cookie, before the response is written. Handlers
+// use Get, Set and Flush, or Current for the session itself.
+//
+// Sessions without values set no cookie, so visitors who never log in do
+// not get one.
+//
+// Example:
+//
+//	web := r.Group("/", session.MiddlewareWithOptions(session.Options{Secure: true}))
+func MiddlewareWithOptions(opts Options) gin.HandlerFunc {
+	defaults := DefaultOptions()
+	if opts.CookieName == "" {
+		opts.CookieName = defaults.CookieName
+	}
+	if opts.Path == "" {
+		opts.Path = defaults.Path
+	}
+	if opts.Lifetime <= 0 {
+		opts.Lifetime = defaults.Lifetime
+	}
+	if opts.SameSite == 0 {
+		opts.SameSite = defaults.SameSite
+	}
+
+	return func(c *gin.Context) {
+		store := opts.Store
+		if store == nil {
+			store = Default()
+		}
+
+		cookie, _ := c.Cookie(opts.CookieName)
+		sess, err := store.Load(c.Request.Context(), cookie)
+		if sess == nil {
+			// The store is unreachable: carry on without a saved session
+			logSessionError(c, "Failed to load session", err)
+			sess = New()
+		}
+		c.Set(contextKey, sess)
+
+		original := c.Writer
+		w := &sessionWriter{ResponseWriter: original}
+		w.save = func() { saveSession(c, store, sess, cookie != "", opts) }
+		c.Writer = w
+		defer func() { c.Writer = original }()
+
+		c.Next()
+		w.commit()
+	}
+}
+
+// saveSession persists sess and sets or clears its cookie
+func saveSession(c *gin.Context, store Store, sess *Session, hadCookie bool, opts Options) {
+	ctx := c.Request.Context()
+	if sess.invalidated {
+		if err := store.Destroy(ctx, sess.previousID); err != nil {
+			logSessionError(c, "Failed to destroy session", err)
+		}
+	}
+
+	if sess.Len() == 0 {
+		// Drop the cookie of a flushed, invalid or expired session
+		if hadCookie {
+			setCookie(c, opts, "", -1)
+		}
+		return
+	}
+
+	value, err := store.Save(ctx, sess, opts.Lifetime)
+	if err != nil {
+		logSessionError(c, "Failed to save session", err)
+		return
+	}
+	setCookie(c, opts, value, int(opts.Lifetime.Seconds()))
+}
+
+func setCookie(c *gin.Context, opts Options, value string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     opts.CookieName,
+		Value:    value,
+		Path:     opts.Path,
+		Domain:   opts.Domain,
+		MaxAge:   maxAge,
+		Secure:   opts.Secure,
+		HttpOnly: true,
+		SameSite: opts.SameSite,
+	})
+}
+
+func logSessionError(c *gin.Context, msg string, err error) {
+	logger.FromContext(c.Request.Context()).Error(msg, map[string]any{
+		"error": err.Error(),
+		"path":  c.Request.URL.Path,
+	})
+}
+
+// sessionWriter saves the session just before the response is written,
+// while the cookie can still be set
+type sessionWriter struct {
+	gin.ResponseWriter
+	save  func()
+	saved bool
+}
+
+func (w *sessionWriter) commit() {
+	if !w.saved {
+		w.saved = true
+		w.save()
+	}
+}
+
+func (w *sessionWriter) WriteHeaderNow() {
+	w.commit()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *sessionWriter) Write(data []byte) (int, error) {
+	w.commit()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *sessionWriter) WriteString(s string) (int, error) {
+	w.commit()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *sessionWriter) Flush() {
+	w.commit()
+	w.ResponseWriter.Flush()
+}
+
+// Current returns the request's session, or nil outside the middleware
+func Current(c *gin.Context) *Session {
+	v, ok := c.Get(contextKey)
+	if !ok {
+		return nil
+	}
+	sess, _ := v.(*Session)
+	return sess
+}
+
+// Get decodes the session value under key into dst and reports whether it
+// was set
+func Get(c *gin.Context, key string, dst any) bool {
+	sess := Current(c)
+	return sess != nil && sess.Get(key, dst)
+}
+
+// Set stores value in the session under key
+func Set(c *gin.Context, key string, value any) error {
+	sess := Current(c)
+	if sess == nil {
+		return ErrNoSession
+	}
+	return sess.Set(key, value)
+}
+
+// Delete removes key from the session
+func Delete(c *gin.Context, key string) {
+	if sess := Current(c); sess != nil {
+		sess.Delete(key)
+	}
+}
+
+// Flush empties the session and invalidates it
+func Flush(c *gin.Context) {
+	if sess := Current(c); sess != nil {
+		sess.Flush()
+	}
+}
+
+// Login starts a session for the user under a new ID
+func Login(c *gin.Context, userID uint, username string) error {
+	sess := Current(c)
+	if sess == nil {
+		return ErrNoSession
+	}
+	sess.Regenerate()
+	if err := sess.Set(userIDKey, userID); err != nil {
+		return err
+	}
+	if err := sess.Set(loggedInAtKey, time.Now().Unix()); err != nil {
+		return err
+	}
+	return sess.Set(usernameKey, username)
+}
+
+// Logout ends the session
+func Logout(c *gin.Context) {
+	Flush(c)
+}
+
+// User returns the user Login recorded in the session
+func User(c *gin.Context) (userID uint, username string, ok bool) {
+	if !Get(c, userIDKey, &userID) || userID == 0 {
+		return 0, "", false
+	}
+	Get(c, usernameKey, &username)
+	return userID, username, true
+}
+
+// LoggedInAt returns when Login was called, to the second. It is zero for
+// sessions without a login.
+func LoggedInAt(c *gin.Context) time.Time {
+	var unix int64
+	if !Get(c, loggedInAtKey, &unix) {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
+}
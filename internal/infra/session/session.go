@@ -0,0 +1,135 @@
+// Package session provides cookie sessions, an alternative to bearer tokens
+// for clients that would rather keep their login in a cookie. Values live
+// either server-side in the kv store, with only a random ID in the cookie,
+// or client-side in a signed (and optionally encrypted) cookie.
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zgiai/zgo/internal/infra/kv"
+)
+
+// ErrInvalid is returned by Load for a cookie that fails verification, such
+// as one that was tampered with or signed with another key
+var ErrInvalid = errors.New("session: invalid cookie")
+
+// ErrNoSession is returned by Set outside the session middleware
+var ErrNoSession = errors.New("session: no session on request")
+
+// Session holds the values of one visitor's session. Values are stored as
+// JSON, so anything json.Marshal accepts can be kept.
+type Session struct {
+	id     string
+	values map[string]json.RawMessage
+
+	changed     bool
+	invalidated bool // Flush or Regenerate: the stored session must go
+	previousID  string
+}
+
+// New creates an empty session
+func New() *Session {
+	return &Session{values: make(map[string]json.RawMessage)}
+}
+
+// ID returns the session ID, empty for cookie sessions and sessions not
+// saved yet
+func (s *Session) ID() string {
+	return s.id
+}
+
+// Get decodes the value under key into dst and reports whether it was set
+func (s *Session) Get(key string, dst any) bool {
+	raw, ok := s.values[key]
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(raw, dst) == nil
+}
+
+// Set stores value under key
+func (s *Session) Set(key string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	s.values[key] = raw
+	s.changed = true
+	return nil
+}
+
+// Delete removes key
+func (s *Session) Delete(key string) {
+	if _, ok := s.values[key]; ok {
+		delete(s.values, key)
+		s.changed = true
+	}
+}
+
+// Len returns the number of values
+func (s *Session) Len() int {
+	return len(s.values)
+}
+
+// Flush removes every value and invalidates the stored session, so its
+// cookie no longer loads it
+func (s *Session) Flush() {
+	s.values = make(map[string]json.RawMessage)
+	s.Regenerate()
+}
+
+// Regenerate keeps the values under a new ID and invalidates the old one.
+// Call it when privileges change, such as on login, so an ID planted by an
+// attacker before the login is worthless after it.
+func (s *Session) Regenerate() {
+	if s.id != "" {
+		s.previousID = s.id
+	}
+	s.id = ""
+	s.invalidated = true
+	s.changed = true
+}
+
+// Store loads and saves sessions
+type Store interface {
+	// Load returns the session carried by a cookie value. A missing or
+	// expired session yields an empty one; a cookie that fails verification
+	// yields an empty one and ErrInvalid.
+	Load(ctx context.Context, cookie string) (*Session, error)
+
+	// Save persists s for ttl and returns the cookie value to send
+	Save(ctx context.Context, s *Session, ttl time.Duration) (string, error)
+
+	// Destroy removes the stored session with the given ID, so cookies
+	// carrying it no longer load it. Cookie sessions have nothing to remove.
+	Destroy(ctx context.Context, id string) error
+}
+
+var (
+	defaultMu    sync.RWMutex
+	defaultStore Store
+)
+
+// Default returns the application's store, one on kv.Default until
+// SetDefault is called
+func Default() Store {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	if defaultStore != nil {
+		return defaultStore
+	}
+	return NewKVStore(kv.Default())
+}
+
+// SetDefault sets the store returned by Default.
+// This should be called during application initialization.
+func SetDefault(store Store) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultStore = store
+}
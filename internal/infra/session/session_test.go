@@ -0,0 +1,246 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/kv"
+)
+
+const testSecret = "test-app-key-for-session-signing"
+
+func stores() map[string]Store {
+	return map[string]Store{
+		"kv":        NewKVStore(kv.NewMemoryStore()),
+		"cookie":    NewCookieStore(testSecret),
+		"encrypted": NewCookieStore(testSecret).WithEncryption(),
+	}
+}
+
+func TestStore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range stores() {
+		t.Run(name, func(t *testing.T) {
+			sess := New()
+			require.NoError(t, sess.Set("user_id", uint(42)))
+			require.NoError(t, sess.Set("roles", []string{"admin"}))
+
+			cookie, err := store.Save(ctx, sess, time.Hour)
+			require.NoError(t, err)
+			require.NotEmpty(t, cookie)
+
+			loaded, err := store.Load(ctx, cookie)
+			require.NoError(t, err)
+			var userID uint
+			var roles []string
+			assert.True(t, loaded.Get("user_id", &userID))
+			assert.True(t, loaded.Get("roles", &roles))
+			assert.Equal(t, uint(42), userID)
+			assert.Equal(t, []string{"admin"}, roles)
+			assert.False(t, loaded.Get("missing", &userID))
+		})
+	}
+}
+
+func TestStore_MissingCookieLoadsEmpty(t *testing.T) {
+	for name, store := range stores() {
+		t.Run(name, func(t *testing.T) {
+			sess, err := store.Load(context.Background(), "")
+			require.NoError(t, err)
+			assert.Zero(t, sess.Len())
+		})
+	}
+}
+
+func TestCookieStore_DetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	store := NewCookieStore(testSecret)
+
+	sess := New()
+	require.NoError(t, sess.Set("user_id", 1))
+	cookie, err := store.Save(ctx, sess, time.Hour)
+	require.NoError(t, err)
+
+	data, sig, _ := strings.Cut(cookie, ".")
+	forged := New()
+	require.NoError(t, forged.Set("user_id", 2))
+	forgedCookie, err := NewCookieStore("another-key").Save(ctx, forged, time.Hour)
+	require.NoError(t, err)
+	forgedData, _, _ := strings.Cut(forgedCookie, ".")
+
+	tests := map[string]string{
+		"payload swapped":   forgedData + "." + sig,
+		"signature altered": data + "." + strings.Repeat("A", len(sig)),
+		"signed by another": forgedCookie,
+		"not signed":        data,
+		"garbage":           "not-a-session",
+	}
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			loaded, err := store.Load(ctx, value)
+			assert.ErrorIs(t, err, ErrInvalid)
+			require.NotNil(t, loaded)
+			assert.Zero(t, loaded.Len())
+		})
+	}
+}
+
+func TestCookieStore_Encryption(t *testing.T) {
+	ctx := context.Background()
+	sess := New()
+	require.NoError(t, sess.Set("secret", "hunter2"))
+
+	plain, err := NewCookieStore(testSecret).Save(ctx, sess, time.Hour)
+	require.NoError(t, err)
+	encrypted, err := NewCookieStore(testSecret).WithEncryption().Save(ctx, sess, time.Hour)
+	require.NoError(t, err)
+
+	decode := func(cookie string) string {
+		data, _, _ := strings.Cut(cookie, ".")
+		raw, _ := (&CookieStore{}).open(data)
+		return string(raw)
+	}
+	assert.Contains(t, decode(plain), "hunter2", "signed cookies are readable")
+	assert.NotContains(t, decode(encrypted), "hunter2", "encrypted cookies are not")
+}
+
+func TestCookieStore_Expiry(t *testing.T) {
+	ctx := context.Background()
+	store := NewCookieStore(testSecret)
+	sess := New()
+	require.NoError(t, sess.Set("user_id", 1))
+
+	cookie, err := store.Save(ctx, sess, -time.Second)
+	require.NoError(t, err)
+	loaded, err := store.Load(ctx, cookie)
+	require.NoError(t, err)
+	assert.Zero(t, loaded.Len())
+}
+
+func TestCookieStore_TooLarge(t *testing.T) {
+	sess := New()
+	require.NoError(t, sess.Set("blob", strings.Repeat("x", maxCookieSize)))
+
+	_, err := NewCookieStore(testSecret).Save(context.Background(), sess, time.Hour)
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
+
+func TestKVStore_UnknownIDGetsFreshSession(t *testing.T) {
+	sess, err := NewKVStore(kv.NewMemoryStore()).Load(context.Background(), "chosen-by-client")
+	require.NoError(t, err)
+	assert.Empty(t, sess.ID())
+}
+
+// newSessionRouter serves routes that log in, read and log out through the
+// session middleware
+func newSessionRouter(store Store) *gin.Engine {
+	r := gin.New()
+	r.Use(MiddlewareWithOptions(Options{Store: store}))
+	r.POST("/login", func(c *gin.Context) {
+		if err := Login(c, 7, "alice"); err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.String(http.StatusOK, "ok")
+	})
+	r.GET("/me", func(c *gin.Context) {
+		userID, username, ok := User(c)
+		if !ok {
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+		c.String(http.StatusOK, "%d:%s", userID, username)
+	})
+	r.POST("/logout", func(c *gin.Context) {
+		Logout(c)
+		c.Status(http.StatusNoContent)
+	})
+	return r
+}
+
+func serve(r http.Handler, method, path string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func sessionCookie(t *testing.T, w *httptest.ResponseRecorder) *http.Cookie {
+	t.Helper()
+	for _, c := range w.Result().Cookies() {
+		if c.Name == DefaultOptions().CookieName {
+			return c
+		}
+	}
+	t.Fatal("no session cookie set")
+	return nil
+}
+
+func TestMiddleware_LoginAndLogout(t *testing.T) {
+	for name, store := range stores() {
+		t.Run(name, func(t *testing.T) {
+			r := newSessionRouter(store)
+
+			w := serve(r, http.MethodGet, "/me", nil)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Empty(t, w.Result().Cookies(), "empty sessions set no cookie")
+
+			w = serve(r, http.MethodPost, "/login", nil)
+			require.Equal(t, http.StatusOK, w.Code)
+			cookie := sessionCookie(t, w)
+			assert.True(t, cookie.HttpOnly)
+			assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+
+			w = serve(r, http.MethodGet, "/me", cookie)
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "7:alice", w.Body.String())
+
+			w = serve(r, http.MethodPost, "/logout", cookie)
+			require.Equal(t, http.StatusNoContent, w.Code)
+			assert.Negative(t, sessionCookie(t, w).MaxAge, "logout clears the cookie")
+		})
+	}
+}
+
+func TestMiddleware_LogoutInvalidatesServerSession(t *testing.T) {
+	r := newSessionRouter(NewKVStore(kv.NewMemoryStore()))
+
+	cookie := sessionCookie(t, serve(r, http.MethodPost, "/login", nil))
+	serve(r, http.MethodPost, "/logout", cookie)
+
+	// A copy of the cookie kept from before the logout is worthless
+	w := serve(r, http.MethodGet, "/me", cookie)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestMiddleware_LoginRegeneratesID(t *testing.T) {
+	r := newSessionRouter(NewKVStore(kv.NewMemoryStore()))
+
+	first := sessionCookie(t, serve(r, http.MethodPost, "/login", nil))
+	second := sessionCookie(t, serve(r, http.MethodPost, "/login", first))
+	assert.NotEqual(t, first.Value, second.Value)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(r, http.MethodGet, "/me", first).Code)
+	assert.Equal(t, http.StatusOK, serve(r, http.MethodGet, "/me", second).Code)
+}
+
+func TestMiddleware_TamperedCookieIsDropped(t *testing.T) {
+	r := newSessionRouter(NewCookieStore(testSecret))
+
+	cookie := sessionCookie(t, serve(r, http.MethodPost, "/login", nil))
+	cookie.Value = "x" + cookie.Value
+
+	w := serve(r, http.MethodGet, "/me", cookie)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Negative(t, sessionCookie(t, w).MaxAge)
+}
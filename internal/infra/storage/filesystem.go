@@ -1,31 +1,71 @@
 package storage
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 )
 
+var (
+	// ErrFileNotFound is reported for paths that do not exist
+	ErrFileNotFound = errors.New("file not found")
+
+	// ErrInvalidVisibility is returned for visibilities other than public and private
+	ErrInvalidVisibility = errors.New("invalid visibility")
+
+	// ErrInvalidCursor is returned for list cursors not issued by the disk
+	ErrInvalidCursor = errors.New("invalid list cursor")
+)
+
+// defaultListLimit matches the page size of S3-compatible list APIs
+const defaultListLimit = 1000
+
+// File visibilities
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
+// VisibilitySetter is implemented by filesystems that support per-file visibility
+type VisibilitySetter interface {
+	SetVisibility(path, visibility string) error
+	Visibility(path string) (string, error)
+}
+
 // Filesystem defines the interface for file operations
 type Filesystem interface {
 	// File operations
 	Exists(path string) bool
 	Get(path string) ([]byte, error)
 	Put(path string, contents []byte) error
-	Append(path string, contents []byte) error
+	Append(ctx context.Context, path string, contents []byte) error
+	Prepend(ctx context.Context, path string, contents []byte) error
 	Delete(paths ...string) error
 	Copy(from, to string) error
 	Move(from, to string) error
 
+	// Bulk operations. Drivers with batch APIs can do these in one round-trip;
+	// a failing path never aborts the rest of the batch.
+	DeleteMany(ctx context.Context, paths []string) error
+	ExistsMany(ctx context.Context, paths []string) (map[string]bool, error)
+
 	// File info
 	Size(path string) (int64, error)
 	LastModified(path string) (time.Time, error)
 	MimeType(path string) string
+	ContentType(ctx context.Context, path string) (string, error)
+	Stat(path string) (FileInfo, error)
 
 	// Directory operations
 	Files(directory string) ([]string, error)
+	FilesPaginated(ctx context.Context, directory string, opts ListOptions) (FilePage, error)
 	AllFiles(directory string) ([]string, error)
 	Directories(directory string) ([]string, error)
 	AllDirectories(directory string) ([]string, error)
@@ -48,9 +88,32 @@ type FileInfo struct {
 	Extension    string
 	Size         int64
 	LastModified time.Time
+	MimeType     string
 	IsDir        bool
 }
 
+// ListOptions selects one page of a directory listing
+type ListOptions struct {
+	// Limit caps the files returned
+	// Default: 1000
+	Limit int
+
+	// Cursor continues after the page that returned it as NextCursor.
+	// Empty starts from the beginning.
+	Cursor string
+
+	// Prefix keeps only files whose names start with it
+	Prefix string
+}
+
+// FilePage is one page of a directory listing, in name order
+type FilePage struct {
+	Files []FileInfo
+
+	// NextCursor fetches the following page; empty on the last one
+	NextCursor string
+}
+
 // Manager manages multiple filesystem disks
 type Manager struct {
 	disks       map[string]Filesystem
@@ -63,11 +126,34 @@ var manager = &Manager{
 	defaultDisk: "local",
 }
 
-// RegisterDisk registers a filesystem disk
+// DiskOptions configures a disk independently of its driver
+type DiskOptions struct {
+	// DefaultVisibility is applied to files written with Put or WriteStream.
+	// Empty keeps the driver's own default.
+	DefaultVisibility string
+
+	// URLTemplate renders public URLs, e.g. "https://cdn.example.com/{path}".
+	// Empty keeps the driver's own URL.
+	URLTemplate string
+}
+
+// RegisterDisk registers a filesystem disk with the driver's defaults
 func RegisterDisk(name string, fs Filesystem) {
 	manager.disks[name] = fs
 }
 
+// RegisterDiskWithOptions registers a filesystem disk configured by opts
+//
+// Example:
+//
+//	storage.RegisterDiskWithOptions("public", storage.NewLocalFilesystem("storage/public"), storage.DiskOptions{
+//		DefaultVisibility: storage.VisibilityPublic,
+//		URLTemplate:       "https://cdn.example.com/{path}",
+//	})
+func RegisterDiskWithOptions(name string, fs Filesystem, opts DiskOptions) {
+	manager.disks[name] = &configuredDisk{Filesystem: fs, opts: opts}
+}
+
 // SetDefaultDisk sets the default disk
 func SetDefaultDisk(name string) {
 	manager.defaultDisk = name
@@ -88,6 +174,47 @@ func Disk(name ...string) Filesystem {
 	return manager.disks["local"]
 }
 
+// configuredDisk applies DiskOptions on top of a driver
+type configuredDisk struct {
+	Filesystem
+	opts DiskOptions
+}
+
+// Put writes contents and applies the disk's default visibility
+func (d *configuredDisk) Put(path string, contents []byte) error {
+	if err := d.Filesystem.Put(path, contents); err != nil {
+		return err
+	}
+	return d.applyVisibility(path)
+}
+
+// WriteStream writes stream and applies the disk's default visibility
+func (d *configuredDisk) WriteStream(path string, stream io.Reader) error {
+	if err := d.Filesystem.WriteStream(path, stream); err != nil {
+		return err
+	}
+	return d.applyVisibility(path)
+}
+
+// URL renders the disk's URL template, or defers to the driver without one
+func (d *configuredDisk) URL(path string) string {
+	if d.opts.URLTemplate == "" {
+		return d.Filesystem.URL(path)
+	}
+	return strings.ReplaceAll(d.opts.URLTemplate, "{path}", strings.TrimPrefix(path, "/"))
+}
+
+func (d *configuredDisk) applyVisibility(path string) error {
+	if d.opts.DefaultVisibility == "" {
+		return nil
+	}
+	setter, ok := d.Filesystem.(VisibilitySetter)
+	if !ok {
+		return nil
+	}
+	return setter.SetVisibility(path, d.opts.DefaultVisibility)
+}
+
 // --- Local Filesystem Implementation ---
 
 // LocalFilesystem implements Filesystem for local disk
@@ -129,7 +256,10 @@ func (fs *LocalFilesystem) Put(path string, contents []byte) error {
 }
 
 // Append appends contents to a file
-func (fs *LocalFilesystem) Append(path string, contents []byte) error {
+func (fs *LocalFilesystem) Append(ctx context.Context, path string, contents []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	fullPath := fs.path(path)
 	dir := filepath.Dir(fullPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -146,6 +276,51 @@ func (fs *LocalFilesystem) Append(path string, contents []byte) error {
 	return err
 }
 
+// Prepend writes contents before the existing content of a file.
+// The result is built in a temp file next to the original and renamed over
+// it, so readers never see a half-written file.
+func (fs *LocalFilesystem) Prepend(ctx context.Context, path string, contents []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	fullPath := fs.path(path)
+	dir := filepath.Dir(fullPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	old, err := os.Open(fullPath)
+	if os.IsNotExist(err) {
+		return os.WriteFile(fullPath, contents, 0644)
+	}
+	if err != nil {
+		return err
+	}
+	defer old.Close()
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(fullPath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := io.Copy(tmp, old); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if info, err := old.Stat(); err == nil {
+		_ = os.Chmod(tmp.Name(), info.Mode().Perm())
+	}
+	return os.Rename(tmp.Name(), fullPath)
+}
+
 // Delete removes files
 func (fs *LocalFilesystem) Delete(paths ...string) error {
 	for _, path := range paths {
@@ -156,6 +331,72 @@ func (fs *LocalFilesystem) Delete(paths ...string) error {
 	return nil
 }
 
+// DeleteMany removes every path it can and returns the per-path failures
+// joined together. Missing paths are reported as ErrFileNotFound.
+func (fs *LocalFilesystem) DeleteMany(ctx context.Context, paths []string) error {
+	var errs []error
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if err := os.Remove(fs.path(path)); err != nil {
+			if os.IsNotExist(err) {
+				err = ErrFileNotFound
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ExistsMany reports which of paths exist.
+// Paths that cannot be checked are left out of the map and reported in the error.
+func (fs *LocalFilesystem) ExistsMany(ctx context.Context, paths []string) (map[string]bool, error) {
+	result := make(map[string]bool, len(paths))
+	var errs []error
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return result, errors.Join(append(errs, err)...)
+		}
+		_, err := os.Stat(fs.path(path))
+		switch {
+		case err == nil:
+			result[path] = true
+		case os.IsNotExist(err):
+			result[path] = false
+		default:
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// SetVisibility maps public to mode 0644 and private to 0600
+func (fs *LocalFilesystem) SetVisibility(path, visibility string) error {
+	var mode os.FileMode
+	switch visibility {
+	case VisibilityPublic:
+		mode = 0644
+	case VisibilityPrivate:
+		mode = 0600
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidVisibility, visibility)
+	}
+	return os.Chmod(fs.path(path), mode)
+}
+
+// Visibility reports a file as public when others can read it
+func (fs *LocalFilesystem) Visibility(path string) (string, error) {
+	info, err := os.Stat(fs.path(path))
+	if err != nil {
+		return "", err
+	}
+	if info.Mode().Perm()&0004 != 0 {
+		return VisibilityPublic, nil
+	}
+	return VisibilityPrivate, nil
+}
+
 // Copy copies a file
 func (fs *LocalFilesystem) Copy(from, to string) error {
 	src, err := os.Open(fs.path(from))
@@ -208,8 +449,71 @@ func (fs *LocalFilesystem) LastModified(path string) (time.Time, error) {
 	return info.ModTime(), nil
 }
 
-// MimeType returns the MIME type based on extension
+// MimeType returns the MIME type of a file, sniffing its content when it
+// exists and falling back to the extension otherwise
 func (fs *LocalFilesystem) MimeType(path string) string {
+	if mime, err := fs.ContentType(context.Background(), path); err == nil {
+		return mime
+	}
+	return extensionMimeType(path)
+}
+
+// ContentType detects the MIME type from the first 512 bytes of a file.
+// Generic results (plain text, octet-stream) defer to a known extension,
+// since sniffing cannot tell JSON or CSS from text.
+func (fs *LocalFilesystem) ContentType(ctx context.Context, path string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	f, err := os.Open(fs.path(path))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	return detectContentType(head[:n], path), nil
+}
+
+// Stat returns the metadata of a file, including its sniffed MIME type
+func (fs *LocalFilesystem) Stat(path string) (FileInfo, error) {
+	info, err := os.Stat(fs.path(path))
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	fi := FileInfo{
+		Path:         path,
+		Name:         info.Name(),
+		Extension:    strings.TrimPrefix(filepath.Ext(path), "."),
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+		IsDir:        info.IsDir(),
+	}
+	if !fi.IsDir {
+		fi.MimeType = fs.MimeType(path)
+	}
+	return fi, nil
+}
+
+// detectContentType sniffs head, preferring the extension for generic results
+func detectContentType(head []byte, path string) string {
+	sniffed := http.DetectContentType(head)
+	if sniffed != "application/octet-stream" && !strings.HasPrefix(sniffed, "text/plain") {
+		return sniffed
+	}
+	if ext := extensionMimeType(path); ext != "application/octet-stream" {
+		return ext
+	}
+	return sniffed
+}
+
+// extensionMimeType returns the MIME type based on extension
+func extensionMimeType(path string) string {
 	ext := strings.ToLower(filepath.Ext(path))
 	mimeTypes := map[string]string{
 		".html": "text/html",
@@ -249,6 +553,89 @@ func (fs *LocalFilesystem) Files(directory string) ([]string, error) {
 	return files, nil
 }
 
+// FilesPaginated returns one page of the files in a directory
+// (non-recursive). Files come in name order and the cursor holds the last
+// name returned, so pages stay stable while files are added or removed.
+// MimeType is taken from the extension; use Stat to sniff the content.
+//
+// Example:
+//
+//	opts := storage.ListOptions{Limit: 100}
+//	for {
+//	    page, err := disk.FilesPaginated(ctx, "exports", opts)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(page.Files)
+//	    if page.NextCursor == "" {
+//	        break
+//	    }
+//	    opts.Cursor = page.NextCursor
+//	}
+func (fs *LocalFilesystem) FilesPaginated(ctx context.Context, directory string, opts ListOptions) (FilePage, error) {
+	if err := ctx.Err(); err != nil {
+		return FilePage{}, err
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultListLimit
+	}
+	after, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return FilePage{}, err
+	}
+
+	// ReadDir sorts entries by name
+	entries, err := os.ReadDir(fs.path(directory))
+	if err != nil {
+		return FilePage{}, err
+	}
+
+	page := FilePage{Files: make([]FileInfo, 0, min(opts.Limit, len(entries)))}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name <= after || !strings.HasPrefix(name, opts.Prefix) {
+			continue
+		}
+		if len(page.Files) == opts.Limit {
+			page.NextCursor = encodeCursor(page.Files[len(page.Files)-1].Name)
+			break
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			// Removed since ReadDir
+			continue
+		}
+		path := filepath.Join(directory, name)
+		page.Files = append(page.Files, FileInfo{
+			Path:         path,
+			Name:         name,
+			Extension:    strings.TrimPrefix(filepath.Ext(name), "."),
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+			MimeType:     extensionMimeType(path),
+		})
+	}
+	return page, nil
+}
+
+// encodeCursor turns the last listed name into an opaque cursor
+func encodeCursor(name string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(name))
+}
+
+// decodeCursor returns the name a cursor continues after
+func decodeCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	name, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(name) == 0 {
+		return "", ErrInvalidCursor
+	}
+	return string(name), nil
+}
+
 // AllFiles returns all files recursively
 func (fs *LocalFilesystem) AllFiles(directory string) ([]string, error) {
 	var files []string
@@ -360,6 +747,16 @@ func Delete(paths ...string) error {
 	return Disk().Delete(paths...)
 }
 
+// DeleteMany removes files, collecting per-path errors (uses default disk)
+func DeleteMany(ctx context.Context, paths []string) error {
+	return Disk().DeleteMany(ctx, paths)
+}
+
+// ExistsMany checks which files exist (uses default disk)
+func ExistsMany(ctx context.Context, paths []string) (map[string]bool, error) {
+	return Disk().ExistsMany(ctx, paths)
+}
+
 // Copy copies a file (uses default disk)
 func Copy(from, to string) error {
 	return Disk().Copy(from, to)
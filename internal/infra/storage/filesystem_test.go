@@ -1,11 +1,20 @@
 package storage
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/zgiai/zgo/pkg/response"
 )
 
+// Filesystem must keep satisfying response.FileSource
+var _ response.FileSource = Filesystem(nil)
+
 func TestLocalFilesystem_PutAndGet(t *testing.T) {
 	fs := NewLocalFilesystem(t.TempDir())
 
@@ -100,9 +109,10 @@ func TestLocalFilesystem_Move(t *testing.T) {
 
 func TestLocalFilesystem_Append(t *testing.T) {
 	fs := NewLocalFilesystem(t.TempDir())
+	ctx := context.Background()
 
 	fs.Put("append.txt", []byte("Hello"))
-	fs.Append("append.txt", []byte(" World"))
+	fs.Append(ctx, "append.txt", []byte(" World"))
 
 	got, _ := fs.Get("append.txt")
 	if string(got) != "Hello World" {
@@ -110,6 +120,57 @@ func TestLocalFilesystem_Append(t *testing.T) {
 	}
 }
 
+func TestLocalFilesystem_AppendAndPrepend(t *testing.T) {
+	fs := NewLocalFilesystem(t.TempDir())
+	ctx := context.Background()
+
+	fs.Append(ctx, "log/app.log", []byte("second\n"))
+	fs.Append(ctx, "log/app.log", []byte("third\n"))
+	if err := fs.Prepend(ctx, "log/app.log", []byte("first\n")); err != nil {
+		t.Fatalf("Prepend failed: %v", err)
+	}
+
+	got, _ := fs.Get("log/app.log")
+	if string(got) != "first\nsecond\nthird\n" {
+		t.Errorf("Expected lines in order, got %q", got)
+	}
+
+	// No temp files are left behind
+	files, _ := fs.Files("log")
+	if len(files) != 1 {
+		t.Errorf("Expected only app.log, got %v", files)
+	}
+}
+
+func TestLocalFilesystem_PrependCreates(t *testing.T) {
+	fs := NewLocalFilesystem(t.TempDir())
+	ctx := context.Background()
+
+	if err := fs.Prepend(ctx, "new.txt", []byte("header")); err != nil {
+		t.Fatalf("Prepend failed: %v", err)
+	}
+	got, _ := fs.Get("new.txt")
+	if string(got) != "header" {
+		t.Errorf("Expected 'header', got '%s'", got)
+	}
+}
+
+func TestLocalFilesystem_AppendCanceled(t *testing.T) {
+	fs := NewLocalFilesystem(t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := fs.Append(ctx, "log.txt", []byte("x")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Append: expected context.Canceled, got %v", err)
+	}
+	if err := fs.Prepend(ctx, "log.txt", []byte("x")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Prepend: expected context.Canceled, got %v", err)
+	}
+	if fs.Exists("log.txt") {
+		t.Error("Canceled writes should not create the file")
+	}
+}
+
 func TestLocalFilesystem_Size(t *testing.T) {
 	fs := NewLocalFilesystem(t.TempDir())
 
@@ -189,6 +250,66 @@ func TestLocalFilesystem_Files(t *testing.T) {
 	}
 }
 
+func TestLocalFilesystem_FilesPaginated(t *testing.T) {
+	root := t.TempDir()
+	fs := NewLocalFilesystem(root)
+	ctx := context.Background()
+
+	var want []string
+	for i := 0; i < 25; i++ {
+		name := fmt.Sprintf("report-%02d.csv", i)
+		fs.Put("exports/"+name, []byte("a,b"))
+		want = append(want, name)
+	}
+	fs.Put("exports/summary.txt", []byte("total"))
+	os.MkdirAll(filepath.Join(root, "exports", "report-archive"), 0755)
+
+	var got []string
+	pages := 0
+	opts := ListOptions{Limit: 10, Prefix: "report-"}
+	for {
+		page, err := fs.FilesPaginated(ctx, "exports", opts)
+		if err != nil {
+			t.Fatalf("FilesPaginated failed: %v", err)
+		}
+		if len(page.Files) > 10 {
+			t.Fatalf("page has %d files, limit is 10", len(page.Files))
+		}
+		pages++
+		for _, f := range page.Files {
+			got = append(got, f.Name)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+
+	if pages != 3 {
+		t.Errorf("Expected 3 pages, got %d", pages)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Listed %v, want %v", got, want)
+	}
+
+	// A page ending exactly at the last file has no cursor
+	page, err := fs.FilesPaginated(ctx, "exports", ListOptions{Limit: 26})
+	if err != nil {
+		t.Fatalf("FilesPaginated failed: %v", err)
+	}
+	if len(page.Files) != 26 || page.NextCursor != "" {
+		t.Errorf("Got %d files and cursor %q, want 26 and none", len(page.Files), page.NextCursor)
+	}
+	first := page.Files[0]
+	if first.Path != filepath.Join("exports", "report-00.csv") || first.Extension != "csv" || first.Size != 3 {
+		t.Errorf("FileInfo = %+v", first)
+	}
+
+	if _, err := fs.FilesPaginated(ctx, "exports", ListOptions{Cursor: "not a cursor!"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
+
 func TestLocalFilesystem_MakeAndDeleteDirectory(t *testing.T) {
 	fs := NewLocalFilesystem(t.TempDir())
 
@@ -210,3 +331,129 @@ func TestLocalFilesystem_MakeAndDeleteDirectory(t *testing.T) {
 		t.Error("Directory should not exist after deletion")
 	}
 }
+
+func TestLocalFilesystem_DeleteMany(t *testing.T) {
+	fs := NewLocalFilesystem(t.TempDir())
+	fs.Put("a.txt", []byte("a"))
+	fs.Put("b.txt", []byte("b"))
+
+	err := fs.DeleteMany(context.Background(), []string{"a.txt", "missing.txt", "b.txt"})
+	if !errors.Is(err, ErrFileNotFound) {
+		t.Fatalf("DeleteMany error = %v, want ErrFileNotFound", err)
+	}
+
+	// The missing path must not stop the files after it from being deleted
+	if fs.Exists("a.txt") || fs.Exists("b.txt") {
+		t.Error("existing files should be deleted despite the missing one")
+	}
+
+	if err := fs.DeleteMany(context.Background(), nil); err != nil {
+		t.Errorf("DeleteMany(nil) error = %v", err)
+	}
+}
+
+func TestLocalFilesystem_ExistsMany(t *testing.T) {
+	fs := NewLocalFilesystem(t.TempDir())
+	fs.Put("a.txt", []byte("a"))
+
+	got, err := fs.ExistsMany(context.Background(), []string{"a.txt", "missing.txt"})
+	if err != nil {
+		t.Fatalf("ExistsMany failed: %v", err)
+	}
+	if !got["a.txt"] || got["missing.txt"] {
+		t.Errorf("ExistsMany = %v, want a.txt only", got)
+	}
+	if len(got) != 2 {
+		t.Errorf("ExistsMany returned %d entries, want 2", len(got))
+	}
+}
+
+func TestLocalFilesystem_ContentType(t *testing.T) {
+	fs := NewLocalFilesystem(t.TempDir())
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	tests := []struct {
+		path     string
+		contents []byte
+		expected string
+	}{
+		{"image.png", png, "image/png"},
+		{"image.bin", png, "image/png"}, // content wins over extension
+		{"data.json", []byte(`{"name":"zgo"}`), "application/json"},
+		{"notes.txt", []byte("hello"), "text/plain"},
+		{"notes", []byte("hello"), "text/plain; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		fs.Put(tt.path, tt.contents)
+		got, err := fs.ContentType(context.Background(), tt.path)
+		if err != nil {
+			t.Fatalf("ContentType(%s) failed: %v", tt.path, err)
+		}
+		if got != tt.expected {
+			t.Errorf("ContentType(%s) = %s, want %s", tt.path, got, tt.expected)
+		}
+	}
+
+	if _, err := fs.ContentType(context.Background(), "missing.png"); !os.IsNotExist(err) {
+		t.Errorf("ContentType(missing) error = %v, want not exist", err)
+	}
+}
+
+func TestLocalFilesystem_Stat(t *testing.T) {
+	fs := NewLocalFilesystem(t.TempDir())
+	fs.Put("docs/data.json", []byte(`[1,2,3]`))
+
+	info, err := fs.Stat("docs/data.json")
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Name != "data.json" || info.Extension != "json" || info.Size != 7 {
+		t.Errorf("Stat = %+v", info)
+	}
+	if info.MimeType != "application/json" {
+		t.Errorf("MimeType = %s, want application/json", info.MimeType)
+	}
+}
+
+func TestRegisterDiskWithOptions(t *testing.T) {
+	local := NewLocalFilesystem(t.TempDir())
+	RegisterDiskWithOptions("options-test", local, DiskOptions{
+		DefaultVisibility: VisibilityPrivate,
+		URLTemplate:       "https://cdn.example.com/{path}",
+	})
+	t.Cleanup(func() { delete(manager.disks, "options-test") })
+
+	disk := Disk("options-test")
+	if got := disk.URL("avatars/1.png"); got != "https://cdn.example.com/avatars/1.png" {
+		t.Errorf("URL = %s", got)
+	}
+
+	if err := disk.Put("secret.txt", []byte("s3cr3t")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if got, _ := local.Visibility("secret.txt"); got != VisibilityPrivate {
+		t.Errorf("Visibility after Put = %s, want private", got)
+	}
+
+	// Explicit visibility still wins afterwards
+	if err := local.SetVisibility("secret.txt", VisibilityPublic); err != nil {
+		t.Fatalf("SetVisibility failed: %v", err)
+	}
+	if got, _ := local.Visibility("secret.txt"); got != VisibilityPublic {
+		t.Errorf("Visibility = %s, want public", got)
+	}
+}
+
+func TestRegisterDisk_DriverDefaults(t *testing.T) {
+	local := NewLocalFilesystem(t.TempDir())
+	RegisterDisk("plain-test", local)
+	t.Cleanup(func() { delete(manager.disks, "plain-test") })
+
+	if got := Disk("plain-test").URL("a.txt"); got != "/a.txt" {
+		t.Errorf("URL = %s, want /a.txt", got)
+	}
+	if err := local.SetVisibility("a.txt", "world"); !errors.Is(err, ErrInvalidVisibility) {
+		t.Errorf("SetVisibility error = %v, want ErrInvalidVisibility", err)
+	}
+}
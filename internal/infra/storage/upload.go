@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrUploadTooLarge is returned when an upload exceeds the configured max size
+	ErrUploadTooLarge = errors.New("upload too large")
+
+	// ErrExtensionNotAllowed is returned for uploads outside the allowed extensions
+	ErrExtensionNotAllowed = errors.New("file extension not allowed")
+)
+
+// UploadOption configures StoreUpload
+type UploadOption func(*uploadOptions)
+
+type uploadOptions struct {
+	maxSize    int64
+	extensions map[string]bool
+	validators []func(*multipart.FileHeader) error
+}
+
+// WithMaxSize rejects uploads larger than size bytes with ErrUploadTooLarge
+func WithMaxSize(size int64) UploadOption {
+	return func(o *uploadOptions) {
+		o.maxSize = size
+	}
+}
+
+// WithAllowedExtensions rejects uploads whose extension is not listed
+// (case-insensitive, with or without the leading dot) with ErrExtensionNotAllowed
+func WithAllowedExtensions(exts ...string) UploadOption {
+	return func(o *uploadOptions) {
+		if o.extensions == nil {
+			o.extensions = make(map[string]bool, len(exts))
+		}
+		for _, ext := range exts {
+			o.extensions["."+strings.TrimPrefix(strings.ToLower(ext), ".")] = true
+		}
+	}
+}
+
+// WithValidator adds a custom check that runs before the upload is stored
+func WithValidator(fn func(*multipart.FileHeader) error) UploadOption {
+	return func(o *uploadOptions) {
+		o.validators = append(o.validators, fn)
+	}
+}
+
+// StoreUpload streams an uploaded file into directory on disk under a random
+// UUID name that keeps the original extension, and returns the stored file's
+// metadata, including its sniffed MIME type.
+//
+// Example:
+//
+//	fh, err := c.FormFile("avatar")
+//	if err != nil { ... }
+//	info, err := storage.StoreUpload(ctx, storage.Disk("public"), fh, "avatars",
+//		storage.WithMaxSize(2<<20),
+//		storage.WithAllowedExtensions("png", "jpg"),
+//	)
+func StoreUpload(ctx context.Context, disk Filesystem, fileHeader *multipart.FileHeader, directory string, opts ...UploadOption) (FileInfo, error) {
+	var o uploadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	if o.maxSize > 0 && fileHeader.Size > o.maxSize {
+		return FileInfo{}, fmt.Errorf("%w: %d bytes exceeds %d", ErrUploadTooLarge, fileHeader.Size, o.maxSize)
+	}
+	if o.extensions != nil && !o.extensions[ext] {
+		return FileInfo{}, fmt.Errorf("%w: %q", ErrExtensionNotAllowed, ext)
+	}
+	for _, validate := range o.validators {
+		if err := validate(fileHeader); err != nil {
+			return FileInfo{}, err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return FileInfo{}, err
+	}
+
+	src, err := fileHeader.Open()
+	if err != nil {
+		return FileInfo{}, fmt.Errorf("open upload: %w", err)
+	}
+	defer src.Close()
+
+	dest := path.Join(directory, uuid.New().String()+ext)
+	if err := disk.WriteStream(dest, src); err != nil {
+		return FileInfo{}, fmt.Errorf("store upload: %w", err)
+	}
+	return disk.Stat(dest)
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newFileHeader builds a multipart.FileHeader the way a request parser would
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("CreateFormFile failed: %v", err)
+	}
+	part.Write(content)
+	w.Close()
+
+	req := httptest.NewRequest("POST", "/upload", &buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatalf("ParseMultipartForm failed: %v", err)
+	}
+	return req.MultipartForm.File["file"][0]
+}
+
+func TestStoreUpload(t *testing.T) {
+	fs := NewLocalFilesystem(t.TempDir())
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...)
+
+	info, err := StoreUpload(context.Background(), fs, newFileHeader(t, "Avatar.PNG", png), "avatars")
+	if err != nil {
+		t.Fatalf("StoreUpload failed: %v", err)
+	}
+
+	if !strings.HasPrefix(info.Path, "avatars/") || !strings.HasSuffix(info.Path, ".png") {
+		t.Errorf("Unexpected stored path %q", info.Path)
+	}
+	if info.Size != int64(len(png)) {
+		t.Errorf("Expected size %d, got %d", len(png), info.Size)
+	}
+	if info.MimeType != "image/png" {
+		t.Errorf("Expected image/png, got %q", info.MimeType)
+	}
+
+	got, _ := fs.Get(info.Path)
+	if !bytes.Equal(got, png) {
+		t.Error("Stored content does not match upload")
+	}
+}
+
+func TestStoreUpload_UniqueNames(t *testing.T) {
+	fs := NewLocalFilesystem(t.TempDir())
+	fh := newFileHeader(t, "report.txt", []byte("same name"))
+
+	first, err := StoreUpload(context.Background(), fs, fh, "docs")
+	if err != nil {
+		t.Fatalf("StoreUpload failed: %v", err)
+	}
+	second, err := StoreUpload(context.Background(), fs, fh, "docs")
+	if err != nil {
+		t.Fatalf("StoreUpload failed: %v", err)
+	}
+	if first.Path == second.Path {
+		t.Errorf("Expected distinct paths, both were %q", first.Path)
+	}
+}
+
+func TestStoreUpload_Validation(t *testing.T) {
+	fs := NewLocalFilesystem(t.TempDir())
+	fh := newFileHeader(t, "script.exe", []byte("0123456789"))
+	ctx := context.Background()
+
+	if _, err := StoreUpload(ctx, fs, fh, "", WithMaxSize(5)); !errors.Is(err, ErrUploadTooLarge) {
+		t.Errorf("Expected ErrUploadTooLarge, got %v", err)
+	}
+	if _, err := StoreUpload(ctx, fs, fh, "", WithAllowedExtensions("png", ".JPG")); !errors.Is(err, ErrExtensionNotAllowed) {
+		t.Errorf("Expected ErrExtensionNotAllowed, got %v", err)
+	}
+
+	rejected := errors.New("rejected")
+	_, err := StoreUpload(ctx, fs, fh, "", WithValidator(func(*multipart.FileHeader) error { return rejected }))
+	if !errors.Is(err, rejected) {
+		t.Errorf("Expected validator error, got %v", err)
+	}
+
+	files, _ := fs.AllFiles("")
+	if len(files) != 0 {
+		t.Errorf("Expected nothing stored, got %v", files)
+	}
+}
@@ -0,0 +1,175 @@
+// Package task runs one-shot maintenance tasks from the command line, such
+// as backfills and recalculations that should not be exposed over HTTP.
+// Tasks are registered by name and run with `zgo task:run <name>`;
+// `zgo task:list` shows them.
+//
+// Example:
+//
+//	task.Register("stats:recalculate", func(ctx context.Context, args task.Args) error {
+//	    since, err := args.Duration("since", 24*time.Hour)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return stats.Recalculate(ctx, since)
+//	}).Describe("Recalculate daily stats")
+package task
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Func is the body of a task
+type Func func(ctx context.Context, args Args) error
+
+// Task is a named task
+type Task struct {
+	name        string
+	description string
+	fn          Func
+}
+
+// Name returns the task name
+func (t *Task) Name() string {
+	return t.name
+}
+
+// Description returns the description shown by task:list
+func (t *Task) Description() string {
+	return t.description
+}
+
+// Describe sets the description shown by task:list
+func (t *Task) Describe(description string) *Task {
+	t.description = description
+	return t
+}
+
+// Run runs the task
+func (t *Task) Run(ctx context.Context, args Args) error {
+	return t.fn(ctx, args)
+}
+
+// Registry holds tasks by name
+type Registry struct {
+	mu    sync.RWMutex
+	tasks map[string]*Task
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{tasks: make(map[string]*Task)}
+}
+
+// Register adds a task, replacing any task with the same name
+func (r *Registry) Register(name string, fn Func) *Task {
+	t := &Task{name: name, fn: fn}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tasks[name] = t
+	return t
+}
+
+// Get returns the task with name
+func (r *Registry) Get(name string) (*Task, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tasks[name]
+	return t, ok
+}
+
+// Tasks returns every task, sorted by name
+func (r *Registry) Tasks() []*Task {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tasks := make([]*Task, 0, len(r.tasks))
+	for _, t := range r.tasks {
+		tasks = append(tasks, t)
+	}
+	slices.SortFunc(tasks, func(a, b *Task) int { return strings.Compare(a.name, b.name) })
+	return tasks
+}
+
+var global = NewRegistry()
+
+// Global returns the registry task:run and task:list use
+func Global() *Registry {
+	return global
+}
+
+// Register adds a task to the global registry
+func Register(name string, fn Func) *Task {
+	return global.Register(name, fn)
+}
+
+// Args holds the key=value arguments given with --arg
+type Args map[string]string
+
+// ParseArgs parses "key=value" pairs
+func ParseArgs(pairs []string) (Args, error) {
+	args := make(Args, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid argument %q, want key=value", pair)
+		}
+		args[key] = value
+	}
+	return args, nil
+}
+
+// String returns the argument under key, or def when it is not given
+func (a Args) String(key, def string) string {
+	if v, ok := a[key]; ok {
+		return v
+	}
+	return def
+}
+
+// Int parses the argument under key as an integer
+func (a Args) Int(key string, def int) (int, error) {
+	v, ok := a[key]
+	if !ok {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("argument %s: %q is not an integer", key, v)
+	}
+	return n, nil
+}
+
+// Bool parses the argument under key as a boolean. A key given with an
+// empty value, as in --arg dry-run=, is true.
+func (a Args) Bool(key string, def bool) (bool, error) {
+	v, ok := a[key]
+	if !ok {
+		return def, nil
+	}
+	if v == "" {
+		return true, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("argument %s: %q is not a boolean", key, v)
+	}
+	return b, nil
+}
+
+// Duration parses the argument under key as a duration such as "72h"
+func (a Args) Duration(key string, def time.Duration) (time.Duration, error) {
+	v, ok := a[key]
+	if !ok {
+		return def, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("argument %s: %q is not a duration", key, v)
+	}
+	return d, nil
+}
@@ -0,0 +1,173 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/zgiai/zgo/internal/infra/events"
+	httpclient "github.com/zgiai/zgo/internal/infra/http"
+	"github.com/zgiai/zgo/internal/infra/queue"
+	"github.com/zgiai/zgo/pkg/logger"
+)
+
+// deliveryTimeout bounds a single POST to an endpoint
+const deliveryTimeout = 10 * time.Second
+
+// Payload is the JSON body POSTed to endpoints
+type Payload struct {
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// Delivery is the queued job POSTing one event to one endpoint. A failed
+// delivery returns an error so the queue worker retries it with backoff.
+// The job only names the endpoint; its URL and secret are looked up when
+// the job runs, so the secret never sits in the queue.
+type Delivery struct {
+	EndpointID uint            `json:"endpoint_id"`
+	Event      string          `json:"event"`
+	DeliveryID string          `json:"delivery_id"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// deliveryRegistry is where deliveries look up their endpoint
+var deliveryRegistry atomic.Pointer[Registry]
+
+// SetRegistry sets the registry deliveries look up their endpoint in.
+// NewListener sets it; workers in other processes must set it themselves.
+func SetRegistry(r *Registry) {
+	deliveryRegistry.Store(r)
+}
+
+// Name returns the name the job is queued under
+func (d *Delivery) Name() string {
+	return "webhook.delivery"
+}
+
+// Handle POSTs the signed payload. Network errors, 5xx and 429 responses
+// fail the job so it is retried; other responses are final. Deliveries to
+// endpoints removed or paused since the event are dropped.
+func (d *Delivery) Handle(ctx context.Context) error {
+	registry := deliveryRegistry.Load()
+	if registry == nil {
+		return errors.New("webhook: no registry set")
+	}
+	endpoint, err := registry.Find(ctx, d.EndpointID)
+	if errors.Is(err, ErrEndpointNotFound) {
+		logger.Warning("webhook delivery dropped", map[string]any{
+			"endpoint_id": d.EndpointID,
+			"event":       d.Event,
+		})
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpclient.New().
+		Timeout(deliveryTimeout).
+		WithHeader(SignatureHeader, Sign(endpoint.Secret, d.Payload)).
+		WithHeader(EventHeader, d.Event).
+		WithHeader(DeliveryHeader, d.DeliveryID).
+		PostContext(ctx, endpoint.URL, d.Payload)
+	if err != nil {
+		return fmt.Errorf("webhook: deliver %s to endpoint %d: %w", d.Event, d.EndpointID, err)
+	}
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("webhook: deliver %s to endpoint %d: status %d", d.Event, d.EndpointID, resp.StatusCode)
+	}
+	if !resp.Ok() {
+		logger.Warning("webhook delivery rejected", map[string]any{
+			"endpoint_id": d.EndpointID,
+			"event":       d.Event,
+			"status":      resp.StatusCode,
+		})
+	}
+	return nil
+}
+
+// Listener queues a Delivery for every endpoint subscribed to an event
+type Listener struct {
+	registry *Registry
+	queue    *queue.Manager
+}
+
+// NewListener creates a listener dispatching deliveries through q. A
+// worker must run q's jobs; with the sync driver a failed delivery is
+// never retried.
+func NewListener(registry *Registry, q *queue.Manager) *Listener {
+	SetRegistry(registry)
+	q.RegisterJob(&Delivery{})
+	return &Listener{registry: registry, queue: q}
+}
+
+// Subscribe registers the listener for every event. Endpoints are looked
+// up asynchronously so webhooks never delay the request.
+func (l *Listener) Subscribe(bus *events.EventBus) {
+	bus.Subscribe("**", l.Handle, events.WithAsync())
+}
+
+// Handle dispatches one delivery per endpoint matching e
+func (l *Listener) Handle(ctx context.Context, e events.Event) error {
+	ctx = context.WithoutCancel(ctx)
+	endpoints, err := l.registry.Match(ctx, e.EventName())
+	if err != nil {
+		logger.Error("failed to load webhook endpoints", map[string]any{
+			"error": err,
+			"event": e.EventName(),
+		})
+		return err
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	payload := payloadFor(e)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("webhook: encode %s: %w", e.EventName(), err)
+	}
+
+	for _, endpoint := range endpoints {
+		delivery := &Delivery{
+			EndpointID: endpoint.ID,
+			Event:      payload.Event,
+			DeliveryID: payload.ID,
+			Payload:    body,
+		}
+		if err := l.queue.Dispatch(ctx, delivery); err != nil {
+			logger.Error("failed to dispatch webhook", map[string]any{
+				"error":       err,
+				"event":       payload.Event,
+				"endpoint_id": endpoint.ID,
+			})
+		}
+	}
+	return nil
+}
+
+// payloadFor builds the body sent for e. Data events send their data,
+// other events the event itself.
+func payloadFor(e events.Event) *Payload {
+	var data any = e
+	if wrapped, ok := e.(events.WrappedEvent); ok {
+		data = wrapped.Event
+	}
+	if de, ok := data.(interface{ Data() any }); ok {
+		data = de.Data()
+	}
+
+	return &Payload{
+		ID:         e.Metadata().ID,
+		Event:      e.EventName(),
+		OccurredAt: e.OccurredAt(),
+		Data:       data,
+	}
+}
@@ -0,0 +1,175 @@
+// Package webhook notifies external systems of domain events. Endpoints are
+// kept in the webhook_endpoints table; a listener on the event bus queues one
+// signed delivery per matching endpoint, and the queue worker retries
+// deliveries that fail.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zgiai/zgo/internal/infra/events"
+	"github.com/zgiai/zgo/pkg/encryption"
+	"gorm.io/gorm"
+)
+
+const (
+	// SignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body
+	SignatureHeader = "X-Signature"
+
+	// EventHeader carries the event name
+	EventHeader = "X-Webhook-Event"
+
+	// DeliveryHeader carries the event ID, the same for every retry
+	DeliveryHeader = "X-Webhook-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	// ErrInvalidEvent is returned for empty or malformed event patterns
+	ErrInvalidEvent = errors.New("webhook: invalid event pattern")
+
+	// ErrInvalidURL is returned for endpoint URLs that are not absolute http(s) URLs
+	ErrInvalidURL = errors.New("webhook: invalid endpoint URL")
+
+	// ErrEmptySecret is returned when an endpoint is registered without a secret
+	ErrEmptySecret = errors.New("webhook: secret is required")
+
+	// ErrEndpointNotFound is returned by Find for removed or paused endpoints
+	ErrEndpointNotFound = errors.New("webhook: endpoint not found")
+)
+
+// Endpoint is one webhook_endpoints row
+type Endpoint struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Event     string    `gorm:"size:100;not null;index" json:"event"` // event name or pattern such as user.*
+	URL       string    `gorm:"size:2048;not null" json:"url"`
+	Secret    string    `gorm:"size:512;not null" json:"-"` // Encrypted with APP_KEY
+	Active    bool      `gorm:"not null;default:true" json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the database table name
+func (Endpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// Sign returns the X-Signature value for body: "sha256=" followed by the
+// hex HMAC-SHA256 of body keyed with secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the X-Signature for body. Receivers
+// written in Go can use it directly; others recompute the HMAC over the raw
+// request body and compare in constant time.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Registry manages webhook endpoints
+type Registry struct {
+	db        *gorm.DB
+	encrypter *encryption.Encrypter
+}
+
+// NewRegistry creates a registry storing endpoints in db, with secrets
+// encrypted by encrypter
+func NewRegistry(db *gorm.DB, encrypter *encryption.Encrypter) *Registry {
+	return &Registry{db: db, encrypter: encrypter}
+}
+
+// Register adds an endpoint receiving every event matching event, which may
+// be a pattern such as "user.*". Deliveries are signed with secret.
+func (r *Registry) Register(ctx context.Context, event, endpointURL, secret string) (*Endpoint, error) {
+	event = strings.TrimSpace(event)
+	if event == "" || !events.ValidatePattern(event) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidEvent, event)
+	}
+	if u, err := url.Parse(endpointURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidURL, endpointURL)
+	}
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+
+	encrypted, err := r.encrypter.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: encrypt secret: %w", err)
+	}
+	endpoint := &Endpoint{Event: event, URL: endpointURL, Secret: encrypted, Active: true}
+	if err := r.db.WithContext(ctx).Create(endpoint).Error; err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// Remove deletes an endpoint
+func (r *Registry) Remove(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Endpoint{}, id).Error
+}
+
+// SetActive pauses or resumes deliveries to an endpoint
+func (r *Registry) SetActive(ctx context.Context, id uint, active bool) error {
+	return r.db.WithContext(ctx).Model(&Endpoint{}).Where("id = ?", id).Update("active", active).Error
+}
+
+// List returns every endpoint, secrets still encrypted
+func (r *Registry) List(ctx context.Context) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	err := r.db.WithContext(ctx).Order("id ASC").Find(&endpoints).Error
+	return endpoints, err
+}
+
+// Find returns the active endpoint id, with its secret decrypted
+func (r *Registry) Find(ctx context.Context, id uint) (*Endpoint, error) {
+	var endpoint Endpoint
+	err := r.db.WithContext(ctx).Where("id = ? AND active = ?", id, true).First(&endpoint).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrEndpointNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := r.encrypter.Decrypt(endpoint.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: decrypt secret of endpoint %d: %w", endpoint.ID, err)
+	}
+	endpoint.Secret = secret
+	return &endpoint, nil
+}
+
+// Match returns the active endpoints subscribed to eventName, with
+// decrypted secrets ready for signing
+func (r *Registry) Match(ctx context.Context, eventName string) ([]Endpoint, error) {
+	var active []Endpoint
+	if err := r.db.WithContext(ctx).Where("active = ?", true).Order("id ASC").Find(&active).Error; err != nil {
+		return nil, err
+	}
+
+	matched := active[:0]
+	for _, endpoint := range active {
+		if !events.MatchPattern(endpoint.Event, eventName) {
+			continue
+		}
+		secret, err := r.encrypter.Decrypt(endpoint.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("webhook: decrypt secret of endpoint %d: %w", endpoint.ID, err)
+		}
+		endpoint.Secret = secret
+		matched = append(matched, endpoint)
+	}
+	return matched, nil
+}
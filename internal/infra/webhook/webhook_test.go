@@ -0,0 +1,300 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/events"
+	"github.com/zgiai/zgo/internal/infra/queue"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"github.com/zgiai/zgo/pkg/encryption"
+)
+
+func setupRegistry(t *testing.T) *Registry {
+	t.Helper()
+	db := dbtest.Open(t, &Endpoint{})
+	return NewRegistry(db, encryption.New("test-app-key"))
+}
+
+// newMemoryQueue returns a manager and worker running jobs from memory,
+// retrying failures almost immediately
+func newMemoryQueue(t *testing.T) (*queue.Manager, *queue.Worker) {
+	t.Helper()
+	driver := queue.NewMemoryDriver(10)
+	manager := queue.NewManager()
+	manager.RegisterDriver("memory", driver)
+	if err := manager.SetDefaultDriver("memory"); err != nil {
+		t.Fatal(err)
+	}
+
+	worker := queue.NewWorker(queue.WorkerConfig{
+		Queue:   "default",
+		Sleep:   10 * time.Millisecond,
+		Backoff: func(int) time.Duration { return 10 * time.Millisecond },
+	})
+	worker.SetManager(manager)
+	worker.SetDriver(driver)
+	return manager, worker
+}
+
+// publish sends e through a bus with the listener subscribed and waits for
+// the deliveries to be queued
+func publish(t *testing.T, listener *Listener, e domain.Event) {
+	t.Helper()
+	bus := events.NewEventBus()
+	listener.Subscribe(bus)
+
+	if err := bus.Publish(context.Background(), e); err != nil {
+		t.Fatal(err)
+	}
+	drainCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := bus.Drain(drainCtx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"event":"user.registered"}`)
+	signature := Sign("secret", body)
+
+	// HMAC-SHA256("secret", body), hex encoded
+	want := "sha256=1cd0095f2e0b206f4e87e12c8d2f8d9c6ebae0f5a63aa5a63bcbbb66b772d30e"
+	if signature != want {
+		t.Fatalf("Sign() = %q, want %q", signature, want)
+	}
+	if !Verify("secret", body, signature) {
+		t.Error("Verify() rejected its own signature")
+	}
+	if Verify("other", body, signature) {
+		t.Error("Verify() accepted a signature made with another secret")
+	}
+	if Verify("secret", []byte(`{"event":"user.deleted"}`), signature) {
+		t.Error("Verify() accepted a signature for another body")
+	}
+}
+
+func TestRegistry_RegisterValidates(t *testing.T) {
+	registry := setupRegistry(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		event, url, secret string
+		want               error
+	}{
+		{"", "https://example.com/hook", "s", ErrInvalidEvent},
+		{"user..registered", "https://example.com/hook", "s", ErrInvalidEvent},
+		{"user.*", "ftp://example.com/hook", "s", ErrInvalidURL},
+		{"user.*", "/hook", "s", ErrInvalidURL},
+		{"user.*", "https://example.com/hook", "", ErrEmptySecret},
+	}
+	for _, tc := range cases {
+		if _, err := registry.Register(ctx, tc.event, tc.url, tc.secret); !errors.Is(err, tc.want) {
+			t.Errorf("Register(%q, %q) error = %v, want %v", tc.event, tc.url, err, tc.want)
+		}
+	}
+}
+
+func TestRegistry_MatchDecryptsSecrets(t *testing.T) {
+	registry := setupRegistry(t)
+	ctx := context.Background()
+
+	all, _ := registry.Register(ctx, "user.*", "https://example.com/users", "users-secret")
+	registry.Register(ctx, "order.created", "https://example.com/orders", "orders-secret")
+	paused, _ := registry.Register(ctx, "**", "https://example.com/paused", "paused-secret")
+	if err := registry.SetActive(ctx, paused.ID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if all.Secret == "users-secret" {
+		t.Error("secret was stored in plain text")
+	}
+
+	matched, err := registry.Match(ctx, "user.registered")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].ID != all.ID {
+		t.Fatalf("Match() = %+v, want only endpoint %d", matched, all.ID)
+	}
+	if matched[0].Secret != "users-secret" {
+		t.Errorf("Secret = %q, want the decrypted secret", matched[0].Secret)
+	}
+}
+
+func TestListener_DeliversSignedPayload(t *testing.T) {
+	registry := setupRegistry(t)
+
+	var (
+		mu      sync.Mutex
+		body    []byte
+		headers http.Header
+	)
+	received := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		body, _ = io.ReadAll(r.Body)
+		headers = r.Header.Clone()
+		mu.Unlock()
+		received <- struct{}{}
+	}))
+	defer server.Close()
+
+	if _, err := registry.Register(context.Background(), "user.registered", server.URL, "s3cret"); err != nil {
+		t.Fatal(err)
+	}
+
+	manager, worker := newMemoryQueue(t)
+	publish(t, NewListener(registry, manager), domain.NewUserRegisteredEvent(42, "alice", "alice@example.com"))
+
+	worker.Start(context.Background())
+	defer worker.Stop()
+
+	select {
+	case <-received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("webhook was not delivered")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !Verify("s3cret", body, headers.Get(SignatureHeader)) {
+		t.Errorf("%s = %q does not sign the body", SignatureHeader, headers.Get(SignatureHeader))
+	}
+	if headers.Get(EventHeader) != "user.registered" || headers.Get(DeliveryHeader) == "" {
+		t.Errorf("event headers = %q / %q", headers.Get(EventHeader), headers.Get(DeliveryHeader))
+	}
+
+	var payload struct {
+		ID    string         `json:"id"`
+		Event string         `json:"event"`
+		Data  map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Event != "user.registered" || payload.ID != headers.Get(DeliveryHeader) {
+		t.Errorf("payload = %+v", payload)
+	}
+	if payload.Data["UserID"] != 42.0 || payload.Data["Email"] != "alice@example.com" {
+		t.Errorf("data = %v, want the event fields", payload.Data)
+	}
+}
+
+func TestListener_RetriesServerErrors(t *testing.T) {
+	registry := setupRegistry(t)
+
+	var hits atomic.Int32
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		close(done)
+	}))
+	defer server.Close()
+
+	if _, err := registry.Register(context.Background(), "user.*", server.URL, "s3cret"); err != nil {
+		t.Fatal(err)
+	}
+
+	manager, worker := newMemoryQueue(t)
+	publish(t, NewListener(registry, manager), domain.NewUserRegisteredEvent(42, "alice", "alice@example.com"))
+
+	worker.Start(context.Background())
+	defer worker.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("delivery attempted %d times, want 3", hits.Load())
+	}
+
+	// Give a wrongly re-queued job the chance to show up
+	time.Sleep(50 * time.Millisecond)
+	if n := hits.Load(); n != 3 {
+		t.Errorf("delivery attempted %d times, want 3", n)
+	}
+}
+
+func TestDelivery_ClientErrorsAreFinal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	registry := setupRegistry(t)
+	SetRegistry(registry)
+	ctx := context.Background()
+	gone, _ := registry.Register(ctx, "user.*", server.URL, "s")
+	down, _ := registry.Register(ctx, "user.*", "http://127.0.0.1:1", "s")
+
+	d := &Delivery{EndpointID: gone.ID, Event: "user.registered", Payload: json.RawMessage(`{}`)}
+	if err := d.Handle(ctx); err != nil {
+		t.Errorf("Handle() = %v, want nil for a 4xx response", err)
+	}
+
+	d.EndpointID = down.ID
+	if err := d.Handle(ctx); err == nil {
+		t.Error("Handle() = nil, want an error when the endpoint is unreachable")
+	}
+}
+
+func TestDelivery_KeepsSecretOutOfQueue(t *testing.T) {
+	registry := setupRegistry(t)
+	if _, err := registry.Register(context.Background(), "user.*", "https://example.com/hook", "s3cret"); err != nil {
+		t.Fatal(err)
+	}
+
+	manager, _ := newMemoryQueue(t)
+	publish(t, NewListener(registry, manager), domain.NewUserRegisteredEvent(42, "alice", "alice@example.com"))
+
+	job, err := manager.DefaultDriver().Pop(context.Background(), "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(job), "s3cret") || strings.Contains(string(job), "example.com/hook") {
+		t.Errorf("queued job %s carries the endpoint's secret or URL", job)
+	}
+}
+
+func TestDelivery_DropsRemovedEndpoint(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer server.Close()
+
+	registry := setupRegistry(t)
+	SetRegistry(registry)
+	ctx := context.Background()
+	removed, _ := registry.Register(ctx, "user.*", server.URL, "s")
+	paused, _ := registry.Register(ctx, "user.*", server.URL, "s")
+	if err := registry.Remove(ctx, removed.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.SetActive(ctx, paused.ID, false); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []uint{removed.ID, paused.ID} {
+		d := &Delivery{EndpointID: id, Event: "user.registered", Payload: json.RawMessage(`{}`)}
+		if err := d.Handle(ctx); err != nil {
+			t.Errorf("Handle() = %v, want nil so the job is not retried", err)
+		}
+	}
+	if n := hits.Load(); n != 0 {
+		t.Errorf("endpoint was called %d times, want 0", n)
+	}
+}
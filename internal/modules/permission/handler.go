@@ -6,6 +6,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/zgiai/zgo/internal/contracts"
+	"github.com/zgiai/zgo/internal/infra/container"
+	"github.com/zgiai/zgo/pkg/pagination"
 	"github.com/zgiai/zgo/pkg/response"
 )
 
@@ -25,6 +27,13 @@ func (h *Handler) Name() string {
 	return "permission"
 }
 
+// Init registers the permission service in the container for code outside
+// the request cycle, such as console commands
+func (h *Handler) Init() error {
+	container.App().Set(container.ServicePermission, h.service)
+	return nil
+}
+
 // CreateRole creates a new role
 // @Summary Create a new role
 // @Tags Roles
@@ -72,20 +81,29 @@ func (h *Handler) GetRole(c *gin.Context) {
 	c.JSON(http.StatusOK, role)
 }
 
-// ListRoles lists all roles
-// @Summary List all roles
+// ListRoles lists roles page by page
+// @Summary List roles
 // @Tags Roles
 // @Produce json
+// @Param page query int false "Page number"
+// @Param per_page query int false "Roles per page"
+// @Param keyword query string false "Filter by name or display name"
 // @Success 200 {array} RoleResponse
 // @Router /api/v1/roles [get]
 func (h *Handler) ListRoles(c *gin.Context) {
-	roles, err := h.service.ListRoles(c.Request.Context())
+	req := pagination.FromContext(c)
+
+	roles, total, err := h.service.ListRoles(c.Request.Context(), req.GetPage(), req.GetPerPage(), req.Keyword)
 	if err != nil {
 		response.InternalServerError(c, "Failed to list roles", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, roles)
+	paginator := pagination.NewPaginator(roles, total, req.GetPage(), req.GetPerPage())
+	paginator.SetPath(c.Request.URL.Path)
+	paginator.WithQuery(c.Request.URL.Query())
+
+	response.Success(c, paginator)
 }
 
 // UpdateRole updates a role
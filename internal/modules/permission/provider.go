@@ -2,13 +2,28 @@ package permission
 
 import (
 	"github.com/google/wire"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/cache"
+	"github.com/zgiai/zgo/internal/infra/config"
 )
 
 // ProviderSet is the provider set for the permission module
 var ProviderSet = wire.NewSet(
 	NewRepository,
 	wire.Bind(new(Repository), new(*repository)),
-	NewService,
+	ProvideService,
 	wire.Bind(new(Service), new(*service)),
+	wire.Bind(new(domain.RoleAssigner), new(*service)),
+	wire.Bind(new(domain.Authorizer), new(*service)),
 	NewHandler,
 )
+
+// ProvideService creates the permission service, caching effective
+// permissions in the default cache store when cfg.Cache.PermissionTTL is set
+func ProvideService(cfg *config.Config, repo Repository) *service {
+	svc := NewService(repo)
+	if cfg.Cache.PermissionTTL > 0 {
+		svc.WithCache(cache.Default(), cfg.Cache.PermissionTTL)
+	}
+	return svc
+}
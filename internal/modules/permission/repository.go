@@ -3,6 +3,10 @@ package permission
 import (
 	"context"
 
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/pkg/pagination"
+	baserepo "github.com/zgiai/zgo/pkg/repository"
 	"gorm.io/gorm"
 )
 
@@ -14,7 +18,7 @@ type Repository interface {
 	DeleteRole(ctx context.Context, id uint) error
 	FindRoleByID(ctx context.Context, id uint) (*Role, error)
 	FindRoleByName(ctx context.Context, name string) (*Role, error)
-	FindAllRoles(ctx context.Context) ([]*Role, error)
+	PaginateRoles(ctx context.Context, page, pageSize int, keyword string) ([]*Role, int64, error)
 	FindDefaultRole(ctx context.Context) (*Role, error)
 
 	// Permission operations
@@ -31,7 +35,9 @@ type Repository interface {
 	AssignRoleToUser(ctx context.Context, userID, roleID uint) error
 	RemoveRoleFromUser(ctx context.Context, userID, roleID uint) error
 	FindRolesByUserID(ctx context.Context, userID uint) ([]*Role, error)
+	FindPermissionsByUserID(ctx context.Context, userID uint) ([]*Permission, error)
 	HasPermission(ctx context.Context, userID uint, permissionName string) (bool, error)
+	UserExists(ctx context.Context, userID uint) (bool, error)
 }
 
 // RepositoryImpl implements the Repository interface
@@ -46,24 +52,25 @@ func NewRepository(db *gorm.DB) *repository {
 
 // CreateRole creates a new role
 func (r *repository) CreateRole(ctx context.Context, role *Role) error {
-	return r.db.WithContext(ctx).Create(role).Error
+	return database.Conn(ctx, r.db).Create(role).Error
 }
 
 // UpdateRole updates an existing role
 func (r *repository) UpdateRole(ctx context.Context, role *Role) error {
-	return r.db.WithContext(ctx).Save(role).Error
+	return database.Conn(ctx, r.db).Save(role).Error
 }
 
 // DeleteRole deletes a role by ID
 func (r *repository) DeleteRole(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&Role{}, id).Error
+	return database.Conn(ctx, r.db).Delete(&Role{}, id).Error
 }
 
-// FindRoleByID finds a role by ID
+// FindRoleByID finds a role by ID.
+// Role finders return domain.ErrRoleNotFound when no role matches.
 func (r *repository) FindRoleByID(ctx context.Context, id uint) (*Role, error) {
 	var role Role
-	if err := r.db.WithContext(ctx).First(&role, id).Error; err != nil {
-		return nil, err
+	if err := database.Conn(ctx, r.db).First(&role, id).Error; err != nil {
+		return nil, baserepo.NotFound(err, domain.ErrRoleNotFound)
 	}
 	return &role, nil
 }
@@ -71,39 +78,47 @@ func (r *repository) FindRoleByID(ctx context.Context, id uint) (*Role, error) {
 // FindRoleByName finds a role by name
 func (r *repository) FindRoleByName(ctx context.Context, name string) (*Role, error) {
 	var role Role
-	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&role).Error; err != nil {
-		return nil, err
+	if err := database.Conn(ctx, r.db).Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, baserepo.NotFound(err, domain.ErrRoleNotFound)
 	}
 	return &role, nil
 }
 
-// FindAllRoles returns all roles
-func (r *repository) FindAllRoles(ctx context.Context) ([]*Role, error) {
-	var roles []*Role
-	if err := r.db.WithContext(ctx).Find(&roles).Error; err != nil {
-		return nil, err
+// PaginateRoles returns one page of roles ordered by ID and the total count.
+// A non-empty keyword matches name or display name.
+func (r *repository) PaginateRoles(ctx context.Context, page, pageSize int, keyword string) ([]*Role, int64, error) {
+	query := database.Conn(ctx, r.db).Model(&Role{}).Order("id")
+	if keyword != "" {
+		like := "%" + keyword + "%"
+		query = query.Where("name LIKE ? OR display_name LIKE ?", like, like)
+	}
+
+	roles, paginator, err := pagination.Paginate[*Role](query, pagination.NewRequest(page, pageSize))
+	if err != nil {
+		return nil, 0, err
 	}
-	return roles, nil
+	return roles, paginator.Total(), nil
 }
 
-// FindDefaultRole returns the default role
+// FindDefaultRole returns the default role, or domain.ErrRoleNotFound when
+// none is marked default
 func (r *repository) FindDefaultRole(ctx context.Context) (*Role, error) {
 	var role Role
-	if err := r.db.WithContext(ctx).Where("is_default = ?", true).First(&role).Error; err != nil {
-		return nil, err
+	if err := database.Conn(ctx, r.db).Where("is_default = ?", true).First(&role).Error; err != nil {
+		return nil, baserepo.NotFound(err, domain.ErrRoleNotFound)
 	}
 	return &role, nil
 }
 
 // CreatePermission creates a new permission
 func (r *repository) CreatePermission(ctx context.Context, perm *Permission) error {
-	return r.db.WithContext(ctx).Create(perm).Error
+	return database.Conn(ctx, r.db).Create(perm).Error
 }
 
 // FindAllPermissions returns all permissions
 func (r *repository) FindAllPermissions(ctx context.Context) ([]*Permission, error) {
 	var perms []*Permission
-	if err := r.db.WithContext(ctx).Find(&perms).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Find(&perms).Error; err != nil {
 		return nil, err
 	}
 	return perms, nil
@@ -112,7 +127,7 @@ func (r *repository) FindAllPermissions(ctx context.Context) ([]*Permission, err
 // FindPermissionsByModule returns permissions by module
 func (r *repository) FindPermissionsByModule(ctx context.Context, module string) ([]*Permission, error) {
 	var perms []*Permission
-	if err := r.db.WithContext(ctx).Where("module = ?", module).Find(&perms).Error; err != nil {
+	if err := database.Conn(ctx, r.db).Where("module = ?", module).Find(&perms).Error; err != nil {
 		return nil, err
 	}
 	return perms, nil
@@ -121,18 +136,18 @@ func (r *repository) FindPermissionsByModule(ctx context.Context, module string)
 // AssignPermissionToRole assigns a permission to a role
 func (r *repository) AssignPermissionToRole(ctx context.Context, roleID, permissionID uint) error {
 	rp := &RolePermission{RoleID: roleID, PermissionID: permissionID}
-	return r.db.WithContext(ctx).FirstOrCreate(rp, rp).Error
+	return database.Conn(ctx, r.db).FirstOrCreate(rp, rp).Error
 }
 
 // RemovePermissionFromRole removes a permission from a role
 func (r *repository) RemovePermissionFromRole(ctx context.Context, roleID, permissionID uint) error {
-	return r.db.WithContext(ctx).Where("role_id = ? AND permission_id = ?", roleID, permissionID).Delete(&RolePermission{}).Error
+	return database.Conn(ctx, r.db).Where("role_id = ? AND permission_id = ?", roleID, permissionID).Delete(&RolePermission{}).Error
 }
 
 // FindPermissionsByRoleID returns permissions for a role
 func (r *repository) FindPermissionsByRoleID(ctx context.Context, roleID uint) ([]*Permission, error) {
 	var perms []*Permission
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
 		Where("role_permissions.role_id = ?", roleID).
 		Find(&perms).Error
@@ -142,28 +157,40 @@ func (r *repository) FindPermissionsByRoleID(ctx context.Context, roleID uint) (
 // AssignRoleToUser assigns a role to a user
 func (r *repository) AssignRoleToUser(ctx context.Context, userID, roleID uint) error {
 	ur := &UserRole{UserID: userID, RoleID: roleID}
-	return r.db.WithContext(ctx).FirstOrCreate(ur, ur).Error
+	return database.Conn(ctx, r.db).FirstOrCreate(ur, ur).Error
 }
 
 // RemoveRoleFromUser removes a role from a user
 func (r *repository) RemoveRoleFromUser(ctx context.Context, userID, roleID uint) error {
-	return r.db.WithContext(ctx).Where("user_id = ? AND role_id = ?", userID, roleID).Delete(&UserRole{}).Error
+	return database.Conn(ctx, r.db).Where("user_id = ? AND role_id = ?", userID, roleID).Delete(&UserRole{}).Error
 }
 
 // FindRolesByUserID returns roles for a user
 func (r *repository) FindRolesByUserID(ctx context.Context, userID uint) ([]*Role, error) {
 	var roles []*Role
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
 		Where("user_roles.user_id = ?", userID).
 		Find(&roles).Error
 	return roles, err
 }
 
+// FindPermissionsByUserID returns the permissions granted to a user through their roles
+func (r *repository) FindPermissionsByUserID(ctx context.Context, userID uint) ([]*Permission, error) {
+	var perms []*Permission
+	err := database.Conn(ctx, r.db).
+		Distinct("permissions.*").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Find(&perms).Error
+	return perms, err
+}
+
 // HasPermission checks if a user has a specific permission
 func (r *repository) HasPermission(ctx context.Context, userID uint, permissionName string) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Table("permissions").
 		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
 		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
@@ -171,3 +198,13 @@ func (r *repository) HasPermission(ctx context.Context, userID uint, permissionN
 		Count(&count).Error
 	return count > 0, err
 }
+
+// UserExists reports whether a user that is not soft-deleted has the given ID
+func (r *repository) UserExists(ctx context.Context, userID uint) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).
+		Table("users").
+		Where("id = ? AND deleted_at IS NULL", userID).
+		Count(&count).Error
+	return count > 0, err
+}
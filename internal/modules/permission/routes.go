@@ -9,7 +9,7 @@ import (
 func (h *Handler) RegisterRoutes(r *router.Router) {
 	// Role routes (admin only)
 	r.Group("", func(auth *router.Router) {
-		auth.WithMiddleware("auth")
+		auth.WithMiddleware("auth", "role:admin")
 
 		// Role management
 		auth.POST("/roles", h.CreateRole).Name("roles.store")
@@ -3,6 +3,15 @@ package permission
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/cache"
 )
 
 // Service defines the interface for permission operations
@@ -12,7 +21,7 @@ type Service interface {
 	UpdateRole(ctx context.Context, id uint, req *UpdateRoleRequest) (*RoleResponse, error)
 	DeleteRole(ctx context.Context, id uint) error
 	GetRole(ctx context.Context, id uint) (*RoleResponse, error)
-	ListRoles(ctx context.Context) ([]*RoleResponse, error)
+	ListRoles(ctx context.Context, page, pageSize int, keyword string) ([]*RoleResponse, int64, error)
 
 	// User role management
 	AssignRoleToUser(ctx context.Context, userID, roleID uint) error
@@ -21,6 +30,7 @@ type Service interface {
 
 	// Permission checking
 	HasPermission(ctx context.Context, userID uint, permission string) (bool, error)
+	EffectivePermissions(ctx context.Context, userID uint) ([]string, error)
 	GetRolePermissions(ctx context.Context, roleID uint) ([]*PermissionResponse, error)
 
 	// Permission management
@@ -32,6 +42,12 @@ type Service interface {
 // ServiceImpl implements the Service interface
 type service struct {
 	repo Repository
+
+	// Optional cache of effective permissions, see WithCache
+	cache    cache.Store
+	cacheTTL time.Duration
+	// generation is bumped when role grants change, orphaning every cached set
+	generation atomic.Uint64
 }
 
 // NewService creates a new permission service
@@ -39,6 +55,15 @@ func NewService(repo Repository) *service {
 	return &service{repo: repo}
 }
 
+// WithCache caches effective permissions in store for ttl.
+// Changes made through this service invalidate the cache immediately;
+// changes made elsewhere (or by other instances) show up within ttl.
+func (s *service) WithCache(store cache.Store, ttl time.Duration) *service {
+	s.cache = store
+	s.cacheTTL = ttl
+	return s
+}
+
 // CreateRole creates a new role
 func (s *service) CreateRole(ctx context.Context, req *CreateRoleRequest) (*RoleResponse, error) {
 	role := &Role{
@@ -59,7 +84,7 @@ func (s *service) CreateRole(ctx context.Context, req *CreateRoleRequest) (*Role
 func (s *service) UpdateRole(ctx context.Context, id uint, req *UpdateRoleRequest) (*RoleResponse, error) {
 	role, err := s.repo.FindRoleByID(ctx, id)
 	if err != nil {
-		return nil, errors.New("role not found")
+		return nil, err
 	}
 
 	if req.Name != "" {
@@ -81,7 +106,11 @@ func (s *service) UpdateRole(ctx context.Context, id uint, req *UpdateRoleReques
 
 // DeleteRole deletes a role
 func (s *service) DeleteRole(ctx context.Context, id uint) error {
-	return s.repo.DeleteRole(ctx, id)
+	if err := s.repo.DeleteRole(ctx, id); err != nil {
+		return err
+	}
+	s.invalidatePermissions()
+	return nil
 }
 
 // GetRole gets a role by ID
@@ -93,28 +122,49 @@ func (s *service) GetRole(ctx context.Context, id uint) (*RoleResponse, error) {
 	return toRoleResponse(role), nil
 }
 
-// ListRoles lists all roles
-func (s *service) ListRoles(ctx context.Context) ([]*RoleResponse, error) {
-	roles, err := s.repo.FindAllRoles(ctx)
+// ListRoles lists one page of roles, optionally filtered by keyword
+func (s *service) ListRoles(ctx context.Context, page, pageSize int, keyword string) ([]*RoleResponse, int64, error) {
+	roles, total, err := s.repo.PaginateRoles(ctx, page, pageSize, keyword)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	var responses []*RoleResponse
+	responses := make([]*RoleResponse, 0, len(roles))
 	for _, r := range roles {
 		responses = append(responses, toRoleResponse(r))
 	}
-	return responses, nil
+	return responses, total, nil
 }
 
 // AssignRoleToUser assigns a role to a user
 func (s *service) AssignRoleToUser(ctx context.Context, userID, roleID uint) error {
-	return s.repo.AssignRoleToUser(ctx, userID, roleID)
+	if err := s.repo.AssignRoleToUser(ctx, userID, roleID); err != nil {
+		return err
+	}
+	s.invalidatePermissions()
+	return nil
+}
+
+// AssignDefaultRole assigns the default role to a user, if one is configured.
+// It implements domain.RoleAssigner.
+func (s *service) AssignDefaultRole(ctx context.Context, userID uint) error {
+	role, err := s.repo.FindDefaultRole(ctx)
+	if errors.Is(err, domain.ErrRoleNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.repo.AssignRoleToUser(ctx, userID, role.ID)
 }
 
 // RemoveRoleFromUser removes a role from a user
 func (s *service) RemoveRoleFromUser(ctx context.Context, userID, roleID uint) error {
-	return s.repo.RemoveRoleFromUser(ctx, userID, roleID)
+	if err := s.repo.RemoveRoleFromUser(ctx, userID, roleID); err != nil {
+		return err
+	}
+	s.invalidatePermissions()
+	return nil
 }
 
 // GetUserRoles gets all roles for a user
@@ -131,9 +181,98 @@ func (s *service) GetUserRoles(ctx context.Context, userID uint) ([]*RoleRespons
 	return responses, nil
 }
 
-// HasPermission checks if a user has a specific permission
+// HasPermission checks if a user has a specific permission.
+// Returns domain.ErrUserNotFound for unknown users.
 func (s *service) HasPermission(ctx context.Context, userID uint, permission string) (bool, error) {
-	return s.repo.HasPermission(ctx, userID, permission)
+	perms, err := s.EffectivePermissions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	_, found := slices.BinarySearch(perms, permission)
+	return found, nil
+}
+
+// EffectivePermissions returns the sorted names of all permissions a user
+// holds through their roles. Users without roles get an empty set; unknown
+// users get domain.ErrUserNotFound.
+func (s *service) EffectivePermissions(ctx context.Context, userID uint) ([]string, error) {
+	if s.cache == nil {
+		return s.loadEffectivePermissions(ctx, userID)
+	}
+
+	key := fmt.Sprintf("permissions:user:%d:g%d", userID, s.generation.Load())
+	val, err := cache.RememberStore(ctx, s.cache, key, s.cacheTTL, func() (interface{}, error) {
+		perms, err := s.loadEffectivePermissions(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		return strings.Join(perms, "\n"), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	joined, _ := val.(string)
+	if joined == "" {
+		return []string{}, nil
+	}
+	return strings.Split(joined, "\n"), nil
+}
+
+// loadEffectivePermissions reads a user's permissions from the repository
+func (s *service) loadEffectivePermissions(ctx context.Context, userID uint) ([]string, error) {
+	exists, err := s.repo.UserExists(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domain.ErrUserNotFound
+	}
+
+	perms, err := s.repo.FindPermissionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(perms))
+	for _, p := range perms {
+		names = append(names, p.Name)
+	}
+	sort.Strings(names)
+	return slices.Compact(names), nil
+}
+
+// invalidatePermissions drops every cached permission set
+func (s *service) invalidatePermissions() {
+	s.generation.Add(1)
+}
+
+// UserRoleNames returns the names of a user's roles
+func (s *service) UserRoleNames(ctx context.Context, userID uint) ([]string, error) {
+	roles, err := s.repo.FindRolesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		names = append(names, r.Name)
+	}
+	return names, nil
+}
+
+// UserPermissionNames returns the names of all permissions a user holds through their roles
+func (s *service) UserPermissionNames(ctx context.Context, userID uint) ([]string, error) {
+	perms, err := s.repo.FindPermissionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(perms))
+	for _, p := range perms {
+		names = append(names, p.Name)
+	}
+	return names, nil
 }
 
 // GetRolePermissions gets all permissions for a role
@@ -152,12 +291,20 @@ func (s *service) GetRolePermissions(ctx context.Context, roleID uint) ([]*Permi
 
 // AssignPermissionToRole assigns a permission to a role
 func (s *service) AssignPermissionToRole(ctx context.Context, roleID, permissionID uint) error {
-	return s.repo.AssignPermissionToRole(ctx, roleID, permissionID)
+	if err := s.repo.AssignPermissionToRole(ctx, roleID, permissionID); err != nil {
+		return err
+	}
+	s.invalidatePermissions()
+	return nil
 }
 
 // RemovePermissionFromRole removes a permission from a role
 func (s *service) RemovePermissionFromRole(ctx context.Context, roleID, permissionID uint) error {
-	return s.repo.RemovePermissionFromRole(ctx, roleID, permissionID)
+	if err := s.repo.RemovePermissionFromRole(ctx, roleID, permissionID); err != nil {
+		return err
+	}
+	s.invalidatePermissions()
+	return nil
 }
 
 // ListPermissions lists all permissions
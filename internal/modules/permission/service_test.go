@@ -0,0 +1,174 @@
+package permission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/cache"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"gorm.io/gorm"
+)
+
+// testUser stands in for the user module's table
+type testUser struct {
+	ID        uint
+	DeletedAt gorm.DeletedAt
+}
+
+func (testUser) TableName() string {
+	return "users"
+}
+
+// setupPermissionDB seeds admin (users:read, users:write), user (users:read)
+// and guest (no permissions) roles, plus users 1 (admin+user), 2 (guest) and 3 (no roles)
+func setupPermissionDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := dbtest.Open(t, &testUser{}, &Role{}, &Permission{}, &RolePermission{}, &UserRole{})
+
+	roles := []*Role{{Name: "admin"}, {Name: "user", IsDefault: true}, {Name: "guest"}}
+	perms := []*Permission{{Name: "users:read"}, {Name: "users:write"}}
+	require.NoError(t, db.Create(roles).Error)
+	require.NoError(t, db.Create(perms).Error)
+	require.NoError(t, db.Create([]*RolePermission{
+		{RoleID: roles[0].ID, PermissionID: perms[0].ID},
+		{RoleID: roles[0].ID, PermissionID: perms[1].ID},
+		{RoleID: roles[1].ID, PermissionID: perms[0].ID},
+	}).Error)
+	require.NoError(t, db.Create([]*testUser{{ID: 1}, {ID: 2}, {ID: 3}}).Error)
+	require.NoError(t, db.Create([]*UserRole{
+		{UserID: 1, RoleID: roles[0].ID},
+		{UserID: 1, RoleID: roles[1].ID},
+		{UserID: 2, RoleID: roles[2].ID},
+	}).Error)
+	return db
+}
+
+func TestEffectivePermissions(t *testing.T) {
+	svc := NewService(NewRepository(setupPermissionDB(t)))
+	ctx := context.Background()
+
+	perms, err := svc.EffectivePermissions(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users:read", "users:write"}, perms)
+
+	// A role without permissions and no roles at all both yield an empty set
+	for _, id := range []uint{2, 3} {
+		perms, err := svc.EffectivePermissions(ctx, id)
+		require.NoError(t, err)
+		assert.Empty(t, perms)
+	}
+
+	_, err = svc.EffectivePermissions(ctx, 99)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestHasPermission(t *testing.T) {
+	svc := NewService(NewRepository(setupPermissionDB(t)))
+	ctx := context.Background()
+
+	ok, err := svc.HasPermission(ctx, 1, "users:write")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = svc.HasPermission(ctx, 2, "users:read")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = svc.HasPermission(ctx, 99, "users:read")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestEffectivePermissions_CachedUntilGrantsChange(t *testing.T) {
+	db := setupPermissionDB(t)
+	store := cache.NewMemoryStore()
+	t.Cleanup(store.Close)
+	svc := NewService(NewRepository(db)).WithCache(store, time.Minute)
+	ctx := context.Background()
+
+	perms, err := svc.EffectivePermissions(ctx, 3)
+	require.NoError(t, err)
+	assert.Empty(t, perms)
+
+	// Writes behind the service's back are served stale from the cache
+	var user Role
+	require.NoError(t, db.Where("name = ?", "user").First(&user).Error)
+	require.NoError(t, db.Create(&UserRole{UserID: 3, RoleID: user.ID}).Error)
+	perms, err = svc.EffectivePermissions(ctx, 3)
+	require.NoError(t, err)
+	assert.Empty(t, perms)
+
+	// Writes through the service invalidate it
+	var guest Role
+	require.NoError(t, db.Where("name = ?", "guest").First(&guest).Error)
+	require.NoError(t, svc.AssignRoleToUser(ctx, 3, guest.ID))
+	perms, err = svc.EffectivePermissions(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users:read"}, perms)
+}
+
+func TestListRoles(t *testing.T) {
+	db := setupPermissionDB(t)
+	require.NoError(t, db.Create([]*Role{
+		{Name: "editor", DisplayName: "Content Editor"},
+		{Name: "reviewer", DisplayName: "Content Reviewer"},
+	}).Error)
+	svc := NewService(NewRepository(db))
+	ctx := context.Background()
+
+	roles, total, err := svc.ListRoles(ctx, 1, 2, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	require.Len(t, roles, 2)
+	assert.Equal(t, "admin", roles[0].Name)
+	assert.Equal(t, "user", roles[1].Name)
+
+	// The last page holds the remainder, past it is empty
+	roles, _, err = svc.ListRoles(ctx, 3, 2, "")
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, "reviewer", roles[0].Name)
+
+	roles, total, err = svc.ListRoles(ctx, 4, 2, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Empty(t, roles)
+
+	// Keywords match the name or the display name
+	roles, total, err = svc.ListRoles(ctx, 1, 10, "content")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, roles, 2)
+
+	roles, total, err = svc.ListRoles(ctx, 1, 10, "adm")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, roles, 1)
+	assert.Equal(t, "admin", roles[0].Name)
+}
+
+func TestRoleFindersReturnRoleNotFound(t *testing.T) {
+	db := setupPermissionDB(t)
+	repo := NewRepository(db)
+	svc := NewService(repo)
+	ctx := context.Background()
+
+	_, err := repo.FindRoleByID(ctx, 999)
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	_, err = repo.FindRoleByName(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+
+	_, err = svc.GetRole(ctx, 999)
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	_, err = svc.UpdateRole(ctx, 999, &UpdateRoleRequest{Name: "renamed"})
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+
+	// Without a default role, new users simply get none
+	require.NoError(t, db.Model(&Role{}).Where("is_default = ?", true).Update("is_default", false).Error)
+	_, err = repo.FindDefaultRole(ctx)
+	assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	assert.NoError(t, svc.AssignDefaultRole(ctx, 3))
+}
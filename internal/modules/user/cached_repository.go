@@ -0,0 +1,115 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/cache"
+)
+
+// cachedRepository decorates a domain.UserRepository with cache-aside
+// lookups for FindByID. Writes to a user invalidate its cache entry.
+type cachedRepository struct {
+	domain.UserRepository
+	store cache.Store
+	ttl   time.Duration
+}
+
+// NewCachedRepository wraps repo so FindByID results are cached in store for ttl
+func NewCachedRepository(repo domain.UserRepository, store cache.Store, ttl time.Duration) *cachedRepository {
+	return &cachedRepository{
+		UserRepository: repo,
+		store:          store,
+		ttl:            ttl,
+	}
+}
+
+func userCacheKey(id uint) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+// FindByID returns the cached user, loading it from the wrapped repository on a miss
+func (r *cachedRepository) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	key := userCacheKey(id)
+	if val, err := r.store.Get(ctx, key); err == nil {
+		if user, err := decodeUser(val); err == nil {
+			return user, nil
+		}
+	}
+
+	user, err := r.UserRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// gob keeps every field, including the password hash that JSON hides
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(user); err == nil {
+		_ = r.store.Put(ctx, key, buf.Bytes(), r.ttl)
+	}
+	return user, nil
+}
+
+// Update modifies a user and invalidates its cache entry
+func (r *cachedRepository) Update(ctx context.Context, user *domain.User) error {
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		// A stale update means the cached copy may be outdated too
+		if errors.Is(err, domain.ErrStaleUpdate) {
+			_ = r.forget(ctx, user.ID)
+		}
+		return err
+	}
+	return r.forget(ctx, user.ID)
+}
+
+// Delete soft-deletes a user and invalidates its cache entry
+func (r *cachedRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.UserRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.forget(ctx, id)
+}
+
+// Restore restores a user and invalidates its cache entry
+func (r *cachedRepository) Restore(ctx context.Context, id uint) error {
+	if err := r.UserRepository.Restore(ctx, id); err != nil {
+		return err
+	}
+	return r.forget(ctx, id)
+}
+
+// ForceDelete permanently deletes a user and invalidates its cache entry
+func (r *cachedRepository) ForceDelete(ctx context.Context, id uint) error {
+	if err := r.UserRepository.ForceDelete(ctx, id); err != nil {
+		return err
+	}
+	return r.forget(ctx, id)
+}
+
+func (r *cachedRepository) forget(ctx context.Context, id uint) error {
+	return r.store.Forget(ctx, userCacheKey(id))
+}
+
+// decodeUser accepts []byte from the memory store and string from Redis
+func decodeUser(val interface{}) (*domain.User, error) {
+	var data []byte
+	switch v := val.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return nil, fmt.Errorf("unexpected cached user type %T", val)
+	}
+
+	var user domain.User
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/cache"
+)
+
+// countingRepository is an in-memory UserRepository that counts FindByID calls
+type countingRepository struct {
+	domain.UserRepository
+	users     map[uint]*domain.User
+	findCalls int
+}
+
+func (r *countingRepository) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	r.findCalls++
+	u, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (r *countingRepository) Update(ctx context.Context, user *domain.User) error {
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+func newCachedTestRepository(t *testing.T) (*cachedRepository, *countingRepository) {
+	inner := &countingRepository{users: map[uint]*domain.User{
+		1: {ID: 1, Username: "alice", Password: "hashed", Status: 1},
+	}}
+	store := cache.NewMemoryStore()
+	t.Cleanup(store.Close)
+	return NewCachedRepository(inner, store, time.Minute), inner
+}
+
+func TestCachedRepository_FindByIDHitsCache(t *testing.T) {
+	repo, inner := newCachedTestRepository(t)
+	ctx := context.Background()
+
+	first, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, inner.findCalls, "second lookup should be served from cache")
+	assert.Equal(t, first, second)
+	assert.Equal(t, "hashed", second.Password, "cached user must keep the password hash")
+}
+
+func TestCachedRepository_UpdateInvalidates(t *testing.T) {
+	repo, inner := newCachedTestRepository(t)
+	ctx := context.Background()
+
+	user, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+
+	user.Nickname = "Alice"
+	require.NoError(t, repo.Update(ctx, user))
+
+	updated, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, inner.findCalls, "update should bust the cache")
+	assert.Equal(t, "Alice", updated.Nickname)
+}
+
+func TestCachedRepository_MissIsNotCached(t *testing.T) {
+	repo, inner := newCachedTestRepository(t)
+	ctx := context.Background()
+
+	_, err := repo.FindByID(ctx, 99)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	_, _ = repo.FindByID(ctx, 99)
+
+	assert.Equal(t, 2, inner.findCalls)
+}
@@ -29,6 +29,10 @@ type UserUpdateRequest struct {
 	Avatar   string `json:"avatar" binding:"max=255"`
 	Phone    string `json:"phone" binding:"max=20"`
 	Bio      string `json:"bio" binding:"max=500"`
+
+	// Version is the profile version the client last read. When given, the
+	// update is refused with 409 if the profile has changed since.
+	Version int `json:"version" binding:"omitempty,min=1"`
 }
 
 // UserChangePasswordRequest represents the password change request
@@ -42,14 +46,56 @@ type UserPasswordResetRequest struct {
 	Email string `json:"email" binding:"required,email"`
 }
 
+// UserResendVerificationRequest represents a request for a new verification email
+type UserResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// UserTwoFactorLoginRequest completes a login that returned a 2FA challenge
+type UserTwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// UserTwoFactorCodeRequest carries a code from the user's authenticator app
+type UserTwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
 // ============================================================================
 // Response DTOs (Output)
 // ============================================================================
 
-// UserLoginResponse represents the login response
+// UserLoginResponse represents the login response.
+// Users with two-factor authentication get a challenge token instead of an
+// access token, to exchange at POST /v1/auth/2fa/verify with a code.
 type UserLoginResponse struct {
-	AccessToken string       `json:"access_token"`
-	User        *domain.User `json:"user"` // Domain直接输出，Password自动隐藏
+	AccessToken       string       `json:"access_token,omitempty"`
+	User              *domain.User `json:"user,omitempty"` // Domain直接输出，Password自动隐藏
+	TwoFactorRequired bool         `json:"2fa_required,omitempty"`
+	ChallengeToken    string       `json:"challenge_token,omitempty"`
+}
+
+// TwoFactorSetupResponse holds the secret to add to an authenticator app
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+}
+
+// ImportResult summarises a bulk user import
+type ImportResult struct {
+	Created int              `json:"created"`
+	Skipped int              `json:"skipped"`
+	Failed  int              `json:"failed"`
+	Errors  []ImportRowError `json:"errors"` // One entry per skipped or failed row
+}
+
+// ImportRowError explains why a row was not imported
+type ImportRowError struct {
+	Row    int    `json:"row"` // 1-based position in the batch
+	Email  string `json:"email"`
+	Status string `json:"status"` // "skipped" or "failed"
+	Error  string `json:"error"`
 }
 
 // ============================================================================
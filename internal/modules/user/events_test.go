@@ -0,0 +1,60 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/events"
+)
+
+// recordEvents subscribes to every user event on the service's bus and
+// returns a function listing the names received so far
+func recordEvents(t *testing.T, svc *service) func() []string {
+	t.Helper()
+	var (
+		mu    sync.Mutex
+		names []string
+	)
+	svc.eventBus.Subscribe("user.*", func(ctx context.Context, e events.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		names = append(names, e.EventName())
+		return nil
+	})
+
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), names...)
+	}
+}
+
+func TestRegister_DispatchesRegisteredEvent(t *testing.T) {
+	svc, _, _ := newVerificationService(t)
+	recorded := recordEvents(t, svc)
+
+	// A cancelled request context must not stop the listeners
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := svc.Register(ctx, &UserRegisterRequest{Username: "alice", Email: "alice@example.com", Password: "secret123"})
+	require.NoError(t, err)
+	cancel()
+
+	assert.Eventually(t, func() bool { return len(recorded()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.ElementsMatch(t, []string{"user.created", "user.registered"}, recorded())
+}
+
+func TestAccountChanges_DispatchEvents(t *testing.T) {
+	svc, _, _ := newVerificationService(t)
+	recorded := recordEvents(t, svc)
+	user := registerPending(t, svc)
+
+	require.NoError(t, svc.ChangePassword(context.Background(), user.ID, &UserChangePasswordRequest{OldPassword: "secret123", NewPassword: "newsecret123"}))
+	require.NoError(t, svc.DeleteAccount(context.Background(), user.ID))
+
+	assert.Eventually(t, func() bool { return len(recorded()) == 4 }, time.Second, 5*time.Millisecond)
+	assert.ElementsMatch(t, []string{"user.created", "user.registered", "user.password_changed", "user.deleted"}, recorded())
+}
@@ -1,12 +1,25 @@
 package user
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/zgiai/zgo/internal/contracts"
 	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/container"
 	"github.com/zgiai/zgo/internal/infra/events"
+	"github.com/zgiai/zgo/internal/infra/middleware"
+	"github.com/zgiai/zgo/internal/infra/session"
 	"github.com/zgiai/zgo/pkg/handler"
 	"github.com/zgiai/zgo/pkg/pagination"
+	"github.com/zgiai/zgo/pkg/query"
+	"github.com/zgiai/zgo/pkg/request"
+	"github.com/zgiai/zgo/pkg/resource"
 	"github.com/zgiai/zgo/pkg/response"
 )
 
@@ -26,6 +39,13 @@ func (h *Handler) Name() string {
 	return "user"
 }
 
+// Init registers the user service in the container for code outside the
+// request cycle, such as console commands
+func (h *Handler) Init() error {
+	container.App().Set(container.ServiceUser, h.service)
+	return nil
+}
+
 // RegisterEvents registers user module event listeners
 func (h *Handler) RegisterEvents(bus *events.EventBus) {
 	bus.Subscribe(domain.EventUserCreated, HandleUserCreated, events.WithAsync())
@@ -67,6 +87,117 @@ func (h *Handler) Login(c *gin.Context) {
 	response.Success(c, resp)
 }
 
+// LoginTwoFactor completes a login that Login answered with a 2FA challenge
+func (h *Handler) LoginTwoFactor(c *gin.Context) {
+	var req UserTwoFactorLoginRequest
+	if !handler.BindJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.LoginWithTwoFactor(c.Request.Context(), &req)
+	if err != nil {
+		response.HandleError(c, "Login failed", err)
+		return
+	}
+
+	response.Success(c, resp)
+}
+
+// SessionLogin signs the user in with a cookie session instead of a token
+func (h *Handler) SessionLogin(c *gin.Context) {
+	var req UserLoginRequest
+	if !handler.BindJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.Login(c.Request.Context(), &req)
+	if err != nil {
+		response.HandleError(c, "Login failed", err)
+		return
+	}
+
+	h.startSession(c, resp)
+}
+
+// SessionLoginTwoFactor completes a session login that SessionLogin
+// answered with a 2FA challenge
+func (h *Handler) SessionLoginTwoFactor(c *gin.Context) {
+	var req UserTwoFactorLoginRequest
+	if !handler.BindJSON(c, &req) {
+		return
+	}
+
+	resp, err := h.service.LoginWithTwoFactor(c.Request.Context(), &req)
+	if err != nil {
+		response.HandleError(c, "Login failed", err)
+		return
+	}
+
+	h.startSession(c, resp)
+}
+
+// startSession records a completed login in the session; 2FA challenges
+// are passed on as they are
+func (h *Handler) startSession(c *gin.Context, resp *UserLoginResponse) {
+	if resp.TwoFactorRequired {
+		response.Success(c, resp)
+		return
+	}
+
+	if err := session.Login(c, resp.User.ID, resp.User.Username); err != nil {
+		response.HandleError(c, "Login failed", err)
+		return
+	}
+
+	// The session cookie replaces the token
+	resp.AccessToken = ""
+	response.Success(c, resp)
+}
+
+// SessionLogout ends the cookie session
+func (h *Handler) SessionLogout(c *gin.Context) {
+	session.Logout(c)
+	response.Success(c, gin.H{"message": "Logged out successfully"})
+}
+
+// SessionCSRF returns the CSRF token that unsafe /session requests must
+// send in the X-CSRF-Token header
+func (h *Handler) SessionCSRF(c *gin.Context) {
+	response.Success(c, gin.H{"token": middleware.CSRFToken(c)})
+}
+
+// VerifyEmail activates an account from the link in the verification email
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		response.BadRequest(c, "Verification token is required")
+		return
+	}
+
+	if _, err := h.service.VerifyEmail(c.Request.Context(), token); err != nil {
+		response.HandleError(c, "Email verification failed", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Email verified successfully"})
+}
+
+// ResendVerification sends a new verification email. It answers 202 for
+// any address, so callers cannot probe which ones have accounts.
+func (h *Handler) ResendVerification(c *gin.Context) {
+	var req UserResendVerificationRequest
+	if !handler.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.service.ResendVerification(c.Request.Context(), &req); err != nil {
+		response.HandleError(c, "Failed to resend verification email", err)
+		return
+	}
+
+	response.Accepted(c, gin.H{"message": "If the account awaits verification, a new email is on its way"})
+}
+
 // ============================================================================
 // Profile (Authenticated User)
 // ============================================================================
@@ -84,7 +215,7 @@ func (h *Handler) GetProfile(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, user)
+	response.SuccessWithETag(c, user)
 }
 
 // UpdateProfile updates current user's profile
@@ -143,6 +274,46 @@ func (h *Handler) DeleteAccount(c *gin.Context) {
 	response.NoContent(c)
 }
 
+// ============================================================================
+// Two-Factor Authentication
+// ============================================================================
+
+// EnableTwoFactor generates a TOTP secret for the current user
+func (h *Handler) EnableTwoFactor(c *gin.Context) {
+	userID, ok := handler.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	secret, url, err := h.service.EnableTwoFactor(c.Request.Context(), userID)
+	if err != nil {
+		response.HandleError(c, "Failed to enable two-factor authentication", err)
+		return
+	}
+
+	response.Success(c, TwoFactorSetupResponse{Secret: secret, OTPAuthURL: url})
+}
+
+// ConfirmTwoFactor turns on two-factor authentication with a first valid code
+func (h *Handler) ConfirmTwoFactor(c *gin.Context) {
+	userID, ok := handler.GetUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UserTwoFactorCodeRequest
+	if !handler.BindJSON(c, &req) {
+		return
+	}
+
+	if err := h.service.ConfirmTwoFactor(c.Request.Context(), userID, req.Code); err != nil {
+		response.HandleError(c, "Failed to confirm two-factor authentication", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "Two-factor authentication enabled"})
+}
+
 // ============================================================================
 // Public
 // ============================================================================
@@ -179,14 +350,26 @@ func (h *Handler) Get(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, user)
+	resource.Respond(c, NewUserResource(user))
 }
 
-// List gets paginated user list
+// listFilterColumns are the user columns List and Export filter and sort on
+var listFilterColumns = []string{"status", "username", "email", "nickname", "created_at"}
+
+// List gets paginated user list, filtered by filter[field][op]=value params
 func (h *Handler) List(c *gin.Context) {
-	req := pagination.FromContext(c)
+	var q request.ListQuery
+	if !request.BindQuery(c, &q) {
+		return
+	}
+	req := q.Pagination()
+	spec, err := query.ParseFromQuery(c, listFilterColumns...)
+	if err != nil {
+		response.BadRequest(c, "Invalid filter", err)
+		return
+	}
 
-	users, total, err := h.service.List(c.Request.Context(), req.GetPage(), req.GetPerPage())
+	users, total, err := h.service.List(c.Request.Context(), spec, req.GetPage(), req.GetPerPage())
 	if err != nil {
 		response.HandleError(c, "Failed to get user list", err)
 		return
@@ -194,8 +377,199 @@ func (h *Handler) List(c *gin.Context) {
 
 	paginator := pagination.NewPaginator(users, total, req.GetPage(), req.GetPerPage())
 	paginator.SetPath(c.Request.URL.Path)
+	paginator.WithQuery(c.Request.URL.Query())
+
+	resource.RespondCollection(c, NewUserCollection(users, paginator))
+}
+
+// exportColumns are the user fields included in CSV exports, in order
+var exportColumns = []string{"id", "username", "email", "nickname", "phone", "status", "last_login", "created_at"}
+
+// exportPageSize is how many users Export loads per query
+const exportPageSize = 500
+
+// Export downloads every user matching the List filters as a CSV file
+func (h *Handler) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+	spec, err := query.ParseFromQuery(c, listFilterColumns...)
+	if err != nil {
+		response.BadRequest(c, "Invalid filter", err)
+		return
+	}
+
+	var items []*UserResource
+	for page := 1; ; page++ {
+		users, total, err := h.service.List(ctx, spec, page, exportPageSize)
+		if err != nil {
+			response.HandleError(c, "Failed to export users", err)
+			return
+		}
+		for _, u := range users {
+			items = append(items, NewUserResource(u))
+		}
+		if len(users) < exportPageSize || int64(len(items)) >= total {
+			break
+		}
+	}
+
+	response.CSV(c, "users.csv", exportColumns, resource.ToCSV(items, exportColumns))
+}
+
+// Import creates users in bulk from a JSON array of registration requests,
+// or from CSV sent as the body (text/csv) or as the "file" form field.
+// Duplicates are skipped and invalid rows reported without failing the batch.
+func (h *Handler) Import(c *gin.Context) {
+	reqs, err := readImportRows(c)
+	if err != nil {
+		response.BadRequest(c, "Invalid import file", err)
+		return
+	}
+
+	result, err := h.service.ImportUsers(c.Request.Context(), reqs)
+	if err != nil {
+		response.HandleError(c, "Failed to import users", err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// readImportRows decodes the import request according to its content type
+func readImportRows(c *gin.Context) ([]UserRegisterRequest, error) {
+	switch c.ContentType() {
+	case "text/csv":
+		return parseImportCSV(c.Request.Body)
+	case "multipart/form-data":
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		f, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return parseImportCSV(f)
+	default:
+		var reqs []UserRegisterRequest
+		if err := json.NewDecoder(c.Request.Body).Decode(&reqs); err != nil {
+			return nil, err
+		}
+		return reqs, nil
+	}
+}
+
+// parseImportCSV reads users from CSV with a header row naming the
+// username, password, email, nickname and phone columns in any order
+func parseImportCSV(r io.Reader) ([]UserRegisterRequest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("empty CSV")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"username", "password", "email"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
 
-	response.Success(c, paginator)
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var reqs []UserRegisterRequest
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return reqs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, UserRegisterRequest{
+			Username: field(record, "username"),
+			Password: field(record, "password"),
+			Email:    field(record, "email"),
+			Nickname: field(record, "nickname"),
+			Phone:    field(record, "phone"),
+		})
+	}
+}
+
+// Restore restores a soft-deleted user
+func (h *Handler) Restore(c *gin.Context) {
+	id, ok := handler.ParseID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RestoreAccount(c.Request.Context(), id); err != nil {
+		response.HandleError(c, "Failed to restore user", err)
+		return
+	}
+
+	response.Success(c, gin.H{"message": "User restored successfully"})
+}
+
+// Disable suspends a user account and revokes its tokens
+func (h *Handler) Disable(c *gin.Context) {
+	id, ok := handler.ParseID(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.service.DisableUser(c.Request.Context(), id)
+	if err != nil {
+		response.HandleError(c, "Failed to disable user", err)
+		return
+	}
+
+	resource.Respond(c, NewUserResource(user))
+}
+
+// Enable reactivates a disabled user account
+func (h *Handler) Enable(c *gin.Context) {
+	id, ok := handler.ParseID(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.service.EnableUser(c.Request.Context(), id)
+	if err != nil {
+		response.HandleError(c, "Failed to enable user", err)
+		return
+	}
+
+	resource.Respond(c, NewUserResource(user))
+}
+
+// ForceDelete permanently deletes a user
+func (h *Handler) ForceDelete(c *gin.Context) {
+	id, ok := handler.ParseID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.PermanentlyDelete(c.Request.Context(), id); err != nil {
+		response.HandleError(c, "Failed to delete user", err)
+		return
+	}
+
+	response.NoContent(c)
 }
 
 // GetUserInfo gets detailed user info by ID (alias for Get)
@@ -0,0 +1,81 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/domain"
+)
+
+func TestImportUsers_MixedBatch(t *testing.T) {
+	svc, repo, sent := newVerificationService(t)
+	registerPending(t, svc)
+	*sent = nil
+
+	result, err := svc.ImportUsers(context.Background(), []UserRegisterRequest{
+		{Username: "bob", Email: "bob@example.com", Password: "secret123"},
+		{Username: "alice2", Email: "alice@example.com", Password: "secret123"},
+		{Username: "carol", Email: "not-an-email", Password: "secret123"},
+		{Username: "dave", Email: "dave@example.com", Password: "secret123", Nickname: "Dave"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, 1, result.Failed)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, ImportRowError{Row: 2, Email: "alice@example.com", Status: "skipped", Error: domain.ErrEmailAlreadyExists.Error()}, result.Errors[0])
+	assert.Equal(t, 3, result.Errors[1].Row)
+	assert.Equal(t, "failed", result.Errors[1].Status)
+	assert.Contains(t, result.Errors[1].Error, "email")
+
+	// Imported users are active, hashed and not sent verification emails
+	dave, err := repo.FindByEmail(context.Background(), "dave@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int(domain.UserStatusActive), dave.Status)
+	assert.Equal(t, "Dave", dave.Nickname)
+	assert.True(t, svc.hasher.Check("secret123", dave.Password))
+	assert.Empty(t, *sent)
+	assert.Len(t, repo.users, 3)
+}
+
+func TestImportUsers_SkipsDuplicatesWithinBatch(t *testing.T) {
+	svc, _, _ := newVerificationService(t)
+
+	result, err := svc.ImportUsers(context.Background(), []UserRegisterRequest{
+		{Username: "bob", Email: "bob@example.com", Password: "secret123"},
+		{Username: "bobby", Email: "BOB@example.com", Password: "secret123"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Skipped)
+}
+
+func TestImportUsers_RejectsEmptyAndOversizedBatches(t *testing.T) {
+	svc, _, _ := newVerificationService(t)
+
+	_, err := svc.ImportUsers(context.Background(), nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, err = svc.ImportUsers(context.Background(), make([]UserRegisterRequest, MaxImportUsers+1))
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestParseImportCSV(t *testing.T) {
+	reqs, err := parseImportCSV(strings.NewReader("Email,username,password,nickname\n" +
+		"bob@example.com, bob, secret123, Bob\n" +
+		"carol@example.com,carol,secret123\n"))
+	require.NoError(t, err)
+	require.Len(t, reqs, 2)
+	assert.Equal(t, UserRegisterRequest{Username: "bob", Email: "bob@example.com", Password: "secret123", Nickname: "Bob"}, reqs[0])
+	assert.Equal(t, "", reqs[1].Nickname)
+
+	_, err = parseImportCSV(strings.NewReader("email,username\nbob@example.com,bob\n"))
+	assert.ErrorContains(t, err, `missing "password" column`)
+
+	_, err = parseImportCSV(strings.NewReader(""))
+	assert.Error(t, err)
+}
@@ -24,6 +24,17 @@ type UserPO struct {
 	Bio       string         `gorm:"size:500"`
 	Status    int            `gorm:"default:1"` // 1: active, 0: disabled
 	LastLogin *time.Time
+
+	VerificationSentAt *time.Time // Latest verification link, pruned from here
+
+	TwoFactorSecret  string `gorm:"size:255"` // Encrypted with APP_KEY
+	TwoFactorEnabled bool   `gorm:"not null;default:false"`
+
+	Version int `gorm:"not null;default:1"` // Optimistic lock, bumped by every update
+
+	// Roles is only loaded by the *With finders. The permission module owns
+	// the roles and user_roles tables, so migrations leave them alone here.
+	Roles []RolePO `gorm:"many2many:user_roles;joinForeignKey:UserID;joinReferences:RoleID;-:migration"`
 }
 
 // TableName specifies the database table name
@@ -31,12 +42,24 @@ func (UserPO) TableName() string {
 	return "users"
 }
 
+// RolePO is the read-only view of a role preloaded with a user
+type RolePO struct {
+	ID        uint `gorm:"primaryKey"`
+	Name      string
+	DeletedAt gorm.DeletedAt
+}
+
+// TableName specifies the database table name
+func (RolePO) TableName() string {
+	return "roles"
+}
+
 // toDomain converts UserPO to domain.User
 func (po *UserPO) toDomain() *domain.User {
 	if po == nil {
 		return nil
 	}
-	return &domain.User{
+	user := &domain.User{
 		ID:        po.ID,
 		Username:  po.Username,
 		Email:     po.Email,
@@ -49,7 +72,25 @@ func (po *UserPO) toDomain() *domain.User {
 		LastLogin: po.LastLogin,
 		CreatedAt: po.CreatedAt,
 		UpdatedAt: po.UpdatedAt,
+
+		VerificationSentAt: po.VerificationSentAt,
+
+		TwoFactorSecret:  po.TwoFactorSecret,
+		TwoFactorEnabled: po.TwoFactorEnabled,
+
+		Version: po.Version,
+	}
+	if po.DeletedAt.Valid {
+		deletedAt := po.DeletedAt.Time
+		user.DeletedAt = &deletedAt
 	}
+	if po.Roles != nil {
+		user.Roles = make([]string, len(po.Roles))
+		for i, role := range po.Roles {
+			user.Roles[i] = role.Name
+		}
+	}
+	return user
 }
 
 // newUserPO converts domain.User to UserPO for database operations
@@ -68,6 +109,13 @@ func newUserPO(u *domain.User) *UserPO {
 		Bio:       u.Bio,
 		Status:    u.Status,
 		LastLogin: u.LastLogin,
+
+		VerificationSentAt: u.VerificationSentAt,
+
+		TwoFactorSecret:  u.TwoFactorSecret,
+		TwoFactorEnabled: u.TwoFactorEnabled,
+
+		Version: u.Version,
 	}
 }
 
@@ -0,0 +1,74 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/pkg/hash"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestNewPasswordHasher(t *testing.T) {
+	hasher, err := NewPasswordHasher(config.AuthConfig{HashDriver: "argon2id", BcryptCost: 12})
+	require.NoError(t, err)
+	hashed, err := hasher.Make("secret123")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hashed, "$argon2id$"))
+
+	_, err = NewPasswordHasher(config.AuthConfig{HashDriver: "md5"})
+	assert.ErrorIs(t, err, hash.ErrUnsupportedAlgorithm)
+}
+
+func TestNewPasswordHasher_AppliesBcryptCost(t *testing.T) {
+	hasher, err := NewPasswordHasher(config.AuthConfig{HashDriver: "bcrypt", BcryptCost: 5})
+	require.NoError(t, err)
+	hashed, err := hasher.Make("secret123")
+	require.NoError(t, err)
+
+	cost, err := bcrypt.Cost([]byte(hashed))
+	require.NoError(t, err)
+	assert.Equal(t, 5, cost)
+}
+
+func TestRegister_UsesConfiguredBcryptCost(t *testing.T) {
+	svc, repo, _ := newVerificationService(t)
+	user := registerPending(t, svc)
+
+	cost, err := bcrypt.Cost([]byte(repo.users[user.ID].Password))
+	require.NoError(t, err)
+	assert.Equal(t, hash.MinBcryptCost, cost)
+
+	err = svc.ChangePassword(context.Background(), user.ID, &UserChangePasswordRequest{OldPassword: "secret123", NewPassword: "newsecret123"})
+	require.NoError(t, err)
+	cost, err = bcrypt.Cost([]byte(repo.users[user.ID].Password))
+	require.NoError(t, err)
+	assert.Equal(t, hash.MinBcryptCost, cost)
+}
+
+func TestLogin_RehashesLegacyPassword(t *testing.T) {
+	svc, repo, sent := newVerificationService(t)
+	svc.hasher = hash.NewBcryptHasher(4)
+	user := registerPending(t, svc)
+	_, err := svc.VerifyEmail(context.Background(), (*sent)[0])
+	require.NoError(t, err)
+	legacy := repo.users[user.ID].Password
+
+	// Switching the driver keeps existing users able to log in
+	svc.hasher = hash.NewArgon2Hasher()
+	_, err = svc.Login(context.Background(), &UserLoginRequest{Username: "alice", Password: "secret123"})
+	require.NoError(t, err)
+
+	upgraded := repo.users[user.ID].Password
+	assert.NotEqual(t, legacy, upgraded)
+	assert.True(t, strings.HasPrefix(upgraded, "$argon2id$"))
+	assert.False(t, svc.hasher.NeedsRehash(upgraded))
+
+	// The upgraded hash still logs in and is left alone
+	_, err = svc.Login(context.Background(), &UserLoginRequest{Username: "alice", Password: "secret123"})
+	require.NoError(t, err)
+	assert.Equal(t, upgraded, repo.users[user.ID].Password)
+}
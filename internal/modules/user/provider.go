@@ -2,6 +2,8 @@ package user
 
 import (
 	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/cache"
+	"github.com/zgiai/zgo/internal/infra/config"
 	"github.com/google/wire"
 )
 
@@ -9,8 +11,17 @@ import (
 // It binds concrete implementations to domain interfaces
 var ProviderSet = wire.NewSet(
 	NewRepository,
-	wire.Bind(new(domain.UserRepository), new(*repository)),
+	ProvideRepository,
 	NewService,
 	wire.Bind(new(Service), new(*service)),
 	NewHandler,
 )
+
+// ProvideRepository binds domain.UserRepository, caching FindByID in the
+// default cache store when cfg.Cache.UserTTL is set
+func ProvideRepository(cfg *config.Config, repo *repository) domain.UserRepository {
+	if cfg.Cache.UserTTL <= 0 {
+		return repo
+	}
+	return NewCachedRepository(repo, cache.Default(), cfg.Cache.UserTTL)
+}
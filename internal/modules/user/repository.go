@@ -4,90 +4,186 @@ import (
 	"context"
 
 	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/pkg/query"
+	baserepo "github.com/zgiai/zgo/pkg/repository"
 	"gorm.io/gorm"
 )
 
 // repository implements domain.UserRepository
 // It uses UserPO internally for database operations and converts to domain.User
 type repository struct {
-	db *gorm.DB
+	baserepo.Base[UserPO]
 }
 
 // NewRepository creates a new repository instance that implements domain.UserRepository
 func NewRepository(db *gorm.DB) *repository {
 	return &repository{
-		db: db,
+		Base: baserepo.NewBase[UserPO](db, database.Conn).WithPreloads(domain.UserRelationRoles),
 	}
 }
 
 // Create adds a new user
 func (r *repository) Create(ctx context.Context, user *domain.User) error {
 	po := newUserPO(user)
-	if err := r.db.WithContext(ctx).Create(po).Error; err != nil {
+	if po.Version == 0 {
+		po.Version = 1
+	}
+	if err := r.Base.Create(ctx, po); err != nil {
 		return err
 	}
 	// Update the domain user with generated ID
 	user.ID = po.ID
 	user.CreatedAt = po.CreatedAt
 	user.UpdatedAt = po.UpdatedAt
+	user.Version = po.Version
 	return nil
 }
 
-// Update modifies an existing user
+// Update modifies an existing user if nobody else has since the caller
+// read it: the row must still hold user.Version, which is then bumped.
+// It returns domain.ErrStaleUpdate when the version has moved on.
 func (r *repository) Update(ctx context.Context, user *domain.User) error {
 	po := newUserPO(user)
-	if err := r.db.WithContext(ctx).Save(po).Error; err != nil {
-		return err
+	po.Version = user.Version + 1
+
+	result := r.DB(ctx).Model(po).
+		Where("version = ?", user.Version).
+		Select("*").Omit("id", "created_at", "deleted_at").
+		Updates(po)
+	if result.Error != nil {
+		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		return domain.ErrStaleUpdate
+	}
+	user.Version = po.Version
 	user.UpdatedAt = po.UpdatedAt
 	return nil
 }
 
 // Delete removes a user by ID
 func (r *repository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&UserPO{}, id).Error
+	return r.Base.Delete(ctx, id)
 }
 
-// FindByID retrieves a user by ID
+// FindByID retrieves a user by ID.
+// Finders return domain.ErrUserNotFound when no user matches.
 func (r *repository) FindByID(ctx context.Context, id uint) (*domain.User, error) {
-	var po UserPO
-	if err := r.db.WithContext(ctx).First(&po, id).Error; err != nil {
-		return nil, err
+	po, err := r.Base.FindByID(ctx, id)
+	if err != nil {
+		return nil, baserepo.NotFound(err, domain.ErrUserNotFound)
 	}
 	return po.toDomain(), nil
 }
 
 // FindAll retrieves users with pagination
 func (r *repository) FindAll(ctx context.Context, page, pageSize int) ([]*domain.User, int64, error) {
-	var poList []*UserPO
-	var total int64
-
-	offset := (page - 1) * pageSize
-	if err := r.db.WithContext(ctx).Model(&UserPO{}).Count(&total).Error; err != nil {
+	poList, total, err := r.Base.FindAll(ctx, page, pageSize)
+	if err != nil {
 		return nil, 0, err
 	}
+	return toDomainList(poList), total, nil
+}
+
+// FindByIDWith retrieves a user by ID with relations such as
+// domain.UserRelationRoles loaded
+func (r *repository) FindByIDWith(ctx context.Context, id uint, relations ...string) (*domain.User, error) {
+	po, err := r.Base.FindByIDWith(ctx, id, relations...)
+	if err != nil {
+		return nil, baserepo.NotFound(err, domain.ErrUserNotFound)
+	}
+	return po.toDomain(), nil
+}
 
-	if err := r.db.WithContext(ctx).Offset(offset).Limit(pageSize).Find(&poList).Error; err != nil {
+// FindAllWith retrieves users with pagination and relations such as
+// domain.UserRelationRoles loaded, so listing them costs one query per
+// relation instead of one per user
+func (r *repository) FindAllWith(ctx context.Context, page, pageSize int, relations ...string) ([]*domain.User, int64, error) {
+	poList, total, err := r.Base.FindAllWith(ctx, page, pageSize, relations...)
+	if err != nil {
 		return nil, 0, err
 	}
+	return toDomainList(poList), total, nil
+}
 
+// Search retrieves the users matching spec with pagination
+func (r *repository) Search(ctx context.Context, spec *query.Spec, page, pageSize int) ([]*domain.User, int64, error) {
+	poList, total, err := r.Base.Search(ctx, spec, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
 	return toDomainList(poList), total, nil
 }
 
 // FindByUsername retrieves a user by username
 func (r *repository) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
-	var po UserPO
-	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&po).Error; err != nil {
-		return nil, err
+	po, err := r.FindBy(ctx, "username", username)
+	if err != nil {
+		return nil, baserepo.NotFound(err, domain.ErrUserNotFound)
 	}
 	return po.toDomain(), nil
 }
 
 // FindByEmail retrieves a user by email
 func (r *repository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	po, err := r.FindBy(ctx, "email", email)
+	if err != nil {
+		return nil, baserepo.NotFound(err, domain.ErrUserNotFound)
+	}
+	return po.toDomain(), nil
+}
+
+// FindWithTrashed retrieves users with pagination, including soft-deleted ones
+func (r *repository) FindWithTrashed(ctx context.Context, page, pageSize int) ([]*domain.User, int64, error) {
+	var poList []*UserPO
+	var total int64
+
+	offset := (page - 1) * pageSize
+	if err := r.DB(ctx).Unscoped().Model(&UserPO{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.DB(ctx).Unscoped().Offset(offset).Limit(pageSize).Find(&poList).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return toDomainList(poList), total, nil
+}
+
+// FindByEmailWithTrashed retrieves a user by email, including soft-deleted ones
+func (r *repository) FindByEmailWithTrashed(ctx context.Context, email string) (*domain.User, error) {
 	var po UserPO
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&po).Error; err != nil {
-		return nil, err
+	if err := r.DB(ctx).Unscoped().Where("email = ?", email).First(&po).Error; err != nil {
+		return nil, baserepo.NotFound(err, domain.ErrUserNotFound)
 	}
 	return po.toDomain(), nil
 }
+
+// Restore clears the soft-delete marker of a user. It returns
+// domain.ErrUserNotFound unless the user exists and is soft-deleted.
+func (r *repository) Restore(ctx context.Context, id uint) error {
+	result := r.DB(ctx).Unscoped().Model(&UserPO{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// ForceDelete permanently removes a user, whether soft-deleted or not.
+// It returns domain.ErrUserNotFound for unknown users.
+func (r *repository) ForceDelete(ctx context.Context, id uint) error {
+	result := r.DB(ctx).Unscoped().Delete(&UserPO{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
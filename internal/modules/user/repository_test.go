@@ -0,0 +1,246 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"github.com/zgiai/zgo/pkg/response"
+	"gorm.io/gorm"
+)
+
+func newSQLiteRepository(t *testing.T) *repository {
+	t.Helper()
+	db := dbtest.Open(t, &UserPO{})
+	return NewRepository(db)
+}
+
+func createAlice(t *testing.T, repo *repository) *domain.User {
+	t.Helper()
+	user := &domain.User{Username: "alice", Email: "alice@example.com", Password: "hash", Status: 1}
+	require.NoError(t, repo.Create(context.Background(), user))
+	require.Equal(t, 1, user.Version)
+	return user
+}
+
+func TestRepository_UpdateBumpsVersion(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	ctx := context.Background()
+	user := createAlice(t, repo)
+
+	user.Nickname = "Alice"
+	require.NoError(t, repo.Update(ctx, user))
+	assert.Equal(t, 2, user.Version)
+
+	// The same copy can keep saving: it tracks the version it wrote
+	user.Bio = "hello"
+	require.NoError(t, repo.Update(ctx, user))
+
+	stored, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stored.Version)
+	assert.Equal(t, "Alice", stored.Nickname)
+	assert.Equal(t, "hello", stored.Bio)
+	assert.Equal(t, user.CreatedAt.Unix(), stored.CreatedAt.Unix(), "created_at is left alone")
+}
+
+func TestRepository_StaleUpdateFails(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	ctx := context.Background()
+	user := createAlice(t, repo)
+
+	// Two requests read the same version ...
+	first, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+
+	// ... the first to save wins, the other is refused
+	first.Nickname = "first"
+	require.NoError(t, repo.Update(ctx, first))
+
+	second.Nickname = "second"
+	err = repo.Update(ctx, second)
+	require.ErrorIs(t, err, domain.ErrStaleUpdate)
+	assert.Equal(t, http.StatusConflict, response.StatusCode(err))
+
+	stored, err := repo.FindByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "first", stored.Nickname)
+	assert.Equal(t, 2, stored.Version)
+}
+
+func TestRepository_ConcurrentUpdatesOnlyOneWins(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	ctx := context.Background()
+	user := createAlice(t, repo)
+
+	const writers = 5
+	var (
+		wg             sync.WaitGroup
+		mu             sync.Mutex
+		saved, refused int
+	)
+	start := make(chan struct{})
+	for i := 0; i < writers; i++ {
+		copied := *user
+		copied.Bio = string(rune('a' + i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			err := repo.Update(ctx, &copied)
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				saved++
+			} else if assert.ErrorIs(t, err, domain.ErrStaleUpdate) {
+				refused++
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, 1, saved)
+	assert.Equal(t, writers-1, refused)
+}
+
+func TestService_UpdateProfileWithStaleVersion(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	svc := &service{repo: repo}
+	ctx := context.Background()
+	user := createAlice(t, repo)
+
+	updated, err := svc.UpdateProfile(ctx, user.ID, &UserUpdateRequest{Nickname: "Alice", Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+
+	// A client still holding version 1 must reload before saving
+	_, err = svc.UpdateProfile(ctx, user.ID, &UserUpdateRequest{Nickname: "Overwrite", Version: 1})
+	require.ErrorIs(t, err, domain.ErrStaleUpdate)
+	assert.Equal(t, http.StatusConflict, response.StatusCode(err))
+}
+
+func TestRepository_FindersReturnUserNotFound(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	ctx := context.Background()
+	alice := createAlice(t, repo)
+	require.NoError(t, repo.Delete(ctx, alice.ID))
+
+	finders := map[string]func() error{
+		"FindByID": func() error {
+			_, err := repo.FindByID(ctx, alice.ID)
+			return err
+		},
+		"FindByIDWith": func() error {
+			_, err := repo.FindByIDWith(ctx, alice.ID, domain.UserRelationRoles)
+			return err
+		},
+		"FindByUsername": func() error {
+			_, err := repo.FindByUsername(ctx, "alice")
+			return err
+		},
+		"FindByEmail": func() error {
+			_, err := repo.FindByEmail(ctx, "alice@example.com")
+			return err
+		},
+		"FindByEmailWithTrashed": func() error {
+			_, err := repo.FindByEmailWithTrashed(ctx, "bob@example.com")
+			return err
+		},
+		"Restore":     func() error { return repo.Restore(ctx, 999) },
+		"ForceDelete": func() error { return repo.ForceDelete(ctx, 999) },
+	}
+	for name, find := range finders {
+		t.Run(name, func(t *testing.T) {
+			err := find()
+			assert.ErrorIs(t, err, domain.ErrUserNotFound)
+			assert.NotErrorIs(t, err, gorm.ErrRecordNotFound, "GORM errors stay inside the repository")
+		})
+	}
+}
+
+// userRolePO stands in for the permission module's user_roles table
+type userRolePO struct {
+	UserID uint
+	RoleID uint
+}
+
+func (userRolePO) TableName() string {
+	return "user_roles"
+}
+
+func TestRepository_PreloadsRoles(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	ctx := context.Background()
+	db := repo.DB(ctx)
+	require.NoError(t, db.AutoMigrate(&RolePO{}, &userRolePO{}))
+
+	alice := createAlice(t, repo)
+	bob := &domain.User{Username: "bob", Email: "bob@example.com", Password: "hash", Status: 1}
+	require.NoError(t, repo.Create(ctx, bob))
+
+	roles := []*RolePO{{Name: "admin"}, {Name: "editor"}, {Name: "retired"}}
+	require.NoError(t, db.Create(roles).Error)
+	require.NoError(t, db.Create([]*userRolePO{
+		{UserID: alice.ID, RoleID: roles[0].ID},
+		{UserID: alice.ID, RoleID: roles[1].ID},
+		{UserID: alice.ID, RoleID: roles[2].ID},
+		{UserID: bob.ID, RoleID: roles[1].ID},
+	}).Error)
+	require.NoError(t, db.Delete(roles[2]).Error, "soft-deleted roles are not loaded")
+
+	found, err := repo.FindByIDWith(ctx, alice.ID, domain.UserRelationRoles)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"admin", "editor"}, found.Roles)
+
+	users, total, err := repo.FindAllWith(ctx, 1, 10, domain.UserRelationRoles)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, users, 2)
+	assert.ElementsMatch(t, []string{"admin", "editor"}, users[0].Roles)
+	assert.Equal(t, []string{"editor"}, users[1].Roles)
+
+	// Without the relation roles stay unloaded
+	found, err = repo.FindByIDWith(ctx, alice.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found.Roles)
+}
+
+func TestRepository_PreloadIgnoresUnknownRelations(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	ctx := context.Background()
+	alice := createAlice(t, repo)
+
+	found, err := repo.FindByIDWith(ctx, alice.ID, "Password", "Roles.Permissions", "")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", found.Username)
+	assert.Nil(t, found.Roles)
+
+	users, total, err := repo.FindAllWith(ctx, 1, 10, "Sessions")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, users, 1)
+}
+
+func TestUserPO_MigrationLeavesRoleTablesAlone(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	db := repo.DB(context.Background())
+
+	assert.False(t, db.Migrator().HasTable("roles"))
+	assert.False(t, db.Migrator().HasTable("user_roles"))
+}
+
+func TestService_GetByIDReturnsUserNotFound(t *testing.T) {
+	svc := &service{repo: newSQLiteRepository(t)}
+
+	_, err := svc.GetByID(context.Background(), 42)
+	require.ErrorIs(t, err, domain.ErrUserNotFound)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode(err))
+}
@@ -0,0 +1,58 @@
+package user
+
+import (
+	"strconv"
+
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/router"
+	"github.com/zgiai/zgo/pkg/resource"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// UserResource is the public API shape of a user.
+// Sensitive and internal fields (password, deleted_at) are never included.
+type UserResource struct {
+	user *domain.User
+}
+
+var _ resource.ResourceWithLinks = (*UserResource)(nil)
+
+// NewUserResource wraps a domain user for output
+func NewUserResource(u *domain.User) *UserResource {
+	return &UserResource{user: u}
+}
+
+// ToArray maps the user to its public fields
+func (r *UserResource) ToArray() map[string]any {
+	u := r.user
+	return resource.Filter(map[string]any{
+		"id":         u.ID,
+		"username":   u.Username,
+		"email":      u.Email,
+		"nickname":   resource.WhenNotEmpty(u.Nickname),
+		"avatar":     resource.WhenNotEmpty(u.Avatar),
+		"phone":      resource.WhenNotEmpty(u.Phone),
+		"bio":        resource.WhenNotEmpty(u.Bio),
+		"status":     u.Status,
+		"last_login": resource.WhenNotNil(u.LastLogin),
+		"version":    u.Version,
+		"created_at": u.CreatedAt,
+		"updated_at": u.UpdatedAt,
+	})
+}
+
+// Links returns the user's self link when the users.show route is registered
+func (r *UserResource) Links() map[string]string {
+	self, err := router.URL("users.show", map[string]string{"id": strconv.FormatUint(uint64(r.user.ID), 10)})
+	if err != nil {
+		return nil
+	}
+	return map[string]string{"self": self}
+}
+
+// NewUserCollection wraps a page of users for output
+func NewUserCollection(users []*domain.User, paginator response.Paginatable) *resource.SimpleCollection[*domain.User] {
+	return resource.NewCollection(users, paginator, func(u *domain.User) map[string]any {
+		return NewUserResource(u).ToArray()
+	})
+}
@@ -0,0 +1,66 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/router"
+	"github.com/zgiai/zgo/pkg/pagination"
+)
+
+func newResourceUser() *domain.User {
+	now := time.Now()
+	return &domain.User{
+		ID:        7,
+		Username:  "alice",
+		Email:     "alice@example.com",
+		Password:  "hashed-secret",
+		Nickname:  "Alice",
+		Status:    1,
+		CreatedAt: now,
+		UpdatedAt: now,
+		DeletedAt: &now,
+	}
+}
+
+func TestUserResource_ToArray(t *testing.T) {
+	result := NewUserResource(newResourceUser()).ToArray()
+
+	for _, key := range []string{"id", "username", "email", "nickname", "status", "created_at", "updated_at"} {
+		assert.Contains(t, result, key)
+	}
+	assert.NotContains(t, result, "password")
+	assert.NotContains(t, result, "deleted_at")
+	assert.NotContains(t, result, "avatar", "empty optional fields are omitted")
+	for _, v := range result {
+		assert.NotEqual(t, "hashed-secret", v)
+	}
+}
+
+func TestUserResource_Links(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := router.New(gin.New())
+	r.Group("/v1", func(v1 *router.Router) {
+		v1.GET("/users/:id", func(c *gin.Context) {}).Name("users.show")
+	})
+
+	links := NewUserResource(newResourceUser()).Links()
+
+	assert.Equal(t, map[string]string{"self": "/v1/users/7"}, links)
+}
+
+func TestUserCollection_ToArray(t *testing.T) {
+	users := []*domain.User{newResourceUser(), newResourceUser()}
+	collection := NewUserCollection(users, pagination.NewPaginator(users, 2, 1, 15))
+
+	items := collection.ToArray()
+
+	assert.Len(t, items, 2)
+	for _, item := range items {
+		assert.NotContains(t, item, "password")
+	}
+	assert.NotNil(t, collection.GetPaginator())
+}
@@ -7,10 +7,40 @@ import (
 // RegisterRoutes registers the user module routes
 // It uses the injected handler instance instead of creating a new one
 func (h *Handler) RegisterRoutes(r *router.Router) {
-	// Public routes
-	r.POST("/register", h.Register).Name("auth.register")
-	r.POST("/login", h.Login).Name("auth.login")
-	r.POST("/password/reset", h.ResetPassword).Name("auth.password.reset")
+	// Public routes (throttled against brute force)
+	r.Group("", func(guest *router.Router) {
+		guest.WithMiddleware("throttle")
+
+		// Retried sign-ups with the same Idempotency-Key replay the first response
+		guest.Group("", func(once *router.Router) {
+			once.WithMiddleware("idempotent")
+			once.POST("/register", h.Register).Name("auth.register")
+		})
+		guest.POST("/login", h.Login).Name("auth.login")
+		guest.POST("/auth/2fa/verify", h.LoginTwoFactor).Name("auth.2fa.verify")
+		guest.POST("/password/reset", h.ResetPassword).Name("auth.password.reset")
+		guest.GET("/auth/verify", h.VerifyEmail).Name("auth.verify")
+		guest.POST("/auth/verify/resend", h.ResendVerification).Name("auth.verify.resend")
+	})
+
+	// Cookie sessions, for clients that would rather not hold a token. Unsafe
+	// methods must echo the CSRF token from GET /session/csrf.
+	r.Group("/session", func(sess *router.Router) {
+		sess.WithMiddleware("session", "csrf")
+
+		sess.GET("/csrf", h.SessionCSRF).Name("session.csrf")
+
+		sess.Group("", func(guest *router.Router) {
+			guest.WithMiddleware("throttle")
+			guest.POST("/login", h.SessionLogin).Name("session.login")
+			guest.POST("/2fa/verify", h.SessionLoginTwoFactor).Name("session.2fa.verify")
+		})
+		sess.POST("/logout", h.SessionLogout).Name("session.logout")
+		sess.Group("", func(auth *router.Router) {
+			auth.WithMiddleware("auth:session")
+			auth.GET("/profile", h.GetProfile).Name("session.profile")
+		})
+	})
 
 	// Protected routes
 	r.Group("", func(auth *router.Router) {
@@ -22,8 +52,24 @@ func (h *Handler) RegisterRoutes(r *router.Router) {
 		auth.PUT("/users/password", h.ChangePassword).Name("users.password.update")
 		auth.DELETE("/users/account", h.DeleteAccount).Name("users.account.delete")
 
+		// Two-factor authentication
+		auth.POST("/users/2fa/enable", h.EnableTwoFactor).Name("users.2fa.enable")
+		auth.POST("/users/2fa/confirm", h.ConfirmTwoFactor).Name("users.2fa.confirm")
+
 		// User management
-		auth.GET("/users", h.List).Name("users.index")
+		auth.Group("", func(list *router.Router) {
+			list.WithMiddleware("compress")
+			list.GET("/users", h.List).Name("users.index")
+		})
+		auth.Group("", func(admin *router.Router) {
+			admin.WithMiddleware("role:admin")
+			admin.GET("/users/export", h.Export).Name("users.export")
+			admin.POST("/users/import", h.Import).Name("users.import")
+			admin.POST("/users/:id/disable", h.Disable).Name("users.disable").WhereNumber("id")
+			admin.POST("/users/:id/enable", h.Enable).Name("users.enable").WhereNumber("id")
+			admin.POST("/users/:id/restore", h.Restore).Name("users.restore").WhereNumber("id")
+			admin.DELETE("/users/:id/force", h.ForceDelete).Name("users.force.delete").WhereNumber("id")
+		})
 		auth.GET("/users/:id", h.Get).Name("users.show").WhereNumber("id")
 		auth.GET("/users/:id/info", h.GetUserInfo).Name("users.info").WhereNumber("id")
 	})
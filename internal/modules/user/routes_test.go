@@ -0,0 +1,57 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/infra/middleware"
+	"github.com/zgiai/zgo/internal/infra/router"
+)
+
+func newRoutesEngine() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	r := router.New(engine)
+	r.MiddlewareGroup("csrf", middleware.CSRF(middleware.DefaultCSRFOptions()))
+	(&Handler{}).RegisterRoutes(r)
+	return engine
+}
+
+func TestRoutes_SessionRequiresCSRFToken(t *testing.T) {
+	engine := newRoutesEngine()
+
+	for _, path := range []string{"/session/login", "/session/2fa/verify", "/session/logout"} {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, path)
+	}
+}
+
+func TestRoutes_SessionAcceptsCSRFToken(t *testing.T) {
+	engine := newRoutesEngine()
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/session/csrf", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Contains(t, w.Body.String(), cookies[0].Value)
+
+	// An empty body fails validation, so the handler never reaches the service
+	req := httptest.NewRequest(http.MethodPost, "/session/login", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-CSRF-Token", cookies[0].Value)
+	req.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	assert.NotEqual(t, http.StatusForbidden, w.Code)
+}
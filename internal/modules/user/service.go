@@ -2,15 +2,48 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/config"
 	"github.com/zgiai/zgo/internal/infra/email"
 	"github.com/zgiai/zgo/internal/infra/events"
 	"github.com/zgiai/zgo/internal/infra/jwt"
+	"github.com/zgiai/zgo/internal/infra/kv"
+	"github.com/zgiai/zgo/internal/infra/ratelimit"
+	"github.com/zgiai/zgo/pkg/encryption"
+	"github.com/zgiai/zgo/pkg/hash"
+	"github.com/zgiai/zgo/pkg/logger"
+	"github.com/zgiai/zgo/pkg/query"
+	"github.com/zgiai/zgo/pkg/totp"
 	"github.com/zgiai/zgo/pkg/utils"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/zgiai/zgo/pkg/validation"
+)
+
+const (
+	// resendVerificationMax caps verification emails per address per window
+	resendVerificationMax    = 3
+	resendVerificationWindow = time.Hour
+
+	// MaxImportUsers caps the rows accepted by one ImportUsers call
+	MaxImportUsers = 1000
+
+	// twoFactorChallengeTTL is how long a 2FA login challenge can be answered
+	twoFactorChallengeTTL = 5 * time.Minute
+
+	// twoFactorMaxFailures is how many wrong codes a user may enter within
+	// twoFactorChallengeTTL before their outstanding challenges are revoked
+	twoFactorMaxFailures = 5
+
+	// twoFactorStepTTL is how long an accepted TOTP step is remembered; after
+	// that it is outside the skew window anyway
+	twoFactorStepTTL = (2*totp.Skew + 2) * totp.Period
 )
 
 // Service defines the interface for user-related operations.
@@ -19,6 +52,9 @@ type Service interface {
 	// Authentication
 	Register(ctx context.Context, req *UserRegisterRequest) (*domain.User, error)
 	Login(ctx context.Context, req *UserLoginRequest) (*UserLoginResponse, error)
+	VerifyEmail(ctx context.Context, token string) (*domain.User, error)
+	ResendVerification(ctx context.Context, req *UserResendVerificationRequest) error
+	LoginWithTwoFactor(ctx context.Context, req *UserTwoFactorLoginRequest) (*UserLoginResponse, error)
 
 	// Profile (authenticated user)
 	GetProfile(ctx context.Context, userID uint) (*domain.User, error)
@@ -26,44 +62,111 @@ type Service interface {
 	ChangePassword(ctx context.Context, userID uint, req *UserChangePasswordRequest) error
 	DeleteAccount(ctx context.Context, userID uint) error
 
+	// Two-factor authentication
+	EnableTwoFactor(ctx context.Context, userID uint) (secret, otpauthURL string, err error)
+	ConfirmTwoFactor(ctx context.Context, userID uint, code string) error
+	VerifyTwoFactor(ctx context.Context, userID uint, code string) (bool, error)
+
 	// Public
 	ResetPassword(ctx context.Context, req *UserPasswordResetRequest) error
 
 	// Admin/Query
 	GetByID(ctx context.Context, id uint) (*domain.User, error)
-	List(ctx context.Context, page, pageSize int) ([]*domain.User, int64, error)
+	List(ctx context.Context, spec *query.Spec, page, pageSize int) ([]*domain.User, int64, error)
+	RestoreAccount(ctx context.Context, id uint) error
+	PermanentlyDelete(ctx context.Context, id uint) error
+	DisableUser(ctx context.Context, id uint) (*domain.User, error)
+	EnableUser(ctx context.Context, id uint) (*domain.User, error)
+	ImportUsers(ctx context.Context, reqs []UserRegisterRequest) (ImportResult, error)
 }
 
 // service implements the Service interface
 type service struct {
 	repo       domain.UserRepository
+	roles      domain.RoleAssigner
+	tx         domain.Transactor
 	jwtService *jwt.Service
 	eventBus   *events.EventBus
+	hasher     hash.Hasher
+
+	// Email verification
+	appURL        string
+	verifyTTL     time.Duration
+	resendLimiter ratelimit.Limiter
+	sendVerify    func(ctx context.Context, user *domain.User, token string) error
+
+	// Two-factor authentication
+	encrypter *encryption.Encrypter
+	issuer    string
+	now       func() time.Time
+	store     kv.Store // Failed attempts and used codes, kv.Default() when nil
 }
 
 // NewService creates a new service instance
-func NewService(repo domain.UserRepository, jwtService *jwt.Service, eventBus *events.EventBus) *service {
-	return &service{
-		repo:       repo,
-		jwtService: jwtService,
-		eventBus:   eventBus,
+func NewService(
+	repo domain.UserRepository,
+	roles domain.RoleAssigner,
+	tx domain.Transactor,
+	jwtService *jwt.Service,
+	eventBus *events.EventBus,
+	cfg *config.Config,
+) *service {
+	hasher, err := NewPasswordHasher(cfg.Auth)
+	if err != nil {
+		// config.Load rejects unknown drivers, so only hand-built configs get here
+		logger.Error("invalid password hash driver, using bcrypt", map[string]any{"error": err})
+		hasher = hash.NewBcryptHasher()
 	}
+
+	s := &service{
+		repo:          repo,
+		roles:         roles,
+		tx:            tx,
+		jwtService:    jwtService,
+		eventBus:      eventBus,
+		hasher:        hasher,
+		appURL:        strings.TrimRight(cfg.App.URL, "/"),
+		verifyTTL:     cfg.JWT.VerifyExpire,
+		resendLimiter: ratelimit.NewMemoryStore(resendVerificationMax, resendVerificationWindow),
+		encrypter:     encryption.New(cfg.App.Key),
+		issuer:        cfg.App.Name,
+		now:           time.Now,
+	}
+	if s.verifyTTL <= 0 {
+		s.verifyTTL = 24 * time.Hour
+	}
+	s.sendVerify = s.sendVerificationEmail
+	return s
+}
+
+// NewPasswordHasher returns the hasher selected by AUTH_HASH_DRIVER,
+// using AUTH_BCRYPT_COST for bcrypt
+func NewPasswordHasher(cfg config.AuthConfig) (hash.Hasher, error) {
+	if hash.Algorithm(cfg.HashDriver) == hash.AlgorithmBcrypt && cfg.BcryptCost > 0 {
+		return hash.NewBcryptHasher(cfg.BcryptCost), nil
+	}
+	return hash.NewHasher(hash.Algorithm(cfg.HashDriver))
+}
+
+// publish sends e to async subscribers. Handlers outlive the request, so
+// they get a context that keeps its values but is never cancelled.
+func (s *service) publish(ctx context.Context, e domain.Event) {
+	s.eventBus.PublishAsync(context.WithoutCancel(ctx), e)
 }
 
 // ============================================================================
 // Authentication
 // ============================================================================
 
-// Register handles user registration
+// Register handles user registration.
+// The account stays pending until the emailed verification link is opened.
 func (s *service) Register(ctx context.Context, req *UserRegisterRequest) (*domain.User, error) {
-	// Check if email already exists
-	exists, err := s.repo.FindByEmail(ctx, req.Email)
-	if err == nil && exists != nil {
-		return nil, domain.ErrEmailAlreadyExists
+	if err := s.checkEmailAvailable(ctx, req.Email); err != nil {
+		return nil, err
 	}
 
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := s.hasher.Make(req.Password)
 	if err != nil {
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -71,22 +174,55 @@ func (s *service) Register(ctx context.Context, req *UserRegisterRequest) (*doma
 	user := &domain.User{
 		Username: req.Username,
 		Email:    req.Email,
-		Password: string(hashedPassword),
+		Password: hashedPassword,
 		Nickname: req.Nickname,
 		Phone:    req.Phone,
-		Status:   1,
+		Status:   int(domain.UserStatusPending),
 	}
 
-	if err := s.repo.Create(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+	// Create the user and grant the default role atomically
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		if err := s.roles.AssignDefaultRole(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to assign default role: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Publish UserCreated event (fully decoupled side effects)
-	s.eventBus.PublishAsync(ctx, domain.NewUserCreatedEvent(user))
+	// Publish events (fully decoupled side effects: welcome email, audit log)
+	s.publish(ctx, domain.NewUserCreatedEvent(user))
+	s.publish(ctx, domain.NewUserRegisteredEvent(user.ID, user.Username, user.Email))
+
+	// A failed send is not fatal: the user can ask for the link again
+	if err := s.issueVerification(ctx, user); err != nil {
+		logger.Error("failed to send verification email", map[string]any{
+			"error": err,
+			"user":  user.Username,
+		})
+	}
 
 	return user, nil
 }
 
+// checkEmailAvailable fails when email is taken. A soft-deleted user keeps
+// its email so it can still be restored, which is reported separately as
+// ErrEmailBelongsToDeletedAccount.
+func (s *service) checkEmailAvailable(ctx context.Context, email string) error {
+	existing, err := s.repo.FindByEmailWithTrashed(ctx, email)
+	if err != nil || existing == nil {
+		return nil
+	}
+	if existing.DeletedAt != nil {
+		return domain.ErrEmailBelongsToDeletedAccount
+	}
+	return domain.ErrEmailAlreadyExists
+}
+
 // Login handles user login
 func (s *service) Login(ctx context.Context, req *UserLoginRequest) (*UserLoginResponse, error) {
 	// Try username first, then email
@@ -98,14 +234,114 @@ func (s *service) Login(ctx context.Context, req *UserLoginRequest) (*UserLoginR
 		}
 	}
 
+	if !s.hasher.Check(req.Password, user.Password) {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if user.IsPending() {
+		return nil, domain.ErrEmailNotVerified
+	}
 	if !user.IsActive() {
 		return nil, domain.ErrAccountDisabled
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return nil, domain.ErrInvalidCredentials
+	// Upgrade hashes made with an older algorithm or cost while the
+	// plain password is at hand; a failure just leaves the old hash
+	if s.hasher.NeedsRehash(user.Password) {
+		if rehashed, err := s.hasher.Make(req.Password); err == nil {
+			user.Password = rehashed
+			_ = s.repo.Update(ctx, user)
+		}
+	}
+
+	// The password alone is not enough: hand out a challenge for the code
+	if user.TwoFactorEnabled {
+		challenge, err := s.jwtService.GeneratePurposeToken(user.ID, user.Username, jwt.PurposeTwoFactor, twoFactorChallengeTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate two-factor challenge: %w", err)
+		}
+		return &UserLoginResponse{TwoFactorRequired: true, ChallengeToken: challenge}, nil
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+// LoginWithTwoFactor exchanges the challenge Login returned and a valid
+// authenticator code for an access token
+func (s *service) LoginWithTwoFactor(ctx context.Context, req *UserTwoFactorLoginRequest) (*UserLoginResponse, error) {
+	claims, err := s.jwtService.ParsePurposeToken(req.ChallengeToken, jwt.PurposeTwoFactor)
+	if err != nil {
+		return nil, domain.ErrInvalidTwoFactorChallenge
+	}
+
+	user, err := s.repo.FindByID(ctx, claims.UserID)
+	if err != nil || user.Username != claims.Subject || !user.TwoFactorEnabled {
+		return nil, domain.ErrInvalidTwoFactorChallenge
+	}
+	if !user.IsActive() {
+		return nil, domain.ErrAccountDisabled
+	}
+
+	if err := s.checkTwoFactorChallenge(ctx, user.ID, claims); err != nil {
+		return nil, err
+	}
+
+	valid, err := s.VerifyTwoFactor(ctx, user.ID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, s.recordTwoFactorFailure(ctx, user.ID)
+	}
+	_ = s.twoFactorStore().Delete(ctx, twoFactorFailuresKey(user.ID))
+
+	return s.completeLogin(ctx, user)
+}
+
+// checkTwoFactorChallenge rejects challenges revoked by too many wrong codes,
+// and every challenge while the user is over the limit
+func (s *service) checkTwoFactorChallenge(ctx context.Context, userID uint, claims *jwt.Claims) error {
+	revokedAt, err := s.storedInt(ctx, twoFactorRevokedKey(userID))
+	if err != nil {
+		return err
+	}
+	if revokedAt > 0 && claims.IssuedTime().Unix() <= revokedAt {
+		return domain.ErrInvalidTwoFactorChallenge
+	}
+
+	failures, err := s.storedInt(ctx, twoFactorFailuresKey(userID))
+	if err != nil {
+		return err
+	}
+	if failures >= twoFactorMaxFailures {
+		return domain.ErrTooManyTwoFactorAttempts
 	}
+	return nil
+}
+
+// recordTwoFactorFailure counts a wrong code. Reaching the limit revokes
+// every challenge issued so far, so guessing has to start over with the
+// password once the lockout ends.
+func (s *service) recordTwoFactorFailure(ctx context.Context, userID uint) error {
+	failures, err := s.twoFactorStore().Incr(ctx, twoFactorFailuresKey(userID), twoFactorChallengeTTL)
+	if err != nil {
+		return fmt.Errorf("failed to count two-factor failure: %w", err)
+	}
+	if failures < twoFactorMaxFailures {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	// Challenges carry the wall-clock iat, so compare against the same clock
+	revokedAt := []byte(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := s.twoFactorStore().Set(ctx, twoFactorRevokedKey(userID), revokedAt, twoFactorChallengeTTL); err != nil {
+		return fmt.Errorf("failed to revoke two-factor challenges: %w", err)
+	}
+	logger.Warning("two-factor challenges revoked after repeated invalid codes", map[string]any{"user_id": userID})
+	return domain.ErrTooManyTwoFactorAttempts
+}
 
+// completeLogin issues the access token for an authenticated user
+func (s *service) completeLogin(ctx context.Context, user *domain.User) (*UserLoginResponse, error) {
 	token, err := s.jwtService.GenerateToken(user.ID, user.Username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
@@ -116,12 +352,104 @@ func (s *service) Login(ctx context.Context, req *UserLoginRequest) (*UserLoginR
 	user.LastLogin = &now
 	_ = s.repo.Update(ctx, user)
 
+	// The audit listener takes the client IP from the request context
+	s.publish(ctx, domain.NewUserLoggedInEvent(user.ID, user.Username, ""))
+
 	return &UserLoginResponse{
 		AccessToken: token,
 		User:        user, // Domain直接输出
 	}, nil
 }
 
+// VerifyEmail activates the account a verification token was issued for
+func (s *service) VerifyEmail(ctx context.Context, token string) (*domain.User, error) {
+	claims, err := s.jwtService.ParsePurposeToken(token, jwt.PurposeEmailVerification)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, domain.ErrVerificationTokenExpired
+		}
+		return nil, domain.ErrInvalidVerificationToken
+	}
+
+	user, err := s.repo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	// The token is bound to the address it was sent to
+	if !strings.EqualFold(user.Email, claims.Subject) {
+		return nil, domain.ErrInvalidVerificationToken
+	}
+
+	switch {
+	case user.IsActive():
+		return nil, domain.ErrEmailAlreadyVerified
+	case !user.IsPending():
+		return nil, domain.ErrAccountDisabled
+	}
+
+	user.Status = int(domain.UserStatusActive)
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to activate user: %w", err)
+	}
+
+	return user, nil
+}
+
+// ResendVerification sends a fresh verification link, limited per email
+// address. It succeeds whether or not a link went out, so the answer does
+// not reveal which addresses have accounts; the reason is only logged.
+func (s *service) ResendVerification(ctx context.Context, req *UserResendVerificationRequest) error {
+	key := "verify:" + strings.ToLower(req.Email)
+	if allowed, _, _ := s.resendLimiter.Allow(ctx, key); !allowed {
+		return domain.ErrTooManyVerificationEmails
+	}
+	s.resendLimiter.Hit(ctx, key)
+
+	user, err := s.repo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		logger.Info("verification email not resent: no such account", map[string]any{"email": req.Email})
+		return nil
+	}
+	if !user.IsPending() {
+		logger.Info("verification email not resent: account is not pending", map[string]any{
+			"user_id": user.ID,
+			"status":  user.Status,
+		})
+		return nil
+	}
+
+	if err := s.issueVerification(ctx, user); err != nil {
+		logger.Error("failed to resend verification email", map[string]any{
+			"error":   err,
+			"user_id": user.ID,
+		})
+	}
+	return nil
+}
+
+// issueVerification signs a verification token for user and sends it.
+// It records when the link went out, so cleanup:expired-tokens keeps the
+// account until the latest link has expired.
+func (s *service) issueVerification(ctx context.Context, user *domain.User) error {
+	token, err := s.jwtService.GeneratePurposeToken(user.ID, user.Email, jwt.PurposeEmailVerification, s.verifyTTL)
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	now := time.Now()
+	user.VerificationSentAt = &now
+	if err := s.repo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to record verification: %w", err)
+	}
+	return s.sendVerify(ctx, user, token)
+}
+
+// sendVerificationEmail emails the link that opens GET /v1/auth/verify
+func (s *service) sendVerificationEmail(ctx context.Context, user *domain.User, token string) error {
+	link := s.appURL + "/v1/auth/verify?token=" + url.QueryEscape(token)
+	return email.SendVerificationEmail(user.Email, user.Username, link)
+}
+
 // ============================================================================
 // Profile (Authenticated User)
 // ============================================================================
@@ -155,6 +483,10 @@ func (s *service) UpdateProfile(ctx context.Context, userID uint, req *UserUpdat
 	if req.Bio != "" {
 		user.Bio = req.Bio
 	}
+	// Save against the version the client saw, not the one just loaded
+	if req.Version != 0 {
+		user.Version = req.Version
+	}
 
 	if err := s.repo.Update(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
@@ -170,22 +502,162 @@ func (s *service) ChangePassword(ctx context.Context, userID uint, req *UserChan
 		return domain.ErrUserNotFound
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.OldPassword)); err != nil {
+	if !s.hasher.Check(req.OldPassword, user.Password) {
 		return fmt.Errorf("incorrect old password")
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	hashedPassword, err := s.hasher.Make(req.NewPassword)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	user.Password = string(hashedPassword)
-	return s.repo.Update(ctx, user)
+	user.Password = hashedPassword
+	if err := s.repo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	s.publish(ctx, domain.NewUserPasswordChangedEvent(user.ID))
+	return nil
 }
 
 // DeleteAccount deletes user account
 func (s *service) DeleteAccount(ctx context.Context, userID uint) error {
-	return s.repo.Delete(ctx, userID)
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.publish(ctx, domain.NewUserDeletedEvent(user.ID, user.Email))
+	return nil
+}
+
+// ============================================================================
+// Two-Factor Authentication
+// ============================================================================
+
+// EnableTwoFactor stores a new TOTP secret for the user and returns it with
+// the otpauth:// URL for authenticator apps. Login keeps working without a
+// code until ConfirmTwoFactor proves the app was set up.
+func (s *service) EnableTwoFactor(ctx context.Context, userID uint) (string, string, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return "", "", domain.ErrUserNotFound
+	}
+	if user.TwoFactorEnabled {
+		return "", "", domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate two-factor secret: %w", err)
+	}
+	encrypted, err := s.encrypter.Encrypt(secret)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt two-factor secret: %w", err)
+	}
+
+	user.TwoFactorSecret = encrypted
+	if err := s.repo.Update(ctx, user); err != nil {
+		return "", "", err
+	}
+
+	return secret, totp.URL(s.issuer, user.Email, secret), nil
+}
+
+// ConfirmTwoFactor turns two-factor authentication on once the user enters
+// a code from the secret EnableTwoFactor returned
+func (s *service) ConfirmTwoFactor(ctx context.Context, userID uint, code string) error {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	if user.TwoFactorEnabled {
+		return domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	valid, err := s.VerifyTwoFactor(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	user.TwoFactorEnabled = true
+	return s.repo.Update(ctx, user)
+}
+
+// VerifyTwoFactor checks a code against the user's TOTP secret
+func (s *service) VerifyTwoFactor(ctx context.Context, userID uint, code string) (bool, error) {
+	user, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return false, domain.ErrUserNotFound
+	}
+	if user.TwoFactorSecret == "" {
+		return false, domain.ErrTwoFactorNotSetUp
+	}
+
+	secret, err := s.encrypter.Decrypt(user.TwoFactorSecret)
+	if err != nil {
+		return false, fmt.Errorf("failed to decrypt two-factor secret: %w", err)
+	}
+	step, ok := totp.Match(code, secret, s.now())
+	if !ok {
+		return false, nil
+	}
+	return s.useTwoFactorStep(ctx, userID, step)
+}
+
+// useTwoFactorStep accepts a TOTP step at most once per user, and never one
+// at or before the last step accepted, so an observed code cannot be replayed
+func (s *service) useTwoFactorStep(ctx context.Context, userID uint, step uint64) (bool, error) {
+	last, err := s.storedInt(ctx, twoFactorStepKey(userID))
+	if err != nil {
+		return false, err
+	}
+	if last > 0 && step <= uint64(last) {
+		return false, nil
+	}
+
+	// Incr makes the step single-use even when two requests race past the check above
+	uses, err := s.twoFactorStore().Incr(ctx, fmt.Sprintf("%s:%d", twoFactorStepKey(userID), step), twoFactorStepTTL)
+	if err != nil {
+		return false, fmt.Errorf("failed to record two-factor code: %w", err)
+	}
+	if uses > 1 {
+		return false, nil
+	}
+	if err := s.twoFactorStore().Set(ctx, twoFactorStepKey(userID), []byte(strconv.FormatUint(step, 10)), twoFactorStepTTL); err != nil {
+		return false, fmt.Errorf("failed to record two-factor code: %w", err)
+	}
+	return true, nil
+}
+
+func twoFactorFailuresKey(userID uint) string { return fmt.Sprintf("2fa:failures:%d", userID) }
+func twoFactorRevokedKey(userID uint) string  { return fmt.Sprintf("2fa:revoked:%d", userID) }
+func twoFactorStepKey(userID uint) string     { return fmt.Sprintf("2fa:step:%d", userID) }
+
+// twoFactorStore returns the store for two-factor state
+func (s *service) twoFactorStore() kv.Store {
+	if s.store != nil {
+		return s.store
+	}
+	return kv.Default()
+}
+
+// storedInt reads an integer from the store, 0 when the key is missing
+func (s *service) storedInt(ctx context.Context, key string) (int64, error) {
+	b, err := s.twoFactorStore().Get(ctx, key)
+	if errors.Is(err, kv.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return strconv.ParseInt(string(b), 10, 64)
 }
 
 // ============================================================================
@@ -200,12 +672,12 @@ func (s *service) ResetPassword(ctx context.Context, req *UserPasswordResetReque
 	}
 
 	newPassword := utils.GenerateRandomString(12)
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	hashedPassword, err := s.hasher.Make(newPassword)
 	if err != nil {
 		return fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	user.Password = string(hashedPassword)
+	user.Password = hashedPassword
 	if err := s.repo.Update(ctx, user); err != nil {
 		return fmt.Errorf("failed to reset password: %w", err)
 	}
@@ -222,7 +694,176 @@ func (s *service) GetByID(ctx context.Context, id uint) (*domain.User, error) {
 	return s.repo.FindByID(ctx, id)
 }
 
-// List retrieves a paginated list of users
-func (s *service) List(ctx context.Context, page, pageSize int) ([]*domain.User, int64, error) {
-	return s.repo.FindAll(ctx, page, pageSize)
+// List retrieves a paginated list of the users matching spec; nil lists all
+func (s *service) List(ctx context.Context, spec *query.Spec, page, pageSize int) ([]*domain.User, int64, error) {
+	return s.repo.Search(ctx, spec, page, pageSize)
+}
+
+// RestoreAccount restores a soft-deleted user
+func (s *service) RestoreAccount(ctx context.Context, id uint) error {
+	return s.repo.Restore(ctx, id)
+}
+
+// PermanentlyDelete removes a user and cannot be undone
+func (s *service) PermanentlyDelete(ctx context.Context, id uint) error {
+	return s.repo.ForceDelete(ctx, id)
+}
+
+// DisableUser suspends an account: the user can no longer log in and the
+// access tokens already handed out are revoked. Disabling a disabled user
+// changes nothing.
+func (s *service) DisableUser(ctx context.Context, id uint) (*domain.User, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == int(domain.UserStatusDisabled) {
+		return user, nil
+	}
+
+	user.Status = int(domain.UserStatusDisabled)
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := s.jwtService.RevokeUserTokens(ctx, user.ID); err != nil {
+		logger.Error("failed to revoke tokens of disabled user", map[string]any{"user_id": user.ID, "error": err})
+	}
+
+	s.publish(ctx, domain.NewUserDisabledEvent(user.ID, user.Email))
+	return user, nil
+}
+
+// EnableUser reactivates a disabled account. Accounts in any other status,
+// including those still waiting for email verification, are left as they are.
+func (s *service) EnableUser(ctx context.Context, id uint) (*domain.User, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != int(domain.UserStatusDisabled) {
+		return user, nil
+	}
+
+	user.Status = int(domain.UserStatusActive)
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := s.jwtService.RestoreUserTokens(ctx, user.ID); err != nil {
+		logger.Error("failed to restore tokens of enabled user", map[string]any{"user_id": user.ID, "error": err})
+	}
+
+	s.publish(ctx, domain.NewUserEnabledEvent(user.ID, user.Email))
+	return user, nil
+}
+
+// ImportUsers creates users in bulk. Each row is validated and created in
+// its own transaction, so one bad row does not undo the others. Rows whose
+// email is already registered, or repeated in the batch, are skipped.
+// Imported accounts are active: an admin vouches for them, so no
+// verification email is sent.
+func (s *service) ImportUsers(ctx context.Context, reqs []UserRegisterRequest) (ImportResult, error) {
+	result := ImportResult{Errors: []ImportRowError{}}
+	if len(reqs) == 0 {
+		return result, domain.NewAppError(domain.ErrInvalidInput, errors.New("no users to import"))
+	}
+	if len(reqs) > MaxImportUsers {
+		return result, domain.NewAppError(domain.ErrInvalidInput, fmt.Errorf("at most %d users can be imported at once", MaxImportUsers))
+	}
+
+	seen := make(map[string]bool, len(reqs))
+	for i := range reqs {
+		req := &reqs[i]
+		rowErr := ImportRowError{Row: i + 1, Email: req.Email}
+
+		if err := validateImportRow(req); err != nil {
+			rowErr.Status, rowErr.Error = "failed", err.Error()
+			result.Failed++
+			result.Errors = append(result.Errors, rowErr)
+			continue
+		}
+
+		email := strings.ToLower(req.Email)
+		if seen[email] {
+			rowErr.Status, rowErr.Error = "skipped", "duplicate email in batch"
+			result.Skipped++
+			result.Errors = append(result.Errors, rowErr)
+			continue
+		}
+		seen[email] = true
+
+		if err := s.checkEmailAvailable(ctx, req.Email); err != nil {
+			rowErr.Status, rowErr.Error = "skipped", err.Error()
+			result.Skipped++
+			result.Errors = append(result.Errors, rowErr)
+			continue
+		}
+
+		if err := s.importUser(ctx, req); err != nil {
+			rowErr.Status, rowErr.Error = "failed", err.Error()
+			result.Failed++
+			result.Errors = append(result.Errors, rowErr)
+			continue
+		}
+		result.Created++
+	}
+
+	return result, nil
+}
+
+// importUser creates one active user with the default role
+func (s *service) importUser(ctx context.Context, req *UserRegisterRequest) error {
+	hashedPassword, err := s.hasher.Make(req.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &domain.User{
+		Username: req.Username,
+		Email:    req.Email,
+		Password: hashedPassword,
+		Nickname: req.Nickname,
+		Phone:    req.Phone,
+		Status:   int(domain.UserStatusActive),
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		if err := s.roles.AssignDefaultRole(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to assign default role: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publish(ctx, domain.NewUserCreatedEvent(user))
+	s.publish(ctx, domain.NewUserRegisteredEvent(user.ID, user.Username, user.Email))
+	return nil
+}
+
+// importValidator checks import rows against the same binding rules the
+// register endpoint applies
+var importValidator = func() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(validation.JSONFieldName)
+	return v
+}()
+
+// validateImportRow reports every invalid field of a row in one error
+func validateImportRow(req *UserRegisterRequest) error {
+	err := importValidator.Struct(req)
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	msgs := make([]string, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		msgs = append(msgs, validation.Message(fe))
+	}
+	return errors.New(strings.Join(msgs, "; "))
 }
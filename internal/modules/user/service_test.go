@@ -41,7 +41,7 @@ func TestService_Register(t *testing.T) {
 				Phone:    "1234567890",
 			},
 			setup: func() {
-				mockRepo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().FindByEmailWithTrashed(gomock.Any(), "test@example.com").Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, user *domain.User) error {
 					assert.Equal(t, "testuser", user.Username)
 					assert.Equal(t, "test@example.com", user.Email)
@@ -63,11 +63,26 @@ func TestService_Register(t *testing.T) {
 			},
 			setup: func() {
 				existingUser := &domain.User{ID: 1, Email: "existing@example.com"}
-				mockRepo.EXPECT().FindByEmail(gomock.Any(), "existing@example.com").Return(existingUser, nil)
+				mockRepo.EXPECT().FindByEmailWithTrashed(gomock.Any(), "existing@example.com").Return(existingUser, nil)
 			},
 			wantErr:     true,
 			expectedErr: domain.ErrEmailAlreadyExists,
 		},
+		{
+			name: "email of a soft-deleted account",
+			req: &UserRegisterRequest{
+				Username: "testuser",
+				Email:    "deleted@example.com",
+				Password: "password123",
+			},
+			setup: func() {
+				deletedAt := time.Now()
+				deletedUser := &domain.User{ID: 2, Email: "deleted@example.com", DeletedAt: &deletedAt}
+				mockRepo.EXPECT().FindByEmailWithTrashed(gomock.Any(), "deleted@example.com").Return(deletedUser, nil)
+			},
+			wantErr:     true,
+			expectedErr: domain.ErrEmailBelongsToDeletedAccount,
+		},
 		{
 			name: "failed to hash password",
 			req: &UserRegisterRequest{
@@ -76,7 +91,7 @@ func TestService_Register(t *testing.T) {
 				Password: string(make([]byte, 10000)), // extremely long password to cause error
 			},
 			setup: func() {
-				mockRepo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().FindByEmailWithTrashed(gomock.Any(), "test@example.com").Return(nil, errors.New("not found"))
 			},
 			wantErr: true,
 		},
@@ -88,7 +103,7 @@ func TestService_Register(t *testing.T) {
 				Password: "password123",
 			},
 			setup: func() {
-				mockRepo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().FindByEmailWithTrashed(gomock.Any(), "test@example.com").Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
 			},
 			wantErr:     true,
@@ -0,0 +1,120 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/kv"
+)
+
+// newActiveUserService returns a service with a verified, active alice
+func newActiveUserService(t *testing.T) (*service, *domain.User) {
+	t.Helper()
+	kv.SetDefault(kv.NewMemoryStore())
+	t.Cleanup(func() { kv.SetDefault(nil) })
+
+	svc, _, sent := newVerificationService(t)
+	user := registerPending(t, svc)
+	user, err := svc.VerifyEmail(context.Background(), (*sent)[0])
+	require.NoError(t, err)
+	return svc, user
+}
+
+func loginAlice(svc *service) (*UserLoginResponse, error) {
+	return svc.Login(context.Background(), &UserLoginRequest{Username: "alice", Password: "secret123"})
+}
+
+func TestDisableUser_BlocksLoginUntilEnabled(t *testing.T) {
+	svc, user := newActiveUserService(t)
+	ctx := context.Background()
+
+	disabled, err := svc.DisableUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int(domain.UserStatusDisabled), disabled.Status)
+
+	_, err = loginAlice(svc)
+	assert.ErrorIs(t, err, domain.ErrAccountDisabled)
+
+	enabled, err := svc.EnableUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, enabled.IsActive())
+
+	resp, err := loginAlice(svc)
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+}
+
+func TestDisableUser_RevokesTokens(t *testing.T) {
+	svc, user := newActiveUserService(t)
+	ctx := context.Background()
+
+	resp, err := loginAlice(svc)
+	require.NoError(t, err)
+	claims, err := svc.jwtService.ParseToken(resp.AccessToken)
+	require.NoError(t, err)
+	assert.False(t, svc.jwtService.UserTokensRevoked(ctx, claims.UserID, claims.IssuedTime()))
+
+	_, err = svc.DisableUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, svc.jwtService.UserTokensRevoked(ctx, claims.UserID, claims.IssuedTime()))
+
+	// The old token stays revoked once the account is enabled again. Issue
+	// times are whole seconds, so move past the second it was issued in.
+	time.Sleep(time.Until(claims.IssuedTime().Add(time.Second)))
+	_, err = svc.EnableUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, svc.jwtService.UserTokensRevoked(ctx, claims.UserID, claims.IssuedTime()))
+
+	resp, err = loginAlice(svc)
+	require.NoError(t, err)
+	claims, err = svc.jwtService.ParseToken(resp.AccessToken)
+	require.NoError(t, err)
+	assert.False(t, svc.jwtService.UserTokensRevoked(ctx, claims.UserID, claims.IssuedTime()))
+}
+
+func TestDisableUser_DispatchesEventsOnChangeOnly(t *testing.T) {
+	svc, user := newActiveUserService(t)
+	recorded := recordEvents(t, svc)
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := svc.DisableUser(ctx, user.ID)
+		require.NoError(t, err)
+	}
+	for range 2 {
+		_, err := svc.EnableUser(ctx, user.ID)
+		require.NoError(t, err)
+	}
+
+	// Registration events may still be arriving; only status changes count
+	statusEvents := func() []string {
+		var names []string
+		for _, name := range recorded() {
+			if name == "user.disabled" || name == "user.enabled" {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	assert.Eventually(t, func() bool { return len(statusEvents()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.Never(t, func() bool { return len(statusEvents()) > 2 }, 50*time.Millisecond, 5*time.Millisecond)
+	assert.ElementsMatch(t, []string{"user.disabled", "user.enabled"}, statusEvents())
+}
+
+func TestEnableUser_LeavesPendingUsersAlone(t *testing.T) {
+	kv.SetDefault(kv.NewMemoryStore())
+	t.Cleanup(func() { kv.SetDefault(nil) })
+	svc, _, _ := newVerificationService(t)
+	user := registerPending(t, svc)
+
+	enabled, err := svc.EnableUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	assert.True(t, enabled.IsPending(), "verification cannot be skipped by enabling")
+
+	_, err = svc.DisableUser(context.Background(), 999)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/task"
+	"github.com/zgiai/zgo/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// PruneExpiredVerifications is the cleanup:expired-tokens task.
+// Verification tokens are stateless, so an expired one leaves nothing behind
+// but the pending account it was issued for. The task deletes accounts that
+// are still pending once their latest link has expired, freeing their
+// usernames and emails. A resent link keeps the account.
+//
+// Arguments:
+//   - older-than: how long ago the latest link must have been sent
+//     (default: ttl, the verification link lifetime)
+//   - dry-run: only count the accounts
+func PruneExpiredVerifications(db *gorm.DB, ttl time.Duration) task.Func {
+	return func(ctx context.Context, args task.Args) error {
+		if db == nil {
+			return errors.New("database is disabled")
+		}
+		olderThan, err := args.Duration("older-than", ttl)
+		if err != nil {
+			return err
+		}
+		dryRun, err := args.Bool("dry-run", false)
+		if err != nil {
+			return err
+		}
+
+		cutoff := time.Now().Add(-olderThan)
+		query := db.WithContext(ctx).Unscoped().
+			Where("status = ? AND COALESCE(verification_sent_at, created_at) < ?", int(domain.UserStatusPending), cutoff)
+
+		if dryRun {
+			var count int64
+			if err := query.Model(&UserPO{}).Count(&count).Error; err != nil {
+				return err
+			}
+			logger.FromContext(ctx).Info("Pending accounts with expired verification links", map[string]any{
+				"count":       count,
+				"sent_before": cutoff,
+			})
+			return nil
+		}
+
+		result := query.Delete(&UserPO{})
+		if result.Error != nil {
+			return result.Error
+		}
+		logger.FromContext(ctx).Info("Deleted pending accounts with expired verification links", map[string]any{
+			"count": result.RowsAffected,
+		})
+		return nil
+	}
+}
@@ -0,0 +1,227 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/kv"
+	"github.com/zgiai/zgo/pkg/totp"
+)
+
+// newTwoFactorService returns a service with an active alice and a fixed clock
+func newTwoFactorService(t *testing.T) (*service, *memoryRepository, *domain.User) {
+	t.Helper()
+	svc, repo, sent := newVerificationService(t)
+	user := registerPending(t, svc)
+	_, err := svc.VerifyEmail(context.Background(), (*sent)[0])
+	require.NoError(t, err)
+
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	return svc, repo, user
+}
+
+// nextPeriod moves the service clock on to the next TOTP code
+func nextPeriod(svc *service) {
+	now := svc.now().Add(totp.Period)
+	svc.now = func() time.Time { return now }
+}
+
+// twoFactorChallenge logs alice in with her password and returns the challenge
+func twoFactorChallenge(t *testing.T, svc *service) string {
+	t.Helper()
+	resp, err := svc.Login(context.Background(), &UserLoginRequest{Username: "alice", Password: "secret123"})
+	require.NoError(t, err)
+	require.True(t, resp.TwoFactorRequired)
+	return resp.ChallengeToken
+}
+
+// enableTwoFactor sets up and confirms 2FA for userID, returning the secret
+func enableTwoFactor(t *testing.T, svc *service, userID uint) string {
+	t.Helper()
+	secret, url, err := svc.EnableTwoFactor(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Contains(t, url, "otpauth://totp/")
+	assert.Contains(t, url, "secret="+secret)
+
+	require.NoError(t, svc.ConfirmTwoFactor(context.Background(), userID, currentCode(t, svc, secret)))
+	return secret
+}
+
+func currentCode(t *testing.T, svc *service, secret string) string {
+	t.Helper()
+	code, err := totp.Code(secret, svc.now())
+	require.NoError(t, err)
+	return code
+}
+
+func TestEnableTwoFactor_StoresEncryptedSecret(t *testing.T) {
+	svc, repo, user := newTwoFactorService(t)
+
+	secret, _, err := svc.EnableTwoFactor(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	stored := repo.users[user.ID]
+	assert.NotEmpty(t, stored.TwoFactorSecret)
+	assert.NotEqual(t, secret, stored.TwoFactorSecret)
+	assert.False(t, stored.TwoFactorEnabled)
+
+	// Login is unaffected until the secret is confirmed
+	resp, err := svc.Login(context.Background(), &UserLoginRequest{Username: "alice", Password: "secret123"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+}
+
+func TestConfirmTwoFactor_RejectsWrongCode(t *testing.T) {
+	svc, repo, user := newTwoFactorService(t)
+
+	_, _, err := svc.EnableTwoFactor(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	err = svc.ConfirmTwoFactor(context.Background(), user.ID, "000000")
+	assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorCode)
+	assert.False(t, repo.users[user.ID].TwoFactorEnabled)
+}
+
+func TestConfirmTwoFactor_RequiresSetup(t *testing.T) {
+	svc, _, user := newTwoFactorService(t)
+
+	err := svc.ConfirmTwoFactor(context.Background(), user.ID, "123456")
+	assert.ErrorIs(t, err, domain.ErrTwoFactorNotSetUp)
+}
+
+func TestEnableTwoFactor_AlreadyEnabled(t *testing.T) {
+	svc, _, user := newTwoFactorService(t)
+	enableTwoFactor(t, svc, user.ID)
+
+	_, _, err := svc.EnableTwoFactor(context.Background(), user.ID)
+	assert.ErrorIs(t, err, domain.ErrTwoFactorAlreadyEnabled)
+}
+
+func TestLogin_TwoFactorChallenge(t *testing.T) {
+	svc, _, user := newTwoFactorService(t)
+	secret := enableTwoFactor(t, svc, user.ID)
+	nextPeriod(svc)
+
+	resp, err := svc.Login(context.Background(), &UserLoginRequest{Username: "alice", Password: "secret123"})
+	require.NoError(t, err)
+	assert.True(t, resp.TwoFactorRequired)
+	assert.NotEmpty(t, resp.ChallengeToken)
+	assert.Empty(t, resp.AccessToken)
+	assert.Nil(t, resp.User)
+
+	// A wrong code is rejected
+	_, err = svc.LoginWithTwoFactor(context.Background(), &UserTwoFactorLoginRequest{ChallengeToken: resp.ChallengeToken, Code: "000000"})
+	assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorCode)
+
+	// The challenge is not an access token and vice versa
+	access, err := svc.jwtService.GenerateToken(user.ID, user.Username)
+	require.NoError(t, err)
+	_, err = svc.LoginWithTwoFactor(context.Background(), &UserTwoFactorLoginRequest{ChallengeToken: access, Code: currentCode(t, svc, secret)})
+	assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorChallenge)
+
+	final, err := svc.LoginWithTwoFactor(context.Background(), &UserTwoFactorLoginRequest{ChallengeToken: resp.ChallengeToken, Code: currentCode(t, svc, secret)})
+	require.NoError(t, err)
+	assert.NotEmpty(t, final.AccessToken)
+	assert.False(t, final.TwoFactorRequired)
+	require.NotNil(t, final.User)
+	assert.Equal(t, user.ID, final.User.ID)
+}
+
+func TestVerifyTwoFactor_AcceptsClockSkew(t *testing.T) {
+	svc, _, user := newTwoFactorService(t)
+	secret, _, err := svc.EnableTwoFactor(context.Background(), user.ID)
+	require.NoError(t, err)
+
+	previous, err := totp.Code(secret, svc.now().Add(-totp.Period))
+	require.NoError(t, err)
+	ok, err := svc.VerifyTwoFactor(context.Background(), user.ID, previous)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	stale, err := totp.Code(secret, svc.now().Add(-3*totp.Period))
+	require.NoError(t, err)
+	ok, err = svc.VerifyTwoFactor(context.Background(), user.ID, stale)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestVerifyTwoFactor_RejectsReplayedCodes(t *testing.T) {
+	svc, _, user := newTwoFactorService(t)
+	secret := enableTwoFactor(t, svc, user.ID)
+	ctx := context.Background()
+
+	// The code used to confirm cannot be used again, nor can an older one
+	ok, err := svc.VerifyTwoFactor(ctx, user.ID, currentCode(t, svc, secret))
+	require.NoError(t, err)
+	assert.False(t, ok, "a used code should not be accepted again")
+
+	previous, err := totp.Code(secret, svc.now().Add(-totp.Period))
+	require.NoError(t, err)
+	ok, err = svc.VerifyTwoFactor(ctx, user.ID, previous)
+	require.NoError(t, err)
+	assert.False(t, ok, "a code older than the last used one should be rejected")
+
+	// The next code is fine, once
+	nextPeriod(svc)
+	code := currentCode(t, svc, secret)
+	ok, err = svc.VerifyTwoFactor(ctx, user.ID, code)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = svc.VerifyTwoFactor(ctx, user.ID, code)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestLoginWithTwoFactor_RevokesChallengeAfterRepeatedFailures(t *testing.T) {
+	svc, _, user := newTwoFactorService(t)
+	secret := enableTwoFactor(t, svc, user.ID)
+	nextPeriod(svc)
+	ctx := context.Background()
+	challenge := twoFactorChallenge(t, svc)
+
+	for i := 1; i < twoFactorMaxFailures; i++ {
+		_, err := svc.LoginWithTwoFactor(ctx, &UserTwoFactorLoginRequest{ChallengeToken: challenge, Code: "000000"})
+		require.ErrorIs(t, err, domain.ErrInvalidTwoFactorCode)
+	}
+	_, err := svc.LoginWithTwoFactor(ctx, &UserTwoFactorLoginRequest{ChallengeToken: challenge, Code: "000000"})
+	require.ErrorIs(t, err, domain.ErrTooManyTwoFactorAttempts)
+
+	// Even the right code no longer redeems the challenge
+	_, err = svc.LoginWithTwoFactor(ctx, &UserTwoFactorLoginRequest{ChallengeToken: challenge, Code: currentCode(t, svc, secret)})
+	assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorChallenge)
+
+	// Nor does a fresh challenge while the user is locked out
+	_, err = svc.LoginWithTwoFactor(ctx, &UserTwoFactorLoginRequest{ChallengeToken: twoFactorChallenge(t, svc), Code: currentCode(t, svc, secret)})
+	assert.Error(t, err)
+
+	// Once the lockout has passed, a new challenge works again
+	require.NoError(t, svc.store.Delete(ctx, twoFactorFailuresKey(user.ID)))
+	require.NoError(t, svc.store.Delete(ctx, twoFactorRevokedKey(user.ID)))
+	final, err := svc.LoginWithTwoFactor(ctx, &UserTwoFactorLoginRequest{ChallengeToken: twoFactorChallenge(t, svc), Code: currentCode(t, svc, secret)})
+	require.NoError(t, err)
+	assert.NotEmpty(t, final.AccessToken)
+}
+
+func TestLoginWithTwoFactor_SuccessResetsFailures(t *testing.T) {
+	svc, _, user := newTwoFactorService(t)
+	secret := enableTwoFactor(t, svc, user.ID)
+	nextPeriod(svc)
+	ctx := context.Background()
+	challenge := twoFactorChallenge(t, svc)
+
+	for i := 1; i < twoFactorMaxFailures; i++ {
+		_, err := svc.LoginWithTwoFactor(ctx, &UserTwoFactorLoginRequest{ChallengeToken: challenge, Code: "000000"})
+		require.ErrorIs(t, err, domain.ErrInvalidTwoFactorCode)
+	}
+	_, err := svc.LoginWithTwoFactor(ctx, &UserTwoFactorLoginRequest{ChallengeToken: challenge, Code: currentCode(t, svc, secret)})
+	require.NoError(t, err)
+
+	_, err = svc.store.Get(ctx, twoFactorFailuresKey(user.ID))
+	assert.ErrorIs(t, err, kv.ErrNotFound)
+}
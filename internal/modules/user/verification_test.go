@@ -0,0 +1,218 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/events"
+	"github.com/zgiai/zgo/internal/infra/jwt"
+	"github.com/zgiai/zgo/internal/infra/kv"
+	"github.com/zgiai/zgo/pkg/hash"
+)
+
+// memoryRepository is an in-memory UserRepository for service tests
+type memoryRepository struct {
+	domain.UserRepository
+	users  map[uint]*domain.User
+	nextID uint
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{users: make(map[uint]*domain.User)}
+}
+
+func (r *memoryRepository) Create(ctx context.Context, user *domain.User) error {
+	r.nextID++
+	user.ID = r.nextID
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+func (r *memoryRepository) Update(ctx context.Context, user *domain.User) error {
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+func (r *memoryRepository) Delete(ctx context.Context, id uint) error {
+	delete(r.users, id)
+	return nil
+}
+
+func (r *memoryRepository) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	copied := *u
+	return &copied, nil
+}
+
+func (r *memoryRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (r *memoryRepository) FindByEmailWithTrashed(ctx context.Context, email string) (*domain.User, error) {
+	return r.FindByEmail(ctx, email)
+}
+
+func (r *memoryRepository) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	for _, u := range r.users {
+		if u.Username == username {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+type noopRoles struct{}
+
+func (noopRoles) AssignDefaultRole(ctx context.Context, userID uint) error { return nil }
+
+type inlineTx struct{}
+
+func (inlineTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// newVerificationService returns a service that records sent tokens instead of emailing them
+func newVerificationService(t *testing.T) (*service, *memoryRepository, *[]string) {
+	t.Helper()
+	repo := newMemoryRepository()
+	cfg := &config.Config{}
+	cfg.App.URL = "http://localhost"
+	cfg.JWT.VerifyExpire = time.Hour
+	cfg.Auth.HashDriver = string(hash.AlgorithmBcrypt)
+	cfg.Auth.BcryptCost = hash.MinBcryptCost
+
+	svc := NewService(repo, noopRoles{}, inlineTx{}, jwt.NewTestService(), events.NewEventBus(), cfg)
+	svc.store = kv.NewMemoryStore()
+	var sent []string
+	svc.sendVerify = func(ctx context.Context, user *domain.User, token string) error {
+		sent = append(sent, token)
+		return nil
+	}
+	return svc, repo, &sent
+}
+
+func registerPending(t *testing.T, svc *service) *domain.User {
+	t.Helper()
+	user, err := svc.Register(context.Background(), &UserRegisterRequest{
+		Username: "alice",
+		Email:    "alice@example.com",
+		Password: "secret123",
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func TestRegister_CreatesPendingUserAndSendsToken(t *testing.T) {
+	svc, repo, sent := newVerificationService(t)
+	user := registerPending(t, svc)
+
+	assert.Equal(t, int(domain.UserStatusPending), repo.users[user.ID].Status)
+	require.Len(t, *sent, 1)
+
+	_, err := svc.Login(context.Background(), &UserLoginRequest{Username: "alice@example.com", Password: "secret123"})
+	assert.ErrorIs(t, err, domain.ErrEmailNotVerified)
+}
+
+func TestVerifyEmail_ValidToken(t *testing.T) {
+	svc, repo, sent := newVerificationService(t)
+	user := registerPending(t, svc)
+
+	verified, err := svc.VerifyEmail(context.Background(), (*sent)[0])
+	require.NoError(t, err)
+	assert.True(t, verified.IsActive())
+	assert.True(t, repo.users[user.ID].IsActive())
+}
+
+func TestVerifyEmail_ExpiredToken(t *testing.T) {
+	svc, repo, _ := newVerificationService(t)
+	user := registerPending(t, svc)
+
+	token, err := svc.jwtService.GeneratePurposeToken(user.ID, user.Email, jwt.PurposeEmailVerification, -time.Minute)
+	require.NoError(t, err)
+
+	_, err = svc.VerifyEmail(context.Background(), token)
+	assert.ErrorIs(t, err, domain.ErrVerificationTokenExpired)
+	assert.True(t, repo.users[user.ID].IsPending())
+}
+
+func TestVerifyEmail_AlreadyVerified(t *testing.T) {
+	svc, _, sent := newVerificationService(t)
+	registerPending(t, svc)
+
+	_, err := svc.VerifyEmail(context.Background(), (*sent)[0])
+	require.NoError(t, err)
+
+	_, err = svc.VerifyEmail(context.Background(), (*sent)[0])
+	assert.ErrorIs(t, err, domain.ErrEmailAlreadyVerified)
+}
+
+func TestVerifyEmail_RejectsAccessToken(t *testing.T) {
+	svc, _, _ := newVerificationService(t)
+	user := registerPending(t, svc)
+
+	token, err := svc.jwtService.GenerateToken(user.ID, user.Username)
+	require.NoError(t, err)
+
+	_, err = svc.VerifyEmail(context.Background(), token)
+	assert.ErrorIs(t, err, domain.ErrInvalidVerificationToken)
+}
+
+func TestResendVerification_RateLimitedPerEmail(t *testing.T) {
+	svc, _, sent := newVerificationService(t)
+	registerPending(t, svc)
+	ctx := context.Background()
+	req := &UserResendVerificationRequest{Email: "alice@example.com"}
+
+	for i := 0; i < resendVerificationMax; i++ {
+		require.NoError(t, svc.ResendVerification(ctx, req))
+	}
+	assert.ErrorIs(t, svc.ResendVerification(ctx, req), domain.ErrTooManyVerificationEmails)
+	assert.Len(t, *sent, resendVerificationMax+1)
+}
+
+func TestResendVerification_RecordsSentAt(t *testing.T) {
+	svc, repo, _ := newVerificationService(t)
+	user := registerPending(t, svc)
+
+	first := repo.users[user.ID].VerificationSentAt
+	require.NotNil(t, first, "registration records the first link")
+
+	time.Sleep(time.Millisecond)
+	require.NoError(t, svc.ResendVerification(context.Background(), &UserResendVerificationRequest{Email: "alice@example.com"}))
+	assert.True(t, repo.users[user.ID].VerificationSentAt.After(*first))
+}
+
+func TestResendVerification_SameAnswerForEveryAddress(t *testing.T) {
+	svc, repo, sent := newVerificationService(t)
+	user := registerPending(t, svc)
+	ctx := context.Background()
+
+	// Unknown addresses get the same answer as pending accounts
+	require.NoError(t, svc.ResendVerification(ctx, &UserResendVerificationRequest{Email: "nobody@example.com"}))
+	assert.Len(t, *sent, 1, "no link for an unknown address")
+
+	repo.users[user.ID].Status = int(domain.UserStatusActive)
+	require.NoError(t, svc.ResendVerification(ctx, &UserResendVerificationRequest{Email: "alice@example.com"}))
+	assert.Len(t, *sent, 1, "no link for a verified account")
+
+	repo.users[user.ID].Status = int(domain.UserStatusDisabled)
+	require.NoError(t, svc.ResendVerification(ctx, &UserResendVerificationRequest{Email: "alice@example.com"}))
+	assert.Len(t, *sent, 1, "no link for a disabled account")
+}
@@ -31,16 +31,21 @@ func InitApplication() (*app.Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	service := jwt.NewService(configConfig)
+	service, err := jwt.NewService(configConfig)
+	if err != nil {
+		return nil, err
+	}
 	emailService := email.NewService(configConfig)
 	eventBus := events.NewEventBus()
 	repository := migration.NewDatabaseRepositoryProvider(db)
 	migrator := migration.NewMigratorProvider(repository, db, eventBus)
+	permissionRepository := permission.NewRepository(db)
+	permissionService := permission.ProvideService(configConfig, permissionRepository)
 	userRepository := user.NewRepository(db)
-	userService := user.NewService(userRepository, service, eventBus)
+	domainUserRepository := user.ProvideRepository(configConfig, userRepository)
+	txManager := database.NewTxManager(db)
+	userService := user.NewService(domainUserRepository, permissionService, txManager, service, eventBus, configConfig)
 	handler := user.NewHandler(userService)
-	permissionRepository := permission.NewRepository(db)
-	permissionService := permission.NewService(permissionRepository)
 	permissionHandler := permission.NewHandler(permissionService)
 	handlers := &app.Handlers{
 		User:       handler,
@@ -53,6 +58,7 @@ func InitApplication() (*app.Application, error) {
 		EmailService: emailService,
 		EventBus:     eventBus,
 		Migrator:     migrator,
+		Authorizer:   permissionService,
 		Handlers:     handlers,
 	}
 	return application, nil
@@ -0,0 +1,106 @@
+// Package authz provides policy-based authorization.
+//
+// A policy decides whether an actor may perform an ability on a resource.
+// Abilities are named "<resource>.<action>", e.g. "post.update", and map to
+// one PolicyFunc each. Abilities without a policy are denied.
+//
+// Example:
+//
+//	authz.Define("post.update", func(ctx context.Context, actor authz.Actor, resource any) (bool, error) {
+//	    post, ok := resource.(*Post)
+//	    return ok && post.AuthorID == actor.ID, nil
+//	})
+//
+//	allowed, err := authz.Can(ctx, actor, "post.update", post)
+package authz
+
+import (
+	"context"
+	"sync"
+)
+
+// Actor is the user performing an action
+type Actor struct {
+	ID       uint
+	Username string
+}
+
+// PolicyFunc decides whether actor may perform an ability on resource.
+// resource is nil for abilities that do not concern a single resource,
+// such as "post.create".
+type PolicyFunc func(ctx context.Context, actor Actor, resource any) (bool, error)
+
+// Policy groups the abilities of one resource type, Laravel style.
+// Abilities returns them keyed by action, e.g. "update".
+type Policy interface {
+	Abilities() map[string]PolicyFunc
+}
+
+// Registry maps abilities to policies
+type Registry struct {
+	mu       sync.RWMutex
+	policies map[string]PolicyFunc
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{policies: make(map[string]PolicyFunc)}
+}
+
+// Define sets the policy for an ability, replacing any previous one
+func (r *Registry) Define(ability string, fn PolicyFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policies[ability] = fn
+}
+
+// Register defines every ability of p under the resource name, so the
+// "update" ability of Register("post", p) is "post.update"
+func (r *Registry) Register(resource string, p Policy) {
+	for action, fn := range p.Abilities() {
+		r.Define(resource+"."+action, fn)
+	}
+}
+
+// Has reports whether ability has a policy
+func (r *Registry) Has(ability string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.policies[ability]
+	return ok
+}
+
+// Can reports whether actor may perform ability on resource. Abilities
+// without a policy are denied.
+func (r *Registry) Can(ctx context.Context, actor Actor, ability string, resource any) (bool, error) {
+	r.mu.RLock()
+	fn, ok := r.policies[ability]
+	r.mu.RUnlock()
+	if !ok {
+		return false, nil
+	}
+	return fn(ctx, actor, resource)
+}
+
+// defaultRegistry backs the package-level functions
+var defaultRegistry = NewRegistry()
+
+// Default returns the registry used by the package-level functions
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Define sets the policy for an ability on the default registry
+func Define(ability string, fn PolicyFunc) {
+	defaultRegistry.Define(ability, fn)
+}
+
+// Register defines the abilities of p on the default registry
+func Register(resource string, p Policy) {
+	defaultRegistry.Register(resource, p)
+}
+
+// Can checks an ability against the default registry
+func Can(ctx context.Context, actor Actor, ability string, resource any) (bool, error) {
+	return defaultRegistry.Can(ctx, actor, ability, resource)
+}
@@ -0,0 +1,122 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+type profile struct {
+	ID      uint
+	OwnerID uint
+}
+
+// profilePolicy lets users edit only their own profile
+type profilePolicy struct{}
+
+func (p profilePolicy) Abilities() map[string]PolicyFunc {
+	return map[string]PolicyFunc{
+		"update": p.Update,
+	}
+}
+
+func (profilePolicy) Update(ctx context.Context, actor Actor, resource any) (bool, error) {
+	p, ok := resource.(*profile)
+	return ok && p.OwnerID == actor.ID, nil
+}
+
+func TestRegistry_Can(t *testing.T) {
+	r := NewRegistry()
+	r.Register("profile", profilePolicy{})
+	ctx := context.Background()
+	own := &profile{ID: 1, OwnerID: 7}
+
+	allowed, err := r.Can(ctx, Actor{ID: 7}, "profile.update", own)
+	require.NoError(t, err)
+	assert.True(t, allowed, "owner may update")
+
+	allowed, err = r.Can(ctx, Actor{ID: 8}, "profile.update", own)
+	require.NoError(t, err)
+	assert.False(t, allowed, "others may not")
+}
+
+func TestRegistry_MissingPolicyDenies(t *testing.T) {
+	r := NewRegistry()
+
+	assert.False(t, r.Has("profile.delete"))
+	allowed, err := r.Can(context.Background(), Actor{ID: 1}, "profile.delete", &profile{OwnerID: 1})
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestRegistry_PolicyError(t *testing.T) {
+	r := NewRegistry()
+	boom := errors.New("boom")
+	r.Define("profile.view", func(ctx context.Context, actor Actor, resource any) (bool, error) {
+		return true, boom
+	})
+
+	_, err := r.Can(context.Background(), Actor{ID: 1}, "profile.view", nil)
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestAuthorize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reg := NewRegistry()
+	reg.Register("profile", profilePolicy{})
+
+	profiles := map[uint]*profile{1: {ID: 1, OwnerID: 7}}
+	load := func(c *gin.Context) (any, error) {
+		id, _ := strconv.Atoi(c.Param("id"))
+		if p, ok := profiles[uint(id)]; ok {
+			return p, nil
+		}
+		return nil, response.ErrNotFound
+	}
+
+	newRouter := func(userID uint, ability string) *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			if userID != 0 {
+				c.Set("userID", userID)
+			}
+		})
+		r.PUT("/profiles/:id", reg.Authorize(ability, load), func(c *gin.Context) {
+			c.String(http.StatusOK, "%d", Resource(c).(*profile).ID)
+		})
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		userID  uint
+		ability string
+		path    string
+		want    int
+	}{
+		{"owner allowed", 7, "profile.update", "/profiles/1", http.StatusOK},
+		{"other user denied", 8, "profile.update", "/profiles/1", http.StatusForbidden},
+		{"missing policy denied", 7, "profile.delete", "/profiles/1", http.StatusForbidden},
+		{"unauthenticated", 0, "profile.update", "/profiles/1", http.StatusUnauthorized},
+		{"resource not found", 7, "profile.update", "/profiles/2", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newRouter(tt.userID, tt.ability).ServeHTTP(w, httptest.NewRequest(http.MethodPut, tt.path, nil))
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				assert.Equal(t, "1", w.Body.String())
+			}
+		})
+	}
+}
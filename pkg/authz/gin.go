@@ -0,0 +1,68 @@
+package authz
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// resourceKey stores the authorized resource in the gin context
+const resourceKey = "authz.resource"
+
+// ResourceLoader loads the resource a request acts on, usually from a path
+// parameter. Errors are reported with response.HandleError, so domain
+// not-found errors become 404s.
+type ResourceLoader func(c *gin.Context) (any, error)
+
+// Authorize guards a handler with the ability's policy on the default
+// registry. Must run after JWTAuth.
+//
+// Example:
+//
+//	users.PUT("/:id", authz.Authorize("user.update", loadUser), h.Update)
+func Authorize(ability string, load ResourceLoader) gin.HandlerFunc {
+	return defaultRegistry.Authorize(ability, load)
+}
+
+// Authorize guards a handler with the ability's policy. The resource is
+// loaded with load, which may be nil for abilities without one, and is
+// available to the handler through Resource. Unauthenticated requests get
+// 401 and denied ones 403.
+func (r *Registry) Authorize(ability string, load ResourceLoader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := Actor{ID: c.GetUint("userID"), Username: c.GetString("username")}
+		if actor.ID == 0 {
+			response.AbortUnauthorized(c, "Authentication required")
+			return
+		}
+
+		var resource any
+		if load != nil {
+			var err error
+			if resource, err = load(c); err != nil {
+				response.HandleError(c, "Failed to load resource", err)
+				c.Abort()
+				return
+			}
+		}
+
+		allowed, err := r.Can(c.Request.Context(), actor, ability, resource)
+		if err != nil {
+			response.HandleError(c, "Failed to authorize request", err)
+			c.Abort()
+			return
+		}
+		if !allowed {
+			response.AbortForbidden(c, "This action is unauthorized")
+			return
+		}
+
+		c.Set(resourceKey, resource)
+		c.Next()
+	}
+}
+
+// Resource returns the resource loaded by Authorize, or nil
+func Resource(c *gin.Context) any {
+	resource, _ := c.Get(resourceKey)
+	return resource
+}
@@ -0,0 +1,41 @@
+// Package dbtest opens throwaway databases for tests.
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open returns a private in-memory SQLite database with models migrated.
+// It fails the test on any error.
+//
+// Example:
+//
+//	db := dbtest.Open(t, &User{}, &Role{})
+func Open(t testing.TB, models ...any) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("dbtest: open: %v", err)
+	}
+
+	// A single connection keeps the in-memory database alive across queries
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("dbtest: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if len(models) > 0 {
+		if err := db.AutoMigrate(models...); err != nil {
+			t.Fatalf("dbtest: migrate: %v", err)
+		}
+	}
+	return db
+}
@@ -18,9 +18,14 @@ import (
 // 6. Default values in code
 
 var (
-	loaded     bool
-	loadedOnce sync.Once
-	appEnv     string
+	loaded      bool
+	loadedOnce  sync.Once
+	appEnv      string
+	loadedFiles []string
+
+	// fileValues records variables set from env files, so Reload can tell
+	// them apart from the process environment
+	fileValues = make(map[string]string)
 )
 
 // Load loads environment files respecting priority.
@@ -41,7 +46,53 @@ func LoadFresh() {
 	Load()
 }
 
+// Reload re-reads the env files, replacing values loaded from them earlier.
+// Variables from the process environment keep their priority; file values
+// that were changed at runtime with Set are left alone.
+func Reload() {
+	for key, value := range fileValues {
+		if current, ok := os.LookupEnv(key); ok && current == value {
+			os.Unsetenv(key)
+		}
+	}
+	fileValues = make(map[string]string)
+	LoadFresh()
+}
+
+// Files returns every env file that would be read, whether or not it exists,
+// in load order. Watchers use it to notice files that appear later.
+func Files() []string {
+	Load()
+	var files []string
+	if envFile := os.Getenv("ZGO_ENV_FILE"); envFile != "" {
+		files = append(files, envFile)
+	}
+	return append(files, candidateFiles(appEnv)...)
+}
+
+// candidateFiles lists the conventional env files for an environment,
+// lowest priority first
+func candidateFiles(appEnv string) []string {
+	files := []string{".env"}
+
+	// Environment-specific file
+	if appEnv != "" {
+		files = append(files, ".env."+appEnv)
+	}
+
+	// Local overrides
+	files = append(files, ".env.local")
+
+	// Environment-specific local overrides
+	if appEnv != "" {
+		files = append(files, ".env."+appEnv+".local")
+	}
+	return files
+}
+
 func loadEnvFiles() {
+	loadedFiles = nil
+
 	// Capture system environment variables BEFORE loading any .env files
 	systemEnv := captureSystemEnv()
 
@@ -57,7 +108,7 @@ func loadEnvFiles() {
 	// Check for explicit environment file (ZGO_ENV_FILE)
 	if envFile := systemEnv["ZGO_ENV_FILE"]; envFile != "" {
 		if _, err := os.Stat(envFile); err == nil {
-			_ = godotenv.Load(envFile)
+			loadFile(envFile)
 			// Allow APP_ENV from this specific file if not set by system env
 			if appEnv == "" {
 				appEnv = os.Getenv("APP_ENV")
@@ -66,11 +117,10 @@ func loadEnvFiles() {
 	}
 
 	// Load .env files in order (lowest priority first)
-	// godotenv.Load does NOT override existing values
-	files := []string{".env"}
+	// loadFile does NOT override existing values
 
 	// Load base first, then check APP_ENV from it if not set
-	_ = godotenv.Load(".env")
+	loadFile(".env")
 
 	// Re-check APP_ENV after loading base .env
 	if appEnv == "" {
@@ -79,24 +129,12 @@ func loadEnvFiles() {
 	if appEnv == "" {
 		appEnv = os.Getenv("GO_ENV")
 	}
-
-	// Environment-specific file
-	if appEnv != "" {
-		files = append(files, ".env."+appEnv)
-	}
-
-	// Local overrides
-	files = append(files, ".env.local")
-
-	// Environment-specific local overrides
-	if appEnv != "" {
-		files = append(files, ".env."+appEnv+".local")
-	}
+	files := candidateFiles(appEnv)
 
 	// Load remaining files (godotenv.Load won't override existing)
 	for _, file := range files[1:] {
 		if _, err := os.Stat(file); err == nil {
-			_ = godotenv.Load(file)
+			loadFile(file)
 		}
 	}
 
@@ -106,6 +144,29 @@ func loadEnvFiles() {
 	}
 }
 
+// loadFile loads a single env file and records it when successful.
+// Like godotenv.Load, it never overrides variables that are already set.
+func loadFile(file string) {
+	values, err := godotenv.Read(file)
+	if err != nil {
+		return
+	}
+	for key, value := range values {
+		if _, ok := os.LookupEnv(key); !ok {
+			os.Setenv(key, value)
+			fileValues[key] = value
+		}
+	}
+	loadedFiles = append(loadedFiles, file)
+}
+
+// LoadedFiles returns the env files that were loaded, in load order.
+// Earlier files take precedence over later ones.
+func LoadedFiles() []string {
+	Load()
+	return append([]string(nil), loadedFiles...)
+}
+
 // captureSystemEnv captures current environment variables before .env loading
 func captureSystemEnv() map[string]string {
 	result := make(map[string]string)
@@ -0,0 +1,53 @@
+package errors
+
+import "sync"
+
+// Reporter sends errors to an aggregation service such as Sentry.
+// ctx carries structured details like request_id and user_id.
+type Reporter interface {
+	Report(err error, ctx map[string]any)
+}
+
+// ReporterFunc adapts a function to Reporter
+type ReporterFunc func(err error, ctx map[string]any)
+
+// Report calls f
+func (f ReporterFunc) Report(err error, ctx map[string]any) {
+	f(err, ctx)
+}
+
+// NopReporter discards every error; it is the default
+type NopReporter struct{}
+
+// Report does nothing
+func (NopReporter) Report(error, map[string]any) {}
+
+var (
+	reporter   Reporter = NopReporter{}
+	reporterMu sync.RWMutex
+)
+
+// SetReporter replaces the global reporter. nil restores the no-op default.
+func SetReporter(r Reporter) {
+	if r == nil {
+		r = NopReporter{}
+	}
+	reporterMu.Lock()
+	defer reporterMu.Unlock()
+	reporter = r
+}
+
+// GetReporter returns the global reporter
+func GetReporter() Reporter {
+	reporterMu.RLock()
+	defer reporterMu.RUnlock()
+	return reporter
+}
+
+// Report sends err to the global reporter. A nil err is ignored.
+func Report(err error, ctx map[string]any) {
+	if err == nil {
+		return
+	}
+	GetReporter().Report(err, ctx)
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// SentryReporter reports errors to Sentry
+type SentryReporter struct {
+	hub *sentry.Hub
+}
+
+// NewSentryReporter creates a reporter for the project behind dsn
+func NewSentryReporter(dsn, environment string) (*SentryReporter, error) {
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:              dsn,
+		Environment:      environment,
+		AttachStacktrace: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SentryReporter{hub: sentry.NewHub(client, sentry.NewScope())}, nil
+}
+
+// Report captures err as a Sentry exception. request_id becomes a tag and
+// user_id the event's user, so events can be searched by either.
+func (r *SentryReporter) Report(err error, ctx map[string]any) {
+	r.hub.WithScope(func(scope *sentry.Scope) {
+		if len(ctx) > 0 {
+			scope.SetContext("details", ctx)
+		}
+		if id, ok := ctx["request_id"]; ok {
+			scope.SetTag("request_id", fmt.Sprint(id))
+		}
+		if id, ok := ctx["user_id"]; ok {
+			scope.SetUser(sentry.User{ID: fmt.Sprint(id)})
+		}
+		r.hub.CaptureException(err)
+	})
+}
+
+// Flush waits up to timeout for queued events to be sent
+func (r *SentryReporter) Flush(timeout time.Duration) bool {
+	return r.hub.Flush(timeout)
+}
@@ -0,0 +1,57 @@
+package factory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type post struct {
+	Title  string
+	Status string
+	Views  int
+}
+
+func postFactory() *Factory[post] {
+	return New(func(f *Faker) post {
+		return post{Title: f.Sentence(3), Status: "published", Views: f.IntBetween(0, 100)}
+	})
+}
+
+func TestFactory_CountAndState(t *testing.T) {
+	base := postFactory()
+	drafts := base.Count(3).
+		State(func(p *post) { p.Status = "draft" }).
+		State(func(p *post) { p.Title = p.Status + ": " + p.Title })
+
+	items := drafts.Make()
+	assert.Len(t, items, 3)
+	for _, p := range items {
+		assert.Equal(t, "draft", p.Status)
+		assert.Contains(t, p.Title, "draft: ")
+		assert.GreaterOrEqual(t, p.Views, 0)
+		assert.LessOrEqual(t, p.Views, 100)
+	}
+
+	// The base factory is untouched
+	single := base.Make()
+	assert.Len(t, single, 1)
+	assert.Equal(t, "published", single[0].Status)
+	assert.Empty(t, base.Count(-1).Make())
+}
+
+func TestFactory_Seed(t *testing.T) {
+	seeded := postFactory().Count(10).Seed(42)
+	assert.Equal(t, seeded.Make(), seeded.Make())
+	assert.NotEqual(t, seeded.Make(), postFactory().Count(10).Seed(43).Make())
+}
+
+func TestFaker_UniqueValues(t *testing.T) {
+	f := NewFaker(1)
+	seen := make(map[string]bool)
+	for range 500 {
+		email := f.Email()
+		assert.False(t, seen[email], email)
+		seen[email] = true
+	}
+}
@@ -0,0 +1,129 @@
+package factory
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+var (
+	firstNames = []string{
+		"Alice", "Bob", "Carol", "David", "Emma", "Frank", "Grace", "Henry", "Isla", "Jack",
+		"Kate", "Liam", "Mia", "Noah", "Olivia", "Peter", "Quinn", "Ruby", "Sam", "Tara",
+		"Uma", "Victor", "Wendy", "Xavier", "Yara", "Zane", "Wei", "Mei", "Hiro", "Sofia",
+	}
+	lastNames = []string{
+		"Smith", "Johnson", "Brown", "Taylor", "Wilson", "Davies", "Evans", "Thomas", "Roberts", "Walker",
+		"Wright", "Green", "Hall", "Wood", "Clarke", "Hughes", "Turner", "Hill", "Moore", "Cooper",
+		"Wang", "Li", "Zhang", "Chen", "Liu", "Tanaka", "Garcia", "Martin", "Rossi", "Novak",
+	}
+	emailDomains = []string{"example.com", "example.org", "example.net"}
+	words        = []string{
+		"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+		"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim",
+	}
+)
+
+// tokenChars are the characters of random tokens
+const tokenChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// Faker generates fake but realistic values. Values marked unique are never
+// repeated by the same Faker.
+type Faker struct {
+	rand *rand.Rand
+	seen map[string]struct{}
+}
+
+// NewFaker creates a Faker. The same non-zero seed yields the same values;
+// 0 picks a random seed.
+func NewFaker(seed uint64) *Faker {
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &Faker{
+		rand: rand.New(rand.NewPCG(seed, seed)),
+		seen: make(map[string]struct{}),
+	}
+}
+
+// Rand returns the underlying generator for values the Faker lacks
+func (f *Faker) Rand() *rand.Rand {
+	return f.rand
+}
+
+// IntBetween returns a number from min to max, both included
+func (f *Faker) IntBetween(min, max int) int {
+	return min + f.rand.IntN(max-min+1)
+}
+
+// Bool returns true or false with equal chance
+func (f *Faker) Bool() bool {
+	return f.rand.IntN(2) == 0
+}
+
+// Element returns one of items
+func (f *Faker) Element(items []string) string {
+	return items[f.rand.IntN(len(items))]
+}
+
+// Token returns n random lowercase letters and digits
+func (f *Faker) Token(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = tokenChars[f.rand.IntN(len(tokenChars))]
+	}
+	return string(b)
+}
+
+func (f *Faker) FirstName() string { return f.Element(firstNames) }
+func (f *Faker) LastName() string  { return f.Element(lastNames) }
+
+// Name returns a first and last name
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// Username returns a unique username such as "grace_walker_x7k2"
+func (f *Faker) Username() string {
+	return f.unique("username", func() string {
+		return strings.ToLower(f.FirstName()+"_"+f.LastName()) + "_" + f.Token(4)
+	})
+}
+
+// Email returns a unique address on a reserved example domain
+func (f *Faker) Email() string {
+	return f.unique("email", func() string {
+		local := strings.ToLower(f.FirstName() + "." + f.LastName())
+		return local + "." + f.Token(4) + "@" + f.Element(emailDomains)
+	})
+}
+
+// Phone returns a phone number in the reserved 555 range
+func (f *Faker) Phone() string {
+	return fmt.Sprintf("+1-555-%03d-%04d", f.rand.IntN(1000), f.rand.IntN(10000))
+}
+
+// Sentence returns n lorem ipsum words, capitalized and ending in a period
+func (f *Faker) Sentence(n int) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = f.Element(words)
+	}
+	s := strings.Join(parts, " ")
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
+
+// unique calls gen until it returns a value not yet seen under kind
+func (f *Faker) unique(kind string, gen func() string) string {
+	for {
+		v := gen()
+		key := kind + ":" + v
+		if _, ok := f.seen[key]; !ok {
+			f.seen[key] = struct{}{}
+			return v
+		}
+	}
+}
@@ -19,6 +19,7 @@ package handler
 import (
 	"strconv"
 
+	"github.com/zgiai/zgo/pkg/request"
 	"github.com/zgiai/zgo/pkg/response"
 	"github.com/gin-gonic/gin"
 )
@@ -103,6 +104,7 @@ func MustGetUserID(c *gin.Context) uint {
 }
 
 // BindJSON binds JSON request body and sends error response if invalid.
+// Validation failures get a 422 with one message per field.
 // Returns false if binding failed (error response already sent).
 //
 // Example:
@@ -113,19 +115,16 @@ func MustGetUserID(c *gin.Context) uint {
 //	}
 func BindJSON(c *gin.Context, obj any) bool {
 	if err := c.ShouldBindJSON(obj); err != nil {
-		response.BadRequest(c, "Invalid request parameters", err)
+		response.ValidationError(c, err)
 		return false
 	}
 	return true
 }
 
 // BindQuery binds query parameters and sends error response if invalid.
+// It is request.BindQuery, so failures are reported the same way as BindJSON.
 func BindQuery(c *gin.Context, obj any) bool {
-	if err := c.ShouldBindQuery(obj); err != nil {
-		response.BadRequest(c, "Invalid query parameters", err)
-		return false
-	}
-	return true
+	return request.BindQuery(c, obj)
 }
 
 // BindURI binds URI parameters and sends error response if invalid.
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"crypto/subtle"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -31,9 +32,18 @@ const (
 	AlgorithmArgon2 Algorithm = "argon2id"
 )
 
+// Bounds of the bcrypt cost factor
+const (
+	MinBcryptCost = bcrypt.MinCost
+	MaxBcryptCost = bcrypt.MaxCost
+)
+
 // DefaultAlgorithm is the default hashing algorithm
 var DefaultAlgorithm Algorithm = AlgorithmBcrypt
 
+// ErrUnsupportedAlgorithm is returned by NewHasher for unknown algorithms
+var ErrUnsupportedAlgorithm = errors.New("unsupported hash algorithm")
+
 // config holds the package configuration
 var config = struct {
 	bcryptCost   int
@@ -72,7 +82,7 @@ func Make(password string) (string, error) {
 
 // Check verifies a password against a hash (auto-detects algorithm)
 func Check(password, hash string) bool {
-	if strings.HasPrefix(hash, "$argon2id$") {
+	if isArgon2(hash) {
 		return CheckArgon2(password, hash)
 	}
 	return CheckBcrypt(password, hash)
@@ -80,12 +90,17 @@ func Check(password, hash string) bool {
 
 // NeedsRehash checks if a hash needs to be upgraded
 func NeedsRehash(hash string) bool {
-	if strings.HasPrefix(hash, "$argon2id$") {
+	if isArgon2(hash) {
 		return NeedsRehashArgon2(hash)
 	}
 	return NeedsRehashBcrypt(hash)
 }
 
+// isArgon2 reports whether hash is in argon2id PHC format
+func isArgon2(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
 // --- Bcrypt Functions ---
 
 // MakeBcrypt creates a bcrypt hash
@@ -227,6 +242,25 @@ func decodeArgon2Hash(encodedHash string) (Argon2Config, []byte, []byte, error)
 }
 
 // --- Hasher Implementations ---
+//
+// Each hasher makes hashes with its own algorithm but checks hashes made by
+// any of them, and reports foreign hashes as needing a rehash. Switching
+// algorithms therefore upgrades stored hashes as users log in.
+
+// NewHasher returns the hasher for algorithm; empty means DefaultAlgorithm
+func NewHasher(algorithm Algorithm) (Hasher, error) {
+	if algorithm == "" {
+		algorithm = DefaultAlgorithm
+	}
+	switch algorithm {
+	case AlgorithmBcrypt:
+		return NewBcryptHasher(), nil
+	case AlgorithmArgon2:
+		return NewArgon2Hasher(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, algorithm)
+	}
+}
 
 // BcryptHasher implements Hasher for bcrypt
 type BcryptHasher struct {
@@ -247,7 +281,7 @@ func (h *BcryptHasher) Make(password string) (string, error) {
 }
 
 func (h *BcryptHasher) Check(password, hash string) bool {
-	return CheckBcrypt(password, hash)
+	return Check(password, hash)
 }
 
 func (h *BcryptHasher) NeedsRehash(hash string) bool {
@@ -277,7 +311,7 @@ func (h *Argon2Hasher) Make(password string) (string, error) {
 }
 
 func (h *Argon2Hasher) Check(password, hash string) bool {
-	return CheckArgon2(password, hash)
+	return Check(password, hash)
 }
 
 func (h *Argon2Hasher) NeedsRehash(hash string) bool {
@@ -1,6 +1,8 @@
 package hash
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -138,3 +140,71 @@ func BenchmarkArgon2(b *testing.B) {
 		MakeArgon2("password")
 	}
 }
+
+func TestNewHasher(t *testing.T) {
+	for algorithm, want := range map[Algorithm]string{
+		"":              "$2a$",
+		AlgorithmBcrypt: "$2a$",
+		AlgorithmArgon2: "$argon2id$",
+	} {
+		hasher, err := NewHasher(algorithm)
+		if err != nil {
+			t.Fatalf("NewHasher(%q) failed: %v", algorithm, err)
+		}
+		hash, _ := hasher.Make("secret123")
+		if !strings.HasPrefix(hash, want) {
+			t.Errorf("NewHasher(%q) made %q, want prefix %q", algorithm, hash, want)
+		}
+	}
+
+	if _, err := NewHasher("md5"); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("NewHasher(md5) error = %v, want ErrUnsupportedAlgorithm", err)
+	}
+}
+
+func TestHashers_CrossAlgorithm(t *testing.T) {
+	password := "secret123"
+	bcryptHasher := NewBcryptHasher(4)
+	argon2Hasher := NewArgon2Hasher()
+
+	bcryptHash, _ := bcryptHasher.Make(password)
+	argon2Hash, _ := argon2Hasher.Make(password)
+
+	// Either hasher checks hashes made by the other
+	if !argon2Hasher.Check(password, bcryptHash) {
+		t.Error("Argon2Hasher.Check should accept a bcrypt hash")
+	}
+	if !bcryptHasher.Check(password, argon2Hash) {
+		t.Error("BcryptHasher.Check should accept an argon2 hash")
+	}
+	if argon2Hasher.Check("wrong", bcryptHash) {
+		t.Error("Argon2Hasher.Check should reject a wrong password")
+	}
+
+	// ...and wants them re-hashed with its own algorithm
+	if !argon2Hasher.NeedsRehash(bcryptHash) {
+		t.Error("Argon2Hasher should rehash bcrypt hashes")
+	}
+	if !bcryptHasher.NeedsRehash(argon2Hash) {
+		t.Error("BcryptHasher should rehash argon2 hashes")
+	}
+	if bcryptHasher.NeedsRehash(bcryptHash) || argon2Hasher.NeedsRehash(argon2Hash) {
+		t.Error("hashes made with current settings should not need a rehash")
+	}
+}
+
+func TestHashers_RehashOnParameterChange(t *testing.T) {
+	bcryptHash, _ := NewBcryptHasher(4).Make("secret123")
+	if !NewBcryptHasher(5).NeedsRehash(bcryptHash) {
+		t.Error("a higher bcrypt cost should trigger a rehash")
+	}
+	if NewBcryptHasher(4).NeedsRehash(bcryptHash) {
+		t.Error("the same bcrypt cost should not trigger a rehash")
+	}
+
+	weak := Argon2Config{Memory: 8 * 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	argon2Hash, _ := NewArgon2Hasher(weak).Make("secret123")
+	if !NewArgon2Hasher().NeedsRehash(argon2Hash) {
+		t.Error("stronger argon2 parameters should trigger a rehash")
+	}
+}
@@ -0,0 +1,55 @@
+package logger
+
+import "context"
+
+// requestIDKey is the context key for the request ID
+type requestIDKey struct{}
+
+// loggerKey is the context key for a request-scoped logger
+type loggerKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or ""
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithContext returns a copy of ctx carrying l, so everything logged through
+// FromContext further down the request includes l's fields:
+//
+//	log := logger.FromContext(ctx).With(map[string]any{"user_id": user.ID})
+//	c.Request = c.Request.WithContext(logger.WithContext(ctx, log))
+func WithContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// attached returns the logger stored in ctx by WithContext
+func attached(ctx context.Context) (*Logger, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	l, ok := ctx.Value(loggerKey{}).(*Logger)
+	return l, ok && l != nil
+}
+
+// FromContext returns the logger attached to ctx by WithContext. Without one
+// it returns the default logger tagged with the request ID from ctx, or the
+// default logger unchanged if there is no request ID either.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := attached(ctx); ok {
+		return l
+	}
+	id := RequestIDFromContext(ctx)
+	if id == "" {
+		return Default()
+	}
+	return Default().With(map[string]any{"request_id": id})
+}
@@ -49,6 +49,9 @@ func (l *Logger) inputHandlers(cfg *Config) {
 	// Add Console Handler if enabled
 	if cfg.StdoutPrint {
 		consoleHandler := NewConsoleHandler(cfg.Level, cfg.ColorEnabled)
+		if cfg.JSON {
+			consoleHandler.SetFormat(FormatJSON)
+		}
 		l.AddHandler(consoleHandler)
 	}
 }
@@ -79,15 +82,22 @@ func (l *Logger) Channel(name string) *Logger {
 	return newLogger
 }
 
-// WithContext returns a new logger instance with the specified context
-func (l *Logger) WithContext(ctx map[string]any) *Logger {
+// With returns a child logger whose entries carry fields on top of the
+// parent's; a field in a call's own context still wins. The child shares the
+// parent's handlers, so level and format changes apply to both.
+func (l *Logger) With(fields map[string]any) *Logger {
 	newLogger := l.clone()
-	for k, v := range ctx {
+	for k, v := range fields {
 		newLogger.context[k] = v
 	}
 	return newLogger
 }
 
+// WithContext is an alias for With
+func (l *Logger) WithContext(ctx map[string]any) *Logger {
+	return l.With(ctx)
+}
+
 func (l *Logger) clone() *Logger {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
@@ -115,6 +125,31 @@ func (l *Logger) AddHandler(h Handler) {
 	l.handlers = append(l.handlers, h)
 }
 
+// SetLevel changes the minimum level of every handler that follows LOG_LEVEL.
+// Handlers with their own threshold, like Sentry and ClickHouse, are unaffected.
+func (l *Logger) SetLevel(level Level) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, h := range l.handlers {
+		if s, ok := h.(LevelSetter); ok {
+			s.SetLevel(level)
+		}
+	}
+}
+
+// SetFormat switches every handler that follows LOG_FORMAT to format
+func (l *Logger) SetFormat(format Format) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, h := range l.handlers {
+		if s, ok := h.(FormatSetter); ok {
+			s.SetFormat(format)
+		}
+	}
+}
+
 // Log logs a message with the specified level
 func (l *Logger) Log(level Level, msg string, ctx map[string]any) {
 	entry := &Entry{
@@ -132,15 +167,17 @@ func (l *Logger) Log(level Level, msg string, ctx map[string]any) {
 	for _, h := range handlers {
 		_ = h.Handle(context.Background(), entry)
 	}
+	report(entry)
 }
 
 func (l *Logger) log(ctx context.Context, level Level, msg string, logCtx map[string]any) {
 	entry := &Entry{
-		Level:   level,
-		Message: msg,
-		Context: l.mergeContext(logCtx),
-		Time:    time.Now(),
-		Channel: l.channel,
+		Level:     level,
+		Message:   msg,
+		Context:   l.mergeContext(logCtx),
+		Time:      time.Now(),
+		Channel:   l.channel,
+		RequestID: RequestIDFromContext(ctx),
 	}
 
 	l.mu.RLock()
@@ -150,6 +187,7 @@ func (l *Logger) log(ctx context.Context, level Level, msg string, logCtx map[st
 	for _, h := range handlers {
 		_ = h.Handle(ctx, entry)
 	}
+	report(entry)
 }
 
 func (l *Logger) mergeContext(ctx map[string]any) map[string]any {
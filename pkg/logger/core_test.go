@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apperrors "github.com/zgiai/zgo/pkg/errors"
+)
+
+// newBufferLogger returns a logger writing plain console lines to a buffer
+func newBufferLogger(level Level) (*Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	h := NewConsoleHandler(level, false)
+	h.writer = &buf
+
+	l := &Logger{}
+	l.AddHandler(h)
+	return l, &buf
+}
+
+func TestLogger_LevelFiltering(t *testing.T) {
+	l, buf := newBufferLogger(ParseLevel("warning"))
+
+	l.Debug("debug line")
+	l.Info("info line")
+	l.Warning("warning line")
+	l.Error("error line")
+
+	out := buf.String()
+	assert.NotContains(t, out, "debug line")
+	assert.NotContains(t, out, "info line")
+	assert.Contains(t, out, "warning line")
+	assert.Contains(t, out, "error line")
+
+	// Lowering the level applies to loggers derived earlier too
+	child := l.With(map[string]any{"k": "v"})
+	l.SetLevel(LevelDebug)
+	child.Debug("child debug line")
+	assert.Contains(t, buf.String(), "child debug line")
+}
+
+func TestLogger_WithMergesFields(t *testing.T) {
+	l, buf := newBufferLogger(LevelDebug)
+	l.SetFormat(FormatJSON)
+
+	parent := l.With(map[string]any{"service": "api", "user_id": 1})
+	child := parent.With(map[string]any{"user_id": 2, "order_id": 7})
+	child.Info("merged", map[string]any{"order_id": 8})
+	parent.Info("parent")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var merged, untouched struct {
+		Message string         `json:"message"`
+		Context map[string]any `json:"context"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &merged))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &untouched))
+
+	assert.Equal(t, "merged", merged.Message)
+	assert.Equal(t, map[string]any{"service": "api", "user_id": 2.0, "order_id": 8.0}, merged.Context)
+	assert.Equal(t, map[string]any{"service": "api", "user_id": 1.0}, untouched.Context, "children do not change the parent")
+}
+
+func TestContext_AttachAndRetrieve(t *testing.T) {
+	l, _ := newBufferLogger(LevelDebug)
+	prev := Default()
+	SetDefault(l)
+	t.Cleanup(func() { SetDefault(prev) })
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	assert.Equal(t, map[string]any{"request_id": "req-1"}, FromContext(ctx).context)
+
+	scoped := FromContext(ctx).With(map[string]any{"user_id": 42})
+	ctx = WithContext(ctx, scoped)
+	assert.Same(t, scoped, FromContext(ctx))
+	assert.Same(t, scoped, Ctx(ctx).l)
+
+	assert.Same(t, l, FromContext(context.Background()))
+}
+
+func TestGinLogger_UsesRequestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	l, buf := newBufferLogger(LevelDebug)
+	l.SetFormat(FormatJSON)
+	prev := Default()
+	SetDefault(l)
+	t.Cleanup(func() { SetDefault(prev) })
+
+	r := gin.New()
+	r.Use(GinLogger())
+	r.GET("/orders", func(c *gin.Context) {
+		log := FromContext(c.Request.Context()).With(map[string]any{"user_id": 42})
+		c.Request = c.Request.WithContext(WithContext(c.Request.Context(), log))
+		c.Status(http.StatusOK)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	var entry struct {
+		Message string         `json:"message"`
+		Context map[string]any `json:"context"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "HTTP Request", entry.Message)
+	assert.Equal(t, 42.0, entry.Context["user_id"])
+	assert.Equal(t, "/orders", entry.Context["path"])
+}
+
+func TestParseFormat(t *testing.T) {
+	assert.Equal(t, FormatJSON, ParseFormat(" JSON "))
+	assert.Equal(t, FormatConsole, ParseFormat("console"))
+	assert.Equal(t, FormatConsole, ParseFormat(""))
+}
+
+func TestLogger_ErrorsAreReported(t *testing.T) {
+	var reports []error
+	var fields []map[string]any
+	apperrors.SetReporter(apperrors.ReporterFunc(func(err error, ctx map[string]any) {
+		reports = append(reports, err)
+		fields = append(fields, ctx)
+	}))
+	t.Cleanup(func() { apperrors.SetReporter(nil) })
+
+	l, _ := newBufferLogger(LevelDebug)
+	l = l.With(map[string]any{"request_id": "req-1"})
+
+	l.Warning("slow query")
+	assert.Empty(t, reports, "warnings are not reported")
+
+	l.Error("charge failed", map[string]any{"error": "card declined"})
+	l.Critical("disk full")
+	require.Len(t, reports, 2)
+	assert.EqualError(t, reports[0], "charge failed: card declined")
+	assert.Equal(t, "req-1", fields[0]["request_id"])
+	assert.Equal(t, "ERROR", fields[0]["level"])
+	assert.EqualError(t, reports[1], "disk full")
+}
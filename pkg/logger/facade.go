@@ -10,9 +10,19 @@ func Channel(name string) *Logger {
 	return Default().Channel(name)
 }
 
-// WithContext returns a logger with additional context
-func WithContext(ctx map[string]any) *Logger {
-	return Default().WithContext(ctx)
+// With returns the default logger with additional fields
+func With(fields map[string]any) *Logger {
+	return Default().With(fields)
+}
+
+// SetLevel changes the minimum level of the default logger
+func SetLevel(level Level) {
+	Default().SetLevel(level)
+}
+
+// SetFormat changes the output format of the default logger
+func SetFormat(format Format) {
+	Default().SetFormat(format)
 }
 
 // Debug logs a debug message
@@ -126,9 +136,14 @@ type ContextLog struct {
 	l   *Logger
 }
 
-// Ctx returns a context-aware logger
+// Ctx returns a context-aware logger, using the logger attached to ctx by
+// WithContext when there is one
 func Ctx(ctx context.Context) *ContextLog {
-	return &ContextLog{ctx: ctx, l: Default()}
+	l, ok := attached(ctx)
+	if !ok {
+		l = Default()
+	}
+	return &ContextLog{ctx: ctx, l: l}
 }
 
 // Debug logs a debug message with context
@@ -0,0 +1,26 @@
+package logger
+
+import "strings"
+
+// Format selects how handlers render entries
+type Format string
+
+const (
+	// FormatConsole renders one human-readable line per entry
+	FormatConsole Format = "console"
+	// FormatJSON renders one JSON object per entry, for log collectors
+	FormatJSON Format = "json"
+)
+
+// FormatSetter is implemented by handlers whose output format follows LOG_FORMAT
+type FormatSetter interface {
+	SetFormat(format Format)
+}
+
+// ParseFormat parses a format string, falling back to FormatConsole
+func ParseFormat(s string) Format {
+	if strings.EqualFold(strings.TrimSpace(s), string(FormatJSON)) {
+		return FormatJSON
+	}
+	return FormatConsole
+}
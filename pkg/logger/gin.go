@@ -6,7 +6,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// GinLogger returns a gin.HandlerFunc that logs requests using the platform logger
+// GinLogger returns a gin.HandlerFunc that logs requests using the platform
+// logger. The logger comes from the request context, so fields attached
+// with WithContext while handling the request appear on the log line.
 func GinLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -26,7 +28,7 @@ func GinLogger() gin.HandlerFunc {
 			path = path + "?" + raw
 		}
 
-		// Use the platform logger
+		// Use the request-scoped logger
 		fields := map[string]any{
 			"status":    statusCode,
 			"latency":   latency.String(),
@@ -34,12 +36,24 @@ func GinLogger() gin.HandlerFunc {
 			"method":    method,
 			"path":      path,
 		}
+		if id := requestID(c); id != "" {
+			fields["request_id"] = id
+		}
 
+		log := FromContext(c.Request.Context())
 		if len(c.Errors) > 0 {
 			fields["errors"] = c.Errors.String()
-			Error("HTTP Request Error", fields)
+			log.Error("HTTP Request Error", fields)
 		} else {
-			Info("HTTP Request", fields)
+			log.Info("HTTP Request", fields)
 		}
 	}
 }
+
+// requestID returns the ID set by the RequestID middleware, if any
+func requestID(c *gin.Context) string {
+	if id := RequestIDFromContext(c.Request.Context()); id != "" {
+		return id
+	}
+	return c.GetString("request_id")
+}
@@ -18,6 +18,11 @@ type Handler interface {
 	Close() error
 }
 
+// LevelSetter is implemented by handlers whose minimum level follows LOG_LEVEL
+type LevelSetter interface {
+	SetLevel(level Level)
+}
+
 // Entry represents a log entry
 type Entry struct {
 	Level     Level
@@ -36,6 +41,7 @@ type ConsoleHandler struct {
 	level        Level
 	colorEnabled bool
 	timeFormat   string
+	json         bool
 }
 
 // NewConsoleHandler creates a new console handler
@@ -48,13 +54,36 @@ func NewConsoleHandler(level Level, colorEnabled bool) *ConsoleHandler {
 	}
 }
 
+// SetLevel changes the minimum level at runtime
+func (h *ConsoleHandler) SetLevel(level Level) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.level = level
+}
+
+// SetFormat switches between colored lines and JSON at runtime
+func (h *ConsoleHandler) SetFormat(format Format) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.json = format == FormatJSON
+}
+
 func (h *ConsoleHandler) Handle(ctx context.Context, entry *Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	if entry.Level < h.level {
 		return nil
 	}
 
-	h.mu.Lock()
-	defer h.mu.Unlock()
+	if h.json {
+		data, err := formatJSON(entry, time.RFC3339)
+		if err != nil {
+			return err
+		}
+		_, err = h.writer.Write(data)
+		return err
+	}
 
 	var sb strings.Builder
 
@@ -133,14 +162,28 @@ func NewFileHandler(cfg *Config) *FileHandler {
 	}
 }
 
+// SetLevel changes the minimum level at runtime
+func (h *FileHandler) SetLevel(level Level) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.level = level
+}
+
+// SetFormat switches between text lines and JSON at runtime
+func (h *FileHandler) SetFormat(format Format) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.json = format == FormatJSON
+}
+
 func (h *FileHandler) Handle(ctx context.Context, entry *Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	if entry.Level < h.level {
 		return nil
 	}
 
-	h.mu.Lock()
-	defer h.mu.Unlock()
-
 	if err := h.ensureFile(entry.Time); err != nil {
 		return err
 	}
@@ -149,7 +192,7 @@ func (h *FileHandler) Handle(ctx context.Context, entry *Entry) error {
 	var err error
 
 	if h.json {
-		data, err = h.formatJSON(entry)
+		data, err = formatJSON(entry, h.timeFormat)
 	} else {
 		data = h.formatText(entry)
 	}
@@ -164,9 +207,10 @@ func (h *FileHandler) Handle(ctx context.Context, entry *Entry) error {
 	return err
 }
 
-func (h *FileHandler) formatJSON(entry *Entry) ([]byte, error) {
+// formatJSON renders entry as one line of JSON
+func formatJSON(entry *Entry, timeFormat string) ([]byte, error) {
 	record := map[string]any{
-		"time":    entry.Time.Format(h.timeFormat),
+		"time":    entry.Time.Format(timeFormat),
 		"level":   entry.Level.String(),
 		"message": entry.Message,
 	}
@@ -30,6 +30,9 @@ func Boot() *Logger {
 	cfg.MaxBackups = env.GetInt("LOG_MAX_BACKUPS", 7)
 	cfg.Compress = env.GetBool("LOG_COMPRESS", true)
 	cfg.JSON = env.GetBool("LOG_JSON", appEnv == "production")
+	if format := env.Get("LOG_FORMAT", ""); format != "" {
+		cfg.JSON = ParseFormat(format) == FormatJSON
+	}
 
 	// In debug mode, always output to stdout with colors
 	if isDebug {
@@ -39,12 +42,8 @@ func Boot() *Logger {
 
 	l := New(cfg)
 
-	// Add Sentry Handler if DSN is provided
-	if sentryDSN := env.Get("SENTRY_DSN", ""); sentryDSN != "" {
-		if sHandler, err := NewSentryHandler(sentryDSN, appEnv); err == nil {
-			l.AddHandler(sHandler)
-		}
-	}
+	// Error and above reach Sentry through the errors reporter, installed
+	// from SENTRY_DSN once config is loaded
 
 	// Add ClickHouse Handler if enabled
 	if env.GetBool("LOG_CH_ENABLED", false) {
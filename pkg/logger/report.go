@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+
+	apperrors "github.com/zgiai/zgo/pkg/errors"
+)
+
+// report forwards Error and above to the error reporter set with
+// errors.SetReporter. The entry's "error" field, if any, is appended to the
+// message so reports of the same failure group together.
+func report(entry *Entry) {
+	if entry.Level < LevelError {
+		return
+	}
+
+	var err error
+	switch detail := entry.Context["error"].(type) {
+	case error:
+		err = fmt.Errorf("%s: %w", entry.Message, detail)
+	case string:
+		if detail != "" {
+			err = fmt.Errorf("%s: %s", entry.Message, detail)
+		}
+	}
+	if err == nil {
+		err = errors.New(entry.Message)
+	}
+
+	ctx := copyMap(entry.Context)
+	ctx["level"] = entry.Level.String()
+	if entry.Channel != "" {
+		ctx["channel"] = entry.Channel
+	}
+	if entry.RequestID != "" {
+		ctx["request_id"] = entry.RequestID
+	}
+	apperrors.Report(err, ctx)
+}
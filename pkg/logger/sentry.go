@@ -8,6 +8,9 @@ import (
 )
 
 // SentryHandler sends logs to Sentry
+//
+// Deprecated: every Error-level log is forwarded to the reporter set with
+// errors.SetReporter; use errors.NewSentryReporter instead.
 type SentryHandler struct {
 	level Level
 }
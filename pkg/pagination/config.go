@@ -0,0 +1,93 @@
+package pagination
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// OverMax decides what happens to a page size above Config.MaxPageSize
+type OverMax int
+
+const (
+	// ClampPageSize serves MaxPageSize items instead
+	ClampPageSize OverMax = iota
+	// RejectPageSize fails the request with ErrPageSizeTooLarge
+	RejectPageSize
+)
+
+// ErrPageSizeTooLarge is returned by Validate under RejectPageSize. It wraps
+// response.ErrValidation, so response.HandleError answers 422.
+var ErrPageSizeTooLarge = fmt.Errorf("pagination: page size exceeds the maximum: %w", response.ErrValidation)
+
+// Config holds the page size rules shared by every parser in this package
+type Config struct {
+	// DefaultPageSize is used when the client sends no page size, or one below 1
+	// Default: 15
+	DefaultPageSize int
+
+	// MaxPageSize is the largest page size served
+	// Default: 100
+	MaxPageSize int
+
+	// OverMax handles page sizes above MaxPageSize
+	// Default: ClampPageSize
+	OverMax OverMax
+}
+
+// DefaultConfig returns default configuration
+func DefaultConfig() Config {
+	return Config{
+		DefaultPageSize: DefaultPerPage,
+		MaxPageSize:     MaxPerPage,
+		OverMax:         ClampPageSize,
+	}
+}
+
+var currentConfig atomic.Pointer[Config]
+
+// SetConfig replaces the page size rules for the whole application. Call it
+// once during initialization; zero fields keep their defaults.
+//
+// Example:
+//
+//	pagination.SetConfig(pagination.Config{DefaultPageSize: 20, MaxPageSize: 50, OverMax: pagination.RejectPageSize})
+func SetConfig(cfg Config) {
+	defaults := DefaultConfig()
+	if cfg.DefaultPageSize < 1 {
+		cfg.DefaultPageSize = defaults.DefaultPageSize
+	}
+	if cfg.MaxPageSize < 1 {
+		cfg.MaxPageSize = defaults.MaxPageSize
+	}
+	cfg.DefaultPageSize = min(cfg.DefaultPageSize, cfg.MaxPageSize)
+	currentConfig.Store(&cfg)
+}
+
+// CurrentConfig returns the page size rules in effect
+func CurrentConfig() Config {
+	if cfg := currentConfig.Load(); cfg != nil {
+		return *cfg
+	}
+	return DefaultConfig()
+}
+
+// pageSize applies the current rules to a requested page size. Sizes over
+// the maximum are clamped here either way; Validate is what rejects them.
+func pageSize(requested int) int {
+	cfg := CurrentConfig()
+	if requested < 1 {
+		return cfg.DefaultPageSize
+	}
+	return min(requested, cfg.MaxPageSize)
+}
+
+// validatePageSize returns ErrPageSizeTooLarge for sizes the current rules reject
+func validatePageSize(requested int) error {
+	cfg := CurrentConfig()
+	if cfg.OverMax == RejectPageSize && requested > cfg.MaxPageSize {
+		return fmt.Errorf("%w: %d requested, at most %d allowed", ErrPageSizeTooLarge, requested, cfg.MaxPageSize)
+	}
+	return nil
+}
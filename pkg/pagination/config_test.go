@@ -0,0 +1,75 @@
+package pagination
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+func useConfig(t *testing.T, cfg Config) {
+	t.Helper()
+	SetConfig(cfg)
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+}
+
+func contextWithQuery(rawQuery string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/items?"+rawQuery, nil)
+	return c
+}
+
+func TestParsersAgreeUnderCustomConfig(t *testing.T) {
+	useConfig(t, Config{DefaultPageSize: 20, MaxPageSize: 50})
+
+	queries := []string{
+		"",
+		"page=3",
+		"per_page=10",
+		"per_page=0",
+		"per_page=-5",
+		"per_page=75",
+		"per_page=abc&page=abc",
+		"page_size=30",
+		"per_page=10&page_size=30",
+		"page=2&per_page=25&keyword=john&sort=created_at&order=asc",
+	}
+	for _, raw := range queries {
+		query, err := url.ParseQuery(raw)
+		require.NoError(t, err)
+
+		fromQuery := FromQuery(query)
+		fromContext := FromContext(contextWithQuery(raw))
+		assert.Equal(t, fromQuery, fromContext, raw)
+		assert.Equal(t, fromQuery.GetPerPage(), fromContext.GetPageSize(), raw)
+		assert.Equal(t, fromQuery.GetPerPage(), CursorFromContext(contextWithQuery(raw)).GetPerPage(), raw)
+	}
+
+	assert.Equal(t, 20, FromQuery(nil).GetPerPage())
+	assert.Equal(t, 20, FromQuery(url.Values{"per_page": {"0"}}).GetPerPage())
+	assert.Equal(t, 50, FromQuery(url.Values{"per_page": {"75"}}).GetPerPage())
+	assert.Equal(t, 30, FromQuery(url.Values{"page_size": {"30"}}).GetPerPage())
+	assert.Equal(t, 50, NewPaginator([]string{}, 100, 1, 75).PerPage())
+}
+
+func TestOverMaxBehavior(t *testing.T) {
+	over := url.Values{"per_page": {"75"}}
+
+	useConfig(t, Config{MaxPageSize: 50})
+	assert.NoError(t, FromQuery(over).Validate(), "clamping is the default")
+	assert.Equal(t, 50, FromQuery(over).GetPerPage())
+
+	useConfig(t, Config{MaxPageSize: 50, OverMax: RejectPageSize})
+	err := FromQuery(over).Validate()
+	assert.True(t, errors.Is(err, ErrPageSizeTooLarge))
+	assert.True(t, errors.Is(err, response.ErrValidation))
+	assert.Equal(t, 422, response.StatusCode(err))
+	assert.NoError(t, FromQuery(url.Values{"per_page": {"50"}}).Validate())
+	assert.Error(t, (&CursorRequest{PerPage: 75}).Validate())
+}
@@ -1,12 +1,18 @@
 package pagination
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/response"
 	"gorm.io/gorm"
 )
 
@@ -40,22 +46,75 @@ type Cursor struct {
 	Direction string `json:"d"` // "next" or "prev"
 }
 
-// Encode encodes the cursor to a base64 string.
+// ErrInvalidCursor is returned for cursors that are malformed, forged or
+// were issued for another sort field. It wraps response.ErrValidation, so
+// response.HandleError answers 422.
+var ErrInvalidCursor = fmt.Errorf("pagination: invalid cursor: %w", response.ErrValidation)
+
+// cursorSecret signs cursors. It starts random, so cursors are safe before
+// SetCursorSecret is called but only valid within this process.
+var cursorSecret atomic.Pointer[[]byte]
+
+func init() {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(fmt.Sprintf("pagination: failed to generate cursor secret: %v", err))
+	}
+	cursorSecret.Store(&secret)
+}
+
+// SetCursorSecret sets the key cursors are signed with. Call it once during
+// initialization with a stable secret, such as APP_KEY, so cursors stay
+// valid across restarts and instances. Cursors signed with the previous
+// secret are rejected.
+func SetCursorSecret(secret []byte) {
+	secret = append([]byte(nil), secret...)
+	cursorSecret.Store(&secret)
+}
+
+// signCursor returns the HMAC of a cursor payload
+func signCursor(payload string) []byte {
+	mac := hmac.New(sha256.New, *cursorSecret.Load())
+	// Keeps cursor signatures distinct from anything else signed with the same key
+	mac.Write([]byte("pagination.cursor:"))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// Encode encodes the cursor to an opaque, URL-safe token signed with the
+// cursor secret, so clients cannot edit it to scan other ranges.
 func (c *Cursor) Encode() string {
 	data, _ := json.Marshal(c)
-	return base64.URLEncoding.EncodeToString(data)
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signCursor(payload))
 }
 
-// DecodeCursor decodes a base64 cursor string.
+// DecodeCursor verifies and decodes a token made by Encode.
+// It returns ErrInvalidCursor if the token was altered or not signed with
+// the current cursor secret. Timestamp values come back as time.Time so
+// they compare as times, not strings.
 func DecodeCursor(encoded string) (*Cursor, error) {
-	data, err := base64.URLEncoding.DecodeString(encoded)
-	if err != nil {
-		return nil, fmt.Errorf("invalid cursor encoding: %w", err)
+	payload, sig, ok := strings.Cut(encoded, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, signCursor(payload)) {
+		return nil, ErrInvalidCursor
 	}
 
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
 	var cursor Cursor
 	if err := json.Unmarshal(data, &cursor); err != nil {
-		return nil, fmt.Errorf("invalid cursor format: %w", err)
+		return nil, ErrInvalidCursor
+	}
+	if s, ok := cursor.Value.(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			cursor.Value = t
+		}
 	}
 
 	return &cursor, nil
@@ -67,15 +126,15 @@ type CursorRequest struct {
 	PerPage int    `form:"per_page" json:"per_page"`
 }
 
-// GetPerPage returns items per page with bounds.
+// GetPerPage returns items per page, bounded by the current Config.
 func (r *CursorRequest) GetPerPage() int {
-	if r.PerPage < 1 {
-		return DefaultPerPage
-	}
-	if r.PerPage > MaxPerPage {
-		return MaxPerPage
-	}
-	return r.PerPage
+	return pageSize(r.PerPage)
+}
+
+// Validate returns ErrPageSizeTooLarge when the page size is over the
+// maximum and the current Config rejects such sizes instead of clamping.
+func (r *CursorRequest) Validate() error {
+	return validatePageSize(r.PerPage)
 }
 
 // CursorFromContext extracts cursor request from Gin context.
@@ -83,9 +142,8 @@ func CursorFromContext(c *gin.Context) *CursorRequest {
 	req := &CursorRequest{}
 	req.Cursor = c.Query("cursor")
 
-	if perPage, err := fmt.Sscanf(c.DefaultQuery("per_page", "15"), "%d", &req.PerPage); err != nil || perPage < 1 {
-		req.PerPage = DefaultPerPage
-	}
+	// Same page size rules as FromQuery
+	req.PerPage = FromQuery(c.Request.URL.Query()).PerPage
 
 	return req
 }
@@ -187,6 +245,10 @@ func CursorPaginate[T any](
 	order string,
 	idField string,
 ) ([]T, *CursorPaginator[T], error) {
+	if err := req.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	var items []T
 	perPage := req.GetPerPage()
 
@@ -197,7 +259,11 @@ func CursorPaginate[T any](
 	if req.Cursor != "" {
 		cursor, err := DecodeCursor(req.Cursor)
 		if err != nil {
-			return nil, nil, fmt.Errorf("invalid cursor: %w", err)
+			return nil, nil, err
+		}
+		// A cursor from a listing sorted by another field would skip rows
+		if cursor.Field != cursorField {
+			return nil, nil, ErrInvalidCursor
 		}
 
 		// Build cursor condition based on order
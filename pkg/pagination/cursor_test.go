@@ -0,0 +1,134 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"github.com/zgiai/zgo/pkg/response"
+	"gorm.io/gorm"
+)
+
+type event struct {
+	ID        uint
+	Name      string
+	CreatedAt time.Time
+}
+
+func (e event) GetID() uint             { return e.ID }
+func (e event) GetCreatedAt() time.Time { return e.CreatedAt }
+
+func setupCursorDB(t *testing.T, n int) *gorm.DB {
+	t.Helper()
+	db := dbtest.Open(t, &event{})
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range n {
+		// Pairs share a timestamp, so pages must break ties on id
+		require.NoError(t, db.Create(&event{Name: string(rune('a' + i)), CreatedAt: start.Add(time.Duration(i/2) * time.Minute)}).Error)
+	}
+	return db
+}
+
+func withCursorSecret(t *testing.T, secret string) {
+	t.Helper()
+	prev := *cursorSecret.Load()
+	SetCursorSecret([]byte(secret))
+	t.Cleanup(func() { SetCursorSecret(prev) })
+}
+
+func TestCursorPaginate_RoundTripsAcrossPages(t *testing.T) {
+	withCursorSecret(t, "secret")
+	db := setupCursorDB(t, 7)
+
+	var names []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 4, "pagination does not terminate")
+		items, paginator, err := CursorPaginate[event](db, &CursorRequest{Cursor: cursor, PerPage: 3}, "created_at", "asc", "id")
+		require.NoError(t, err)
+		for _, e := range items {
+			names = append(names, e.Name)
+		}
+		if !paginator.HasMore() {
+			assert.Nil(t, paginator.NextCursor())
+			break
+		}
+		cursor = *paginator.NextCursor()
+		assert.NotContains(t, cursor, "=", "cursors are URL-safe without escaping")
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f", "g"}, names)
+
+	// And back the other way
+	names = nil
+	cursor = ""
+	for {
+		items, paginator, err := CursorPaginate[event](db, &CursorRequest{Cursor: cursor, PerPage: 4}, "created_at", "desc", "id")
+		require.NoError(t, err)
+		for _, e := range items {
+			names = append(names, e.Name)
+		}
+		if !paginator.HasMore() {
+			break
+		}
+		cursor = *paginator.NextCursor()
+	}
+	assert.Equal(t, []string{"g", "f", "e", "d", "c", "b", "a"}, names)
+}
+
+func TestCursorPaginate_RejectsTamperedCursor(t *testing.T) {
+	withCursorSecret(t, "secret")
+	db := setupCursorDB(t, 5)
+
+	_, paginator, err := CursorPaginate[event](db, &CursorRequest{PerPage: 2}, "created_at", "asc", "id")
+	require.NoError(t, err)
+	require.NotNil(t, paginator.NextCursor())
+	cursor := *paginator.NextCursor()
+
+	payload, sig, ok := strings.Cut(cursor, ".")
+	require.True(t, ok)
+
+	// Rewrite the payload to jump back to the start, keeping the old signature
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	require.NoError(t, err)
+	var c Cursor
+	require.NoError(t, json.Unmarshal(data, &c))
+	c.ID = 0
+	c.Value = time.Time{}
+	forged, err := json.Marshal(c)
+	require.NoError(t, err)
+
+	other := &Cursor{Field: "name", Value: "a", ID: 1}
+	tampered := map[string]string{
+		"edited payload":   base64.RawURLEncoding.EncodeToString(forged) + "." + sig,
+		"unsigned":         payload,
+		"plain base64":     base64.URLEncoding.EncodeToString(data),
+		"bad signature":    payload + ".AAAA",
+		"garbage":          "not-a-cursor",
+		"other sort field": other.Encode(),
+	}
+	for name, cursor := range tampered {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := CursorPaginate[event](db, &CursorRequest{Cursor: cursor, PerPage: 2}, "created_at", "asc", "id")
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+			assert.ErrorIs(t, err, response.ErrValidation)
+		})
+	}
+}
+
+func TestDecodeCursor_RejectsOtherSecret(t *testing.T) {
+	withCursorSecret(t, "old")
+	token := (&Cursor{Field: "created_at", ID: 3}).Encode()
+
+	decoded, err := DecodeCursor(token)
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), decoded.ID)
+
+	SetCursorSecret([]byte("new"))
+	_, err = DecodeCursor(token)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -60,12 +60,7 @@ func NewPaginator[T any](items []T, total int64, page, perPage int) *Paginator[T
 	if page < 1 {
 		page = 1
 	}
-	if perPage < 1 {
-		perPage = DefaultPerPage
-	}
-	if perPage > MaxPerPage {
-		perPage = MaxPerPage
-	}
+	perPage = pageSize(perPage)
 
 	lastPage := int(math.Ceil(float64(total) / float64(perPage)))
 	if lastPage < 1 {
@@ -623,6 +618,9 @@ func PaginateWithPath[T any](db *gorm.DB, req *Request, path string) ([]T, *Pagi
 //	}
 func New[T any](c *gin.Context, db *gorm.DB) ([]T, *Paginator[T], error) {
 	req := FromContext(c)
+	if err := req.Validate(); err != nil {
+		return nil, nil, err
+	}
 	items, paginator, err := Paginate[T](db, req)
 	if err != nil {
 		return nil, nil, err
@@ -1,12 +1,13 @@
 package pagination
 
 import (
+	"net/url"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
-// Default pagination settings
+// Default pagination settings; see Config to change the page sizes
 const (
 	DefaultPage    = 1
 	DefaultPerPage = 15
@@ -18,7 +19,7 @@ const (
 //
 // Query Parameters:
 //   - page: Current page number (default: 1)
-//   - per_page: Items per page (default: 15, max: 100)
+//   - per_page: Items per page, page_size also accepted (default: 15, max: 100, see Config)
 //   - keyword: Optional search keyword
 //   - sort: Sort field (e.g., "created_at")
 //   - order: Sort order ("asc" or "desc")
@@ -40,15 +41,9 @@ func (r *Request) GetPage() int {
 	return r.Page
 }
 
-// GetPerPage returns items per page, with min/max bounds.
+// GetPerPage returns items per page, bounded by the current Config.
 func (r *Request) GetPerPage() int {
-	if r.PerPage < 1 {
-		return DefaultPerPage
-	}
-	if r.PerPage > MaxPerPage {
-		return MaxPerPage
-	}
-	return r.PerPage
+	return pageSize(r.PerPage)
 }
 
 // GetPageSize is an alias for GetPerPage for backward compatibility.
@@ -56,6 +51,12 @@ func (r *Request) GetPageSize() int {
 	return r.GetPerPage()
 }
 
+// Validate returns ErrPageSizeTooLarge when the page size is over the
+// maximum and the current Config rejects such sizes instead of clamping.
+func (r *Request) Validate() error {
+	return validatePageSize(r.PerPage)
+}
+
 // GetOffset calculates the SQL offset for the current page.
 func (r *Request) GetOffset() int {
 	return (r.GetPage() - 1) * r.GetPerPage()
@@ -94,7 +95,7 @@ func (r *Request) HasKeyword() bool {
 }
 
 // FromContext extracts pagination request from Gin context.
-// Reads from query string parameters.
+// Reads from query string parameters, exactly as FromQuery does.
 //
 // Example:
 //
@@ -103,50 +104,37 @@ func (r *Request) HasKeyword() bool {
 //	    // req.Page, req.PerPage, req.Keyword, etc.
 //	}
 func FromContext(c *gin.Context) *Request {
-	req := &Request{}
-
-	if page, err := strconv.Atoi(c.DefaultQuery("page", "1")); err == nil {
-		req.Page = page
-	}
-
-	if perPage, err := strconv.Atoi(c.DefaultQuery("per_page", strconv.Itoa(DefaultPerPage))); err == nil {
-		req.PerPage = perPage
-	}
-
-	req.Keyword = c.Query("keyword")
-	req.Sort = c.Query("sort")
-	req.Order = c.DefaultQuery("order", "desc")
-
-	return req
+	return FromQuery(c.Request.URL.Query())
 }
 
 // FromQuery creates pagination request from a query map.
-// Useful for testing or non-Gin contexts.
+// Useful for testing or non-Gin contexts. page_size is accepted as an alias
+// of per_page. Missing or malformed values get the defaults from the
+// current Config.
 func FromQuery(query map[string][]string) *Request {
-	req := &Request{}
-
-	if pages, ok := query["page"]; ok && len(pages) > 0 {
-		if page, err := strconv.Atoi(pages[0]); err == nil {
-			req.Page = page
-		}
+	values := url.Values(query)
+	req := &Request{
+		Page:    DefaultPage,
+		PerPage: CurrentConfig().DefaultPageSize,
+		Keyword: values.Get("keyword"),
+		Sort:    values.Get("sort"),
+		Order:   "desc",
 	}
 
-	if perPages, ok := query["per_page"]; ok && len(perPages) > 0 {
-		if perPage, err := strconv.Atoi(perPages[0]); err == nil {
-			req.PerPage = perPage
-		}
+	if page, err := strconv.Atoi(values.Get("page")); err == nil {
+		req.Page = page
 	}
 
-	if keywords, ok := query["keyword"]; ok && len(keywords) > 0 {
-		req.Keyword = keywords[0]
+	perPage := values.Get("per_page")
+	if perPage == "" {
+		perPage = values.Get("page_size")
 	}
-
-	if sorts, ok := query["sort"]; ok && len(sorts) > 0 {
-		req.Sort = sorts[0]
+	if n, err := strconv.Atoi(perPage); err == nil {
+		req.PerPage = n
 	}
 
-	if orders, ok := query["order"]; ok && len(orders) > 0 {
-		req.Order = orders[0]
+	if order := values.Get("order"); order != "" {
+		req.Order = order
 	}
 
 	return req
@@ -0,0 +1,121 @@
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Filter operators accepted in query strings
+const (
+	OpEq      = "eq"      // filter[status][eq]=1, or filter[status]=1
+	OpLike    = "like"    // filter[username][like]=ali
+	OpIn      = "in"      // filter[status][in]=1,2
+	OpBetween = "between" // filter[created_at][between]=2026-01-01,2026-02-01
+)
+
+// filterKey matches filter[field] and filter[field][op]
+var filterKey = regexp.MustCompile(`^filter\[([^\[\]]+)\](?:\[([^\[\]]+)\])?$`)
+
+// ParseFromQuery builds a Spec from the request's filter[field][op]=value
+// parameters and its sort/order pair (the same ones pagination reads).
+// Only the listed columns may be filtered or sorted on; anything else is
+// rejected with ErrInvalidFilter rather than silently ignored.
+//
+// Example:
+//
+//	spec, err := query.ParseFromQuery(c, "status", "email", "created_at")
+//	if err != nil {
+//	    response.BadRequest(c, "Invalid filter", err)
+//	    return
+//	}
+func ParseFromQuery(c *gin.Context, columns ...string) (*Spec, error) {
+	return ParseValues(c.Request.URL.Query(), columns...)
+}
+
+// ParseValues is ParseFromQuery for a parsed query string.
+// Useful for testing or non-Gin contexts.
+func ParseValues(values url.Values, columns ...string) (*Spec, error) {
+	spec := New()
+
+	// Map order is random; sort so the generated SQL is stable
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if strings.HasPrefix(key, "filter[") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		m := filterKey.FindStringSubmatch(key)
+		if m == nil {
+			return nil, fmt.Errorf("%w: malformed parameter %q", ErrInvalidFilter, key)
+		}
+		field, op := m[1], m[2]
+		if op == "" {
+			op = OpEq
+		}
+		if !slices.Contains(columns, field) {
+			return nil, fmt.Errorf("%w: cannot filter on %q", ErrInvalidFilter, field)
+		}
+		for _, value := range values[key] {
+			if err := addFilter(spec, field, op, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if field := values.Get("sort"); field != "" {
+		if !slices.Contains(columns, field) {
+			return nil, fmt.Errorf("%w: cannot sort on %q", ErrInvalidFilter, field)
+		}
+		spec.OrderBy(field, values.Get("order") != "asc")
+	}
+
+	return spec, spec.Err()
+}
+
+// addFilter adds one field/op/value filter to spec
+func addFilter(spec *Spec, field, op, value string) error {
+	switch op {
+	case OpEq:
+		spec.Eq(field, value)
+	case OpLike:
+		if value == "" {
+			return fmt.Errorf("%w: empty like value for %q", ErrInvalidFilter, field)
+		}
+		spec.Like(field, value)
+	case OpIn:
+		items := splitList(value)
+		if len(items) == 0 {
+			return fmt.Errorf("%w: empty in list for %q", ErrInvalidFilter, field)
+		}
+		spec.In(field, items...)
+	case OpBetween:
+		bounds := splitList(value)
+		if len(bounds) != 2 {
+			return fmt.Errorf("%w: between on %q needs two comma-separated values", ErrInvalidFilter, field)
+		}
+		spec.Between(field, bounds[0], bounds[1])
+	default:
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, op)
+	}
+	return nil
+}
+
+// splitList splits a comma-separated value, dropping empty items
+func splitList(value string) []any {
+	var items []any
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
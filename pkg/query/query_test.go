@@ -0,0 +1,185 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"gorm.io/gorm"
+)
+
+type account struct {
+	ID        uint
+	Username  string
+	Status    int
+	CreatedAt string
+}
+
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	return dbtest.Open(t, &account{})
+}
+
+// compile returns the SQL and bound values spec produces for a SELECT
+func compile(t *testing.T, db *gorm.DB, spec *Spec) (string, []any) {
+	t.Helper()
+	stmt := db.Session(&gorm.Session{DryRun: true}).Model(&account{}).Scopes(spec.Scope()).Find(&[]account{}).Statement
+	if stmt.Error != nil {
+		t.Fatalf("compile: %v", stmt.Error)
+	}
+	return stmt.SQL.String(), stmt.Vars
+}
+
+func TestSpec_Compile(t *testing.T) {
+	db := setupDB(t)
+
+	tests := []struct {
+		name string
+		spec *Spec
+		sql  string
+		vars int
+	}{
+		{"empty", New(), "SELECT * FROM `accounts`", 0},
+		{"eq", New().Eq("status", 1), "SELECT * FROM `accounts` WHERE `status` = ?", 1},
+		{"like", New().Like("username", "ali"), "SELECT * FROM `accounts` WHERE `username` LIKE ?", 1},
+		{"in", New().In("status", 1, 2), "SELECT * FROM `accounts` WHERE `status` IN (?,?)", 2},
+		{"between", New().Between("created_at", "a", "b"), "SELECT * FROM `accounts` WHERE `created_at` >= ? AND `created_at` <= ?", 2},
+		{"and", New().Eq("status", 1).Like("username", "a"), "SELECT * FROM `accounts` WHERE `status` = ? AND `username` LIKE ?", 2},
+		{
+			"or",
+			New().Eq("status", 1).Or(New().Like("username", "a"), New().Eq("id", 1).Eq("status", 2)),
+			"SELECT * FROM `accounts` WHERE `status` = ? AND (`username` LIKE ? OR (`id` = ? AND `status` = ?))",
+			4,
+		},
+		{"qualified", New().Eq("accounts.status", 1), "SELECT * FROM `accounts` WHERE `accounts`.`status` = ?", 1},
+		{"order", New().OrderBy("created_at", true).OrderBy("id", false), "SELECT * FROM `accounts` ORDER BY `created_at` DESC,`id`", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, vars := compile(t, db, tt.spec)
+			if sql != tt.sql {
+				t.Errorf("SQL = %s\nwant  %s", sql, tt.sql)
+			}
+			if len(vars) != tt.vars {
+				t.Errorf("got %d bound values, want %d", len(vars), tt.vars)
+			}
+		})
+	}
+}
+
+func TestSpec_Query(t *testing.T) {
+	db := setupDB(t)
+	db.Create(&[]account{
+		{Username: "alice", Status: 1, CreatedAt: "2026-01-10"},
+		{Username: "bob", Status: 2, CreatedAt: "2026-02-10"},
+		{Username: "carol", Status: 1, CreatedAt: "2026-03-10"},
+	})
+
+	var got []account
+	spec := New().Eq("status", 1).Between("created_at", "2026-01-01", "2026-02-28")
+	if err := db.Scopes(spec.Scope()).Find(&got).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Username != "alice" {
+		t.Errorf("got %+v, want only alice", got)
+	}
+
+	got = nil
+	spec = New().Or(New().Like("username", "ali"), New().Like("username", "car")).OrderBy("id", true)
+	if err := db.Scopes(spec.Scope()).Find(&got).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Username != "carol" || got[1].Username != "alice" {
+		t.Errorf("got %+v, want carol then alice", got)
+	}
+}
+
+func TestSpec_RejectsBadColumns(t *testing.T) {
+	db := setupDB(t)
+
+	for _, field := range []string{"status; DROP TABLE accounts", "status`", "1status", "a.b.c", "", "status = 1 OR 1"} {
+		spec := New().Eq(field, 1)
+		if !errors.Is(spec.Err(), ErrInvalidFilter) {
+			t.Errorf("Eq(%q) Err() = %v, want ErrInvalidFilter", field, spec.Err())
+		}
+		err := db.Scopes(spec.Scope()).Find(&[]account{}).Error
+		if !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("query with %q = %v, want ErrInvalidFilter", field, err)
+		}
+	}
+
+	// The error stays on the query and does not poison the shared handle
+	if err := db.Find(&[]account{}).Error; err != nil {
+		t.Errorf("later query = %v", err)
+	}
+
+	if err := New().OrderBy("id desc", false).Err(); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("OrderBy Err() = %v, want ErrInvalidFilter", err)
+	}
+	if err := New().Or(New().Like("x;", "a")).Err(); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Or Err() = %v, want ErrInvalidFilter", err)
+	}
+}
+
+func TestSpec_BindsValues(t *testing.T) {
+	db := setupDB(t)
+	db.Create(&account{Username: "alice", Status: 1})
+
+	var got []account
+	spec := New().Eq("username", "' OR '1'='1")
+	if err := db.Scopes(spec.Scope()).Find(&got).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("injected value matched %d rows", len(got))
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	db := setupDB(t)
+	values := url.Values{
+		"filter[status][in]":          {"1,2"},
+		"filter[created_at][between]": {"2026-01-01, 2026-02-01"},
+		"filter[username]":            {"alice"},
+		"sort":                        {"created_at"},
+		"order":                       {"asc"},
+		"page":                        {"2"},
+	}
+
+	spec, err := ParseValues(values, "status", "username", "created_at")
+	if err != nil {
+		t.Fatalf("ParseValues() error = %v", err)
+	}
+	sql, vars := compile(t, db, spec)
+	want := "SELECT * FROM `accounts` WHERE (`created_at` >= ? AND `created_at` <= ?) AND `status` IN (?,?) AND `username` = ? ORDER BY `created_at`"
+	if sql != want {
+		t.Errorf("SQL = %s\nwant  %s", sql, want)
+	}
+	if len(vars) != 5 || vars[0] != "2026-01-01" || vars[1] != "2026-02-01" {
+		t.Errorf("vars = %v", vars)
+	}
+}
+
+func TestParseValues_Rejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		values url.Values
+	}{
+		{"unlisted column", url.Values{"filter[password][eq]": {"x"}}},
+		{"injected column", url.Values{"filter[status) OR (1=1][eq]": {"1"}}},
+		{"unknown operator", url.Values{"filter[status][gt]": {"1"}}},
+		{"malformed key", url.Values{"filter[status][eq][x]": {"1"}}},
+		{"between arity", url.Values{"filter[created_at][between]": {"2026-01-01"}}},
+		{"empty in", url.Values{"filter[status][in]": {" , "}}},
+		{"empty like", url.Values{"filter[username][like]": {""}}},
+		{"unlisted sort", url.Values{"sort": {"password"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseValues(tt.values, "status", "username", "created_at"); !errors.Is(err, ErrInvalidFilter) {
+				t.Errorf("ParseValues() error = %v, want ErrInvalidFilter", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package repository provides a generic GORM repository that module
+// repositories embed for the common CRUD operations.
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zgiai/zgo/pkg/pagination"
+	"github.com/zgiai/zgo/pkg/query"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ConnFunc returns the session a repository query runs on: the transaction
+// carried by ctx when there is one, otherwise db bound to ctx. The database
+// package's Conn is the one applications pass to NewBase.
+type ConnFunc func(ctx context.Context, db *gorm.DB) *gorm.DB
+
+// Base implements Create/Update/Delete/Find for a GORM model T.
+// Every query goes through DB(ctx), so calls join a transaction carried
+// by ctx and honour its cancellation.
+//
+// Example (imported as baserepo, since modules name their own type repository):
+//
+//	type repository struct {
+//		baserepo.Base[ArticlePO]
+//	}
+//
+//	func NewRepository(db *gorm.DB) *repository {
+//		return &repository{Base: baserepo.NewBase[ArticlePO](db, database.Conn)}
+//	}
+//
+//	func (r *repository) FindBySlug(ctx context.Context, slug string) (*ArticlePO, error) {
+//		return r.FindBy(ctx, "slug", slug)
+//	}
+type Base[T any] struct {
+	db       *gorm.DB
+	conn     ConnFunc
+	preloads map[string]bool
+}
+
+// NewBase creates a base repository for model T resolving its session with
+// conn. A nil conn uses db.WithContext(ctx) and never joins a transaction.
+func NewBase[T any](db *gorm.DB, conn ConnFunc) Base[T] {
+	return Base[T]{db: db, conn: conn}
+}
+
+// WithPreloads returns a copy of the repository that lets FindByIDWith and
+// FindAllWith preload relations, named by their struct field ("Roles").
+// Only these relations are ever preloaded, so callers can pass names taken
+// from a request:
+//
+//	Base: baserepo.NewBase[UserPO](db, database.Conn).WithPreloads("Roles")
+func (r Base[T]) WithPreloads(relations ...string) Base[T] {
+	preloads := make(map[string]bool, len(r.preloads)+len(relations))
+	for rel := range r.preloads {
+		preloads[rel] = true
+	}
+	for _, rel := range relations {
+		preloads[rel] = true
+	}
+	r.preloads = preloads
+	return r
+}
+
+// DB returns a session bound to ctx, or the transaction ctx carries
+func (r *Base[T]) DB(ctx context.Context) *gorm.DB {
+	if r.conn != nil {
+		return r.conn(ctx, r.db)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create inserts entity, filling generated fields such as ID and timestamps
+func (r *Base[T]) Create(ctx context.Context, entity *T) error {
+	return r.DB(ctx).Create(entity).Error
+}
+
+// Update saves all fields of entity
+func (r *Base[T]) Update(ctx context.Context, entity *T) error {
+	return r.DB(ctx).Save(entity).Error
+}
+
+// Delete removes the record with the given primary key.
+// Models with gorm.DeletedAt are soft-deleted.
+func (r *Base[T]) Delete(ctx context.Context, id any) error {
+	return r.DB(ctx).Delete(new(T), id).Error
+}
+
+// NotFound replaces gorm.ErrRecordNotFound with target and returns any
+// other error unchanged. Module repositories call it on their finders so
+// services see domain errors instead of GORM ones:
+//
+//	po, err := r.Base.FindByID(ctx, id)
+//	if err != nil {
+//		return nil, baserepo.NotFound(err, domain.ErrUserNotFound)
+//	}
+func NotFound(err, target error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return target
+	}
+	return err
+}
+
+// FindByID retrieves a record by primary key.
+// Returns gorm.ErrRecordNotFound when there is none.
+func (r *Base[T]) FindByID(ctx context.Context, id any) (*T, error) {
+	var entity T
+	if err := r.DB(ctx).First(&entity, id).Error; err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// FindByIDWith retrieves a record by primary key with relations preloaded.
+// Relations not allowed by WithPreloads are ignored.
+func (r *Base[T]) FindByIDWith(ctx context.Context, id any, relations ...string) (*T, error) {
+	var entity T
+	if err := r.preload(r.DB(ctx), relations).First(&entity, id).Error; err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// FindBy retrieves the first record whose column field equals value.
+// The column name is quoted, so it is safe to pass through from callers.
+func (r *Base[T]) FindBy(ctx context.Context, field string, value any) (*T, error) {
+	var entity T
+	err := r.DB(ctx).Where(clause.Eq{Column: clause.Column{Name: field}, Value: value}).First(&entity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// FindAll retrieves one page of records and the total count
+func (r *Base[T]) FindAll(ctx context.Context, page, pageSize int) ([]*T, int64, error) {
+	items, paginator, err := r.Paginate(ctx, pagination.NewRequest(page, pageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, paginator.Total(), nil
+}
+
+// FindAllWith retrieves one page of records with relations preloaded and
+// the total count. Relations not allowed by WithPreloads are ignored.
+func (r *Base[T]) FindAllWith(ctx context.Context, page, pageSize int, relations ...string) ([]*T, int64, error) {
+	items, paginator, err := pagination.Paginate[*T](r.preload(r.DB(ctx).Model(new(T)), relations), pagination.NewRequest(page, pageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, paginator.Total(), nil
+}
+
+// Search retrieves one page of the records matching spec and their total
+// count. A nil spec matches every record.
+func (r *Base[T]) Search(ctx context.Context, spec *query.Spec, page, pageSize int) ([]*T, int64, error) {
+	items, paginator, err := pagination.Paginate[*T](r.DB(ctx).Model(new(T)).Scopes(spec.Scope()), pagination.NewRequest(page, pageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, paginator.Total(), nil
+}
+
+// Paginate retrieves the page described by req
+func (r *Base[T]) Paginate(ctx context.Context, req *pagination.Request) ([]*T, *pagination.Paginator[*T], error) {
+	return pagination.Paginate[*T](r.DB(ctx).Model(new(T)), req)
+}
+
+// preload adds the allowed relations to db, each one once
+func (r *Base[T]) preload(db *gorm.DB, relations []string) *gorm.DB {
+	seen := make(map[string]bool, len(relations))
+	for _, rel := range relations {
+		if r.preloads[rel] && !seen[rel] {
+			seen[rel] = true
+			db = db.Preload(rel)
+		}
+	}
+	return db
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/pkg/dbtest"
+	"github.com/zgiai/zgo/pkg/pagination"
+	"github.com/zgiai/zgo/pkg/query"
+	"gorm.io/gorm"
+)
+
+type article struct {
+	ID        uint
+	Slug      string
+	Title     string
+	DeletedAt gorm.DeletedAt
+}
+
+func setupBase(t *testing.T) (Base[article], *gorm.DB) {
+	t.Helper()
+	db := dbtest.Open(t, &article{})
+	return NewBase[article](db, nil), db
+}
+
+func TestBase_CRUD(t *testing.T) {
+	repo, _ := setupBase(t)
+	ctx := context.Background()
+
+	a := &article{Slug: "hello", Title: "Hello"}
+	require.NoError(t, repo.Create(ctx, a))
+	require.NotZero(t, a.ID)
+
+	found, err := repo.FindByID(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", found.Title)
+
+	found.Title = "Hello, world"
+	require.NoError(t, repo.Update(ctx, found))
+
+	bySlug, err := repo.FindBy(ctx, "slug", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, world", bySlug.Title)
+
+	require.NoError(t, repo.Delete(ctx, a.ID))
+	_, err = repo.FindByID(ctx, a.ID)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestBase_FindByMissing(t *testing.T) {
+	repo, _ := setupBase(t)
+
+	_, err := repo.FindBy(context.Background(), "slug", "missing")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestNotFound(t *testing.T) {
+	errGone := errors.New("article not found")
+	repo, _ := setupBase(t)
+
+	_, err := repo.FindByID(context.Background(), 42)
+	assert.Same(t, errGone, NotFound(err, errGone))
+
+	other := errors.New("connection refused")
+	assert.Same(t, other, NotFound(other, errGone))
+	assert.NoError(t, NotFound(nil, errGone))
+}
+
+func TestBase_FindAllAndPaginate(t *testing.T) {
+	repo, _ := setupBase(t)
+	ctx := context.Background()
+	for _, slug := range []string{"a", "b", "c", "d", "e"} {
+		require.NoError(t, repo.Create(ctx, &article{Slug: slug}))
+	}
+
+	items, total, err := repo.FindAll(ctx, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	require.Len(t, items, 2)
+	assert.Equal(t, "c", items[0].Slug)
+
+	_, paginator, err := repo.Paginate(ctx, pagination.NewRequest(3, 2))
+	require.NoError(t, err)
+	assert.Equal(t, 3, paginator.LastPage())
+	assert.Len(t, paginator.Items(), 1)
+}
+
+func TestBase_Search(t *testing.T) {
+	repo, _ := setupBase(t)
+	ctx := context.Background()
+	for _, slug := range []string{"go-1", "go-2", "rust-1", "go-3"} {
+		require.NoError(t, repo.Create(ctx, &article{Slug: slug}))
+	}
+
+	items, total, err := repo.Search(ctx, query.New().Like("slug", "go-").OrderBy("id", true), 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, items, 2)
+	assert.Equal(t, "go-3", items[0].Slug)
+
+	_, total, err = repo.Search(ctx, nil, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+
+	_, _, err = repo.Search(ctx, query.New().Eq("slug;", "x"), 1, 10)
+	assert.ErrorIs(t, err, query.ErrInvalidFilter)
+}
+
+type txKey struct{}
+
+func TestBase_UsesConnFunc(t *testing.T) {
+	_, db := setupBase(t)
+	repo := NewBase[article](db, func(ctx context.Context, db *gorm.DB) *gorm.DB {
+		if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+			return tx.WithContext(ctx)
+		}
+		return db.WithContext(ctx)
+	})
+	ctx := context.Background()
+
+	rollback := errors.New("rollback")
+	err := db.Transaction(func(tx *gorm.DB) error {
+		require.NoError(t, repo.Create(context.WithValue(ctx, txKey{}, tx), &article{Slug: "draft"}))
+		return rollback
+	})
+	require.ErrorIs(t, err, rollback)
+
+	_, err = repo.FindBy(ctx, "slug", "draft")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+type comment struct {
+	ID     uint
+	PostID uint
+	Body   string
+}
+
+type post struct {
+	ID       uint
+	Title    string
+	Comments []comment
+}
+
+func TestBase_Preload(t *testing.T) {
+	_, db := setupBase(t)
+	require.NoError(t, db.AutoMigrate(&post{}, &comment{}))
+	ctx := context.Background()
+
+	repo := NewBase[post](db, nil).WithPreloads("Comments")
+	p := &post{Title: "Hello", Comments: []comment{{Body: "first"}, {Body: "second"}}}
+	require.NoError(t, repo.Create(ctx, p))
+
+	found, err := repo.FindByIDWith(ctx, p.ID, "Comments")
+	require.NoError(t, err)
+	assert.Len(t, found.Comments, 2)
+
+	items, total, err := repo.FindAllWith(ctx, 1, 10, "Comments")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, items, 1)
+	assert.Len(t, items[0].Comments, 2)
+
+	// Relations are only loaded when asked for
+	found, err = repo.FindByIDWith(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Empty(t, found.Comments)
+}
+
+func TestBase_PreloadIgnoresUnknownRelations(t *testing.T) {
+	_, db := setupBase(t)
+	require.NoError(t, db.AutoMigrate(&post{}, &comment{}))
+	ctx := context.Background()
+
+	p := &post{Title: "Hello", Comments: []comment{{Body: "first"}}}
+	require.NoError(t, db.Create(p).Error)
+
+	// Comments is a real relation, but not one this repository allows
+	repo := NewBase[post](db, nil)
+	found, err := repo.FindByIDWith(ctx, p.ID, "Comments", "Author", "1=1; DROP TABLE posts")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", found.Title)
+	assert.Empty(t, found.Comments)
+
+	items, total, err := repo.FindAllWith(ctx, 1, 10, "Author")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, items, 1)
+}
@@ -0,0 +1,90 @@
+package request
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/pagination"
+	"github.com/zgiai/zgo/pkg/response"
+	"github.com/zgiai/zgo/pkg/validation"
+)
+
+// ListQuery holds the pagination and sort parameters shared by list
+// endpoints. Embed it in a DTO to add endpoint-specific parameters.
+//
+// Query Parameters:
+//   - page: Page number, at least 1 (default: 1)
+//   - per_page: Items per page, at least 1; page_size is accepted as an
+//     alias. Sizes over the pagination.Config maximum are clamped or
+//     rejected as that config says (default: 15)
+//   - keyword: Optional search keyword
+//   - sort: Sort field; which fields are allowed is up to the endpoint
+//   - order: "asc" or "desc" (default: "desc")
+type ListQuery struct {
+	Page     int    `form:"page" json:"page" binding:"omitempty,min=1"`
+	PerPage  int    `form:"per_page" json:"per_page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" json:"page_size,omitempty" binding:"omitempty,min=1"`
+	Keyword  string `form:"keyword" json:"keyword" binding:"max=100"`
+	Sort     string `form:"sort" json:"sort" binding:"max=64"`
+	Order    string `form:"order" json:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// Pagination returns the pagination request described by q
+func (q *ListQuery) Pagination() *pagination.Request {
+	perPage := q.PerPage
+	if perPage == 0 {
+		perPage = q.PageSize
+	}
+	return &pagination.Request{
+		Page:    q.Page,
+		PerPage: perPage,
+		Keyword: q.Keyword,
+		Sort:    q.Sort,
+		Order:   q.Order,
+	}
+}
+
+// validatePageSize checks the page size against the pagination config in
+// effect, reporting the parameter the client used
+func (q *ListQuery) validatePageSize() error {
+	if err := q.Pagination().Validate(); err == nil {
+		return nil
+	}
+	field := "per_page"
+	if q.PerPage == 0 {
+		field = "page_size"
+	}
+	max := strconv.Itoa(pagination.CurrentConfig().MaxPageSize)
+	return validation.ValidationErrors{{
+		Field:   field,
+		Message: field + " must be at most " + max,
+		Tag:     "max",
+		Param:   max,
+	}}
+}
+
+// BindQuery binds query parameters into dto and runs its binding rules.
+// A dto embedding ListQuery also has its page size checked against the
+// current pagination config. Validation failures get a 422 with one message
+// per field, and values of the wrong type a 400. Returns false if binding
+// failed (error response already sent).
+//
+// Example:
+//
+//	var q request.ListQuery
+//	if !request.BindQuery(c, &q) {
+//	    return
+//	}
+func BindQuery(c *gin.Context, dto any) bool {
+	if err := c.ShouldBindQuery(dto); err != nil {
+		response.ValidationError(c, err)
+		return false
+	}
+	if q, ok := dto.(interface{ validatePageSize() error }); ok {
+		if err := q.validatePageSize(); err != nil {
+			response.ValidationError(c, err)
+			return false
+		}
+	}
+	return true
+}
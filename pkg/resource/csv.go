@@ -0,0 +1,46 @@
+package resource
+
+import (
+	"fmt"
+	"time"
+)
+
+// ToCSV projects each resource's ToArray map onto columns, producing rows
+// for response.CSV. Missing and nil fields become empty cells; times are
+// formatted as RFC 3339.
+//
+// Example:
+//
+//	columns := []string{"id", "username", "email"}
+//	response.CSV(c, "users.csv", columns, resource.ToCSV(items, columns))
+func ToCSV[T Resource](items []T, columns []string) [][]string {
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		data := item.ToArray()
+		row := make([]string, len(columns))
+		for j, col := range columns {
+			row[j] = csvValue(data[col])
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// csvValue formats a single field for a CSV cell
+func csvValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case time.Time:
+		return val.Format(time.RFC3339)
+	case *time.Time:
+		if val == nil {
+			return ""
+		}
+		return val.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,92 @@
+package resource
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/pkg/response"
+)
+
+// ============================================================================
+// Sparse Fieldsets
+// ============================================================================
+// Clients may request a subset of fields with ?fields=id,name.
+
+// FieldsParam is the query parameter used to select fields.
+const FieldsParam = "fields"
+
+// Fields parses the requested fields from the query string.
+// It returns nil when no fields were requested.
+func Fields(c *gin.Context) []string {
+	raw := c.Query(FieldsParam)
+	if raw == "" {
+		return nil
+	}
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Respond sends a resource with an ETag, limited to the fields requested in ?fields=.
+// Links of a ResourceWithLinks are included under "links".
+//
+// Example:
+//
+//	resource.Respond(c, NewUserResource(user))
+func Respond(c *gin.Context, res Resource) {
+	data := res.ToArray()
+	if withLinks, ok := res.(ResourceWithLinks); ok {
+		if links := withLinks.Links(); len(links) > 0 {
+			data["links"] = links
+		}
+	}
+	if fields := Fields(c); fields != nil {
+		data = Only(data, fields...)
+	}
+	response.SuccessWithETag(c, data)
+}
+
+// RespondCollection sends a collection, limiting every item to the fields
+// requested in ?fields=. Paginated collections keep their meta and links.
+//
+// Example:
+//
+//	resource.RespondCollection(c, resource.NewCollection(users, paginator, UserTransformer))
+func RespondCollection(c *gin.Context, collection Collection) {
+	items := sparseCollection{Collection: collection, fields: Fields(c)}.ToArray()
+
+	paginator := collection.GetPaginator()
+	if paginator == nil {
+		response.SuccessWithETag(c, items)
+		return
+	}
+	response.JSONWithETag(c, http.StatusOK, response.PaginatedResponse{
+		Code:    0,
+		Message: "success",
+		Data:    items,
+		Meta:    paginator.GetMeta(),
+		Links:   paginator.GetLinks(),
+	})
+}
+
+// sparseCollection filters the items of a wrapped collection
+type sparseCollection struct {
+	Collection
+	fields []string
+}
+
+func (s sparseCollection) ToArray() []map[string]any {
+	items := s.Collection.ToArray()
+	if s.fields == nil {
+		return items
+	}
+	for i, item := range items {
+		items[i] = Only(item, s.fields...)
+	}
+	return items
+}
@@ -78,6 +78,34 @@ func Filter(m map[string]any) map[string]any {
 	return result
 }
 
+// Only keeps the given keys of a map.
+//
+// Example:
+//
+//	resource.Only(UserTransformer(u), "id", "username")
+func Only(m map[string]any, fields ...string) map[string]any {
+	result := make(map[string]any, len(fields))
+	for _, field := range fields {
+		if v, ok := m[field]; ok {
+			result[field] = v
+		}
+	}
+	return result
+}
+
+// Except removes the given keys from a map.
+//
+// Example:
+//
+//	resource.Except(UserTransformer(u), "email")
+func Except(m map[string]any, fields ...string) map[string]any {
+	result := Merge(m)
+	for _, field := range fields {
+		delete(result, field)
+	}
+	return result
+}
+
 // Merge combines multiple maps into one.
 func Merge(maps ...map[string]any) map[string]any {
 	result := make(map[string]any)
@@ -38,6 +38,7 @@
 package resource
 
 import (
+	"github.com/zgiai/zgo/pkg/pagination"
 	"github.com/zgiai/zgo/pkg/response"
 )
 
@@ -74,6 +75,13 @@ type Resource interface {
 	ToArray() map[string]any
 }
 
+// ResourceWithLinks is a Resource that also exposes related URLs,
+// e.g. {"self": "/v1/users/1"}. Respond adds them under "links".
+type ResourceWithLinks interface {
+	Resource
+	Links() map[string]string
+}
+
 // Collection interface for paginated collections.
 type Collection interface {
 	ToArray() []map[string]any
@@ -115,3 +123,64 @@ func (c *SimpleCollection[T]) ToArray() []map[string]any {
 func (c *SimpleCollection[T]) GetPaginator() response.Paginatable {
 	return c.paginator
 }
+
+// ============================================================================
+// Paginated Collection
+// ============================================================================
+
+// PaginatedCollection wraps resources with pagination from a service result.
+// Meta is computed by the pagination package, so it has the same shape as
+// handlers that respond with a pagination.Paginator directly.
+type PaginatedCollection[T Resource] struct {
+	items     []T
+	paginator response.Paginatable
+	setPath   func(path string)
+}
+
+// FromPaginationResult builds a PaginatedCollection from a service result.
+// Items are the already-wrapped resources for result.Items.
+//
+// Example:
+//
+//	result, _ := h.service.List(ctx, req.GetPage(), req.GetPerPage())
+//	collection := resource.FromPaginationResult(resource.Map(result.Items, NewUserResource), result)
+//	response.Collection(c, collection.WithPath(c.Request.URL.Path))
+func FromPaginationResult[T Resource, M any](items []T, result *pagination.Result[M]) *PaginatedCollection[T] {
+	paginator := pagination.NewPaginator(result.Items, result.Total, result.Page, result.PerPage)
+	return &PaginatedCollection[T]{
+		items:     items,
+		paginator: paginator,
+		setPath:   func(path string) { paginator.SetPath(path) },
+	}
+}
+
+// WithPath sets the base path used for pagination links.
+func (c *PaginatedCollection[T]) WithPath(path string) *PaginatedCollection[T] {
+	c.setPath(path)
+	return c
+}
+
+// ToArray transforms all items.
+func (c *PaginatedCollection[T]) ToArray() []map[string]any {
+	result := make([]map[string]any, len(c.items))
+	for i, item := range c.items {
+		result[i] = item.ToArray()
+	}
+	return result
+}
+
+// GetPaginator returns the paginator.
+func (c *PaginatedCollection[T]) GetPaginator() response.Paginatable {
+	return c.paginator
+}
+
+// ToResponse builds the paginated response envelope.
+func (c *PaginatedCollection[T]) ToResponse() response.PaginatedResponse {
+	return response.PaginatedResponse{
+		Code:    0,
+		Message: "success",
+		Data:    c.ToArray(),
+		Meta:    c.paginator.GetMeta(),
+		Links:   c.paginator.GetLinks(),
+	}
+}
@@ -1,9 +1,15 @@
 package resource
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zgiai/zgo/pkg/pagination"
 )
 
 // Test model
@@ -163,3 +169,117 @@ func TestPluck(t *testing.T) {
 	names := Pluck(users, func(u *testUser) string { return u.Username })
 	assert.Equal(t, []string{"alice", "bob"}, names)
 }
+
+// testUserResource wraps testUser as a Resource
+type testUserResource struct {
+	user *testUser
+}
+
+func (r testUserResource) ToArray() map[string]any {
+	return testUserTransformer.Apply(r.user)
+}
+
+func TestFromPaginationResult(t *testing.T) {
+	users := []*testUser{
+		{ID: 16, Username: "alice"},
+		{ID: 17, Username: "bob"},
+	}
+	result := pagination.NewResult(users, 32, 2, 15)
+
+	items := Map(users, func(u *testUser) testUserResource { return testUserResource{user: u} })
+	collection := FromPaginationResult(items, result).WithPath("/api/users")
+
+	meta := collection.GetPaginator().GetMeta()
+	assert.Equal(t, 3, meta.LastPage)
+	assert.Equal(t, 16, meta.From)
+	assert.Equal(t, 30, meta.To)
+	assert.Equal(t, "/api/users?page=1", collection.GetPaginator().GetLinks().First)
+
+	body, err := json.Marshal(collection.ToResponse())
+	require.NoError(t, err)
+	var got struct {
+		Data []map[string]any `json:"data"`
+		Meta map[string]any   `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(body, &got))
+
+	// Meta keys must match what pagination.Paginator produces
+	expected, err := json.Marshal(pagination.NewPaginator(users, 32, 2, 15).GetMeta())
+	require.NoError(t, err)
+	var want map[string]any
+	require.NoError(t, json.Unmarshal(expected, &want))
+
+	assert.Equal(t, want, got.Meta)
+	assert.Len(t, got.Data, 2)
+	assert.Equal(t, "alice", got.Data[0]["username"])
+}
+
+func TestOnly(t *testing.T) {
+	input := map[string]any{"id": 1, "username": "alice", "email": "alice@example.com"}
+
+	result := Only(input, "id", "username", "missing")
+
+	assert.Equal(t, map[string]any{"id": 1, "username": "alice"}, result)
+}
+
+func TestExcept(t *testing.T) {
+	input := map[string]any{"id": 1, "username": "alice", "email": "alice@example.com"}
+
+	result := Except(input, "email")
+
+	assert.Equal(t, map[string]any{"id": 1, "username": "alice"}, result)
+	assert.Contains(t, input, "email", "input map must not be modified")
+}
+
+func serveFields(query string, handler gin.HandlerFunc) map[string]any {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/users?"+query, nil)
+	handler(c)
+
+	var body map[string]any
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	return body
+}
+
+func TestRespond_Fields(t *testing.T) {
+	user := testUserResource{user: &testUser{ID: 1, Username: "alice", Email: "alice@example.com"}}
+
+	body := serveFields("fields=id,%20username", func(c *gin.Context) { Respond(c, user) })
+	assert.Equal(t, map[string]any{"id": float64(1), "username": "alice"}, body["data"])
+
+	body = serveFields("", func(c *gin.Context) { Respond(c, user) })
+	assert.Contains(t, body["data"], "email")
+}
+
+func TestRespondCollection_Fields(t *testing.T) {
+	users := []*testUser{
+		{ID: 1, Username: "alice", Email: "alice@example.com"},
+		{ID: 2, Username: "bob", Email: "bob@example.com"},
+	}
+	paginator := pagination.NewPaginator(users, 2, 1, 15)
+	collection := NewCollection(users, paginator, testUserTransformer)
+
+	body := serveFields("fields=username", func(c *gin.Context) { RespondCollection(c, collection) })
+
+	assert.Equal(t, []any{
+		map[string]any{"username": "alice"},
+		map[string]any{"username": "bob"},
+	}, body["data"])
+	assert.NotNil(t, body["meta"], "pagination meta should be kept")
+}
+
+func TestToCSV(t *testing.T) {
+	items := []testUserResource{
+		{user: &testUser{ID: 1, Username: "alice", Email: "alice@example.com", IsAdmin: true}},
+		{user: &testUser{ID: 2, Username: "bob, jr", Email: "bob@example.com"}},
+	}
+
+	rows := ToCSV(items, []string{"id", "username", "is_admin", "avatar"})
+
+	assert.Equal(t, [][]string{
+		{"1", "alice", "true", ""},
+		{"2", "bob, jr", "", ""},
+	}, rows)
+}
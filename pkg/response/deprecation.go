@@ -0,0 +1,23 @@
+package response
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecate tells the client the endpoint is deprecated. It sets the
+// Deprecation header and, unless sunset is zero, the Sunset header
+// (RFC 8594) with the date the endpoint stops working. Routes tagged with
+// Route.Deprecated in the router get these headers automatically.
+//
+// Example:
+//
+//	response.Deprecate(c, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+func Deprecate(c *gin.Context, sunset time.Time) {
+	c.Header("Deprecation", "true")
+	if !sunset.IsZero() {
+		c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,74 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ============================================================================
+// Conditional Responses
+// ============================================================================
+
+// JSONWithETag sends data as JSON with a weak ETag derived from its content.
+// When a GET or HEAD request's If-None-Match matches, it responds
+// 304 Not Modified with no body instead.
+//
+// Example:
+//
+//	response.JSONWithETag(c, http.StatusOK, gin.H{"version": 3})
+func JSONWithETag(c *gin.Context, status int, data any) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		InternalServerError(c, "Failed to encode response", err)
+		return
+	}
+
+	etag := weakETag(body)
+	c.Header("ETag", etag)
+
+	if status == http.StatusOK && isSafeMethod(c.Request.Method) && etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(status, "application/json; charset=utf-8", body)
+}
+
+// SuccessWithETag sends the Success envelope through JSONWithETag.
+//
+// Example:
+//
+//	response.SuccessWithETag(c, user)
+func SuccessWithETag(c *gin.Context, data any) {
+	JSONWithETag(c, http.StatusOK, successBody(c, data))
+}
+
+// weakETag returns a weak validator for body
+func weakETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches applies the weak comparison used by If-None-Match
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	opaque := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
@@ -0,0 +1,76 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func newETagRouter(payload *gin.H) *gin.Engine {
+	r := gin.New()
+	handler := func(c *gin.Context) {
+		JSONWithETag(c, http.StatusOK, *payload)
+	}
+	r.GET("/profile", handler)
+	r.PUT("/profile", handler)
+	return r
+}
+
+func requestWithETag(r http.Handler, method, etag string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/profile", nil)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestJSONWithETag_CacheHit(t *testing.T) {
+	payload := gin.H{"name": "alice"}
+	r := newETagRouter(&payload)
+
+	first := requestWithETag(r, http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.JSONEq(t, `{"name":"alice"}`, first.Body.String())
+
+	etag := first.Header().Get("ETag")
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+
+	second := requestWithETag(r, http.MethodGet, etag)
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Equal(t, etag, second.Header().Get("ETag"))
+	assert.Zero(t, second.Body.Len(), "304 must not carry a body")
+
+	// Strong form and lists of candidates match too
+	assert.Equal(t, http.StatusNotModified, requestWithETag(r, http.MethodGet, etag[2:]).Code)
+	assert.Equal(t, http.StatusNotModified, requestWithETag(r, http.MethodGet, `"other", `+etag).Code)
+}
+
+func TestJSONWithETag_ChangedPayload(t *testing.T) {
+	payload := gin.H{"name": "alice"}
+	r := newETagRouter(&payload)
+
+	oldETag := requestWithETag(r, http.MethodGet, "").Header().Get("ETag")
+
+	payload = gin.H{"name": "bob"}
+	w := requestWithETag(r, http.MethodGet, oldETag)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name":"bob"}`, w.Body.String())
+	assert.NotEqual(t, oldETag, w.Header().Get("ETag"))
+}
+
+func TestJSONWithETag_UnsafeMethodIgnoresPrecondition(t *testing.T) {
+	payload := gin.H{"name": "alice"}
+	r := newETagRouter(&payload)
+
+	etag := requestWithETag(r, http.MethodGet, "").Header().Get("ETag")
+
+	w := requestWithETag(r, http.MethodPut, etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotZero(t, w.Body.Len())
+}
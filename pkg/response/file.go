@@ -0,0 +1,136 @@
+package response
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ============================================================================
+// File Responses
+// ============================================================================
+
+// FileSource is the subset of storage.Filesystem needed to serve stored files.
+type FileSource interface {
+	ReadStream(path string) (io.ReadCloser, error)
+	Size(path string) (int64, error)
+	MimeType(path string) string
+}
+
+// Download streams reader to the client as an attachment and closes it.
+// An empty contentType defaults to application/octet-stream; a negative
+// size omits Content-Length.
+//
+// Example:
+//
+//	f, _ := os.Open("report.csv")
+//	response.Download(c, f, "report.csv", "text/csv", info.Size())
+func Download(c *gin.Context, reader io.ReadCloser, filename string, contentType string, size int64) {
+	stream(c, reader, "attachment", filename, contentType, size)
+}
+
+// Inline streams reader to the client for display in the browser and closes it.
+// Arguments match Download.
+func Inline(c *gin.Context, reader io.ReadCloser, filename string, contentType string, size int64) {
+	stream(c, reader, "inline", filename, contentType, size)
+}
+
+// CSV sends headers and rows as a CSV attachment named filename.
+// Fields containing commas, quotes or newlines are quoted per RFC 4180, and
+// fields a spreadsheet would evaluate as a formula are prefixed with a quote.
+//
+// Example:
+//
+//	response.CSV(c, "users.csv", []string{"id", "email"}, [][]string{{"1", "a@example.com"}})
+func CSV(c *gin.Context, filename string, headers []string, rows [][]string) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if len(headers) > 0 {
+		_ = w.Write(headers)
+	}
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = csvSafe(cell)
+		}
+		_ = w.Write(cells)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		InternalServerError(c, "Failed to write CSV", err)
+		return
+	}
+
+	Download(c, io.NopCloser(&buf), filename, "text/csv; charset=utf-8", int64(buf.Len()))
+}
+
+// csvSafe defuses CSV formula injection: a cell starting with =, +, -, @,
+// tab or carriage return is run as a formula by Excel and Sheets, so it is
+// prefixed with a single quote to be shown as text.
+func csvSafe(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// DownloadFile serves a file from disk as an attachment.
+// The filename defaults to the base name of filePath. Missing files get a 404.
+//
+// Example:
+//
+//	response.DownloadFile(c, storage.Disk("local"), "exports/users.csv", "")
+func DownloadFile(c *gin.Context, disk FileSource, filePath string, filename string) {
+	streamFile(c, disk, "attachment", filePath, filename)
+}
+
+// InlineFile serves a file from disk for display in the browser.
+// Arguments match DownloadFile.
+func InlineFile(c *gin.Context, disk FileSource, filePath string, filename string) {
+	streamFile(c, disk, "inline", filePath, filename)
+}
+
+func streamFile(c *gin.Context, disk FileSource, disposition, filePath, filename string) {
+	reader, err := disk.ReadStream(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			NotFound(c, "File not found")
+			return
+		}
+		InternalServerError(c, "Failed to open file", err)
+		return
+	}
+
+	size, err := disk.Size(filePath)
+	if err != nil {
+		size = -1
+	}
+	if filename == "" {
+		filename = path.Base(filePath)
+	}
+	stream(c, reader, disposition, filename, disk.MimeType(filePath), size)
+}
+
+func stream(c *gin.Context, reader io.ReadCloser, disposition, filename, contentType string, size int64) {
+	defer reader.Close()
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if filename != "" {
+		// FormatMediaType switches to RFC 2231 encoding for non-ASCII names
+		disposition = mime.FormatMediaType(disposition, map[string]string{"filename": filename})
+	}
+
+	c.DataFromReader(http.StatusOK, size, contentType, reader, map[string]string{
+		"Content-Disposition": disposition,
+	})
+}
@@ -0,0 +1,149 @@
+package response
+
+import (
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// memDisk is an in-memory FileSource
+type memDisk struct {
+	fstest.MapFS
+}
+
+func (d memDisk) ReadStream(name string) (io.ReadCloser, error) {
+	return d.Open(name)
+}
+
+func (d memDisk) Size(name string) (int64, error) {
+	info, err := fs.Stat(d.MapFS, name)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (d memDisk) MimeType(name string) string {
+	return mime.TypeByExtension(path.Ext(name))
+}
+
+// trackingReader records how much was read and whether it was closed
+type trackingReader struct {
+	*strings.Reader
+	closed bool
+}
+
+func (r *trackingReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestDownload(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	body := strings.Repeat("id,name\n", 1000)
+	reader := &trackingReader{Reader: strings.NewReader(body)}
+
+	Download(c, reader, "users.csv", "text/csv", int64(len(body)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename=users.csv`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	assert.Equal(t, "8000", w.Header().Get("Content-Length"))
+	assert.Equal(t, body, w.Body.String())
+	assert.Zero(t, reader.Len(), "reader should be fully streamed")
+	assert.True(t, reader.closed, "reader should be closed")
+}
+
+func TestInline(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	reader := &trackingReader{Reader: strings.NewReader("%PDF-1.4")}
+
+	Inline(c, reader, "résumé.pdf", "", -1)
+
+	assert.Equal(t, `inline; filename*=utf-8''r%C3%A9sum%C3%A9.pdf`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("Content-Length"))
+	assert.True(t, reader.closed)
+}
+
+func TestDownloadFile(t *testing.T) {
+	disk := memDisk{fstest.MapFS{
+		"exports/report.json": {Data: []byte(`{"ok":true}`)},
+	}}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	DownloadFile(c, disk, "exports/report.json", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename=report.json`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, "11", w.Header().Get("Content-Length"))
+	assert.Equal(t, `{"ok":true}`, w.Body.String())
+}
+
+func TestDownloadFile_NotFound(t *testing.T) {
+	disk := memDisk{fstest.MapFS{}}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	DownloadFile(c, disk, "missing.txt", "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCSV(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	CSV(c, "users.csv", []string{"id", "name", "bio"}, [][]string{
+		{"1", "alice", "likes go, tea"},
+		{"2", `bob "the builder"`, "line one\nline two"},
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=users.csv`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "id,name,bio\n"+
+		"1,alice,\"likes go, tea\"\n"+
+		"2,\"bob \"\"the builder\"\"\",\"line one\nline two\"\n", w.Body.String())
+}
+
+func TestCSV_EscapesFormulas(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	CSV(c, "users.csv", []string{"name"}, [][]string{
+		{"=HYPERLINK(\"http://evil.example\")"},
+		{"+1"},
+		{"-2+3"},
+		{"@SUM(A1)"},
+		{"\tcmd"},
+		{"alice"},
+		{""},
+	})
+
+	assert.Equal(t, "name\n"+
+		"\"'=HYPERLINK(\"\"http://evil.example\"\")\"\n"+
+		"'+1\n"+
+		"'-2+3\n"+
+		"'@SUM(A1)\n"+
+		"'\tcmd\n"+
+		"alice\n"+
+		"\n", w.Body.String())
+}
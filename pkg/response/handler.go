@@ -3,8 +3,11 @@ package response
 import (
 	"errors"
 	"net/http"
+	"sync"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/zgiai/zgo/pkg/validation"
 	"gorm.io/gorm"
 )
 
@@ -18,12 +21,66 @@ var (
 	ErrValidation   = errors.New("validation failed")
 )
 
+// CodedError is an error reported with its own HTTP status and a
+// machine-readable code, such as the application's domain errors.
+type CodedError interface {
+	error
+	StatusCode() int
+	ErrorCode() string
+}
+
+// ErrorResolver converts errors a package knows, such as its plain
+// sentinels, to a CodedError. It returns nil for other errors.
+type ErrorResolver func(err error) CodedError
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   []ErrorResolver
+)
+
+// RegisterErrorResolver adds a resolver HandleError consults for errors
+// that are not a CodedError themselves. The domain package registers one
+// for its sentinels.
+func RegisterErrorResolver(fn ErrorResolver) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+	resolvers = append(resolvers, fn)
+}
+
+// asCodedError returns the CodedError in err's chain, or the one a
+// registered resolver converts err to
+func asCodedError(err error) CodedError {
+	if coded, ok := explicitCodedError(err); ok {
+		return coded
+	}
+	return resolveCodedError(err)
+}
+
+// explicitCodedError returns the CodedError in err's chain
+func explicitCodedError(err error) (CodedError, bool) {
+	var coded CodedError
+	return coded, errors.As(err, &coded)
+}
+
+// resolveCodedError asks the registered resolvers to convert err
+func resolveCodedError(err error) CodedError {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+	for _, resolve := range resolvers {
+		if coded := resolve(err); coded != nil {
+			return coded
+		}
+	}
+	return nil
+}
+
 // ErrorMapper maps domain errors to HTTP status codes.
 type ErrorMapper struct {
 	mappings map[error]int
 }
 
 // DefaultErrorMapper provides default error to status code mappings.
+// Coded errors carry their own status (see CodedError) and need no entry.
 var DefaultErrorMapper = &ErrorMapper{
 	mappings: map[error]int{
 		ErrNotFound:            http.StatusNotFound,
@@ -41,15 +98,47 @@ func (m *ErrorMapper) Register(err error, statusCode int) {
 }
 
 // GetStatusCode returns the HTTP status code for an error.
+// An explicit CodedError decides its own status; registered mappings come
+// next, then errors converted by a registered ErrorResolver. Validation
+// errors map to 422 unless registered otherwise.
 func (m *ErrorMapper) GetStatusCode(err error) int {
+	if coded, ok := explicitCodedError(err); ok {
+		return coded.StatusCode()
+	}
 	for mappedErr, code := range m.mappings {
 		if errors.Is(err, mappedErr) {
 			return code
 		}
 	}
+	if coded := resolveCodedError(err); coded != nil {
+		return coded.StatusCode()
+	}
+	if isValidationError(err) {
+		return http.StatusUnprocessableEntity
+	}
+	if isBodyTooLarge(err) {
+		return http.StatusRequestEntityTooLarge
+	}
 	return http.StatusInternalServerError
 }
 
+// StatusCode returns the HTTP status code for an error using DefaultErrorMapper.
+// It is the single source of truth for error-to-status mapping.
+//
+// Example:
+//
+//	response.StatusCode(domain.ErrUserNotFound) // 404
+func StatusCode(err error) int {
+	return DefaultErrorMapper.GetStatusCode(err)
+}
+
+// isValidationError reports whether err comes from request validation
+func isValidationError(err error) bool {
+	var fieldErrs validator.ValidationErrors
+	var ruleErrs validation.ValidationErrors
+	return errors.As(err, &fieldErrs) || errors.As(err, &ruleErrs)
+}
+
 // HandleError automatically maps errors to appropriate HTTP responses.
 // It checks for common error types and returns the correct status code.
 //
@@ -61,21 +150,27 @@ func (m *ErrorMapper) GetStatusCode(err error) int {
 //	    return
 //	}
 //
+// Domain errors also set "error_code" in the body, e.g. "USER_NOT_FOUND".
+//
 // Error Mapping:
-//   - gorm.ErrRecordNotFound -> 404 Not Found
-//   - ErrUnauthorized -> 401 Unauthorized
-//   - ErrForbidden -> 403 Forbidden
-//   - ErrConflict -> 409 Conflict
-//   - ErrValidation -> 422 Unprocessable Entity
+//   - CodedError, such as *domain.AppError -> its StatusCode
+//   - gorm.ErrRecordNotFound, domain.ErrUserNotFound -> 404 Not Found
+//   - ErrUnauthorized, domain.ErrInvalidCredentials -> 401 Unauthorized
+//   - ErrForbidden, domain.ErrPermissionDenied -> 403 Forbidden
+//   - ErrConflict, domain.ErrEmailAlreadyExists -> 409 Conflict
+//   - ErrValidation, validation errors -> 422 Unprocessable Entity
+//   - *http.MaxBytesError -> 413 Request Entity Too Large
 //   - Other errors -> 500 Internal Server Error
+//
+// See DefaultErrorMapper for the full table.
 func HandleError(c *gin.Context, message string, err error) {
 	if err == nil {
 		InternalServerError(c, message)
 		return
 	}
 
-	statusCode := DefaultErrorMapper.GetStatusCode(err)
-	ErrorWithDetails(c, statusCode, message, err)
+	statusCode := StatusCode(err)
+	errorWithCode(c, statusCode, message, err)
 }
 
 // HandleErrorWithMapper uses a custom error mapper.
@@ -86,7 +181,23 @@ func HandleErrorWithMapper(c *gin.Context, message string, err error, mapper *Er
 	}
 
 	statusCode := mapper.GetStatusCode(err)
-	ErrorWithDetails(c, statusCode, message, err)
+	errorWithCode(c, statusCode, message, err)
+}
+
+// errorWithCode sends an error response, adding the machine-readable code
+// of coded errors
+func errorWithCode(c *gin.Context, statusCode int, message string, err error) {
+	coded := asCodedError(err)
+	if coded == nil {
+		ErrorWithDetails(c, statusCode, message, err)
+		return
+	}
+	c.JSON(statusCode, ErrorResponse{
+		Code:      statusCode,
+		ErrorCode: coded.ErrorCode(),
+		Message:   translate(c, message),
+		Error:     err.Error(),
+	})
 }
 
 // Abort sends an error response and aborts the request chain.
@@ -106,7 +217,7 @@ func HandleErrorWithMapper(c *gin.Context, message string, err error, mapper *Er
 func Abort(c *gin.Context, statusCode int, message string) {
 	c.AbortWithStatusJSON(statusCode, ErrorResponse{
 		Code:    statusCode,
-		Message: message,
+		Message: translate(c, message),
 	})
 }
 
@@ -118,7 +229,7 @@ func AbortWithError(c *gin.Context, statusCode int, message string, err error) {
 	}
 	c.AbortWithStatusJSON(statusCode, ErrorResponse{
 		Code:    statusCode,
-		Message: message,
+		Message: translate(c, message),
 		Error:   errMsg,
 	})
 }
@@ -0,0 +1,130 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/zgiai/zgo/pkg/validation"
+	"gorm.io/gorm"
+)
+
+// codedError is a CodedError like the application's domain errors
+type codedError struct {
+	sentinel error
+	status   int
+	code     string
+}
+
+func (e *codedError) Error() string     { return e.sentinel.Error() }
+func (e *codedError) Unwrap() error     { return e.sentinel }
+func (e *codedError) StatusCode() int   { return e.status }
+func (e *codedError) ErrorCode() string { return e.code }
+
+var (
+	errQuotaExceeded = errors.New("quota exceeded")
+	errEmailTaken    = errors.New("email already registered")
+)
+
+// Resolve the test sentinels the way the domain package resolves its own
+func init() {
+	RegisterErrorResolver(func(err error) CodedError {
+		switch {
+		case errors.Is(err, errQuotaExceeded):
+			return &codedError{errQuotaExceeded, http.StatusTooManyRequests, "QUOTA_EXCEEDED"}
+		case errors.Is(err, errEmailTaken):
+			return &codedError{errEmailTaken, http.StatusConflict, "EMAIL_ALREADY_EXISTS"}
+		}
+		return nil
+	})
+}
+
+func TestStatusCode(t *testing.T) {
+	type signup struct {
+		Email string `validate:"required,email"`
+	}
+	fieldErr := validator.New().Struct(signup{})
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"record not found", gorm.ErrRecordNotFound, http.StatusNotFound},
+		{"resolved sentinel", errEmailTaken, http.StatusConflict},
+		{"wrapped resolved sentinel", fmt.Errorf("signup: %w", errQuotaExceeded), http.StatusTooManyRequests},
+		{"validator errors", fieldErr, http.StatusUnprocessableEntity},
+		{"validation package errors", validation.ValidationErrors{{Field: "email", Message: "required"}}, http.StatusUnprocessableEntity},
+		{"response sentinel", ErrForbidden, http.StatusForbidden},
+		{"body too large", fmt.Errorf("bind: %w", &http.MaxBytesError{Limit: 64}), http.StatusRequestEntityTooLarge},
+		{"coded error", &codedError{errors.New("gone"), http.StatusGone, "GONE"}, http.StatusGone},
+		{"coded error overrides mapping", &codedError{gorm.ErrRecordNotFound, http.StatusConflict, "CONFLICT"}, http.StatusConflict},
+		{"unknown error", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StatusCode(tt.err))
+		})
+	}
+}
+
+func TestHandleError(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	HandleError(c, "Registration failed", errEmailTaken)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var resp ErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Equal(t, "Registration failed", resp.Message)
+	assert.Equal(t, errEmailTaken.Error(), resp.Error)
+	assert.Equal(t, "EMAIL_ALREADY_EXISTS", resp.ErrorCode)
+}
+
+func TestHandleError_CodedError(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	coded := &codedError{errors.New("user not found"), http.StatusNotFound, "USER_NOT_FOUND"}
+	HandleError(c, "User not found", fmt.Errorf("get user: %w", coded))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var resp ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "USER_NOT_FOUND", resp.ErrorCode)
+}
+
+func TestHandleError_PlainErrorHasNoCode(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	HandleError(c, "Failed", errors.New("boom"))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "error_code")
+}
+
+func TestHandleErrorWithMapper(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	errQuota := errors.New("quota exceeded")
+	mapper := &ErrorMapper{mappings: map[error]int{}}
+	mapper.Register(errQuota, http.StatusTooManyRequests)
+
+	HandleErrorWithMapper(c, "Upload failed", errQuota, mapper)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
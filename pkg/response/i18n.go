@@ -0,0 +1,40 @@
+package response
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Translator returns message in the locale of ctx, or message unchanged
+// when it has no translation
+type Translator func(ctx context.Context, message string) string
+
+// translator is the Translator set with SetTranslator
+var translator atomic.Pointer[Translator]
+
+// SetTranslator sets how response messages are translated. Without one
+// they are sent as written. The application sets it when loading its
+// translations.
+func SetTranslator(fn Translator) {
+	if fn == nil {
+		translator.Store(nil)
+		return
+	}
+	translator.Store(&fn)
+}
+
+// translate returns message in the request locale. Messages are their own
+// translation keys ("Unauthorized", "Login failed"), so a message without
+// a translation is sent unchanged.
+func translate(c *gin.Context, message string) string {
+	if message == "" || c == nil || c.Request == nil {
+		return message
+	}
+	fn := translator.Load()
+	if fn == nil {
+		return message
+	}
+	return (*fn)(c.Request.Context(), message)
+}
@@ -1,6 +1,7 @@
 package response
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -24,23 +25,27 @@ import (
 //	response.Success(c, paginator)
 //	// Output: {"code": 0, "message": "success", "data": [...], "meta": {...}, "links": {...}}
 func Success(c *gin.Context, data any) {
+	c.JSON(http.StatusOK, successBody(c, data))
+}
+
+// successBody wraps data in the success envelope, expanding paginators
+func successBody(c *gin.Context, data any) any {
 	// Check if data is a Paginator (implements Paginatable with Items)
 	if p, ok := data.(PaginatableWithItems); ok {
-		c.JSON(http.StatusOK, PaginatedResponse{
+		return PaginatedResponse{
 			Code:    0,
-			Message: "success",
+			Message: translate(c, "success"),
 			Data:    p.GetItems(),
 			Meta:    p.GetMeta(),
 			Links:   p.GetLinks(),
-		})
-		return
+		}
 	}
 
-	c.JSON(http.StatusOK, Response{
+	return Response{
 		Code:    0,
-		Message: "success",
+		Message: translate(c, "success"),
 		Data:    data,
-	})
+	}
 }
 
 // OK is an alias for Success.
@@ -57,7 +62,7 @@ func OK(c *gin.Context, data any) {
 func Created(c *gin.Context, data any) {
 	c.JSON(http.StatusCreated, Response{
 		Code:    0,
-		Message: "created",
+		Message: translate(c, "created"),
 		Data:    data,
 	})
 }
@@ -73,7 +78,7 @@ func NoContent(c *gin.Context) {
 func Accepted(c *gin.Context, data any) {
 	c.JSON(http.StatusAccepted, Response{
 		Code:    0,
-		Message: "accepted",
+		Message: translate(c, "accepted"),
 		Data:    data,
 	})
 }
@@ -93,7 +98,7 @@ func Accepted(c *gin.Context, data any) {
 func Paginated(c *gin.Context, data any, paginator Paginatable) {
 	c.JSON(http.StatusOK, PaginatedResponse{
 		Code:    0,
-		Message: "success",
+		Message: translate(c, "success"),
 		Data:    data,
 		Meta:    paginator.GetMeta(),
 		Links:   paginator.GetLinks(),
@@ -173,7 +178,7 @@ func TransformPaginated[T any](c *gin.Context, items []T, paginator Paginatable,
 func Resource(c *gin.Context, resource Resourceable) {
 	c.JSON(http.StatusOK, Response{
 		Code:    0,
-		Message: "success",
+		Message: translate(c, "success"),
 		Data:    resource.ToArray(),
 	})
 }
@@ -182,7 +187,7 @@ func Resource(c *gin.Context, resource Resourceable) {
 func ResourceCreated(c *gin.Context, resource Resourceable) {
 	c.JSON(http.StatusCreated, Response{
 		Code:    0,
-		Message: "created",
+		Message: translate(c, "created"),
 		Data:    resource.ToArray(),
 	})
 }
@@ -198,7 +203,7 @@ func Collection(c *gin.Context, collection Collectable) {
 	if paginator != nil {
 		c.JSON(http.StatusOK, PaginatedResponse{
 			Code:    0,
-			Message: "success",
+			Message: translate(c, "success"),
 			Data:    collection.ToArray(),
 			Meta:    paginator.GetMeta(),
 			Links:   paginator.GetLinks(),
@@ -208,7 +213,7 @@ func Collection(c *gin.Context, collection Collectable) {
 
 	c.JSON(http.StatusOK, Response{
 		Code:    0,
-		Message: "success",
+		Message: translate(c, "success"),
 		Data:    collection.ToArray(),
 	})
 }
@@ -221,7 +226,7 @@ func Collection(c *gin.Context, collection Collectable) {
 func Error(c *gin.Context, statusCode int, message string) {
 	c.JSON(statusCode, ErrorResponse{
 		Code:    statusCode,
-		Message: message,
+		Message: translate(c, message),
 	})
 }
 
@@ -233,13 +238,14 @@ func ErrorWithDetails(c *gin.Context, statusCode int, message string, err error)
 	}
 	c.JSON(statusCode, ErrorResponse{
 		Code:    statusCode,
-		Message: message,
+		Message: translate(c, message),
 		Error:   errMsg,
 	})
 }
 
 // BadRequest sends a 400 Bad Request response.
-// Use this for invalid request data.
+// Use this for invalid request data. Bind errors caused by a body over the
+// MaxBodySize limit are reported as 413 Request Entity Too Large instead.
 //
 // Example:
 //
@@ -249,6 +255,10 @@ func BadRequest(c *gin.Context, message string, err ...error) {
 	if len(err) > 0 {
 		e = err[0]
 	}
+	if isBodyTooLarge(e) {
+		ErrorWithDetails(c, http.StatusRequestEntityTooLarge, "Request body too large", e)
+		return
+	}
 	ErrorWithDetails(c, http.StatusBadRequest, message, e)
 }
 
@@ -323,7 +333,7 @@ func UnprocessableEntity(c *gin.Context, message string, err ...error) {
 func ValidationFailed(c *gin.Context, errors map[string][]string) {
 	c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
 		Code:    http.StatusUnprocessableEntity,
-		Message: "Validation failed",
+		Message: translate(c, "Validation failed"),
 		Errors:  errors,
 	})
 }
@@ -357,3 +367,9 @@ func ServiceUnavailable(c *gin.Context, message ...string) {
 	}
 	Error(c, http.StatusServiceUnavailable, msg)
 }
+
+// isBodyTooLarge reports whether err comes from reading past a body size limit
+func isBodyTooLarge(err error) bool {
+	var maxErr *http.MaxBytesError
+	return errors.As(err, &maxErr)
+}
@@ -1,6 +1,7 @@
 package response
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -250,3 +251,36 @@ func TestPaginated(t *testing.T) {
 	assert.NotNil(t, resp.Links)
 	assert.Equal(t, "/api/users?page=1", resp.Links.First)
 }
+
+type localeKey struct{}
+
+func TestError_TranslatesMessage(t *testing.T) {
+	SetTranslator(func(ctx context.Context, message string) string {
+		if ctx.Value(localeKey{}) == "zh" && message == "Test message" {
+			return "测试消息"
+		}
+		return message
+	})
+	t.Cleanup(func() { SetTranslator(nil) })
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), localeKey{}, "zh"))
+
+	Error(c, http.StatusBadRequest, "Test message")
+
+	var resp ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "测试消息", resp.Message)
+
+	// Untranslated messages are sent unchanged
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), localeKey{}, "zh"))
+	Error(c, http.StatusBadRequest, "No translation")
+
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "No translation", resp.Message)
+}
@@ -0,0 +1,140 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SSEEvent is one server-sent event
+type SSEEvent struct {
+	// ID sets the client's Last-Event-ID (optional)
+	ID string
+
+	// Event names the event type; clients receive unnamed events as "message"
+	Event string
+
+	// Data is the payload. Strings and []byte are sent as-is, anything
+	// else is JSON-encoded. Multi-line data is split across data: lines.
+	Data any
+
+	// Retry tells the client how long to wait before reconnecting (optional)
+	Retry time.Duration
+}
+
+// SSEConfig holds SSE stream configuration
+type SSEConfig struct {
+	// KeepAlive is how often a comment is sent while no events arrive, so
+	// proxies and load balancers do not close an idle stream
+	// Default: 15s
+	KeepAlive time.Duration
+}
+
+// DefaultSSEConfig returns default configuration
+func DefaultSSEConfig() SSEConfig {
+	return SSEConfig{KeepAlive: 15 * time.Second}
+}
+
+// SSE streams events from ch as text/event-stream until ch is closed or
+// the client disconnects. The producer should stop sending once the
+// request context is done.
+//
+// Example:
+//
+//	func (h *Handler) Progress(c *gin.Context) {
+//	    ch := make(chan response.SSEEvent)
+//	    go h.service.Watch(c.Request.Context(), jobID, ch) // closes ch when finished
+//	    response.SSE(c, ch)
+//	}
+func SSE(c *gin.Context, ch <-chan SSEEvent) {
+	SSEWithConfig(c, ch, DefaultSSEConfig())
+}
+
+// SSEWithConfig is SSE with custom configuration
+func SSEWithConfig(c *gin.Context, ch <-chan SSEEvent, cfg SSEConfig) {
+	if cfg.KeepAlive <= 0 {
+		cfg.KeepAlive = DefaultSSEConfig().KeepAlive
+	}
+
+	h := c.Writer.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	// Stop nginx from buffering the stream
+	h.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(cfg.KeepAlive)
+	defer keepAlive.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			data, err := event.encode()
+			if err != nil {
+				// Data that cannot be encoded is dropped; the stream goes on
+				continue
+			}
+			if _, err := c.Writer.Write(data); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := c.Writer.WriteString(": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// encode renders the event in the text/event-stream wire format
+func (e SSEEvent) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	if e.ID != "" {
+		buf.WriteString("id: " + sseField(e.ID) + "\n")
+	}
+	if e.Event != "" {
+		buf.WriteString("event: " + sseField(e.Event) + "\n")
+	}
+	if e.Retry > 0 {
+		buf.WriteString("retry: " + strconv.FormatInt(e.Retry.Milliseconds(), 10) + "\n")
+	}
+
+	var data string
+	switch v := e.Data.(type) {
+	case nil:
+	case string:
+		data = v
+	case []byte:
+		data = string(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		data = string(b)
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		buf.WriteString("data: " + line + "\n")
+	}
+
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+// sseField strips line breaks, which would end the field early
+func sseField(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
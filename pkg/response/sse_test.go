@@ -0,0 +1,82 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSSEContext(ctx context.Context) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+	return c, w
+}
+
+func TestSSE_WireFormat(t *testing.T) {
+	c, w := newSSEContext(context.Background())
+
+	ch := make(chan SSEEvent, 3)
+	ch <- SSEEvent{ID: "1", Event: "progress", Data: map[string]int{"percent": 50}}
+	ch <- SSEEvent{Data: "line one\nline two", Retry: 3 * time.Second}
+	ch <- SSEEvent{ID: "2\nevent: injected", Event: "done"}
+	close(ch)
+
+	SSE(c, ch)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "no", w.Header().Get("X-Accel-Buffering"))
+	assert.True(t, w.Flushed)
+
+	assert.Equal(t, "id: 1\nevent: progress\ndata: {\"percent\":50}\n\n"+
+		"retry: 3000\ndata: line one\ndata: line two\n\n"+
+		"id: 2event: injected\nevent: done\ndata: \n\n", w.Body.String())
+}
+
+func TestSSE_SkipsUnencodableData(t *testing.T) {
+	c, w := newSSEContext(context.Background())
+
+	ch := make(chan SSEEvent, 2)
+	ch <- SSEEvent{Data: func() {}}
+	ch <- SSEEvent{Data: "ok"}
+	close(ch)
+
+	SSE(c, ch)
+	assert.Equal(t, "data: ok\n\n", w.Body.String())
+}
+
+func TestSSE_KeepAliveAndDisconnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c, w := newSSEContext(ctx)
+
+	// Never closed: only the client going away ends the stream
+	ch := make(chan SSEEvent)
+
+	done := make(chan struct{})
+	go func() {
+		SSEWithConfig(c, ch, SSEConfig{KeepAlive: 10 * time.Millisecond})
+		close(done)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("SSE did not return after the request context was cancelled")
+	}
+
+	body := w.Body.String()
+	require.NotEmpty(t, body)
+	assert.True(t, strings.HasPrefix(body, ": keep-alive\n\n"), body)
+}
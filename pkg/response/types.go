@@ -105,9 +105,10 @@ type Links struct {
 //	    "error": "record not found"
 //	}
 type ErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Error   string `json:"error,omitempty"`
+	Code      int    `json:"code"`
+	ErrorCode string `json:"error_code,omitempty"` // Machine-readable domain code, e.g. "USER_NOT_FOUND"
+	Message   string `json:"message"`
+	Error     string `json:"error,omitempty"`
 }
 
 // ValidationErrorResponse is returned for validation failures.
@@ -129,6 +130,25 @@ type ValidationErrorResponse struct {
 	Errors  map[string][]string `json:"errors"`
 }
 
+// FieldErrorResponse is returned by ValidationError.
+// Holds one message per request field.
+//
+// Example JSON output:
+//
+//	{
+//	    "code": 422,
+//	    "message": "Validation failed",
+//	    "errors": {
+//	        "email": "email must be a valid email address",
+//	        "password": "password must be at least 6 characters"
+//	    }
+//	}
+type FieldErrorResponse struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors"`
+}
+
 // Responder interface for custom response types.
 // Implement this interface to create custom response handlers.
 type Responder interface {
@@ -0,0 +1,63 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/zgiai/zgo/pkg/validation"
+)
+
+func init() {
+	// Report binding errors against JSON field names instead of Go field names
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(validation.JSONFieldName)
+	}
+}
+
+// ValidationError sends a 422 response with one readable message per field.
+// Errors that are not validation failures (e.g. malformed JSON) get a 400.
+//
+// Example:
+//
+//	if err := c.ShouldBindJSON(&req); err != nil {
+//	    response.ValidationError(c, err)
+//	    return
+//	}
+//	// Output: {"code": 422, "message": "Validation failed", "errors": {"email": "email must be a valid email address"}}
+func ValidationError(c *gin.Context, err error) {
+	fields := ValidationMessages(err)
+	if fields == nil {
+		BadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, FieldErrorResponse{
+		Code:    http.StatusUnprocessableEntity,
+		Message: translate(c, "Validation failed"),
+		Errors:  fields,
+	})
+}
+
+// ValidationMessages converts validation errors to a field→message map.
+// Returns nil when err is not a validation error.
+func ValidationMessages(err error) map[string]string {
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		fields := make(map[string]string, len(fieldErrs))
+		for _, fe := range fieldErrs {
+			if _, exists := fields[fe.Field()]; !exists {
+				fields[fe.Field()] = validation.Message(fe)
+			}
+		}
+		return fields
+	}
+
+	var ruleErrs validation.ValidationErrors
+	if errors.As(err, &ruleErrs) {
+		return ruleErrs.ToSimpleMap()
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type signupRequest struct {
+	Username string `json:"username" binding:"required,max=10"`
+	Password string `json:"password" binding:"required,min=6"`
+	Email    string `json:"email" binding:"required,email"`
+}
+
+func bindAndRespond(body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var req signupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ValidationError(c, err)
+	}
+	return w
+}
+
+func TestValidationError(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		field string
+		want  string
+	}{
+		{"required", `{"password":"secret1","email":"a@example.com"}`, "username", "username is required"},
+		{"min", `{"username":"bob","password":"abc","email":"a@example.com"}`, "password", "password must be at least 6 characters"},
+		{"max", `{"username":"averyverylongname","password":"secret1","email":"a@example.com"}`, "username", "username must be at most 10 characters"},
+		{"email", `{"username":"bob","password":"secret1","email":"not-an-email"}`, "email", "email must be a valid email address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := bindAndRespond(tt.body)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+			var resp FieldErrorResponse
+			err := json.Unmarshal(w.Body.Bytes(), &resp)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+			assert.Equal(t, "Validation failed", resp.Message)
+			assert.Equal(t, map[string]string{tt.field: tt.want}, resp.Errors)
+		})
+	}
+}
+
+func TestValidationError_MultipleFields(t *testing.T) {
+	w := bindAndRespond(`{}`)
+
+	var resp FieldErrorResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Errors, 3)
+	assert.Contains(t, resp.Errors, "username")
+	assert.Contains(t, resp.Errors, "password")
+	assert.Contains(t, resp.Errors, "email")
+}
+
+func TestValidationError_MalformedJSON(t *testing.T) {
+	w := bindAndRespond(`{"username":`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
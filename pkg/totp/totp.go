@@ -0,0 +1,141 @@
+// Package totp implements time-based one-time passwords (RFC 6238) as used
+// by authenticator apps: HMAC-SHA1, 6 digits and a 30 second period.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits is the length of generated codes
+	Digits = 6
+
+	// Period is how long each code is valid
+	Period = 30 * time.Second
+
+	// Skew is how many periods either side of now Validate accepts, to
+	// allow for clock drift and codes typed just as they roll over
+	Skew = 1
+
+	// secretSize is the secret length in bytes (160 bits, as RFC 4226 recommends)
+	secretSize = 20
+)
+
+// ErrInvalidSecret is returned for secrets that are not valid base32
+var ErrInvalidSecret = errors.New("totp: invalid secret")
+
+// encoding is unpadded base32, the form authenticator apps expect
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random base32-encoded secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// Code returns the code for secret at time t
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, counter(t)), nil
+}
+
+// Validate reports whether code is valid for secret now
+func Validate(code, secret string) bool {
+	return ValidateAt(code, secret, time.Now())
+}
+
+// ValidateAt reports whether code is valid for secret at time t, accepting
+// codes up to Skew periods early or late
+func ValidateAt(passcode, secret string, t time.Time) bool {
+	_, ok := Match(passcode, secret, t)
+	return ok
+}
+
+// Match returns the time step code is valid for at time t, accepting codes
+// up to Skew periods early or late. A code stays valid for several periods,
+// so callers that must not accept it twice should remember the step and
+// reject any step not after it.
+func Match(passcode, secret string, t time.Time) (uint64, bool) {
+	passcode = strings.TrimSpace(passcode)
+	if len(passcode) != Digits {
+		return 0, false
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	now := counter(t)
+	for i := -Skew; i <= Skew; i++ {
+		step := uint64(int64(now) + int64(i))
+		want := code(key, step)
+		if subtle.ConstantTimeCompare([]byte(want), []byte(passcode)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// URL returns the otpauth:// URL authenticator apps import, usually shown
+// as a QR code
+func URL(issuer, account, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(Digits))
+	params.Set("period", fmt.Sprint(int(Period.Seconds())))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// decodeSecret accepts secrets with or without padding, spaces or lowercase
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := encoding.DecodeString(strings.TrimRight(secret, "="))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+// counter returns the number of periods since the Unix epoch
+func counter(t time.Time) uint64 {
+	return uint64(t.Unix()) / uint64(Period.Seconds())
+}
+
+// code computes the HOTP value (RFC 4226) for key and counter
+func code(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
@@ -0,0 +1,96 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 key from the RFC 6238 test vectors
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	// The RFC lists 8-digit codes; 6-digit codes are their last six digits
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(t=%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateAt_Skew(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, _ := Code(rfcSecret, now)
+
+	if !ValidateAt(code, rfcSecret, now) {
+		t.Error("current code rejected")
+	}
+	if !ValidateAt(code, rfcSecret, now.Add(Period)) {
+		t.Error("code from the previous period rejected")
+	}
+	if ValidateAt(code, rfcSecret, now.Add(3*Period)) {
+		t.Error("code from three periods ago accepted")
+	}
+	if ValidateAt("12345", rfcSecret, now) || ValidateAt(code, "not base32!", now) {
+		t.Error("malformed input accepted")
+	}
+}
+
+func TestMatch_ReturnsCodeStep(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, _ := Code(rfcSecret, now)
+	want := uint64(1234567890) / uint64(Period.Seconds())
+
+	// The step is the code's own, wherever in the skew window it is checked
+	for _, at := range []time.Time{now, now.Add(Period), now.Add(-Period)} {
+		step, ok := Match(code, rfcSecret, at)
+		if !ok || step != want {
+			t.Errorf("Match at %v = (%d, %v), want (%d, true)", at, step, ok, want)
+		}
+	}
+	if _, ok := Match(code, rfcSecret, now.Add(3*Period)); ok {
+		t.Error("code from three periods ago matched")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret) != 32 || strings.Contains(secret, "=") {
+		t.Errorf("secret = %q, want 32 unpadded base32 characters", secret)
+	}
+
+	code, err := Code(strings.ToLower(secret), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Validate(code, secret) {
+		t.Error("generated secret does not round-trip")
+	}
+}
+
+func TestURL(t *testing.T) {
+	got := URL("ZGO", "alice@example.com", "JBSWY3DPEHPK3PXP")
+	want := "otpauth://totp/ZGO:alice@example.com?algorithm=SHA1&digits=6&issuer=ZGO&period=30&secret=JBSWY3DPEHPK3PXP"
+	if got != want {
+		t.Errorf("URL() =\n%s\nwant\n%s", got, want)
+	}
+}
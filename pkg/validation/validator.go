@@ -35,16 +35,7 @@ func New() *Validator {
 	v := validator.New()
 
 	// Use JSON tag names for field names
-	v.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
-		if name == "-" {
-			return fld.Name
-		}
-		if name == "" {
-			return toSnakeCase(fld.Name)
-		}
-		return name
-	})
+	v.RegisterTagNameFunc(JSONFieldName)
 
 	val := &Validator{
 		v:        v,
@@ -57,6 +48,20 @@ func New() *Validator {
 	return val
 }
 
+// JSONFieldName returns the name a struct field has in JSON, falling back to
+// snake_case. Register it with validator.RegisterTagNameFunc to report
+// errors against request field names.
+func JSONFieldName(fld reflect.StructField) string {
+	name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return fld.Name
+	}
+	if name == "" {
+		return toSnakeCase(fld.Name)
+	}
+	return name
+}
+
 // registerDefaultRules registers commonly used custom validation rules
 func (v *Validator) registerDefaultRules() {
 	// Phone number validation (simple)
@@ -324,9 +329,9 @@ func getDefaultMessage(fe validator.FieldError) string {
 	case "uri":
 		return field + " must be a valid URI"
 	case "min":
-		return field + " must be at least " + param
+		return field + " must be at least " + param + sizeUnit(fe)
 	case "max":
-		return field + " must be at most " + param
+		return field + " must be at most " + param + sizeUnit(fe)
 	case "len":
 		return field + " must be exactly " + param + " characters"
 	case "eq":
@@ -444,6 +449,18 @@ func getDefaultMessage(fe validator.FieldError) string {
 	}
 }
 
+// sizeUnit names what min/max count for strings and collections
+func sizeUnit(fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
+
 // ValidationError represents a single validation error
 type ValidationError struct {
 	Field   string      `json:"field"`
@@ -570,6 +587,11 @@ func SetMessages(messages map[string]string) {
 	Global().SetMessages(messages)
 }
 
+// Message returns the human-readable message for a field error using the global validator
+func Message(fe validator.FieldError) string {
+	return Global().getMessage(fe)
+}
+
 // toSnakeCase converts PascalCase/camelCase to snake_case
 func toSnakeCase(s string) string {
 	var result strings.Builder
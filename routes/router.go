@@ -1,10 +1,17 @@
 package routes
 
 import (
+	"time"
+
 	"github.com/zgiai/zgo/internal/app"
+	"github.com/zgiai/zgo/internal/infra/batch"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/kv"
 	"github.com/zgiai/zgo/internal/infra/middleware"
 	"github.com/zgiai/zgo/internal/infra/monitor"
+	"github.com/zgiai/zgo/internal/infra/ratelimit"
 	"github.com/zgiai/zgo/internal/infra/router"
+	"github.com/zgiai/zgo/internal/infra/session"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -14,26 +21,56 @@ import (
 func Setup(engine *gin.Engine, handlers *app.Handlers) *router.Router {
 	r := router.New(engine)
 
-	// Register middleware groups
-	r.MiddlewareGroup("web", gin.Logger(), gin.Recovery())
-	r.MiddlewareGroup("api", gin.Logger(), gin.Recovery())
+	// Register middleware groups. Access logging and panic recovery are
+	// global, installed by the HTTP kernel before any route.
 	r.MiddlewareGroup("auth", middleware.JWTAuth())
+	// Rate limits and idempotency keys live in the KV_DRIVER store. Throttled
+	// routes allow a burst of 10 requests, refilled at 10 a minute.
+	r.MiddlewareGroup("throttle", middleware.RateLimit(middleware.RateLimitOptions{
+		Limiter: ratelimit.NewTokenBucket(kv.Default(), 10, time.Minute, 10),
+	}))
+	r.MiddlewareGroup("compress", middleware.Compress(middleware.DefaultCompressOptions()))
+	r.MiddlewareGroup("idempotent", middleware.Idempotency(middleware.NewKVIdempotencyStore(kv.Default())))
+	// Cookie sessions in the SESSION_DRIVER store, an alternative to "auth"
+	r.MiddlewareGroup("session", session.Middleware())
+	// Double-submit CSRF tokens, required next to "session" since browsers
+	// attach the session cookie to cross-site requests
+	csrf := middleware.DefaultCSRFOptions()
+	if cfg := config.GlobalConfig; cfg != nil {
+		csrf.Secure = cfg.Session.Secure
+	}
+	r.MiddlewareGroup("csrf", middleware.CSRF(csrf))
 
 	// Register middleware aliases
 	r.AliasMiddleware("jwt", middleware.JWTAuth())
+	r.AliasMiddleware("auth:session", middleware.SessionAuth())
+	r.AliasMiddleware("role:admin", middleware.RequireRole("admin"))
 
-	// Apply global middleware
-	r.Use(gin.Logger(), gin.Recovery())
-
-	// Swagger documentation
-	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Swagger documentation, readable from any origin without credentials
+	docsCORS := middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: []string{"*"},
+		AllowMethods: []string{"GET", "HEAD", "OPTIONS"},
+		MaxAge:       86400,
+	})
+	engine.GET("/swagger/*any", docsCORS, ginSwagger.WrapHandler(swaggerFiles.Handler))
+	engine.OPTIONS("/swagger/*any", docsCORS)
 
 	// Root endpoint - Welcome page
 	RegisterWelcome(engine)
 
 	// Register V1 API Routes
 	r.Group("/v1", func(api *router.Router) {
+		api.Use(middleware.APIVersionWithConfig(middleware.APIVersionConfig{
+			Supported: []string{"v1"},
+		}))
+		// Scope tenant models to the request's tenant when TENANT_ENABLED is set
+		if cfg := config.GlobalConfig; cfg != nil && cfg.Tenant.Enabled {
+			api.Use(middleware.Tenant(middleware.TenantConfigFrom(cfg.Tenant)))
+		}
 		RegisterAPI(api, handlers)
+
+		// Sub-requests are replayed through the engine with the caller's headers
+		api.POST("/batch", batch.Handler(engine)).Name("batch")
 	})
 
 	// Register Monitor
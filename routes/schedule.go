@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/zgiai/zgo/internal/app"
+	"github.com/zgiai/zgo/internal/infra/schedule"
+)
+
+// Schedule registers the application's periodic jobs. They run alongside
+// the HTTP server when SCHEDULE_ENABLED is true, and `zgo schedule:list`
+// shows them with their next run times.
+//
+// Example:
+//
+//	s.Schedule("@every 1h", tokens.PruneExpired)
+//	s.Schedule("0 3 * * *", reports.SendDaily).Named("reports:daily")
+func Schedule(s *schedule.Scheduler, application *app.Application) {
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/zgiai/zgo/internal/app"
+	"github.com/zgiai/zgo/internal/infra/task"
+	"github.com/zgiai/zgo/internal/modules/user"
+)
+
+// Tasks registers the application's one-shot maintenance tasks. Run them
+// with `zgo task:run <name> [--arg key=value]`; `zgo task:list` shows them.
+//
+// Example:
+//
+//	r.Register("stats:recalculate", stats.Recalculate(application.DB)).
+//	    Describe("Recalculate daily stats")
+func Tasks(r *task.Registry, application *app.Application) {
+	r.Register("cleanup:expired-tokens", user.PruneExpiredVerifications(application.DB, application.Config.JWT.VerifyExpire)).
+		Describe("Delete pending accounts whose latest verification link has expired")
+}
@@ -3,49 +3,74 @@ package feature
 import (
 	"fmt"
 	"math/rand"
+	"net/url"
+	"regexp"
 	"testing"
 	"time"
+
+	"github.com/zgiai/zgo/internal/infra/email"
 )
 
 func TestUserRegistration(t *testing.T) {
 	// Generate unique email to avoid constraint errors if DB persists
 	rand.Seed(time.Now().UnixNano())
-	email := fmt.Sprintf("test_%d@example.com", rand.Intn(100000))
+	address := fmt.Sprintf("test_%d@example.com", rand.Intn(100000))
 
 	tc := NewTestCase(t)
 
 	tc.Post("/v1/register").
 		WithJSON(map[string]any{
 			"username": "testuser",
-			"email":    email,
+			"email":    address,
 			"password": "password123",
 		}).
 		Call().
-		AssertOk().
+		AssertCreated().
 		AssertJSONPath("data.username", "testuser").
-		AssertJSONPath("data.email", email)
+		AssertJSONPath("data.email", address)
 }
 
+// verifyLink matches the token in the link of a verification email
+var verifyLink = regexp.MustCompile(`/v1/auth/verify\?token=([^"&]+)`)
+
 func TestUserLogin(t *testing.T) {
 	// 1. Register
 	rand.Seed(time.Now().UnixNano())
-	email := fmt.Sprintf("login_%d@example.com", rand.Intn(100000))
+	address := fmt.Sprintf("login_%d@example.com", rand.Intn(100000))
 	password := "password123"
 
 	tc := NewTestCase(t)
+	email.ResetSent()
 	tc.Post("/v1/register").
 		WithJSON(map[string]any{
 			"username": "loginuser",
-			"email":    email,
+			"email":    address,
 			"password": password,
 		}).
+		Call().
+		AssertCreated()
+
+	// 2. Verify the address, since pending accounts cannot log in
+	msg, ok := email.LastSent()
+	if !ok {
+		t.Fatal("expected a verification email")
+	}
+	match := verifyLink.FindStringSubmatch(msg.HTML)
+	if match == nil {
+		t.Fatalf("no verification link in %q", msg.HTML)
+	}
+	token, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatalf("unescape token: %v", err)
+	}
+	tc.Get("/v1/auth/verify?token=" + url.QueryEscape(token)).
 		Call().
 		AssertOk()
 
-	// 2. Login
+	// 3. Login
 	tc.Post("/v1/login").
 		WithJSON(map[string]any{
-			"username": email,
+			"username": address,
 			"password": password,
 		}).
 		Call().
@@ -17,6 +17,7 @@ import (
 	test_platform "github.com/zgiai/zgo/internal/infra/testing"
 	"github.com/zgiai/zgo/internal/modules/permission"
 	"github.com/zgiai/zgo/internal/modules/user"
+	"github.com/zgiai/zgo/pkg/hash"
 	"github.com/zgiai/zgo/routes"
 )
 
@@ -33,6 +34,9 @@ func SetupApp() *gin.Engine {
 	cfg.Database.MaxOpenConns = 1
 	cfg.JWT.Secret = "testing-secret"
 	cfg.JWT.Expire = time.Hour
+	cfg.Auth.HashDriver = string(hash.AlgorithmBcrypt)
+	cfg.Auth.BcryptCost = hash.MinBcryptCost // Fast hashing for test suites
+	cfg.Email.DryRun = true                  // Capture emails instead of sending them
 
 	// 2. Initialize Database (In-Memory SQLite)
 	db, err := database.NewDB(cfg)
@@ -48,7 +52,10 @@ func SetupApp() *gin.Engine {
 	}
 
 	// 4. Create Services via DI
-	jwtService := jwt.NewService(cfg)
+	jwtService, err := jwt.NewService(cfg)
+	if err != nil {
+		panic("failed to create JWT service: " + err.Error())
+	}
 	emailService := email.NewService(cfg)
 	eventBus := events.NewEventBus()
 
@@ -60,8 +67,8 @@ func SetupApp() *gin.Engine {
 	permRepo := permission.NewRepository(db)
 
 	// 6. Create Services
-	userService := user.NewService(userRepo, jwtService, eventBus)
 	permService := permission.NewService(permRepo)
+	userService := user.NewService(userRepo, permService, database.NewTxManager(db), jwtService, eventBus, cfg)
 
 	// 7. Create Handlers
 	handlers := &app.Handlers{
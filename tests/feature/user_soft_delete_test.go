@@ -0,0 +1,204 @@
+package feature
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zgiai/zgo/internal/bootstrap"
+	"github.com/zgiai/zgo/internal/domain"
+	"github.com/zgiai/zgo/internal/infra/config"
+	"github.com/zgiai/zgo/internal/infra/database"
+	"github.com/zgiai/zgo/internal/infra/events"
+	"github.com/zgiai/zgo/internal/infra/jwt"
+	test_platform "github.com/zgiai/zgo/internal/infra/testing"
+	"github.com/zgiai/zgo/internal/modules/permission"
+	"github.com/zgiai/zgo/internal/modules/user"
+	"github.com/zgiai/zgo/pkg/hash"
+)
+
+func setupUserRepository(t *testing.T) (domain.UserRepository, *test_platform.DatabaseTestCase) {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Database.Enabled = true
+	cfg.Database.Driver = "sqlite"
+	cfg.Database.Memory = true
+	cfg.Database.MaxIdleConns = 1
+	cfg.Database.MaxOpenConns = 1
+
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+
+	if err := bootstrap.RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+	return user.NewRepository(db), test_platform.NewDatabaseTestCase(t, db)
+}
+
+func findUser(users []*domain.User, id uint) *domain.User {
+	for _, u := range users {
+		if u.ID == id {
+			return u
+		}
+	}
+	return nil
+}
+
+func TestUserRepository_SoftDeleteAndRestore(t *testing.T) {
+	repo, dbtc := setupUserRepository(t)
+	ctx := context.Background()
+
+	// Migrations may seed users; compare against the starting count
+	_, before, err := repo.FindAll(ctx, 1, 100)
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+
+	u := &domain.User{Username: "trashed", Email: "trashed@example.com", Password: "x", Status: 1}
+	if err := repo.Create(ctx, u); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Soft delete hides the user from regular queries
+	if err := repo.Delete(ctx, u.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	dbtc.AssertSoftDeleted("users", map[string]any{"id": u.ID})
+
+	if _, err := repo.FindByID(ctx, u.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("FindByID after delete: err = %v, want ErrUserNotFound", err)
+	}
+	if users, total, _ := repo.FindAll(ctx, 1, 100); total != before || findUser(users, u.ID) != nil {
+		t.Errorf("FindAll total = %d, want %d without the deleted user", total, before)
+	}
+
+	trashed, total, err := repo.FindWithTrashed(ctx, 1, 100)
+	if err != nil {
+		t.Fatalf("FindWithTrashed: %v", err)
+	}
+	if got := findUser(trashed, u.ID); total != before+1 || got == nil || got.DeletedAt == nil {
+		t.Fatalf("FindWithTrashed total = %d, want %d including the soft-deleted user", total, before+1)
+	}
+
+	// Restore brings the user back
+	if err := repo.Restore(ctx, u.ID); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	dbtc.AssertNotSoftDeleted("users", map[string]any{"id": u.ID})
+
+	users, total, err := repo.FindAll(ctx, 1, 100)
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	restored := findUser(users, u.ID)
+	if total != before+1 || restored == nil {
+		t.Fatalf("FindAll after restore total = %d, want %d including the restored user", total, before+1)
+	}
+	if restored.DeletedAt != nil {
+		t.Error("restored user should not carry DeletedAt")
+	}
+
+	// Restoring an active user is a not-found
+	if err := repo.Restore(ctx, u.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("Restore active user: err = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestUserRepository_ForceDelete(t *testing.T) {
+	repo, dbtc := setupUserRepository(t)
+	ctx := context.Background()
+
+	u := &domain.User{Username: "gone", Email: "gone@example.com", Password: "x", Status: 1}
+	if err := repo.Create(ctx, u); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := repo.Delete(ctx, u.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	if err := repo.ForceDelete(ctx, u.ID); err != nil {
+		t.Fatalf("ForceDelete: %v", err)
+	}
+	dbtc.AssertDatabaseMissing("users", map[string]any{"id": u.ID})
+
+	if err := repo.Restore(ctx, u.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("Restore after ForceDelete: err = %v, want ErrUserNotFound", err)
+	}
+	if err := repo.ForceDelete(ctx, u.ID); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("ForceDelete missing user: err = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestUserService_RegisterWithSoftDeletedEmail(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Database.Enabled = true
+	cfg.Database.Driver = "sqlite"
+	cfg.Database.Memory = true
+	cfg.Database.MaxIdleConns = 1
+	cfg.Database.MaxOpenConns = 1
+	cfg.JWT.Secret = "testing-secret"
+	cfg.JWT.Expire = time.Hour
+	cfg.Auth.HashDriver = string(hash.AlgorithmBcrypt)
+	cfg.Auth.BcryptCost = hash.MinBcryptCost
+
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db); err != nil {
+		t.Fatalf("RunMigrations: %v", err)
+	}
+	jwtService, err := jwt.NewService(cfg)
+	if err != nil {
+		t.Fatalf("jwt.NewService: %v", err)
+	}
+
+	repo := user.NewRepository(db)
+	roles := permission.NewService(permission.NewRepository(db))
+	svc := user.NewService(repo, roles, database.NewTxManager(db), jwtService, events.NewEventBus(), cfg)
+	ctx := context.Background()
+
+	req := &user.UserRegisterRequest{Username: "leaver", Email: "leaver@example.com", Password: "secret123"}
+	first, err := svc.Register(ctx, req)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := repo.Delete(ctx, first.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	// The soft-deleted account keeps its email reserved
+	again := &user.UserRegisterRequest{Username: "returner", Email: "leaver@example.com", Password: "secret123"}
+	_, err = svc.Register(ctx, again)
+	if !errors.Is(err, domain.ErrEmailBelongsToDeletedAccount) {
+		t.Fatalf("Register with a soft-deleted email: err = %v, want ErrEmailBelongsToDeletedAccount", err)
+	}
+	if appErr := domain.AsAppError(err); appErr == nil || appErr.HTTPStatus != http.StatusConflict {
+		t.Errorf("Register with a soft-deleted email: want a 409, got %+v", appErr)
+	}
+
+	found, err := repo.FindByEmailWithTrashed(ctx, "leaver@example.com")
+	if err != nil || found.ID != first.ID || found.DeletedAt == nil {
+		t.Fatalf("FindByEmailWithTrashed = %+v, %v; want the soft-deleted user", found, err)
+	}
+
+	// Restoring still works because nobody took the email
+	if err := repo.Restore(ctx, first.ID); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if _, err := svc.Register(ctx, again); !errors.Is(err, domain.ErrEmailAlreadyExists) {
+		t.Errorf("Register with a restored email: err = %v, want ErrEmailAlreadyExists", err)
+	}
+
+	// Once force-deleted, the email is free again
+	if err := repo.ForceDelete(ctx, first.ID); err != nil {
+		t.Fatalf("ForceDelete: %v", err)
+	}
+	if _, err := svc.Register(ctx, again); err != nil {
+		t.Errorf("Register after ForceDelete: %v", err)
+	}
+}
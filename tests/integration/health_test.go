@@ -5,9 +5,12 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/zgiai/zgo/internal/infra/email"
 	"github.com/zgiai/zgo/internal/infra/health"
 	"github.com/gin-gonic/gin"
 )
@@ -113,6 +116,28 @@ func TestChecker_GetHealth_Unhealthy(t *testing.T) {
 	}
 }
 
+func TestChecker_Stop(t *testing.T) {
+	checker := health.New()
+
+	var ran bool
+	checker.Register("db", func(ctx context.Context) health.CheckResult {
+		ran = true
+		return health.CheckResult{Status: health.StatusUp}
+	})
+	checker.Stop()
+
+	response := checker.GetHealth(context.Background())
+	if response.Status != health.StatusDown {
+		t.Errorf("Expected status down after Stop, got %s", response.Status)
+	}
+	if _, ok := response.Checks["shutdown"]; !ok {
+		t.Error("Expected a shutdown check result")
+	}
+	if ran {
+		t.Error("Expected checkers not to run after Stop")
+	}
+}
+
 func TestCheck_Up(t *testing.T) {
 	check := health.Up("all good")
 	ctx := context.Background()
@@ -163,6 +188,24 @@ func TestCheck_Custom(t *testing.T) {
 	}
 }
 
+func TestCheck_NonCritical(t *testing.T) {
+	ctx := context.Background()
+
+	result := health.NonCritical(health.Down("provider unreachable"))(ctx)
+	if result.Status != health.StatusDegraded {
+		t.Errorf("Expected down check to be degraded, got %s", result.Status)
+	}
+	if result.Message != "provider unreachable" {
+		t.Errorf("Expected message to be kept, got '%s'", result.Message)
+	}
+
+	checker := health.New()
+	checker.Register("email", health.NonCritical(health.Down("provider unreachable")))
+	if !checker.IsHealthy(ctx) {
+		t.Error("Expected a non-critical failure to keep the service healthy")
+	}
+}
+
 func TestCheck_Timeout(t *testing.T) {
 	slowCheck := func(ctx context.Context) health.CheckResult {
 		time.Sleep(200 * time.Millisecond)
@@ -195,6 +238,41 @@ func TestCheck_Timeout_Passes(t *testing.T) {
 	}
 }
 
+// authFailingTransport is an email transport whose credentials are rejected
+type authFailingTransport struct {
+	sent int
+}
+
+func (t *authFailingTransport) Send(msg email.Message) error {
+	t.sent++
+	return errors.New("535 authentication failed")
+}
+
+func (t *authFailingTransport) Ping(ctx context.Context) error {
+	return errors.New("535 authentication failed")
+}
+
+func TestCheck_Email(t *testing.T) {
+	transport := &authFailingTransport{}
+	result := health.Email(email.NewServiceWithTransport("noreply@example.com", transport))(context.Background())
+
+	if result.Status != health.StatusDown {
+		t.Errorf("Expected rejected credentials to be down, got %s", result.Status)
+	}
+	if result.Message != "535 authentication failed" {
+		t.Errorf("Expected the provider error, got '%s'", result.Message)
+	}
+	if transport.sent != 0 {
+		t.Error("Expected the check not to send a message")
+	}
+
+	// Transports without credentials to check are up
+	result = health.Email(email.NewServiceWithTransport("noreply@example.com", email.LogTransport{}))(context.Background())
+	if result.Status != health.StatusUp {
+		t.Errorf("Expected log transport to be up, got %s", result.Status)
+	}
+}
+
 func TestCheck_DiskSpace(t *testing.T) {
 	check := health.DiskSpace("/", 1024*1024)
 	ctx := context.Background()
@@ -407,3 +485,66 @@ func TestParallelChecks(t *testing.T) {
 		t.Errorf("Expected 5 results, got %d", len(results))
 	}
 }
+
+func TestHealth_PrometheusHandler(t *testing.T) {
+	checker := health.New()
+	checker.Register("redis", health.Up("ok"))
+	checker.Register("queue", health.Down("unreachable"))
+
+	r := gin.New()
+	checker.RegisterRoutes(r, health.WithPrometheus(true))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics/health", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	body := w.Body.String()
+	for _, line := range []string{
+		`health_check_up{name="redis"} 1`,
+		`health_check_up{name="queue"} 0`,
+		`health_check_duration_seconds{name="redis"}`,
+		"health_up 0",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestHealth_PrometheusRouteDisabledByDefault(t *testing.T) {
+	r := gin.New()
+	health.New().RegisterRoutes(r)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics/health", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestHealth_PrometheusConcurrentScrapes(t *testing.T) {
+	checker := health.New()
+	checker.Register("db", health.Up("ok"))
+	handler := checker.PrometheusHandler()
+
+	r := gin.New()
+	r.GET("/metrics/health", handler)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics/health", nil))
+			if !strings.Contains(w.Body.String(), "health_up 1") {
+				t.Error("Expected health_up 1 in concurrent scrape")
+			}
+		}()
+	}
+	wg.Wait()
+}
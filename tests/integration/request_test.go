@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/zgiai/zgo/pkg/pagination"
 	"github.com/zgiai/zgo/pkg/request"
 	"github.com/gin-gonic/gin"
 )
@@ -440,3 +441,100 @@ func TestValidateQuery(t *testing.T) {
 		t.Errorf("Expected status 200, got %d: %s", w.Code, w.Body.String())
 	}
 }
+
+func TestBindQuery_ListQuery(t *testing.T) {
+	router := gin.New()
+	router.GET("/users", func(c *gin.Context) {
+		var q request.ListQuery
+		if !request.BindQuery(c, &q) {
+			return
+		}
+		p := q.Pagination()
+		c.JSON(http.StatusOK, gin.H{"page": p.GetPage(), "per_page": p.GetPerPage(), "order": p.GetOrder()})
+	})
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantField  string
+	}{
+		{"", http.StatusOK, ""},
+		{"?page=2&per_page=50&sort=created_at&order=asc", http.StatusOK, ""},
+		{"?per_page=500", http.StatusOK, ""}, // clamped under the default config
+		{"?per_page=0", http.StatusOK, ""},
+		{"?page_size=-5", http.StatusUnprocessableEntity, "page_size"},
+		{"?page=-1", http.StatusUnprocessableEntity, "page"},
+		{"?order=sideways", http.StatusUnprocessableEntity, "order"},
+		{"?page=abc", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/users"+tt.query, nil)
+		router.ServeHTTP(w, httpReq)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%q: expected status %d, got %d: %s", tt.query, tt.wantStatus, w.Code, w.Body.String())
+			continue
+		}
+		if tt.wantField == "" {
+			continue
+		}
+		var body struct {
+			Errors map[string]string `json:"errors"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &body)
+		if _, ok := body.Errors[tt.wantField]; !ok || len(body.Errors) != 1 {
+			t.Errorf("%q: expected one error for %s, got %v", tt.query, tt.wantField, body.Errors)
+		}
+	}
+}
+
+func TestBindQuery_ListQueryPageSizeFollowsConfig(t *testing.T) {
+	defer pagination.SetConfig(pagination.DefaultConfig())
+	pagination.SetConfig(pagination.Config{MaxPageSize: 20, OverMax: pagination.RejectPageSize})
+
+	router := gin.New()
+	router.GET("/users", func(c *gin.Context) {
+		var q request.ListQuery
+		if !request.BindQuery(c, &q) {
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"per_page": q.Pagination().GetPerPage()})
+	})
+
+	tests := []struct {
+		query       string
+		wantStatus  int
+		wantPerPage int
+		wantField   string
+	}{
+		{"?page_size=20", http.StatusOK, 20, ""},
+		{"?per_page=5&page_size=20", http.StatusOK, 5, ""},
+		{"?per_page=21", http.StatusUnprocessableEntity, 0, "per_page"},
+		{"?page_size=50", http.StatusUnprocessableEntity, 0, "page_size"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("GET", "/users"+tt.query, nil)
+		router.ServeHTTP(w, httpReq)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%q: expected status %d, got %d: %s", tt.query, tt.wantStatus, w.Code, w.Body.String())
+			continue
+		}
+		var body struct {
+			PerPage int               `json:"per_page"`
+			Errors  map[string]string `json:"errors"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &body)
+		if tt.wantField == "" {
+			if body.PerPage != tt.wantPerPage {
+				t.Errorf("%q: expected per_page %d, got %d", tt.query, tt.wantPerPage, body.PerPage)
+			}
+			continue
+		}
+		if msg := body.Errors[tt.wantField]; msg != tt.wantField+" must be at most 20" {
+			t.Errorf("%q: expected a max error for %s, got %v", tt.query, tt.wantField, body.Errors)
+		}
+	}
+}
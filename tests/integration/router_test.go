@@ -1,11 +1,15 @@
 package integration
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/zgiai/zgo/internal/infra/router"
+	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
@@ -221,12 +225,12 @@ func TestRouter_ResourceWithOnly(t *testing.T) {
 		t.Errorf("Expected 'index', got '%s'", w.Body.String())
 	}
 
-	// Should 404
+	// Should 405, the path still exists for index
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("POST", "/comments", nil)
 	engine.ServeHTTP(w, req)
-	if w.Code != http.StatusNotFound {
-		t.Errorf("Expected 404 for excluded route, got %d", w.Code)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for excluded route, got %d", w.Code)
 	}
 }
 
@@ -247,12 +251,12 @@ func TestRouter_ResourceWithExcept(t *testing.T) {
 		t.Errorf("Expected 'index', got '%s'", w.Body.String())
 	}
 
-	// Should 404
+	// Should 405, the path still exists for show/update
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("DELETE", "/articles/1", nil)
 	engine.ServeHTTP(w, req)
-	if w.Code != http.StatusNotFound {
-		t.Errorf("Expected 404 for excluded route, got %d", w.Code)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for excluded route, got %d", w.Code)
 	}
 }
 
@@ -547,3 +551,298 @@ func TestRoute_WhereAlpha(t *testing.T) {
 		t.Errorf("Expected 404 for non-alpha slug, got %d", w.Code)
 	}
 }
+
+// ============================================
+// Reusable Route Groups
+// ============================================
+
+func TestRouteGroup_PrefixMiddlewareGroup(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+
+	var order []string
+	admin := r.Prefix("/admin").Middleware(func(c *gin.Context) {
+		order = append(order, "admin")
+		c.Next()
+	})
+	admin.Group(func(g *router.Router) {
+		g.GET("/users/:id", func(c *gin.Context) {
+			order = append(order, "handler")
+			c.String(200, "user:"+c.Param("id"))
+		}).Name("admin.users.show").WhereNumber("id")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/users/42", nil)
+	engine.ServeHTTP(w, req)
+
+	if w.Body.String() != "user:42" {
+		t.Errorf("Expected 'user:42', got '%s'", w.Body.String())
+	}
+	if len(order) != 2 || order[0] != "admin" || order[1] != "handler" {
+		t.Errorf("Expected [admin handler], got %v", order)
+	}
+
+	// Constraints still apply
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/admin/users/abc", nil)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for non-numeric id, got %d", w.Code)
+	}
+
+	// Named routes carry the combined prefix
+	if url := r.URL("admin.users.show", "id", "7"); url != "/admin/users/7" {
+		t.Errorf("Expected '/admin/users/7', got '%s'", url)
+	}
+}
+
+func TestRouteGroup_SharedAcrossModules(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+
+	calls := 0
+	r.MiddlewareGroup("auth", func(c *gin.Context) {
+		calls++
+		c.Next()
+	})
+
+	admin := r.Prefix("/admin").WithMiddleware("auth")
+
+	// Two modules register into the same group independently
+	registerUsers := func(g *router.RouteGroup) {
+		g.Group(func(users *router.Router) {
+			users.GET("/users", func(c *gin.Context) { c.String(200, "users") })
+		})
+	}
+	registerRoles := func(g *router.RouteGroup) {
+		g.Group(func(roles *router.Router) {
+			roles.GET("/roles", func(c *gin.Context) { c.String(200, "roles") })
+		})
+	}
+	registerUsers(admin)
+	registerRoles(admin)
+
+	// Routes outside the group are untouched
+	r.GET("/public", func(c *gin.Context) { c.String(200, "public") })
+
+	registered := map[string]bool{}
+	for _, route := range engine.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for _, want := range []string{"GET /admin/users", "GET /admin/roles", "GET /public"} {
+		if !registered[want] {
+			t.Errorf("Expected route %s to be registered", want)
+		}
+	}
+
+	for _, path := range []string{"/admin/users", "/admin/roles", "/public"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		engine.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", path, w.Code)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected auth middleware on both admin routes only, got %d calls", calls)
+	}
+}
+
+func TestRouteGroup_NestedPrefix(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+
+	var order []string
+	api := r.Prefix("/api").Use(func(c *gin.Context) {
+		order = append(order, "api")
+		c.Next()
+	})
+	api.Prefix("/v2").Use(func(c *gin.Context) {
+		order = append(order, "v2")
+		c.Next()
+	}).Group(func(v2 *router.Router) {
+		v2.GET("/ping", func(c *gin.Context) { c.String(200, "pong") })
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v2/ping", nil)
+	engine.ServeHTTP(w, req)
+
+	if w.Body.String() != "pong" {
+		t.Errorf("Expected 'pong', got '%s'", w.Body.String())
+	}
+	if len(order) != 2 || order[0] != "api" || order[1] != "v2" {
+		t.Errorf("Expected [api v2], got %v", order)
+	}
+}
+
+// ============================================
+// Named Route URL Generation
+// ============================================
+
+func TestURL_UsersShow(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+
+	r.Group("/v1", func(v1 *router.Router) {
+		v1.GET("/users/:id", func(c *gin.Context) {}).Name("users.show")
+	})
+
+	url, err := router.URL("users.show", map[string]string{"id": "42"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if url != "/v1/users/42" {
+		t.Errorf("Expected '/v1/users/42', got '%s'", url)
+	}
+
+	url, err = router.URL("users.show", map[string]string{"id": "42", "tab": "posts"})
+	if err != nil || url != "/v1/users/42?tab=posts" {
+		t.Errorf("Expected '/v1/users/42?tab=posts', got '%s' (%v)", url, err)
+	}
+}
+
+func TestURL_MissingParam(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+
+	r.GET("/v1/users/:id", func(c *gin.Context) {}).Name("users.show")
+
+	if _, err := router.URL("users.show", nil); !errors.Is(err, router.ErrMissingParam) {
+		t.Errorf("Expected ErrMissingParam, got %v", err)
+	}
+}
+
+func TestURL_UnknownRoute(t *testing.T) {
+	if _, err := router.URL("users.missing", nil); !errors.Is(err, router.ErrRouteNotFound) {
+		t.Errorf("Expected ErrRouteNotFound, got %v", err)
+	}
+}
+
+// ============================================
+// Method Not Allowed & HEAD
+// ============================================
+
+func TestRouter_MethodNotAllowed(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+
+	r.GET("/users/:id", func(c *gin.Context) { c.String(200, "show") })
+	r.PUT("/users/:id", func(c *gin.Context) { c.String(200, "update") })
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/users/1", nil)
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected 405, got %d", w.Code)
+	}
+	allow := w.Header().Get("Allow")
+	for _, method := range []string{"GET", "HEAD", "PUT"} {
+		if !strings.Contains(allow, method) {
+			t.Errorf("Expected Allow header to contain %s, got '%s'", method, allow)
+		}
+	}
+	if !strings.Contains(w.Body.String(), `"code":405`) {
+		t.Errorf("Expected JSON error envelope, got '%s'", w.Body.String())
+	}
+
+	// Unknown paths are still 404
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/missing", nil)
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown path, got %d", w.Code)
+	}
+}
+
+func TestRouter_HeadForGet(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+
+	r.GET("/health", func(c *gin.Context) {
+		c.Header("X-Status", "up")
+		c.String(200, "ok")
+	})
+	r.Match([]string{"GET", "POST"}, "/echo", func(c *gin.Context) { c.String(200, "echo") })
+
+	for _, path := range []string{"/health", "/echo"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("HEAD", path, nil)
+		engine.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected 200 for HEAD, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestRouter_PreflightOnGetRoute(t *testing.T) {
+	engine := gin.New()
+	engine.Use(cors.Default())
+	r := router.New(engine)
+
+	r.GET("/users", func(c *gin.Context) { c.String(200, "index") })
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("OPTIONS", "/users", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	engine.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Expected 204 for preflight, got %d", w.Code)
+	}
+	if w.Header().Get("Access-Control-Allow-Origin") == "" {
+		t.Error("Expected CORS headers on preflight response")
+	}
+}
+
+func TestRoute_Deprecated(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+
+	sunset := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	r.GET("/profile", func(c *gin.Context) { c.String(200, "old") }).Name("profile.v1").Deprecated(sunset)
+	r.GET("/legacy", func(c *gin.Context) { c.String(200, "legacy") }).Deprecated(time.Time{})
+	r.GET("/current", func(c *gin.Context) { c.String(200, "new") })
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/profile", nil)
+	engine.ServeHTTP(w, req)
+	if w.Header().Get("Deprecation") != "true" {
+		t.Errorf("Expected Deprecation: true, got '%s'", w.Header().Get("Deprecation"))
+	}
+	if w.Header().Get("Sunset") != "Fri, 01 Jan 2027 00:00:00 GMT" {
+		t.Errorf("Unexpected Sunset header: '%s'", w.Header().Get("Sunset"))
+	}
+
+	// Without a sunset date only Deprecation is sent
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/legacy", nil)
+	engine.ServeHTTP(w, req)
+	if w.Header().Get("Deprecation") != "true" || w.Header().Get("Sunset") != "" {
+		t.Errorf("Unexpected headers for /legacy: %v", w.Header())
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/current", nil)
+	engine.ServeHTTP(w, req)
+	if w.Header().Get("Deprecation") != "" {
+		t.Error("Expected no Deprecation header on a current route")
+	}
+
+	for _, info := range r.List() {
+		switch info.Path {
+		case "/profile":
+			if !info.Deprecated || info.Sunset == nil || !info.Sunset.Equal(sunset) {
+				t.Errorf("Unexpected route info for /profile: %+v", info)
+			}
+		case "/current":
+			if info.Deprecated || info.Sunset != nil {
+				t.Errorf("Unexpected route info for /current: %+v", info)
+			}
+		}
+	}
+}
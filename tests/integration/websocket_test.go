@@ -0,0 +1,151 @@
+package integration
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zgiai/zgo/internal/infra/jwt"
+	"github.com/zgiai/zgo/internal/infra/middleware"
+	"github.com/zgiai/zgo/internal/infra/router"
+	"golang.org/x/net/websocket"
+)
+
+type wsMessage struct {
+	Text string `json:"text"`
+	User uint   `json:"user,omitempty"`
+}
+
+// dialWS connects to path on srv with a same-origin Origin header
+func dialWS(t *testing.T, srv *httptest.Server, path string) *websocket.Conn {
+	t.Helper()
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+path, "", srv.URL)
+	if err != nil {
+		t.Fatalf("Dial(%s) error = %v", path, err)
+	}
+	t.Cleanup(func() { ws.Close() })
+	_ = ws.SetDeadline(time.Now().Add(5 * time.Second))
+	return ws
+}
+
+func TestRouter_WSEcho(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+	r.WS("/ws/echo", func(conn *router.WSConn) {
+		for {
+			var msg wsMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			if err := conn.WriteJSON(wsMessage{Text: "echo: " + msg.Text}); err != nil {
+				return
+			}
+		}
+	}).Name("ws.echo")
+
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	ws := dialWS(t, srv, "/ws/echo")
+	for _, text := range []string{"hello", "again"} {
+		if err := websocket.JSON.Send(ws, wsMessage{Text: text}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		var reply wsMessage
+		if err := websocket.JSON.Receive(ws, &reply); err != nil {
+			t.Fatalf("Receive() error = %v", err)
+		}
+		if reply.Text != "echo: "+text {
+			t.Errorf("reply = %q, want %q", reply.Text, "echo: "+text)
+		}
+	}
+
+	if got := r.Routes()["ws.echo"]; got != "GET /ws/echo" {
+		t.Errorf("Routes()[ws.echo] = %q", got)
+	}
+}
+
+func TestRouter_WSRejectsPlainRequestsAndForeignOrigins(t *testing.T) {
+	engine := gin.New()
+	r := router.New(engine)
+	r.WS("/ws", func(conn *router.WSConn) {})
+
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 400 {
+		t.Errorf("plain GET status = %d, want 400", resp.StatusCode)
+	}
+
+	if _, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", "", "https://evil.example"); err == nil {
+		t.Error("Dial() from a foreign origin succeeded, want rejection")
+	}
+}
+
+func TestRouter_WSAuthAndHub(t *testing.T) {
+	jwtService := jwt.NewTestService()
+	token, err := jwtService.GenerateToken(42, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hub := router.NewHub()
+	registered := make(chan struct{}, 1)
+
+	engine := gin.New()
+	r := router.New(engine)
+	r.Group("", func(auth *router.Router) {
+		auth.Use(middleware.JWTAuthWithService(jwtService))
+		auth.WS("/ws/notifications", func(conn *router.WSConn) {
+			hub.Register(conn)
+			defer hub.Unregister(conn)
+			registered <- struct{}{}
+			conn.Wait()
+		})
+	})
+
+	srv := httptest.NewServer(engine)
+	defer srv.Close()
+
+	// No token: the handshake is refused before the upgrade
+	if _, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws/notifications", "", srv.URL); err == nil {
+		t.Fatal("Dial() without a token succeeded")
+	}
+
+	ws := dialWS(t, srv, "/ws/notifications?token="+token)
+	<-registered
+
+	if !hub.Online(42) || hub.Count() != 1 {
+		t.Fatalf("Online(42) = %v, Count() = %d", hub.Online(42), hub.Count())
+	}
+	if n := hub.SendToUser(7, wsMessage{Text: "not yours"}); n != 0 {
+		t.Errorf("SendToUser(7) = %d, want 0", n)
+	}
+	if n := hub.SendToUser(42, wsMessage{Text: "hi alice", User: 42}); n != 1 {
+		t.Errorf("SendToUser(42) = %d, want 1", n)
+	}
+
+	var msg wsMessage
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+	if msg.Text != "hi alice" || msg.User != 42 {
+		t.Errorf("received %+v", msg)
+	}
+
+	ws.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for hub.Count() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if hub.Count() != 0 {
+		t.Errorf("Count() after disconnect = %d, want 0", hub.Count())
+	}
+}